@@ -82,6 +82,52 @@ func (ExecutionStatus) EnumDescriptor() ([]byte, []int) {
 }
 
 // 工作节点状态
+type OutputStream int32
+
+const (
+	OutputStream_STDOUT OutputStream = 0
+	OutputStream_STDERR OutputStream = 1
+)
+
+// Enum value maps for OutputStream.
+var (
+	OutputStream_name = map[int32]string{
+		0: "STDOUT",
+		1: "STDERR",
+	}
+	OutputStream_value = map[string]int32{
+		"STDOUT": 0,
+		"STDERR": 1,
+	}
+)
+
+func (x OutputStream) Enum() *OutputStream {
+	p := new(OutputStream)
+	*p = x
+	return p
+}
+
+func (x OutputStream) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutputStream) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_grpc_job_proto_enumTypes[1].Descriptor()
+}
+
+func (OutputStream) Type() protoreflect.EnumType {
+	return &file_api_grpc_job_proto_enumTypes[1]
+}
+
+func (x OutputStream) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutputStream.Descriptor instead.
+func (OutputStream) EnumDescriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{1}
+}
+
 type WorkerStatus int32
 
 const (
@@ -118,11 +164,11 @@ func (x WorkerStatus) String() string {
 }
 
 func (WorkerStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_grpc_job_proto_enumTypes[1].Descriptor()
+	return file_api_grpc_job_proto_enumTypes[2].Descriptor()
 }
 
 func (WorkerStatus) Type() protoreflect.EnumType {
-	return &file_api_grpc_job_proto_enumTypes[1]
+	return &file_api_grpc_job_proto_enumTypes[2]
 }
 
 func (x WorkerStatus) Number() protoreflect.EnumNumber {
@@ -131,7 +177,7 @@ func (x WorkerStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use WorkerStatus.Descriptor instead.
 func (WorkerStatus) EnumDescriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{1}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{2}
 }
 
 // 任务定义
@@ -154,6 +200,35 @@ type Job struct {
 	Department    *Department            `protobuf:"bytes,15,opt,name=department,proto3" json:"department,omitempty"`
 	Creator       *User                  `protobuf:"bytes,16,opt,name=creator,proto3" json:"creator,omitempty"`
 	AiSchedules   []*AISchedule          `protobuf:"bytes,17,rep,name=ai_schedules,json=aiSchedules,proto3" json:"ai_schedules,omitempty"`
+	// 链式触发: 成功/失败/结束后触发的下游任务 ID
+	OnSuccess []string `protobuf:"bytes,18,rep,name=on_success,json=onSuccess,proto3" json:"on_success,omitempty"`
+	OnFailure []string `protobuf:"bytes,19,rep,name=on_failure,json=onFailure,proto3" json:"on_failure,omitempty"`
+	OnFinish  []string `protobuf:"bytes,20,rep,name=on_finish,json=onFinish,proto3" json:"on_finish,omitempty"`
+	// SLA: 最晚开始/完成时间 (HH:MM) 和最长运行时长 (秒)
+	SlaStartBy      string `protobuf:"bytes,21,opt,name=sla_start_by,json=slaStartBy,proto3" json:"sla_start_by,omitempty"`
+	SlaFinishBy     string `protobuf:"bytes,22,opt,name=sla_finish_by,json=slaFinishBy,proto3" json:"sla_finish_by,omitempty"`
+	SlaMaxDuration  int32  `protobuf:"varint,23,opt,name=sla_max_duration,json=slaMaxDuration,proto3" json:"sla_max_duration,omitempty"`
+	CronDescription string `protobuf:"bytes,24,opt,name=cron_description,json=cronDescription,proto3" json:"cron_description,omitempty"` // 调度计划的中文描述
+	Revision        int32  `protobuf:"varint,25,opt,name=revision,proto3" json:"revision,omitempty"`                                     // 当前版本号
+	// 模板派生信息
+	TemplateId      string                 `protobuf:"bytes,26,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	TemplateValues  map[string]string      `protobuf:"bytes,27,rep,name=template_values,json=templateValues,proto3" json:"template_values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TemplateVersion int32                  `protobuf:"varint,28,opt,name=template_version,json=templateVersion,proto3" json:"template_version,omitempty"`
+	ManagedBy       string                 `protobuf:"bytes,29,opt,name=managed_by,json=managedBy,proto3" json:"managed_by,omitempty"`                                                    // 声明式配置的所有者, 为空表示手工创建
+	Labels          map[string]string      `protobuf:"bytes,30,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 标签, 值为空表示只打标签
+	Paused          bool                   `protobuf:"varint,31,opt,name=paused,proto3" json:"paused,omitempty"`                                                                          // 暂停时不按计划和事件调度
+	PausedUntil     *timestamppb.Timestamp `protobuf:"bytes,32,opt,name=paused_until,json=pausedUntil,proto3" json:"paused_until,omitempty"`
+	// 执行方式: exec、shell 或 script, 为空时为 shell
+	ExecMode    string   `protobuf:"bytes,33,opt,name=exec_mode,json=execMode,proto3" json:"exec_mode,omitempty"`
+	Args        []string `protobuf:"bytes,34,rep,name=args,proto3" json:"args,omitempty"`
+	Interpreter string   `protobuf:"bytes,35,opt,name=interpreter,proto3" json:"interpreter,omitempty"`
+	WorkDir     string   `protobuf:"bytes,36,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	Umask       string   `protobuf:"bytes,37,opt,name=umask,proto3" json:"umask,omitempty"`
+	// 任务类型: command(默认)、http、grpc、sql 或 handler, config 为该类型的 JSON 配置
+	Type          string `protobuf:"bytes,38,opt,name=type,proto3" json:"type,omitempty"`
+	Config        string `protobuf:"bytes,39,opt,name=config,proto3" json:"config,omitempty"`
+	Limits        string `protobuf:"bytes,40,opt,name=limits,proto3" json:"limits,omitempty"`   // command 类型任务的资源限制, JSON
+	Sandbox       string `protobuf:"bytes,41,opt,name=sandbox,proto3" json:"sandbox,omitempty"` // command 类型任务的运行身份、工作目录和环境变量策略, JSON
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -307,146 +382,202 @@ func (x *Job) GetAiSchedules() []*AISchedule {
 	return nil
 }
 
-// 任务执行记录
-type JobExecution struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	WorkerId      string                 `protobuf:"bytes,3,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
-	Status        ExecutionStatus        `protobuf:"varint,4,opt,name=status,proto3,enum=api.grpc.ExecutionStatus" json:"status,omitempty"`
-	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
-	Output        string                 `protobuf:"bytes,7,opt,name=output,proto3" json:"output,omitempty"`
-	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
-	ExitCode      int32                  `protobuf:"varint,9,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Job) GetOnSuccess() []string {
+	if x != nil {
+		return x.OnSuccess
+	}
+	return nil
 }
 
-func (x *JobExecution) Reset() {
-	*x = JobExecution{}
-	mi := &file_api_grpc_job_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Job) GetOnFailure() []string {
+	if x != nil {
+		return x.OnFailure
+	}
+	return nil
 }
 
-func (x *JobExecution) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Job) GetOnFinish() []string {
+	if x != nil {
+		return x.OnFinish
+	}
+	return nil
 }
 
-func (*JobExecution) ProtoMessage() {}
+func (x *Job) GetSlaStartBy() string {
+	if x != nil {
+		return x.SlaStartBy
+	}
+	return ""
+}
 
-func (x *JobExecution) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[1]
+func (x *Job) GetSlaFinishBy() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.SlaFinishBy
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use JobExecution.ProtoReflect.Descriptor instead.
-func (*JobExecution) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{1}
+func (x *Job) GetSlaMaxDuration() int32 {
+	if x != nil {
+		return x.SlaMaxDuration
+	}
+	return 0
 }
 
-func (x *JobExecution) GetId() string {
+func (x *Job) GetCronDescription() string {
 	if x != nil {
-		return x.Id
+		return x.CronDescription
 	}
 	return ""
 }
 
-func (x *JobExecution) GetJobId() string {
+func (x *Job) GetRevision() int32 {
 	if x != nil {
-		return x.JobId
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *Job) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
 	}
 	return ""
 }
 
-func (x *JobExecution) GetWorkerId() string {
+func (x *Job) GetTemplateValues() map[string]string {
 	if x != nil {
-		return x.WorkerId
+		return x.TemplateValues
+	}
+	return nil
+}
+
+func (x *Job) GetTemplateVersion() int32 {
+	if x != nil {
+		return x.TemplateVersion
+	}
+	return 0
+}
+
+func (x *Job) GetManagedBy() string {
+	if x != nil {
+		return x.ManagedBy
 	}
 	return ""
 }
 
-func (x *JobExecution) GetStatus() ExecutionStatus {
+func (x *Job) GetLabels() map[string]string {
 	if x != nil {
-		return x.Status
+		return x.Labels
 	}
-	return ExecutionStatus_PENDING
+	return nil
 }
 
-func (x *JobExecution) GetStartedAt() *timestamppb.Timestamp {
+func (x *Job) GetPaused() bool {
 	if x != nil {
-		return x.StartedAt
+		return x.Paused
+	}
+	return false
+}
+
+func (x *Job) GetPausedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PausedUntil
 	}
 	return nil
 }
 
-func (x *JobExecution) GetFinishedAt() *timestamppb.Timestamp {
+func (x *Job) GetExecMode() string {
 	if x != nil {
-		return x.FinishedAt
+		return x.ExecMode
+	}
+	return ""
+}
+
+func (x *Job) GetArgs() []string {
+	if x != nil {
+		return x.Args
 	}
 	return nil
 }
 
-func (x *JobExecution) GetOutput() string {
+func (x *Job) GetInterpreter() string {
 	if x != nil {
-		return x.Output
+		return x.Interpreter
 	}
 	return ""
 }
 
-func (x *JobExecution) GetError() string {
+func (x *Job) GetWorkDir() string {
 	if x != nil {
-		return x.Error
+		return x.WorkDir
 	}
 	return ""
 }
 
-func (x *JobExecution) GetExitCode() int32 {
+func (x *Job) GetUmask() string {
 	if x != nil {
-		return x.ExitCode
+		return x.Umask
 	}
-	return 0
+	return ""
 }
 
-// 工作节点
-type Worker struct {
+func (x *Job) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Job) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
+}
+
+func (x *Job) GetLimits() string {
+	if x != nil {
+		return x.Limits
+	}
+	return ""
+}
+
+func (x *Job) GetSandbox() string {
+	if x != nil {
+		return x.Sandbox
+	}
+	return ""
+}
+
+// 任务模板参数定义
+type TemplateParam struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
-	Port          int32                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
-	Status        WorkerStatus           `protobuf:"varint,5,opt,name=status,proto3,enum=api.grpc.WorkerStatus" json:"status,omitempty"`
-	Capacity      int32                  `protobuf:"varint,6,opt,name=capacity,proto3" json:"capacity,omitempty"`
-	CurrentLoad   int32                  `protobuf:"varint,7,opt,name=current_load,json=currentLoad,proto3" json:"current_load,omitempty"`
-	LastHeartbeat *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Required      bool                   `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	DefaultValue  string                 `protobuf:"bytes,4,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	Pattern       string                 `protobuf:"bytes,5,opt,name=pattern,proto3" json:"pattern,omitempty"` // 参数值需要匹配的正则
+	Raw           bool                   `protobuf:"varint,6,opt,name=raw,proto3" json:"raw,omitempty"`        // 原样替换占位符, 默认按 shell 单引号转义
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Worker) Reset() {
-	*x = Worker{}
-	mi := &file_api_grpc_job_proto_msgTypes[2]
+func (x *TemplateParam) Reset() {
+	*x = TemplateParam{}
+	mi := &file_api_grpc_job_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Worker) String() string {
+func (x *TemplateParam) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Worker) ProtoMessage() {}
+func (*TemplateParam) ProtoMessage() {}
 
-func (x *Worker) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[2]
+func (x *TemplateParam) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,109 +588,87 @@ func (x *Worker) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Worker.ProtoReflect.Descriptor instead.
-func (*Worker) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *Worker) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use TemplateParam.ProtoReflect.Descriptor instead.
+func (*TemplateParam) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Worker) GetName() string {
+func (x *TemplateParam) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Worker) GetIp() string {
+func (x *TemplateParam) GetDescription() string {
 	if x != nil {
-		return x.Ip
+		return x.Description
 	}
 	return ""
 }
 
-func (x *Worker) GetPort() int32 {
-	if x != nil {
-		return x.Port
-	}
-	return 0
-}
-
-func (x *Worker) GetStatus() WorkerStatus {
-	if x != nil {
-		return x.Status
-	}
-	return WorkerStatus_OFFLINE
-}
-
-func (x *Worker) GetCapacity() int32 {
+func (x *TemplateParam) GetRequired() bool {
 	if x != nil {
-		return x.Capacity
+		return x.Required
 	}
-	return 0
+	return false
 }
 
-func (x *Worker) GetCurrentLoad() int32 {
+func (x *TemplateParam) GetDefaultValue() string {
 	if x != nil {
-		return x.CurrentLoad
+		return x.DefaultValue
 	}
-	return 0
+	return ""
 }
 
-func (x *Worker) GetLastHeartbeat() *timestamppb.Timestamp {
+func (x *TemplateParam) GetPattern() string {
 	if x != nil {
-		return x.LastHeartbeat
+		return x.Pattern
 	}
-	return nil
+	return ""
 }
 
-func (x *Worker) GetMetadata() map[string]string {
+func (x *TemplateParam) GetRaw() bool {
 	if x != nil {
-		return x.Metadata
+		return x.Raw
 	}
-	return nil
+	return false
 }
 
-// 用户定义
-type User struct {
+// 任务模板
+type JobTemplate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	RealName      string                 `protobuf:"bytes,4,opt,name=real_name,json=realName,proto3" json:"real_name,omitempty"`
-	Phone         string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
-	Avatar        string                 `protobuf:"bytes,6,opt,name=avatar,proto3" json:"avatar,omitempty"`
-	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
-	DepartmentId  string                 `protobuf:"bytes,8,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	LastLoginAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"`
-	Department    *Department            `protobuf:"bytes,12,opt,name=department,proto3" json:"department,omitempty"`
-	Roles         []*Role                `protobuf:"bytes,13,rep,name=roles,proto3" json:"roles,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Command       string                 `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"` // 可包含 {{参数名}} 占位符
+	Params        []*TemplateParam       `protobuf:"bytes,5,rep,name=params,proto3" json:"params,omitempty"`
+	Timeout       int32                  `protobuf:"varint,6,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	RetryAttempts int32                  `protobuf:"varint,7,opt,name=retry_attempts,json=retryAttempts,proto3" json:"retry_attempts,omitempty"`
+	Version       int32                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	JobCount      int64                  `protobuf:"varint,12,opt,name=job_count,json=jobCount,proto3" json:"job_count,omitempty"` // 派生任务数
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *User) Reset() {
-	*x = User{}
-	mi := &file_api_grpc_job_proto_msgTypes[3]
+func (x *JobTemplate) Reset() {
+	*x = JobTemplate{}
+	mi := &file_api_grpc_job_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *User) String() string {
+func (x *JobTemplate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*User) ProtoMessage() {}
+func (*JobTemplate) ProtoMessage() {}
 
-func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[3]
+func (x *JobTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,134 +679,197 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use JobTemplate.ProtoReflect.Descriptor instead.
+func (*JobTemplate) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *User) GetId() string {
+func (x *JobTemplate) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *User) GetUsername() string {
+func (x *JobTemplate) GetName() string {
 	if x != nil {
-		return x.Username
+		return x.Name
 	}
 	return ""
 }
 
-func (x *User) GetEmail() string {
+func (x *JobTemplate) GetDescription() string {
 	if x != nil {
-		return x.Email
+		return x.Description
 	}
 	return ""
 }
 
-func (x *User) GetRealName() string {
+func (x *JobTemplate) GetCommand() string {
 	if x != nil {
-		return x.RealName
+		return x.Command
 	}
 	return ""
 }
 
-func (x *User) GetPhone() string {
+func (x *JobTemplate) GetParams() []*TemplateParam {
 	if x != nil {
-		return x.Phone
+		return x.Params
 	}
-	return ""
+	return nil
 }
 
-func (x *User) GetAvatar() string {
+func (x *JobTemplate) GetTimeout() int32 {
 	if x != nil {
-		return x.Avatar
+		return x.Timeout
 	}
-	return ""
+	return 0
 }
 
-func (x *User) GetStatus() string {
+func (x *JobTemplate) GetRetryAttempts() int32 {
 	if x != nil {
-		return x.Status
+		return x.RetryAttempts
 	}
-	return ""
+	return 0
 }
 
-func (x *User) GetDepartmentId() string {
+func (x *JobTemplate) GetVersion() int32 {
 	if x != nil {
-		return x.DepartmentId
+		return x.Version
+	}
+	return 0
+}
+
+func (x *JobTemplate) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
 	}
 	return ""
 }
 
-func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+func (x *JobTemplate) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return nil
 }
 
-func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *JobTemplate) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.UpdatedAt
 	}
 	return nil
 }
 
-func (x *User) GetLastLoginAt() *timestamppb.Timestamp {
+func (x *JobTemplate) GetJobCount() int64 {
 	if x != nil {
-		return x.LastLoginAt
+		return x.JobCount
 	}
-	return nil
+	return 0
 }
 
-func (x *User) GetDepartment() *Department {
+// 模板变更对派生任务的影响
+type TemplateJobChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	JobName       string                 `protobuf:"bytes,2,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // 无法按新模板生成任务定义时的原因
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateJobChange) Reset() {
+	*x = TemplateJobChange{}
+	mi := &file_api_grpc_job_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateJobChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateJobChange) ProtoMessage() {}
+
+func (x *TemplateJobChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[3]
 	if x != nil {
-		return x.Department
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *User) GetRoles() []*Role {
+// Deprecated: Use TemplateJobChange.ProtoReflect.Descriptor instead.
+func (*TemplateJobChange) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TemplateJobChange) GetJobId() string {
 	if x != nil {
-		return x.Roles
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *TemplateJobChange) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+func (x *TemplateJobChange) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
 	}
 	return nil
 }
 
-// 部门定义
-type Department struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	ParentId      string                 `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	Sort          int32                  `protobuf:"varint,7,opt,name=sort,proto3" json:"sort,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Parent        *Department            `protobuf:"bytes,10,opt,name=parent,proto3" json:"parent,omitempty"`
-	Children      []*Department          `protobuf:"bytes,11,rep,name=children,proto3" json:"children,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *TemplateJobChange) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
 }
 
-func (x *Department) Reset() {
-	*x = Department{}
+// 任务执行记录
+type JobExecution struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId               string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	WorkerId            string                 `protobuf:"bytes,3,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Status              ExecutionStatus        `protobuf:"varint,4,opt,name=status,proto3,enum=api.grpc.ExecutionStatus" json:"status,omitempty"`
+	StartedAt           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Output              string                 `protobuf:"bytes,7,opt,name=output,proto3" json:"output,omitempty"`
+	Error               string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	ExitCode            int32                  `protobuf:"varint,9,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	UpstreamExecutionId string                 `protobuf:"bytes,10,opt,name=upstream_execution_id,json=upstreamExecutionId,proto3" json:"upstream_execution_id,omitempty"`
+	ChainDepth          int32                  `protobuf:"varint,11,opt,name=chain_depth,json=chainDepth,proto3" json:"chain_depth,omitempty"`
+	RevisionId          string                 `protobuf:"bytes,12,opt,name=revision_id,json=revisionId,proto3" json:"revision_id,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *JobExecution) Reset() {
+	*x = JobExecution{}
 	mi := &file_api_grpc_job_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Department) String() string {
+func (x *JobExecution) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Department) ProtoMessage() {}
+func (*JobExecution) ProtoMessage() {}
 
-func (x *Department) ProtoReflect() protoreflect.Message {
+func (x *JobExecution) ProtoReflect() protoreflect.Message {
 	mi := &file_api_grpc_job_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -709,117 +881,125 @@ func (x *Department) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Department.ProtoReflect.Descriptor instead.
-func (*Department) Descriptor() ([]byte, []int) {
+// Deprecated: Use JobExecution.ProtoReflect.Descriptor instead.
+func (*JobExecution) Descriptor() ([]byte, []int) {
 	return file_api_grpc_job_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Department) GetId() string {
+func (x *JobExecution) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Department) GetName() string {
+func (x *JobExecution) GetJobId() string {
 	if x != nil {
-		return x.Name
+		return x.JobId
 	}
 	return ""
 }
 
-func (x *Department) GetCode() string {
+func (x *JobExecution) GetWorkerId() string {
 	if x != nil {
-		return x.Code
+		return x.WorkerId
 	}
 	return ""
 }
 
-func (x *Department) GetDescription() string {
+func (x *JobExecution) GetStatus() ExecutionStatus {
 	if x != nil {
-		return x.Description
+		return x.Status
 	}
-	return ""
+	return ExecutionStatus_PENDING
 }
 
-func (x *Department) GetParentId() string {
+func (x *JobExecution) GetStartedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ParentId
+		return x.StartedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *Department) GetStatus() string {
+func (x *JobExecution) GetFinishedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Status
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *JobExecution) GetOutput() string {
+	if x != nil {
+		return x.Output
 	}
 	return ""
 }
 
-func (x *Department) GetSort() int32 {
+func (x *JobExecution) GetError() string {
 	if x != nil {
-		return x.Sort
+		return x.Error
 	}
-	return 0
+	return ""
 }
 
-func (x *Department) GetCreatedAt() *timestamppb.Timestamp {
+func (x *JobExecution) GetExitCode() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.ExitCode
 	}
-	return nil
+	return 0
 }
 
-func (x *Department) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *JobExecution) GetUpstreamExecutionId() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.UpstreamExecutionId
 	}
-	return nil
+	return ""
 }
 
-func (x *Department) GetParent() *Department {
+func (x *JobExecution) GetChainDepth() int32 {
 	if x != nil {
-		return x.Parent
+		return x.ChainDepth
 	}
-	return nil
+	return 0
 }
 
-func (x *Department) GetChildren() []*Department {
+func (x *JobExecution) GetRevisionId() string {
 	if x != nil {
-		return x.Children
+		return x.RevisionId
 	}
-	return nil
+	return ""
 }
 
-// 角色定义
-type Role struct {
+// 任务定义版本
+type JobRevision struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Permissions   []*Permission          `protobuf:"bytes,8,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Revision      int32                  `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	ChangeType    string                 `protobuf:"bytes,4,opt,name=change_type,json=changeType,proto3" json:"change_type,omitempty"` // baseline/create/update/rollback
+	Snapshot      *Job                   `protobuf:"bytes,5,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	RollbackFrom  int32                  `protobuf:"varint,6,opt,name=rollback_from,json=rollbackFrom,proto3" json:"rollback_from,omitempty"`
+	Comment       string                 `protobuf:"bytes,7,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Role) Reset() {
-	*x = Role{}
+func (x *JobRevision) Reset() {
+	*x = JobRevision{}
 	mi := &file_api_grpc_job_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Role) String() string {
+func (x *JobRevision) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Role) ProtoMessage() {}
+func (*JobRevision) ProtoMessage() {}
 
-func (x *Role) ProtoReflect() protoreflect.Message {
+func (x *JobRevision) ProtoReflect() protoreflect.Message {
 	mi := &file_api_grpc_job_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -831,103 +1011,98 @@ func (x *Role) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Role.ProtoReflect.Descriptor instead.
-func (*Role) Descriptor() ([]byte, []int) {
+// Deprecated: Use JobRevision.ProtoReflect.Descriptor instead.
+func (*JobRevision) Descriptor() ([]byte, []int) {
 	return file_api_grpc_job_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Role) GetId() string {
+func (x *JobRevision) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Role) GetName() string {
+func (x *JobRevision) GetJobId() string {
 	if x != nil {
-		return x.Name
+		return x.JobId
 	}
 	return ""
 }
 
-func (x *Role) GetCode() string {
+func (x *JobRevision) GetRevision() int32 {
 	if x != nil {
-		return x.Code
+		return x.Revision
 	}
-	return ""
+	return 0
 }
 
-func (x *Role) GetDescription() string {
+func (x *JobRevision) GetChangeType() string {
 	if x != nil {
-		return x.Description
+		return x.ChangeType
 	}
 	return ""
 }
 
-func (x *Role) GetStatus() string {
+func (x *JobRevision) GetSnapshot() *Job {
 	if x != nil {
-		return x.Status
+		return x.Snapshot
 	}
-	return ""
+	return nil
 }
 
-func (x *Role) GetCreatedAt() *timestamppb.Timestamp {
+func (x *JobRevision) GetRollbackFrom() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.RollbackFrom
 	}
-	return nil
+	return 0
 }
 
-func (x *Role) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *JobRevision) GetComment() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Comment
 	}
-	return nil
+	return ""
 }
 
-func (x *Role) GetPermissions() []*Permission {
+func (x *JobRevision) GetCreatedBy() string {
 	if x != nil {
-		return x.Permissions
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *JobRevision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return nil
 }
 
-// 权限定义
-type Permission struct {
+// 版本差异中的单个字段变更
+type FieldChange struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
-	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
-	Resource      string                 `protobuf:"bytes,5,opt,name=resource,proto3" json:"resource,omitempty"`
-	Action        string                 `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
-	ParentId      string                 `protobuf:"bytes,7,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	Path          string                 `protobuf:"bytes,8,opt,name=path,proto3" json:"path,omitempty"`
-	Icon          string                 `protobuf:"bytes,9,opt,name=icon,proto3" json:"icon,omitempty"`
-	Sort          int32                  `protobuf:"varint,10,opt,name=sort,proto3" json:"sort,omitempty"`
-	Status        string                 `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Parent        *Permission            `protobuf:"bytes,14,opt,name=parent,proto3" json:"parent,omitempty"`
-	Children      []*Permission          `protobuf:"bytes,15,rep,name=children,proto3" json:"children,omitempty"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	OldValue      string                 `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      string                 `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Permission) Reset() {
-	*x = Permission{}
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
 	mi := &file_api_grpc_job_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Permission) String() string {
+func (x *FieldChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Permission) ProtoMessage() {}
+func (*FieldChange) ProtoMessage() {}
 
-func (x *Permission) ProtoReflect() protoreflect.Message {
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
 	mi := &file_api_grpc_job_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -939,147 +1114,176 @@ func (x *Permission) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Permission.ProtoReflect.Descriptor instead.
-func (*Permission) Descriptor() ([]byte, []int) {
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
 	return file_api_grpc_job_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Permission) GetId() string {
+func (x *FieldChange) GetField() string {
 	if x != nil {
-		return x.Id
+		return x.Field
 	}
 	return ""
 }
 
-func (x *Permission) GetName() string {
+func (x *FieldChange) GetOldValue() string {
 	if x != nil {
-		return x.Name
+		return x.OldValue
 	}
 	return ""
 }
 
-func (x *Permission) GetCode() string {
+func (x *FieldChange) GetNewValue() string {
 	if x != nil {
-		return x.Code
+		return x.NewValue
 	}
 	return ""
 }
 
-func (x *Permission) GetType() string {
-	if x != nil {
-		return x.Type
-	}
-	return ""
+// 工作节点
+type Worker struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port          int32                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	Status        WorkerStatus           `protobuf:"varint,5,opt,name=status,proto3,enum=api.grpc.WorkerStatus" json:"status,omitempty"`
+	Capacity      int32                  `protobuf:"varint,6,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	CurrentLoad   int32                  `protobuf:"varint,7,opt,name=current_load,json=currentLoad,proto3" json:"current_load,omitempty"`
+	LastHeartbeat *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Permission) GetResource() string {
-	if x != nil {
-		return x.Resource
-	}
-	return ""
+func (x *Worker) Reset() {
+	*x = Worker{}
+	mi := &file_api_grpc_job_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Permission) GetAction() string {
+func (x *Worker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Worker) ProtoMessage() {}
+
+func (x *Worker) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[7]
 	if x != nil {
-		return x.Action
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Permission) GetParentId() string {
+// Deprecated: Use Worker.ProtoReflect.Descriptor instead.
+func (*Worker) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Worker) GetId() string {
 	if x != nil {
-		return x.ParentId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *Permission) GetPath() string {
+func (x *Worker) GetName() string {
 	if x != nil {
-		return x.Path
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Permission) GetIcon() string {
+func (x *Worker) GetIp() string {
 	if x != nil {
-		return x.Icon
+		return x.Ip
 	}
 	return ""
 }
 
-func (x *Permission) GetSort() int32 {
+func (x *Worker) GetPort() int32 {
 	if x != nil {
-		return x.Sort
+		return x.Port
 	}
 	return 0
 }
 
-func (x *Permission) GetStatus() string {
+func (x *Worker) GetStatus() WorkerStatus {
 	if x != nil {
 		return x.Status
 	}
-	return ""
+	return WorkerStatus_OFFLINE
 }
 
-func (x *Permission) GetCreatedAt() *timestamppb.Timestamp {
+func (x *Worker) GetCapacity() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Capacity
 	}
-	return nil
+	return 0
 }
 
-func (x *Permission) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *Worker) GetCurrentLoad() int32 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.CurrentLoad
 	}
-	return nil
+	return 0
 }
 
-func (x *Permission) GetParent() *Permission {
+func (x *Worker) GetLastHeartbeat() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Parent
+		return x.LastHeartbeat
 	}
 	return nil
 }
 
-func (x *Permission) GetChildren() []*Permission {
+func (x *Worker) GetMetadata() map[string]string {
 	if x != nil {
-		return x.Children
+		return x.Metadata
 	}
 	return nil
 }
 
-// AI 调度定义
-type AISchedule struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	JobId          string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	PromptTemplate string                 `protobuf:"bytes,3,opt,name=prompt_template,json=promptTemplate,proto3" json:"prompt_template,omitempty"`
-	AiResponse     string                 `protobuf:"bytes,4,opt,name=ai_response,json=aiResponse,proto3" json:"ai_response,omitempty"`
-	Strategy       string                 `protobuf:"bytes,5,opt,name=strategy,proto3" json:"strategy,omitempty"`
-	Priority       int32                  `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
-	Context        string                 `protobuf:"bytes,7,opt,name=context,proto3" json:"context,omitempty"`
-	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// 用户定义
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	RealName      string                 `protobuf:"bytes,4,opt,name=real_name,json=realName,proto3" json:"real_name,omitempty"`
+	Phone         string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
+	Avatar        string                 `protobuf:"bytes,6,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	DepartmentId  string                 `protobuf:"bytes,8,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	LastLoginAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"`
+	Department    *Department            `protobuf:"bytes,12,opt,name=department,proto3" json:"department,omitempty"`
+	Roles         []*Role                `protobuf:"bytes,13,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AISchedule) Reset() {
-	*x = AISchedule{}
-	mi := &file_api_grpc_job_proto_msgTypes[7]
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_api_grpc_job_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AISchedule) String() string {
+func (x *User) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AISchedule) ProtoMessage() {}
+func (*User) ProtoMessage() {}
 
-func (x *AISchedule) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[7]
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1090,105 +1294,136 @@ func (x *AISchedule) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AISchedule.ProtoReflect.Descriptor instead.
-func (*AISchedule) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *AISchedule) GetId() string {
+func (x *User) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *AISchedule) GetJobId() string {
+func (x *User) GetUsername() string {
 	if x != nil {
-		return x.JobId
+		return x.Username
 	}
 	return ""
 }
 
-func (x *AISchedule) GetPromptTemplate() string {
+func (x *User) GetEmail() string {
 	if x != nil {
-		return x.PromptTemplate
+		return x.Email
 	}
 	return ""
 }
 
-func (x *AISchedule) GetAiResponse() string {
+func (x *User) GetRealName() string {
 	if x != nil {
-		return x.AiResponse
+		return x.RealName
 	}
 	return ""
 }
 
-func (x *AISchedule) GetStrategy() string {
+func (x *User) GetPhone() string {
 	if x != nil {
-		return x.Strategy
+		return x.Phone
 	}
 	return ""
 }
 
-func (x *AISchedule) GetPriority() int32 {
+func (x *User) GetAvatar() string {
 	if x != nil {
-		return x.Priority
+		return x.Avatar
 	}
-	return 0
+	return ""
 }
 
-func (x *AISchedule) GetContext() string {
+func (x *User) GetStatus() string {
 	if x != nil {
-		return x.Context
+		return x.Status
 	}
 	return ""
 }
 
-func (x *AISchedule) GetCreatedAt() *timestamppb.Timestamp {
+func (x *User) GetDepartmentId() string {
+	if x != nil {
+		return x.DepartmentId
+	}
+	return ""
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return nil
 }
 
-func (x *AISchedule) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.UpdatedAt
 	}
 	return nil
 }
 
-// 创建任务请求
-type CreateJobRequest struct {
+func (x *User) GetLastLoginAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLoginAt
+	}
+	return nil
+}
+
+func (x *User) GetDepartment() *Department {
+	if x != nil {
+		return x.Department
+	}
+	return nil
+}
+
+func (x *User) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+// 部门定义
+type Department struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Cron          string                 `protobuf:"bytes,3,opt,name=cron,proto3" json:"cron,omitempty"`
-	Command       string                 `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
-	Params        map[string]string      `protobuf:"bytes,5,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	RetryAttempts int32                  `protobuf:"varint,6,opt,name=retry_attempts,json=retryAttempts,proto3" json:"retry_attempts,omitempty"`
-	Timeout       int32                  `protobuf:"varint,7,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	Priority      int32                  `protobuf:"varint,8,opt,name=priority,proto3" json:"priority,omitempty"`
-	DepartmentId  string                 `protobuf:"bytes,9,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	ParentId      string                 `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Sort          int32                  `protobuf:"varint,7,opt,name=sort,proto3" json:"sort,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Parent        *Department            `protobuf:"bytes,10,opt,name=parent,proto3" json:"parent,omitempty"`
+	Children      []*Department          `protobuf:"bytes,11,rep,name=children,proto3" json:"children,omitempty"`
+	Sandbox       string                 `protobuf:"bytes,12,opt,name=sandbox,proto3" json:"sandbox,omitempty"` // 部门任务默认的沙箱设置, JSON
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateJobRequest) Reset() {
-	*x = CreateJobRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[8]
+func (x *Department) Reset() {
+	*x = Department{}
+	mi := &file_api_grpc_job_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateJobRequest) String() string {
+func (x *Department) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateJobRequest) ProtoMessage() {}
+func (*Department) ProtoMessage() {}
 
-func (x *CreateJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[8]
+func (x *Department) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1199,96 +1434,3278 @@ func (x *CreateJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateJobRequest.ProtoReflect.Descriptor instead.
-func (*CreateJobRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use Department.ProtoReflect.Descriptor instead.
+func (*Department) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CreateJobRequest) GetName() string {
+func (x *Department) GetId() string {
 	if x != nil {
-		return x.Name
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CreateJobRequest) GetDescription() string {
+func (x *Department) GetName() string {
 	if x != nil {
-		return x.Description
+		return x.Name
 	}
 	return ""
 }
 
-func (x *CreateJobRequest) GetCron() string {
+func (x *Department) GetCode() string {
 	if x != nil {
-		return x.Cron
+		return x.Code
 	}
 	return ""
 }
 
-func (x *CreateJobRequest) GetCommand() string {
+func (x *Department) GetDescription() string {
 	if x != nil {
-		return x.Command
+		return x.Description
 	}
 	return ""
 }
 
-func (x *CreateJobRequest) GetParams() map[string]string {
+func (x *Department) GetParentId() string {
 	if x != nil {
-		return x.Params
+		return x.ParentId
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateJobRequest) GetRetryAttempts() int32 {
+func (x *Department) GetStatus() string {
 	if x != nil {
-		return x.RetryAttempts
+		return x.Status
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateJobRequest) GetTimeout() int32 {
+func (x *Department) GetSort() int32 {
 	if x != nil {
-		return x.Timeout
+		return x.Sort
 	}
 	return 0
 }
 
-func (x *CreateJobRequest) GetPriority() int32 {
+func (x *Department) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Department) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Department) GetParent() *Department {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *Department) GetChildren() []*Department {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *Department) GetSandbox() string {
+	if x != nil {
+		return x.Sandbox
+	}
+	return ""
+}
+
+// 角色定义
+type Role struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Permissions   []*Permission          `protobuf:"bytes,8,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Role) Reset() {
+	*x = Role{}
+	mi := &file_api_grpc_job_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Role) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Role) ProtoMessage() {}
+
+func (x *Role) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Role.ProtoReflect.Descriptor instead.
+func (*Role) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Role) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Role) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Role) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Role) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Role) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Role) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Role) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Role) GetPermissions() []*Permission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+// 权限定义
+type Permission struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Resource      string                 `protobuf:"bytes,5,opt,name=resource,proto3" json:"resource,omitempty"`
+	Action        string                 `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
+	ParentId      string                 `protobuf:"bytes,7,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Path          string                 `protobuf:"bytes,8,opt,name=path,proto3" json:"path,omitempty"`
+	Icon          string                 `protobuf:"bytes,9,opt,name=icon,proto3" json:"icon,omitempty"`
+	Sort          int32                  `protobuf:"varint,10,opt,name=sort,proto3" json:"sort,omitempty"`
+	Status        string                 `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Parent        *Permission            `protobuf:"bytes,14,opt,name=parent,proto3" json:"parent,omitempty"`
+	Children      []*Permission          `protobuf:"bytes,15,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Permission) Reset() {
+	*x = Permission{}
+	mi := &file_api_grpc_job_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Permission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Permission) ProtoMessage() {}
+
+func (x *Permission) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Permission.ProtoReflect.Descriptor instead.
+func (*Permission) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Permission) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Permission) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Permission) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Permission) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Permission) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *Permission) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Permission) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Permission) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Permission) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *Permission) GetSort() int32 {
+	if x != nil {
+		return x.Sort
+	}
+	return 0
+}
+
+func (x *Permission) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Permission) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Permission) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Permission) GetParent() *Permission {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *Permission) GetChildren() []*Permission {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// AI 调度定义
+type AISchedule struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId          string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	PromptTemplate string                 `protobuf:"bytes,3,opt,name=prompt_template,json=promptTemplate,proto3" json:"prompt_template,omitempty"`
+	AiResponse     string                 `protobuf:"bytes,4,opt,name=ai_response,json=aiResponse,proto3" json:"ai_response,omitempty"`
+	Strategy       string                 `protobuf:"bytes,5,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Priority       int32                  `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	Context        string                 `protobuf:"bytes,7,opt,name=context,proto3" json:"context,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AISchedule) Reset() {
+	*x = AISchedule{}
+	mi := &file_api_grpc_job_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AISchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AISchedule) ProtoMessage() {}
+
+func (x *AISchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AISchedule.ProtoReflect.Descriptor instead.
+func (*AISchedule) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AISchedule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AISchedule) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *AISchedule) GetPromptTemplate() string {
+	if x != nil {
+		return x.PromptTemplate
+	}
+	return ""
+}
+
+func (x *AISchedule) GetAiResponse() string {
+	if x != nil {
+		return x.AiResponse
+	}
+	return ""
+}
+
+func (x *AISchedule) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *AISchedule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *AISchedule) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+func (x *AISchedule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AISchedule) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// 创建任务请求
+type CreateJobRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Cron           string                 `protobuf:"bytes,3,opt,name=cron,proto3" json:"cron,omitempty"`
+	Command        string                 `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
+	Params         map[string]string      `protobuf:"bytes,5,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RetryAttempts  int32                  `protobuf:"varint,6,opt,name=retry_attempts,json=retryAttempts,proto3" json:"retry_attempts,omitempty"`
+	Timeout        int32                  `protobuf:"varint,7,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Priority       int32                  `protobuf:"varint,8,opt,name=priority,proto3" json:"priority,omitempty"`
+	DepartmentId   string                 `protobuf:"bytes,9,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"`
+	OnSuccess      []string               `protobuf:"bytes,10,rep,name=on_success,json=onSuccess,proto3" json:"on_success,omitempty"`
+	OnFailure      []string               `protobuf:"bytes,11,rep,name=on_failure,json=onFailure,proto3" json:"on_failure,omitempty"`
+	OnFinish       []string               `protobuf:"bytes,12,rep,name=on_finish,json=onFinish,proto3" json:"on_finish,omitempty"`
+	SlaStartBy     string                 `protobuf:"bytes,13,opt,name=sla_start_by,json=slaStartBy,proto3" json:"sla_start_by,omitempty"`
+	SlaFinishBy    string                 `protobuf:"bytes,14,opt,name=sla_finish_by,json=slaFinishBy,proto3" json:"sla_finish_by,omitempty"`
+	SlaMaxDuration int32                  `protobuf:"varint,15,opt,name=sla_max_duration,json=slaMaxDuration,proto3" json:"sla_max_duration,omitempty"`
+	Labels         map[string]string      `protobuf:"bytes,16,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExecMode       string                 `protobuf:"bytes,17,opt,name=exec_mode,json=execMode,proto3" json:"exec_mode,omitempty"`
+	Args           []string               `protobuf:"bytes,18,rep,name=args,proto3" json:"args,omitempty"`
+	Interpreter    string                 `protobuf:"bytes,19,opt,name=interpreter,proto3" json:"interpreter,omitempty"`
+	WorkDir        string                 `protobuf:"bytes,20,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	Umask          string                 `protobuf:"bytes,21,opt,name=umask,proto3" json:"umask,omitempty"`
+	Type           string                 `protobuf:"bytes,22,opt,name=type,proto3" json:"type,omitempty"`
+	Config         string                 `protobuf:"bytes,23,opt,name=config,proto3" json:"config,omitempty"`
+	Limits         string                 `protobuf:"bytes,24,opt,name=limits,proto3" json:"limits,omitempty"`
+	Sandbox        string                 `protobuf:"bytes,25,opt,name=sandbox,proto3" json:"sandbox,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateJobRequest) Reset() {
+	*x = CreateJobRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJobRequest) ProtoMessage() {}
+
+func (x *CreateJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJobRequest.ProtoReflect.Descriptor instead.
+func (*CreateJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetRetryAttempts() int32 {
+	if x != nil {
+		return x.RetryAttempts
+	}
+	return 0
+}
+
+func (x *CreateJobRequest) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *CreateJobRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *CreateJobRequest) GetDepartmentId() string {
+	if x != nil {
+		return x.DepartmentId
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetOnSuccess() []string {
+	if x != nil {
+		return x.OnSuccess
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetOnFailure() []string {
+	if x != nil {
+		return x.OnFailure
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetOnFinish() []string {
+	if x != nil {
+		return x.OnFinish
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetSlaStartBy() string {
+	if x != nil {
+		return x.SlaStartBy
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetSlaFinishBy() string {
+	if x != nil {
+		return x.SlaFinishBy
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetSlaMaxDuration() int32 {
+	if x != nil {
+		return x.SlaMaxDuration
+	}
+	return 0
+}
+
+func (x *CreateJobRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetExecMode() string {
+	if x != nil {
+		return x.ExecMode
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetInterpreter() string {
+	if x != nil {
+		return x.Interpreter
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetWorkDir() string {
+	if x != nil {
+		return x.WorkDir
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetUmask() string {
+	if x != nil {
+		return x.Umask
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetLimits() string {
+	if x != nil {
+		return x.Limits
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetSandbox() string {
+	if x != nil {
+		return x.Sandbox
+	}
+	return ""
+}
+
+type CreateJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateJobResponse) Reset() {
+	*x = CreateJobResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJobResponse) ProtoMessage() {}
+
+func (x *CreateJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJobResponse.ProtoReflect.Descriptor instead.
+func (*CreateJobResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// 获取任务请求
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobResponse) Reset() {
+	*x = GetJobResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobResponse) ProtoMessage() {}
+
+func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
+func (*GetJobResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// 任务列表请求
+type ListJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Keyword       string                 `protobuf:"bytes,3,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	Enabled       bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	DepartmentId  string                 `protobuf:"bytes,5,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	LabelSelector string                 `protobuf:"bytes,7,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`   // 标签选择器, 例如 team=data,env!=dev
+	State         string                 `protobuf:"bytes,8,opt,name=state,proto3" json:"state,omitempty"`                                        // enabled 或 disabled, 为空不过滤
+	LastRunStatus string                 `protobuf:"bytes,9,opt,name=last_run_status,json=lastRunStatus,proto3" json:"last_run_status,omitempty"` // 最近一次执行的状态, none 表示从未执行
+	ScheduleType  string                 `protobuf:"bytes,10,opt,name=schedule_type,json=scheduleType,proto3" json:"schedule_type,omitempty"`     // cron、interval(@every) 或 event(配置了事件触发器)
+	SortBy        string                 `protobuf:"bytes,11,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`                       // name、created_at、updated_at、priority、last_run
+	SortOrder     string                 `protobuf:"bytes,12,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`              // asc 或 desc
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListJobsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListJobsRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListJobsRequest) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *ListJobsRequest) GetDepartmentId() string {
+	if x != nil {
+		return x.DepartmentId
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetLastRunStatus() string {
+	if x != nil {
+		return x.LastRunStatus
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetScheduleType() string {
+	if x != nil {
+		return x.ScheduleType
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+type ListJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ListJobsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 更新任务请求
+type UpdateJobRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Cron           string                 `protobuf:"bytes,4,opt,name=cron,proto3" json:"cron,omitempty"`
+	Command        string                 `protobuf:"bytes,5,opt,name=command,proto3" json:"command,omitempty"`
+	Params         map[string]string      `protobuf:"bytes,6,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Enabled        bool                   `protobuf:"varint,7,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	RetryAttempts  int32                  `protobuf:"varint,8,opt,name=retry_attempts,json=retryAttempts,proto3" json:"retry_attempts,omitempty"`
+	Timeout        int32                  `protobuf:"varint,9,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Priority       int32                  `protobuf:"varint,10,opt,name=priority,proto3" json:"priority,omitempty"`
+	DepartmentId   string                 `protobuf:"bytes,11,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"`
+	OnSuccess      []string               `protobuf:"bytes,12,rep,name=on_success,json=onSuccess,proto3" json:"on_success,omitempty"`
+	OnFailure      []string               `protobuf:"bytes,13,rep,name=on_failure,json=onFailure,proto3" json:"on_failure,omitempty"`
+	OnFinish       []string               `protobuf:"bytes,14,rep,name=on_finish,json=onFinish,proto3" json:"on_finish,omitempty"`
+	SlaStartBy     string                 `protobuf:"bytes,15,opt,name=sla_start_by,json=slaStartBy,proto3" json:"sla_start_by,omitempty"`
+	SlaFinishBy    string                 `protobuf:"bytes,16,opt,name=sla_finish_by,json=slaFinishBy,proto3" json:"sla_finish_by,omitempty"`
+	SlaMaxDuration int32                  `protobuf:"varint,17,opt,name=sla_max_duration,json=slaMaxDuration,proto3" json:"sla_max_duration,omitempty"`
+	Labels         map[string]string      `protobuf:"bytes,18,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExecMode       string                 `protobuf:"bytes,19,opt,name=exec_mode,json=execMode,proto3" json:"exec_mode,omitempty"`
+	Args           []string               `protobuf:"bytes,20,rep,name=args,proto3" json:"args,omitempty"`
+	Interpreter    string                 `protobuf:"bytes,21,opt,name=interpreter,proto3" json:"interpreter,omitempty"`
+	WorkDir        string                 `protobuf:"bytes,22,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	Umask          string                 `protobuf:"bytes,23,opt,name=umask,proto3" json:"umask,omitempty"`
+	Type           string                 `protobuf:"bytes,24,opt,name=type,proto3" json:"type,omitempty"`
+	Config         string                 `protobuf:"bytes,25,opt,name=config,proto3" json:"config,omitempty"`
+	Limits         string                 `protobuf:"bytes,26,opt,name=limits,proto3" json:"limits,omitempty"`
+	Sandbox        string                 `protobuf:"bytes,27,opt,name=sandbox,proto3" json:"sandbox,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateJobRequest) Reset() {
+	*x = UpdateJobRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateJobRequest) ProtoMessage() {}
+
+func (x *UpdateJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateJobRequest.ProtoReflect.Descriptor instead.
+func (*UpdateJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *UpdateJobRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *UpdateJobRequest) GetRetryAttempts() int32 {
+	if x != nil {
+		return x.RetryAttempts
+	}
+	return 0
+}
+
+func (x *UpdateJobRequest) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *UpdateJobRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *UpdateJobRequest) GetDepartmentId() string {
+	if x != nil {
+		return x.DepartmentId
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetOnSuccess() []string {
+	if x != nil {
+		return x.OnSuccess
+	}
+	return nil
+}
+
+func (x *UpdateJobRequest) GetOnFailure() []string {
+	if x != nil {
+		return x.OnFailure
+	}
+	return nil
+}
+
+func (x *UpdateJobRequest) GetOnFinish() []string {
+	if x != nil {
+		return x.OnFinish
+	}
+	return nil
+}
+
+func (x *UpdateJobRequest) GetSlaStartBy() string {
+	if x != nil {
+		return x.SlaStartBy
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetSlaFinishBy() string {
+	if x != nil {
+		return x.SlaFinishBy
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetSlaMaxDuration() int32 {
+	if x != nil {
+		return x.SlaMaxDuration
+	}
+	return 0
+}
+
+func (x *UpdateJobRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UpdateJobRequest) GetExecMode() string {
+	if x != nil {
+		return x.ExecMode
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *UpdateJobRequest) GetInterpreter() string {
+	if x != nil {
+		return x.Interpreter
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetWorkDir() string {
+	if x != nil {
+		return x.WorkDir
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetUmask() string {
+	if x != nil {
+		return x.Umask
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetLimits() string {
+	if x != nil {
+		return x.Limits
+	}
+	return ""
+}
+
+func (x *UpdateJobRequest) GetSandbox() string {
+	if x != nil {
+		return x.Sandbox
+	}
+	return ""
+}
+
+type UpdateJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateJobResponse) Reset() {
+	*x = UpdateJobResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateJobResponse) ProtoMessage() {}
+
+func (x *UpdateJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateJobResponse.ProtoReflect.Descriptor instead.
+func (*UpdateJobResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// 删除任务请求
+type DeleteJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobRequest) Reset() {
+	*x = DeleteJobRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobRequest) ProtoMessage() {}
+
+func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobRequest.ProtoReflect.Descriptor instead.
+func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobResponse) Reset() {
+	*x = DeleteJobResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobResponse) ProtoMessage() {}
+
+func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobResponse.ProtoReflect.Descriptor instead.
+func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteJobResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// 触发任务请求
+type TriggerJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Params        map[string]string      `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerJobRequest) Reset() {
+	*x = TriggerJobRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerJobRequest) ProtoMessage() {}
+
+func (x *TriggerJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerJobRequest.ProtoReflect.Descriptor instead.
+func (*TriggerJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TriggerJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TriggerJobRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type TriggerJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerJobResponse) Reset() {
+	*x = TriggerJobResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerJobResponse) ProtoMessage() {}
+
+func (x *TriggerJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerJobResponse.ProtoReflect.Descriptor instead.
+func (*TriggerJobResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TriggerJobResponse) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+// 取消执行请求
+type CancelExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelExecutionRequest) Reset() {
+	*x = CancelExecutionRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelExecutionRequest) ProtoMessage() {}
+
+func (x *CancelExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelExecutionRequest.ProtoReflect.Descriptor instead.
+func (*CancelExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CancelExecutionRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *CancelExecutionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelExecutionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	WorkerNotified bool                   `protobuf:"varint,2,opt,name=worker_notified,json=workerNotified,proto3" json:"worker_notified,omitempty"` // 是否已通知工作节点终止任务, 尚未下发的执行直接取消
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelExecutionResponse) Reset() {
+	*x = CancelExecutionResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelExecutionResponse) ProtoMessage() {}
+
+func (x *CancelExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelExecutionResponse.ProtoReflect.Descriptor instead.
+func (*CancelExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CancelExecutionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelExecutionResponse) GetWorkerNotified() bool {
+	if x != nil {
+		return x.WorkerNotified
+	}
+	return false
+}
+
+// 任务版本列表请求
+type ListJobRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobRevisionsRequest) Reset() {
+	*x = ListJobRevisionsRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobRevisionsRequest) ProtoMessage() {}
+
+func (x *ListJobRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListJobRevisionsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ListJobRevisionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListJobRevisionsRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ListJobRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*JobRevision         `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobRevisionsResponse) Reset() {
+	*x = ListJobRevisionsResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobRevisionsResponse) ProtoMessage() {}
+
+func (x *ListJobRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListJobRevisionsResponse) GetRevisions() []*JobRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+func (x *ListJobRevisionsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 任务版本对比请求
+type DiffJobRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	FromRevision  int32                  `protobuf:"varint,2,opt,name=from_revision,json=fromRevision,proto3" json:"from_revision,omitempty"`
+	ToRevision    int32                  `protobuf:"varint,3,opt,name=to_revision,json=toRevision,proto3" json:"to_revision,omitempty"` // 为 0 时与当前版本对比
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffJobRevisionsRequest) Reset() {
+	*x = DiffJobRevisionsRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffJobRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffJobRevisionsRequest) ProtoMessage() {}
+
+func (x *DiffJobRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffJobRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*DiffJobRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DiffJobRevisionsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *DiffJobRevisionsRequest) GetFromRevision() int32 {
+	if x != nil {
+		return x.FromRevision
+	}
+	return 0
+}
+
+func (x *DiffJobRevisionsRequest) GetToRevision() int32 {
+	if x != nil {
+		return x.ToRevision
+	}
+	return 0
+}
+
+type DiffJobRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromRevision  int32                  `protobuf:"varint,1,opt,name=from_revision,json=fromRevision,proto3" json:"from_revision,omitempty"`
+	ToRevision    int32                  `protobuf:"varint,2,opt,name=to_revision,json=toRevision,proto3" json:"to_revision,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffJobRevisionsResponse) Reset() {
+	*x = DiffJobRevisionsResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffJobRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffJobRevisionsResponse) ProtoMessage() {}
+
+func (x *DiffJobRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffJobRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*DiffJobRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DiffJobRevisionsResponse) GetFromRevision() int32 {
+	if x != nil {
+		return x.FromRevision
+	}
+	return 0
+}
+
+func (x *DiffJobRevisionsResponse) GetToRevision() int32 {
+	if x != nil {
+		return x.ToRevision
+	}
+	return 0
+}
+
+func (x *DiffJobRevisionsResponse) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// 任务回滚请求
+type RollbackJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Revision      int32                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackJobRequest) Reset() {
+	*x = RollbackJobRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackJobRequest) ProtoMessage() {}
+
+func (x *RollbackJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackJobRequest.ProtoReflect.Descriptor instead.
+func (*RollbackJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RollbackJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *RollbackJobRequest) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RollbackJobRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type RollbackJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Revision      *JobRevision           `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackJobResponse) Reset() {
+	*x = RollbackJobResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackJobResponse) ProtoMessage() {}
+
+func (x *RollbackJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackJobResponse.ProtoReflect.Descriptor instead.
+func (*RollbackJobResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RollbackJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *RollbackJobResponse) GetRevision() *JobRevision {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
+
+// 创建任务模板请求
+type CreateJobTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Command       string                 `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Params        []*TemplateParam       `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty"`
+	Timeout       int32                  `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	RetryAttempts int32                  `protobuf:"varint,6,opt,name=retry_attempts,json=retryAttempts,proto3" json:"retry_attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateJobTemplateRequest) Reset() {
+	*x = CreateJobTemplateRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJobTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJobTemplateRequest) ProtoMessage() {}
+
+func (x *CreateJobTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJobTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateJobTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateJobTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateJobTemplateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateJobTemplateRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *CreateJobTemplateRequest) GetParams() []*TemplateParam {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *CreateJobTemplateRequest) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *CreateJobTemplateRequest) GetRetryAttempts() int32 {
+	if x != nil {
+		return x.RetryAttempts
+	}
+	return 0
+}
+
+type CreateJobTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *JobTemplate           `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateJobTemplateResponse) Reset() {
+	*x = CreateJobTemplateResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJobTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJobTemplateResponse) ProtoMessage() {}
+
+func (x *CreateJobTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJobTemplateResponse.ProtoReflect.Descriptor instead.
+func (*CreateJobTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CreateJobTemplateResponse) GetTemplate() *JobTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+// 获取任务模板请求
+type GetJobTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobTemplateRequest) Reset() {
+	*x = GetJobTemplateRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobTemplateRequest) ProtoMessage() {}
+
+func (x *GetJobTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetJobTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetJobTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetJobTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *JobTemplate           `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobTemplateResponse) Reset() {
+	*x = GetJobTemplateResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobTemplateResponse) ProtoMessage() {}
+
+func (x *GetJobTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobTemplateResponse.ProtoReflect.Descriptor instead.
+func (*GetJobTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetJobTemplateResponse) GetTemplate() *JobTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+// 任务模板列表请求
+type ListJobTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Keyword       string                 `protobuf:"bytes,3,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobTemplatesRequest) Reset() {
+	*x = ListJobTemplatesRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobTemplatesRequest) ProtoMessage() {}
+
+func (x *ListJobTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListJobTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListJobTemplatesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListJobTemplatesRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListJobTemplatesRequest) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+type ListJobTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*JobTemplate         `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobTemplatesResponse) Reset() {
+	*x = ListJobTemplatesResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobTemplatesResponse) ProtoMessage() {}
+
+func (x *ListJobTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListJobTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListJobTemplatesResponse) GetTemplates() []*JobTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+func (x *ListJobTemplatesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 更新任务模板请求
+type UpdateJobTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Command       string                 `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
+	Params        []*TemplateParam       `protobuf:"bytes,5,rep,name=params,proto3" json:"params,omitempty"`
+	Timeout       int32                  `protobuf:"varint,6,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	RetryAttempts int32                  `protobuf:"varint,7,opt,name=retry_attempts,json=retryAttempts,proto3" json:"retry_attempts,omitempty"`
+	Propagate     bool                   `protobuf:"varint,8,opt,name=propagate,proto3" json:"propagate,omitempty"`         // 同步更新所有派生任务
+	DryRun        bool                   `protobuf:"varint,9,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // 只预览受影响的派生任务, 不保存
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateJobTemplateRequest) Reset() {
+	*x = UpdateJobTemplateRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateJobTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateJobTemplateRequest) ProtoMessage() {}
+
+func (x *UpdateJobTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateJobTemplateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateJobTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UpdateJobTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateJobTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateJobTemplateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateJobTemplateRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *UpdateJobTemplateRequest) GetParams() []*TemplateParam {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *UpdateJobTemplateRequest) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *UpdateJobTemplateRequest) GetRetryAttempts() int32 {
+	if x != nil {
+		return x.RetryAttempts
+	}
+	return 0
+}
+
+func (x *UpdateJobTemplateRequest) GetPropagate() bool {
+	if x != nil {
+		return x.Propagate
+	}
+	return false
+}
+
+func (x *UpdateJobTemplateRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type UpdateJobTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *JobTemplate           `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	AffectedJobs  []*TemplateJobChange   `protobuf:"bytes,2,rep,name=affected_jobs,json=affectedJobs,proto3" json:"affected_jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateJobTemplateResponse) Reset() {
+	*x = UpdateJobTemplateResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateJobTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateJobTemplateResponse) ProtoMessage() {}
+
+func (x *UpdateJobTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateJobTemplateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateJobTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *UpdateJobTemplateResponse) GetTemplate() *JobTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+func (x *UpdateJobTemplateResponse) GetAffectedJobs() []*TemplateJobChange {
+	if x != nil {
+		return x.AffectedJobs
+	}
+	return nil
+}
+
+// 删除任务模板请求
+type DeleteJobTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobTemplateRequest) Reset() {
+	*x = DeleteJobTemplateRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteJobTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteJobTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeleteJobTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteJobTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobTemplateResponse) Reset() {
+	*x = DeleteJobTemplateResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteJobTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteJobTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DeleteJobTemplateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// 从模板创建任务请求
+type InstantiateJobTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Cron          string                 `protobuf:"bytes,4,opt,name=cron,proto3" json:"cron,omitempty"`
+	Values        map[string]string      `protobuf:"bytes,5,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DepartmentId  string                 `protobuf:"bytes,6,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"`
+	Priority      int32                  `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstantiateJobTemplateRequest) Reset() {
+	*x = InstantiateJobTemplateRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstantiateJobTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstantiateJobTemplateRequest) ProtoMessage() {}
+
+func (x *InstantiateJobTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstantiateJobTemplateRequest.ProtoReflect.Descriptor instead.
+func (*InstantiateJobTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *InstantiateJobTemplateRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *InstantiateJobTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InstantiateJobTemplateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *InstantiateJobTemplateRequest) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *InstantiateJobTemplateRequest) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *InstantiateJobTemplateRequest) GetDepartmentId() string {
+	if x != nil {
+		return x.DepartmentId
+	}
+	return ""
+}
+
+func (x *InstantiateJobTemplateRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type InstantiateJobTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstantiateJobTemplateResponse) Reset() {
+	*x = InstantiateJobTemplateResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstantiateJobTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstantiateJobTemplateResponse) ProtoMessage() {}
+
+func (x *InstantiateJobTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstantiateJobTemplateResponse.ProtoReflect.Descriptor instead.
+func (*InstantiateJobTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *InstantiateJobTemplateResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// 批量任务操作请求
+type BulkJobOperationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`                                 // enable、disable、pause、resume、delete、trigger、move-department
+	JobIds        []string               `protobuf:"bytes,2,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"`                   // 指定任务, 与 filter 二选一
+	Filter        *ListJobsRequest       `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`                                 // 按列表过滤条件选择任务, 忽略分页和排序
+	Atomic        bool                   `protobuf:"varint,4,opt,name=atomic,proto3" json:"atomic,omitempty"`                                // 在同一事务中执行, 任一任务失败则全部回滚
+	DepartmentId  string                 `protobuf:"bytes,5,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"` // move-department 的目标部门
+	PausedUntil   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=paused_until,json=pausedUntil,proto3" json:"paused_until,omitempty"`    // pause 的截止时间, 为空表示直到手动恢复
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkJobOperationRequest) Reset() {
+	*x = BulkJobOperationRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkJobOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkJobOperationRequest) ProtoMessage() {}
+
+func (x *BulkJobOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkJobOperationRequest.ProtoReflect.Descriptor instead.
+func (*BulkJobOperationRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *BulkJobOperationRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *BulkJobOperationRequest) GetJobIds() []string {
+	if x != nil {
+		return x.JobIds
+	}
+	return nil
+}
+
+func (x *BulkJobOperationRequest) GetFilter() *ListJobsRequest {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *BulkJobOperationRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+func (x *BulkJobOperationRequest) GetDepartmentId() string {
+	if x != nil {
+		return x.DepartmentId
+	}
+	return ""
+}
+
+func (x *BulkJobOperationRequest) GetPausedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PausedUntil
+	}
+	return nil
+}
+
+// 单个任务的批量操作结果
+type BulkJobResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	JobName       string                 `protobuf:"bytes,2,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,5,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"` // trigger 创建的执行记录
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkJobResult) Reset() {
+	*x = BulkJobResult{}
+	mi := &file_api_grpc_job_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkJobResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkJobResult) ProtoMessage() {}
+
+func (x *BulkJobResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkJobResult.ProtoReflect.Descriptor instead.
+func (*BulkJobResult) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *BulkJobResult) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *BulkJobResult) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+func (x *BulkJobResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BulkJobResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BulkJobResult) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+type BulkJobOperationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BulkJobResult       `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Succeeded     int32                  `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	AuditId       string                 `protobuf:"bytes,4,opt,name=audit_id,json=auditId,proto3" json:"audit_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkJobOperationResponse) Reset() {
+	*x = BulkJobOperationResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkJobOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkJobOperationResponse) ProtoMessage() {}
+
+func (x *BulkJobOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkJobOperationResponse.ProtoReflect.Descriptor instead.
+func (*BulkJobOperationResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *BulkJobOperationResponse) GetResults() []*BulkJobResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BulkJobOperationResponse) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *BulkJobOperationResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BulkJobOperationResponse) GetAuditId() string {
+	if x != nil {
+		return x.AuditId
+	}
+	return ""
+}
+
+// 校验 Cron 表达式请求
+type ValidateCronRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cron          string                 `protobuf:"bytes,1,opt,name=cron,proto3" json:"cron,omitempty"`
+	Lang          string                 `protobuf:"bytes,2,opt,name=lang,proto3" json:"lang,omitempty"`                             // 描述语言: zh 或 en, 默认 zh
+	NextCount     int32                  `protobuf:"varint,3,opt,name=next_count,json=nextCount,proto3" json:"next_count,omitempty"` // 返回接下来几次运行时间, 默认 5
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateCronRequest) Reset() {
+	*x = ValidateCronRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateCronRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCronRequest) ProtoMessage() {}
+
+func (x *ValidateCronRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCronRequest.ProtoReflect.Descriptor instead.
+func (*ValidateCronRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ValidateCronRequest) GetCron() string {
 	if x != nil {
-		return x.Priority
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *ValidateCronRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *ValidateCronRequest) GetNextCount() int32 {
+	if x != nil {
+		return x.NextCount
+	}
+	return 0
+}
+
+// 校验 Cron 表达式响应
+type ValidateCronResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Valid         bool                     `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Error         string                   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorPosition int32                    `protobuf:"varint,3,opt,name=error_position,json=errorPosition,proto3" json:"error_position,omitempty"` // 出错字段的位置, 从 1 开始, 0 表示非字段级错误
+	ErrorField    string                   `protobuf:"bytes,4,opt,name=error_field,json=errorField,proto3" json:"error_field,omitempty"`
+	Description   string                   `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	NextRuns      []*timestamppb.Timestamp `protobuf:"bytes,6,rep,name=next_runs,json=nextRuns,proto3" json:"next_runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateCronResponse) Reset() {
+	*x = ValidateCronResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateCronResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCronResponse) ProtoMessage() {}
+
+func (x *ValidateCronResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCronResponse.ProtoReflect.Descriptor instead.
+func (*ValidateCronResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ValidateCronResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateCronResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ValidateCronResponse) GetErrorPosition() int32 {
+	if x != nil {
+		return x.ErrorPosition
+	}
+	return 0
+}
+
+func (x *ValidateCronResponse) GetErrorField() string {
+	if x != nil {
+		return x.ErrorField
+	}
+	return ""
+}
+
+func (x *ValidateCronResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ValidateCronResponse) GetNextRuns() []*timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRuns
+	}
+	return nil
+}
+
+// 注册工作节点请求
+type RegisterWorkerRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Ip       string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port     int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Capacity int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Metadata map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 进程内处理函数名称, handler 类型的任务只分配给提供对应处理函数的工作节点
+	Handlers      []string `protobuf:"bytes,6,rep,name=handlers,proto3" json:"handlers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterWorkerRequest) Reset() {
+	*x = RegisterWorkerRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterWorkerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWorkerRequest) ProtoMessage() {}
+
+func (x *RegisterWorkerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWorkerRequest.ProtoReflect.Descriptor instead.
+func (*RegisterWorkerRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RegisterWorkerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterWorkerRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *RegisterWorkerRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *RegisterWorkerRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *RegisterWorkerRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *RegisterWorkerRequest) GetHandlers() []string {
+	if x != nil {
+		return x.Handlers
+	}
+	return nil
+}
+
+type RegisterWorkerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkerId      string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterWorkerResponse) Reset() {
+	*x = RegisterWorkerResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterWorkerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWorkerResponse) ProtoMessage() {}
+
+func (x *RegisterWorkerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWorkerResponse.ProtoReflect.Descriptor instead.
+func (*RegisterWorkerResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RegisterWorkerResponse) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+// 心跳请求
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkerId      string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	CurrentLoad   int32                  `protobuf:"varint,2,opt,name=current_load,json=currentLoad,proto3" json:"current_load,omitempty"`
+	Status        WorkerStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=api.grpc.WorkerStatus" json:"status,omitempty"`
+	Host          *HostMetrics           `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"` // 不支持采集的平台为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *HeartbeatRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetCurrentLoad() int32 {
+	if x != nil {
+		return x.CurrentLoad
 	}
 	return 0
 }
 
-func (x *CreateJobRequest) GetDepartmentId() string {
+func (x *HeartbeatRequest) GetStatus() WorkerStatus {
 	if x != nil {
-		return x.DepartmentId
+		return x.Status
 	}
-	return ""
+	return WorkerStatus_OFFLINE
 }
 
-type CreateJobResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *HeartbeatRequest) GetHost() *HostMetrics {
+	if x != nil {
+		return x.Host
+	}
+	return nil
 }
 
-func (x *CreateJobResponse) Reset() {
-	*x = CreateJobResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[9]
+// 工作节点所在主机的资源使用情况
+type HostMetrics struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	CpuPercent           float64                `protobuf:"fixed64,1,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"` // 距上次采集的 CPU 使用率, 所有 CPU 合计为 100
+	CpuCount             int32                  `protobuf:"varint,2,opt,name=cpu_count,json=cpuCount,proto3" json:"cpu_count,omitempty"`
+	Load1                float64                `protobuf:"fixed64,3,opt,name=load1,proto3" json:"load1,omitempty"`
+	Load5                float64                `protobuf:"fixed64,4,opt,name=load5,proto3" json:"load5,omitempty"`
+	Load15               float64                `protobuf:"fixed64,5,opt,name=load15,proto3" json:"load15,omitempty"`
+	MemoryTotalBytes     int64                  `protobuf:"varint,6,opt,name=memory_total_bytes,json=memoryTotalBytes,proto3" json:"memory_total_bytes,omitempty"`
+	MemoryAvailableBytes int64                  `protobuf:"varint,7,opt,name=memory_available_bytes,json=memoryAvailableBytes,proto3" json:"memory_available_bytes,omitempty"`
+	DiskTotalBytes       int64                  `protobuf:"varint,8,opt,name=disk_total_bytes,json=diskTotalBytes,proto3" json:"disk_total_bytes,omitempty"` // 工作节点临时目录所在的文件系统
+	DiskFreeBytes        int64                  `protobuf:"varint,9,opt,name=disk_free_bytes,json=diskFreeBytes,proto3" json:"disk_free_bytes,omitempty"`
+	HostUptimeSeconds    float64                `protobuf:"fixed64,10,opt,name=host_uptime_seconds,json=hostUptimeSeconds,proto3" json:"host_uptime_seconds,omitempty"`
+	UptimeSeconds        float64                `protobuf:"fixed64,11,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"` // 工作节点进程的运行时间
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *HostMetrics) Reset() {
+	*x = HostMetrics{}
+	mi := &file_api_grpc_job_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateJobResponse) String() string {
+func (x *HostMetrics) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateJobResponse) ProtoMessage() {}
+func (*HostMetrics) ProtoMessage() {}
 
-func (x *CreateJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[9]
+func (x *HostMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1299,85 +4716,111 @@ func (x *CreateJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateJobResponse.ProtoReflect.Descriptor instead.
-func (*CreateJobResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use HostMetrics.ProtoReflect.Descriptor instead.
+func (*HostMetrics) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *CreateJobResponse) GetJob() *Job {
+func (x *HostMetrics) GetCpuPercent() float64 {
 	if x != nil {
-		return x.Job
+		return x.CpuPercent
 	}
-	return nil
+	return 0
 }
 
-// 获取任务请求
-type GetJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *HostMetrics) GetCpuCount() int32 {
+	if x != nil {
+		return x.CpuCount
+	}
+	return 0
 }
 
-func (x *GetJobRequest) Reset() {
-	*x = GetJobRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *HostMetrics) GetLoad1() float64 {
+	if x != nil {
+		return x.Load1
+	}
+	return 0
 }
 
-func (x *GetJobRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *HostMetrics) GetLoad5() float64 {
+	if x != nil {
+		return x.Load5
+	}
+	return 0
 }
 
-func (*GetJobRequest) ProtoMessage() {}
+func (x *HostMetrics) GetLoad15() float64 {
+	if x != nil {
+		return x.Load15
+	}
+	return 0
+}
 
-func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[10]
+func (x *HostMetrics) GetMemoryTotalBytes() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.MemoryTotalBytes
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
-func (*GetJobRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{10}
+func (x *HostMetrics) GetMemoryAvailableBytes() int64 {
+	if x != nil {
+		return x.MemoryAvailableBytes
+	}
+	return 0
 }
 
-func (x *GetJobRequest) GetId() string {
+func (x *HostMetrics) GetDiskTotalBytes() int64 {
 	if x != nil {
-		return x.Id
+		return x.DiskTotalBytes
 	}
-	return ""
+	return 0
 }
 
-type GetJobResponse struct {
+func (x *HostMetrics) GetDiskFreeBytes() int64 {
+	if x != nil {
+		return x.DiskFreeBytes
+	}
+	return 0
+}
+
+func (x *HostMetrics) GetHostUptimeSeconds() float64 {
+	if x != nil {
+		return x.HostUptimeSeconds
+	}
+	return 0
+}
+
+func (x *HostMetrics) GetUptimeSeconds() float64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+type HeartbeatResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Cancels       []*CancelTask          `protobuf:"bytes,2,rep,name=cancels,proto3" json:"cancels,omitempty"` // 没有推送连接的工作节点通过心跳响应接收取消指令
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobResponse) Reset() {
-	*x = GetJobResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[11]
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobResponse) String() string {
+func (x *HeartbeatResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobResponse) ProtoMessage() {}
+func (*HeartbeatResponse) ProtoMessage() {}
 
-func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[11]
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1388,46 +4831,49 @@ func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
-func (*GetJobResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *GetJobResponse) GetJob() *Job {
+func (x *HeartbeatResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Job
+		return x.Success
+	}
+	return false
+}
+
+func (x *HeartbeatResponse) GetCancels() []*CancelTask {
+	if x != nil {
+		return x.Cancels
 	}
 	return nil
 }
 
-// 任务列表请求
-type ListJobsRequest struct {
+// 获取任务请求
+type GetTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
-	Keyword       string                 `protobuf:"bytes,3,opt,name=keyword,proto3" json:"keyword,omitempty"`
-	Enabled       bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	DepartmentId  string                 `protobuf:"bytes,5,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"`
-	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	WorkerId      string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Capacity      int32                  `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListJobsRequest) Reset() {
-	*x = ListJobsRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[12]
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListJobsRequest) String() string {
+func (x *GetTaskRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListJobsRequest) ProtoMessage() {}
+func (*GetTaskRequest) ProtoMessage() {}
 
-func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[12]
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1438,76 +4884,47 @@ func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
-func (*ListJobsRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *ListJobsRequest) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-func (x *ListJobsRequest) GetSize() int32 {
-	if x != nil {
-		return x.Size
-	}
-	return 0
-}
-
-func (x *ListJobsRequest) GetKeyword() string {
-	if x != nil {
-		return x.Keyword
-	}
-	return ""
-}
-
-func (x *ListJobsRequest) GetEnabled() bool {
-	if x != nil {
-		return x.Enabled
-	}
-	return false
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *ListJobsRequest) GetDepartmentId() string {
+func (x *GetTaskRequest) GetWorkerId() string {
 	if x != nil {
-		return x.DepartmentId
+		return x.WorkerId
 	}
 	return ""
 }
 
-func (x *ListJobsRequest) GetCreatedBy() string {
+func (x *GetTaskRequest) GetCapacity() int32 {
 	if x != nil {
-		return x.CreatedBy
+		return x.Capacity
 	}
-	return ""
+	return 0
 }
 
-type ListJobsResponse struct {
+type GetTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListJobsResponse) Reset() {
-	*x = ListJobsResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[13]
+func (x *GetTaskResponse) Reset() {
+	*x = GetTaskResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListJobsResponse) String() string {
+func (x *GetTaskResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListJobsResponse) ProtoMessage() {}
+func (*GetTaskResponse) ProtoMessage() {}
 
-func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[13]
+func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1518,58 +4935,58 @@ func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
-func (*ListJobsResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *ListJobsResponse) GetJobs() []*Job {
+func (x *GetTaskResponse) GetTasks() []*Task {
 	if x != nil {
-		return x.Jobs
+		return x.Tasks
 	}
 	return nil
 }
 
-func (x *ListJobsResponse) GetTotal() int64 {
-	if x != nil {
-		return x.Total
-	}
-	return 0
-}
-
-// 更新任务请求
-type UpdateJobRequest struct {
+// 任务
+type Task struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Cron          string                 `protobuf:"bytes,4,opt,name=cron,proto3" json:"cron,omitempty"`
-	Command       string                 `protobuf:"bytes,5,opt,name=command,proto3" json:"command,omitempty"`
-	Params        map[string]string      `protobuf:"bytes,6,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Enabled       bool                   `protobuf:"varint,7,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	RetryAttempts int32                  `protobuf:"varint,8,opt,name=retry_attempts,json=retryAttempts,proto3" json:"retry_attempts,omitempty"`
-	Timeout       int32                  `protobuf:"varint,9,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	Priority      int32                  `protobuf:"varint,10,opt,name=priority,proto3" json:"priority,omitempty"`
-	DepartmentId  string                 `protobuf:"bytes,11,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Command       string                 `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Params        map[string]string      `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Timeout       int32                  `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	RetryAttempts int32                  `protobuf:"varint,6,opt,name=retry_attempts,json=retryAttempts,proto3" json:"retry_attempts,omitempty"`
+	Traceparent   string                 `protobuf:"bytes,7,opt,name=traceparent,proto3" json:"traceparent,omitempty"` // W3C Trace Context, 工作节点据此继续追踪并传给子进程
+	ExecMode      string                 `protobuf:"bytes,8,opt,name=exec_mode,json=execMode,proto3" json:"exec_mode,omitempty"`
+	Args          []string               `protobuf:"bytes,9,rep,name=args,proto3" json:"args,omitempty"`
+	Interpreter   string                 `protobuf:"bytes,10,opt,name=interpreter,proto3" json:"interpreter,omitempty"`
+	WorkDir       string                 `protobuf:"bytes,11,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	Umask         string                 `protobuf:"bytes,12,opt,name=umask,proto3" json:"umask,omitempty"`
+	Type          string                 `protobuf:"bytes,13,opt,name=type,proto3" json:"type,omitempty"`             // 任务类型, 为空时为 command
+	Config        string                 `protobuf:"bytes,14,opt,name=config,proto3" json:"config,omitempty"`         // 任务类型的 JSON 配置
+	Connection    *Connection            `protobuf:"bytes,15,opt,name=connection,proto3" json:"connection,omitempty"` // sql 类型任务引用的数据库连接
+	Limits        string                 `protobuf:"bytes,16,opt,name=limits,proto3" json:"limits,omitempty"`         // 资源限制, JSON
+	Sandbox       string                 `protobuf:"bytes,17,opt,name=sandbox,proto3" json:"sandbox,omitempty"`       // 合并部门默认值后的沙箱设置, JSON
+	Redact        []string               `protobuf:"bytes,18,rep,name=redact,proto3" json:"redact,omitempty"`         // 参数引用的密钥值, 工作节点从输出中屏蔽
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateJobRequest) Reset() {
-	*x = UpdateJobRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[14]
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_api_grpc_job_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateJobRequest) String() string {
+func (x *Task) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateJobRequest) ProtoMessage() {}
+func (*Task) ProtoMessage() {}
 
-func (x *UpdateJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[14]
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1580,155 +4997,162 @@ func (x *UpdateJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateJobRequest.ProtoReflect.Descriptor instead.
-func (*UpdateJobRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *UpdateJobRequest) GetId() string {
+func (x *Task) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *UpdateJobRequest) GetName() string {
+func (x *Task) GetJobId() string {
 	if x != nil {
-		return x.Name
+		return x.JobId
 	}
 	return ""
 }
 
-func (x *UpdateJobRequest) GetDescription() string {
+func (x *Task) GetCommand() string {
 	if x != nil {
-		return x.Description
+		return x.Command
 	}
 	return ""
 }
 
-func (x *UpdateJobRequest) GetCron() string {
+func (x *Task) GetParams() map[string]string {
 	if x != nil {
-		return x.Cron
+		return x.Params
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateJobRequest) GetCommand() string {
+func (x *Task) GetTimeout() int32 {
 	if x != nil {
-		return x.Command
+		return x.Timeout
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateJobRequest) GetParams() map[string]string {
+func (x *Task) GetRetryAttempts() int32 {
 	if x != nil {
-		return x.Params
+		return x.RetryAttempts
 	}
-	return nil
+	return 0
 }
 
-func (x *UpdateJobRequest) GetEnabled() bool {
+func (x *Task) GetTraceparent() string {
 	if x != nil {
-		return x.Enabled
+		return x.Traceparent
 	}
-	return false
+	return ""
 }
 
-func (x *UpdateJobRequest) GetRetryAttempts() int32 {
+func (x *Task) GetExecMode() string {
 	if x != nil {
-		return x.RetryAttempts
+		return x.ExecMode
 	}
-	return 0
+	return ""
 }
 
-func (x *UpdateJobRequest) GetTimeout() int32 {
+func (x *Task) GetArgs() []string {
 	if x != nil {
-		return x.Timeout
+		return x.Args
 	}
-	return 0
+	return nil
 }
 
-func (x *UpdateJobRequest) GetPriority() int32 {
+func (x *Task) GetInterpreter() string {
 	if x != nil {
-		return x.Priority
+		return x.Interpreter
 	}
-	return 0
+	return ""
 }
 
-func (x *UpdateJobRequest) GetDepartmentId() string {
+func (x *Task) GetWorkDir() string {
 	if x != nil {
-		return x.DepartmentId
+		return x.WorkDir
 	}
 	return ""
 }
 
-type UpdateJobResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Task) GetUmask() string {
+	if x != nil {
+		return x.Umask
+	}
+	return ""
 }
 
-func (x *UpdateJobResponse) Reset() {
-	*x = UpdateJobResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[15]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Task) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
 
-func (x *UpdateJobResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Task) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
 }
 
-func (*UpdateJobResponse) ProtoMessage() {}
+func (x *Task) GetConnection() *Connection {
+	if x != nil {
+		return x.Connection
+	}
+	return nil
+}
 
-func (x *UpdateJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[15]
+func (x *Task) GetLimits() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Limits
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use UpdateJobResponse.ProtoReflect.Descriptor instead.
-func (*UpdateJobResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{15}
+func (x *Task) GetSandbox() string {
+	if x != nil {
+		return x.Sandbox
+	}
+	return ""
 }
 
-func (x *UpdateJobResponse) GetJob() *Job {
+func (x *Task) GetRedact() []string {
 	if x != nil {
-		return x.Job
+		return x.Redact
 	}
 	return nil
 }
 
-// 删除任务请求
-type DeleteJobRequest struct {
+// 数据库连接
+type Connection struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Driver        string                 `protobuf:"bytes,2,opt,name=driver,proto3" json:"driver,omitempty"`
+	Dsn           string                 `protobuf:"bytes,3,opt,name=dsn,proto3" json:"dsn,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteJobRequest) Reset() {
-	*x = DeleteJobRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[16]
+func (x *Connection) Reset() {
+	*x = Connection{}
+	mi := &file_api_grpc_job_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteJobRequest) String() string {
+func (x *Connection) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteJobRequest) ProtoMessage() {}
+func (*Connection) ProtoMessage() {}
 
-func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[16]
+func (x *Connection) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1739,40 +5163,67 @@ func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteJobRequest.ProtoReflect.Descriptor instead.
-func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
+func (*Connection) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *DeleteJobRequest) GetId() string {
+func (x *Connection) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-type DeleteJobResponse struct {
+func (x *Connection) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *Connection) GetDsn() string {
+	if x != nil {
+		return x.Dsn
+	}
+	return ""
+}
+
+// 任务结果报告请求
+type ReportTaskResultRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	WorkerId      string                 `protobuf:"bytes,2,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Status        ExecutionStatus        `protobuf:"varint,3,opt,name=status,proto3,enum=api.grpc.ExecutionStatus" json:"status,omitempty"`
+	Output        string                 `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Traceparent   string                 `protobuf:"bytes,9,opt,name=traceparent,proto3" json:"traceparent,omitempty"`                           // 工作节点执行跨度, 通过长连接上报时用于关联追踪
+	Result        string                 `protobuf:"bytes,10,opt,name=result,proto3" json:"result,omitempty"`                                    // 处理函数返回的结构化结果, JSON
+	Termination   string                 `protobuf:"bytes,11,opt,name=termination,proto3" json:"termination,omitempty"`                          // 任务被终止的原因: timeout、cancel 或 shutdown, 自行结束时为空
+	PeakRssBytes  int64                  `protobuf:"varint,12,opt,name=peak_rss_bytes,json=peakRssBytes,proto3" json:"peak_rss_bytes,omitempty"` // 任务进程的峰值常驻内存
+	CpuSeconds    float64                `protobuf:"fixed64,13,opt,name=cpu_seconds,json=cpuSeconds,proto3" json:"cpu_seconds,omitempty"`        // 任务进程消耗的 CPU 时间(用户态和内核态)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteJobResponse) Reset() {
-	*x = DeleteJobResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[17]
+func (x *ReportTaskResultRequest) Reset() {
+	*x = ReportTaskResultRequest{}
+	mi := &file_api_grpc_job_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteJobResponse) String() string {
+func (x *ReportTaskResultRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteJobResponse) ProtoMessage() {}
+func (*ReportTaskResultRequest) ProtoMessage() {}
 
-func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[17]
+func (x *ReportTaskResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1783,93 +5234,124 @@ func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteJobResponse.ProtoReflect.Descriptor instead.
-func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ReportTaskResultRequest.ProtoReflect.Descriptor instead.
+func (*ReportTaskResultRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *DeleteJobResponse) GetSuccess() bool {
+func (x *ReportTaskResultRequest) GetTaskId() string {
 	if x != nil {
-		return x.Success
+		return x.TaskId
 	}
-	return false
+	return ""
 }
 
-// 触发任务请求
-type TriggerJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Params        map[string]string      `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ReportTaskResultRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
 }
 
-func (x *TriggerJobRequest) Reset() {
-	*x = TriggerJobRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[18]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ReportTaskResultRequest) GetStatus() ExecutionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ExecutionStatus_PENDING
 }
 
-func (x *TriggerJobRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ReportTaskResultRequest) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
 }
 
-func (*TriggerJobRequest) ProtoMessage() {}
+func (x *ReportTaskResultRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
 
-func (x *TriggerJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[18]
+func (x *ReportTaskResultRequest) GetExitCode() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ExitCode
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use TriggerJobRequest.ProtoReflect.Descriptor instead.
-func (*TriggerJobRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{18}
+func (x *ReportTaskResultRequest) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
 }
 
-func (x *TriggerJobRequest) GetId() string {
+func (x *ReportTaskResultRequest) GetFinishedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *ReportTaskResultRequest) GetTraceparent() string {
+	if x != nil {
+		return x.Traceparent
 	}
 	return ""
 }
 
-func (x *TriggerJobRequest) GetParams() map[string]string {
+func (x *ReportTaskResultRequest) GetResult() string {
 	if x != nil {
-		return x.Params
+		return x.Result
 	}
-	return nil
+	return ""
+}
+
+func (x *ReportTaskResultRequest) GetTermination() string {
+	if x != nil {
+		return x.Termination
+	}
+	return ""
+}
+
+func (x *ReportTaskResultRequest) GetPeakRssBytes() int64 {
+	if x != nil {
+		return x.PeakRssBytes
+	}
+	return 0
+}
+
+func (x *ReportTaskResultRequest) GetCpuSeconds() float64 {
+	if x != nil {
+		return x.CpuSeconds
+	}
+	return 0
 }
 
-type TriggerJobResponse struct {
+type ReportTaskResultResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TriggerJobResponse) Reset() {
-	*x = TriggerJobResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[19]
+func (x *ReportTaskResultResponse) Reset() {
+	*x = ReportTaskResultResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TriggerJobResponse) String() string {
+func (x *ReportTaskResultResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TriggerJobResponse) ProtoMessage() {}
+func (*ReportTaskResultResponse) ProtoMessage() {}
 
-func (x *TriggerJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[19]
+func (x *ReportTaskResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1880,45 +5362,49 @@ func (x *TriggerJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TriggerJobResponse.ProtoReflect.Descriptor instead.
-func (*TriggerJobResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ReportTaskResultResponse.ProtoReflect.Descriptor instead.
+func (*ReportTaskResultResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *TriggerJobResponse) GetExecutionId() string {
+func (x *ReportTaskResultResponse) GetSuccess() bool {
 	if x != nil {
-		return x.ExecutionId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-// 注册工作节点请求
-type RegisterWorkerRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
-	Port          int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
-	Capacity      int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+// 工作节点发往调度器的长连接消息, 连接建立后第一条必须是 hello
+type WorkerMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*WorkerMessage_Hello
+	//	*WorkerMessage_Heartbeat
+	//	*WorkerMessage_Ack
+	//	*WorkerMessage_Result
+	//	*WorkerMessage_Output
+	//	*WorkerMessage_Progress
+	Payload       isWorkerMessage_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterWorkerRequest) Reset() {
-	*x = RegisterWorkerRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[20]
+func (x *WorkerMessage) Reset() {
+	*x = WorkerMessage{}
+	mi := &file_api_grpc_job_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterWorkerRequest) String() string {
+func (x *WorkerMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterWorkerRequest) ProtoMessage() {}
+func (*WorkerMessage) ProtoMessage() {}
 
-func (x *RegisterWorkerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[20]
+func (x *WorkerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1929,115 +5415,135 @@ func (x *RegisterWorkerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterWorkerRequest.ProtoReflect.Descriptor instead.
-func (*RegisterWorkerRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use WorkerMessage.ProtoReflect.Descriptor instead.
+func (*WorkerMessage) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *RegisterWorkerRequest) GetName() string {
+func (x *WorkerMessage) GetPayload() isWorkerMessage_Payload {
 	if x != nil {
-		return x.Name
+		return x.Payload
 	}
-	return ""
+	return nil
 }
 
-func (x *RegisterWorkerRequest) GetIp() string {
+func (x *WorkerMessage) GetHello() *WorkerHello {
 	if x != nil {
-		return x.Ip
+		if x, ok := x.Payload.(*WorkerMessage_Hello); ok {
+			return x.Hello
+		}
 	}
-	return ""
+	return nil
 }
 
-func (x *RegisterWorkerRequest) GetPort() int32 {
+func (x *WorkerMessage) GetHeartbeat() *HeartbeatRequest {
 	if x != nil {
-		return x.Port
+		if x, ok := x.Payload.(*WorkerMessage_Heartbeat); ok {
+			return x.Heartbeat
+		}
 	}
-	return 0
+	return nil
 }
 
-func (x *RegisterWorkerRequest) GetCapacity() int32 {
+func (x *WorkerMessage) GetAck() *TaskAck {
 	if x != nil {
-		return x.Capacity
+		if x, ok := x.Payload.(*WorkerMessage_Ack); ok {
+			return x.Ack
+		}
 	}
-	return 0
+	return nil
 }
 
-func (x *RegisterWorkerRequest) GetMetadata() map[string]string {
+func (x *WorkerMessage) GetResult() *ReportTaskResultRequest {
 	if x != nil {
-		return x.Metadata
+		if x, ok := x.Payload.(*WorkerMessage_Result); ok {
+			return x.Result
+		}
 	}
 	return nil
 }
 
-type RegisterWorkerResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WorkerId      string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *WorkerMessage) GetOutput() *TaskOutput {
+	if x != nil {
+		if x, ok := x.Payload.(*WorkerMessage_Output); ok {
+			return x.Output
+		}
+	}
+	return nil
 }
 
-func (x *RegisterWorkerResponse) Reset() {
-	*x = RegisterWorkerResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[21]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *WorkerMessage) GetProgress() *TaskProgress {
+	if x != nil {
+		if x, ok := x.Payload.(*WorkerMessage_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
 }
 
-func (x *RegisterWorkerResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+type isWorkerMessage_Payload interface {
+	isWorkerMessage_Payload()
 }
 
-func (*RegisterWorkerResponse) ProtoMessage() {}
+type WorkerMessage_Hello struct {
+	Hello *WorkerHello `protobuf:"bytes,1,opt,name=hello,proto3,oneof"`
+}
 
-func (x *RegisterWorkerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[21]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+type WorkerMessage_Heartbeat struct {
+	Heartbeat *HeartbeatRequest `protobuf:"bytes,2,opt,name=heartbeat,proto3,oneof"`
 }
 
-// Deprecated: Use RegisterWorkerResponse.ProtoReflect.Descriptor instead.
-func (*RegisterWorkerResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{21}
+type WorkerMessage_Ack struct {
+	Ack *TaskAck `protobuf:"bytes,3,opt,name=ack,proto3,oneof"`
 }
 
-func (x *RegisterWorkerResponse) GetWorkerId() string {
-	if x != nil {
-		return x.WorkerId
-	}
-	return ""
+type WorkerMessage_Result struct {
+	Result *ReportTaskResultRequest `protobuf:"bytes,4,opt,name=result,proto3,oneof"`
 }
 
-// 心跳请求
-type HeartbeatRequest struct {
+type WorkerMessage_Output struct {
+	Output *TaskOutput `protobuf:"bytes,5,opt,name=output,proto3,oneof"`
+}
+
+type WorkerMessage_Progress struct {
+	Progress *TaskProgress `protobuf:"bytes,6,opt,name=progress,proto3,oneof"`
+}
+
+func (*WorkerMessage_Hello) isWorkerMessage_Payload() {}
+
+func (*WorkerMessage_Heartbeat) isWorkerMessage_Payload() {}
+
+func (*WorkerMessage_Ack) isWorkerMessage_Payload() {}
+
+func (*WorkerMessage_Result) isWorkerMessage_Payload() {}
+
+func (*WorkerMessage_Output) isWorkerMessage_Payload() {}
+
+func (*WorkerMessage_Progress) isWorkerMessage_Payload() {}
+
+type WorkerHello struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WorkerId      string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
-	CurrentLoad   int32                  `protobuf:"varint,2,opt,name=current_load,json=currentLoad,proto3" json:"current_load,omitempty"`
-	Status        WorkerStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=api.grpc.WorkerStatus" json:"status,omitempty"`
+	Capacity      int32                  `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HeartbeatRequest) Reset() {
-	*x = HeartbeatRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[22]
+func (x *WorkerHello) Reset() {
+	*x = WorkerHello{}
+	mi := &file_api_grpc_job_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HeartbeatRequest) String() string {
+func (x *WorkerHello) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HeartbeatRequest) ProtoMessage() {}
+func (*WorkerHello) ProtoMessage() {}
 
-func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[22]
+func (x *WorkerHello) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2048,54 +5554,50 @@ func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
-func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use WorkerHello.ProtoReflect.Descriptor instead.
+func (*WorkerHello) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *HeartbeatRequest) GetWorkerId() string {
+func (x *WorkerHello) GetWorkerId() string {
 	if x != nil {
 		return x.WorkerId
 	}
 	return ""
 }
 
-func (x *HeartbeatRequest) GetCurrentLoad() int32 {
+func (x *WorkerHello) GetCapacity() int32 {
 	if x != nil {
-		return x.CurrentLoad
+		return x.Capacity
 	}
 	return 0
 }
 
-func (x *HeartbeatRequest) GetStatus() WorkerStatus {
-	if x != nil {
-		return x.Status
-	}
-	return WorkerStatus_OFFLINE
-}
-
-type HeartbeatResponse struct {
+// 任务确认, 拒绝的任务由调度器稍后重新下发
+type TaskAck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Accepted      bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HeartbeatResponse) Reset() {
-	*x = HeartbeatResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[23]
+func (x *TaskAck) Reset() {
+	*x = TaskAck{}
+	mi := &file_api_grpc_job_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HeartbeatResponse) String() string {
+func (x *TaskAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HeartbeatResponse) ProtoMessage() {}
+func (*TaskAck) ProtoMessage() {}
 
-func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[23]
+func (x *TaskAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2106,42 +5608,59 @@ func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
-func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use TaskAck.ProtoReflect.Descriptor instead.
+func (*TaskAck) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *HeartbeatResponse) GetSuccess() bool {
+func (x *TaskAck) GetTaskId() string {
 	if x != nil {
-		return x.Success
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskAck) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
 	}
 	return false
 }
 
-// 获取任务请求
-type GetTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WorkerId      string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
-	Capacity      int32                  `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+func (x *TaskAck) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 调度器发往工作节点的长连接消息
+type SchedulerMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*SchedulerMessage_Task
+	//	*SchedulerMessage_Cancel
+	Payload       isSchedulerMessage_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskRequest) Reset() {
-	*x = GetTaskRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[24]
+func (x *SchedulerMessage) Reset() {
+	*x = SchedulerMessage{}
+	mi := &file_api_grpc_job_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskRequest) String() string {
+func (x *SchedulerMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskRequest) ProtoMessage() {}
+func (*SchedulerMessage) ProtoMessage() {}
 
-func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[24]
+func (x *SchedulerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2152,47 +5671,75 @@ func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use SchedulerMessage.ProtoReflect.Descriptor instead.
+func (*SchedulerMessage) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *GetTaskRequest) GetWorkerId() string {
+func (x *SchedulerMessage) GetPayload() isSchedulerMessage_Payload {
 	if x != nil {
-		return x.WorkerId
+		return x.Payload
 	}
-	return ""
+	return nil
 }
 
-func (x *GetTaskRequest) GetCapacity() int32 {
+func (x *SchedulerMessage) GetTask() *Task {
 	if x != nil {
-		return x.Capacity
+		if x, ok := x.Payload.(*SchedulerMessage_Task); ok {
+			return x.Task
+		}
 	}
-	return 0
+	return nil
 }
 
-type GetTaskResponse struct {
+func (x *SchedulerMessage) GetCancel() *CancelTask {
+	if x != nil {
+		if x, ok := x.Payload.(*SchedulerMessage_Cancel); ok {
+			return x.Cancel
+		}
+	}
+	return nil
+}
+
+type isSchedulerMessage_Payload interface {
+	isSchedulerMessage_Payload()
+}
+
+type SchedulerMessage_Task struct {
+	Task *Task `protobuf:"bytes,1,opt,name=task,proto3,oneof"`
+}
+
+type SchedulerMessage_Cancel struct {
+	Cancel *CancelTask `protobuf:"bytes,2,opt,name=cancel,proto3,oneof"`
+}
+
+func (*SchedulerMessage_Task) isSchedulerMessage_Payload() {}
+
+func (*SchedulerMessage_Cancel) isSchedulerMessage_Payload() {}
+
+type CancelTask struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskResponse) Reset() {
-	*x = GetTaskResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[25]
+func (x *CancelTask) Reset() {
+	*x = CancelTask{}
+	mi := &file_api_grpc_job_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskResponse) String() string {
+func (x *CancelTask) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskResponse) ProtoMessage() {}
+func (*CancelTask) ProtoMessage() {}
 
-func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[25]
+func (x *CancelTask) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2203,46 +5750,53 @@ func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
-func (*GetTaskResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use CancelTask.ProtoReflect.Descriptor instead.
+func (*CancelTask) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *GetTaskResponse) GetTasks() []*Task {
+func (x *CancelTask) GetTaskId() string {
 	if x != nil {
-		return x.Tasks
+		return x.TaskId
 	}
-	return nil
+	return ""
 }
 
-// 任务
-type Task struct {
+func (x *CancelTask) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 任务输出片段, 每个输出流的 seq 从 1 开始递增
+type TaskOutput struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Command       string                 `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
-	Params        map[string]string      `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Timeout       int32                  `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	RetryAttempts int32                  `protobuf:"varint,6,opt,name=retry_attempts,json=retryAttempts,proto3" json:"retry_attempts,omitempty"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	WorkerId      string                 `protobuf:"bytes,2,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Stream        OutputStream           `protobuf:"varint,3,opt,name=stream,proto3,enum=api.grpc.OutputStream" json:"stream,omitempty"`
+	Seq           int64                  `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
+	Data          []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task) Reset() {
-	*x = Task{}
-	mi := &file_api_grpc_job_proto_msgTypes[26]
+func (x *TaskOutput) Reset() {
+	*x = TaskOutput{}
+	mi := &file_api_grpc_job_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task) String() string {
+func (x *TaskOutput) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task) ProtoMessage() {}
+func (*TaskOutput) ProtoMessage() {}
 
-func (x *Task) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[26]
+func (x *TaskOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2253,83 +5807,75 @@ func (x *Task) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task.ProtoReflect.Descriptor instead.
-func (*Task) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use TaskOutput.ProtoReflect.Descriptor instead.
+func (*TaskOutput) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *Task) GetId() string {
+func (x *TaskOutput) GetTaskId() string {
 	if x != nil {
-		return x.Id
+		return x.TaskId
 	}
 	return ""
 }
 
-func (x *Task) GetJobId() string {
+func (x *TaskOutput) GetWorkerId() string {
 	if x != nil {
-		return x.JobId
+		return x.WorkerId
 	}
 	return ""
 }
 
-func (x *Task) GetCommand() string {
+func (x *TaskOutput) GetStream() OutputStream {
 	if x != nil {
-		return x.Command
+		return x.Stream
 	}
-	return ""
+	return OutputStream_STDOUT
 }
 
-func (x *Task) GetParams() map[string]string {
+func (x *TaskOutput) GetSeq() int64 {
 	if x != nil {
-		return x.Params
+		return x.Seq
 	}
-	return nil
+	return 0
 }
 
-func (x *Task) GetTimeout() int32 {
+func (x *TaskOutput) GetData() []byte {
 	if x != nil {
-		return x.Timeout
+		return x.Data
 	}
-	return 0
+	return nil
 }
 
-func (x *Task) GetRetryAttempts() int32 {
+func (x *TaskOutput) GetTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.RetryAttempts
+		return x.Time
 	}
-	return 0
+	return nil
 }
 
-// 任务结果报告请求
-type ReportTaskResultRequest struct {
+type ReportTaskOutputResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	WorkerId      string                 `protobuf:"bytes,2,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
-	Status        ExecutionStatus        `protobuf:"varint,3,opt,name=status,proto3,enum=api.grpc.ExecutionStatus" json:"status,omitempty"`
-	Output        string                 `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"`
-	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
-	ExitCode      int32                  `protobuf:"varint,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
-	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportTaskResultRequest) Reset() {
-	*x = ReportTaskResultRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[27]
+func (x *ReportTaskOutputResponse) Reset() {
+	*x = ReportTaskOutputResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportTaskResultRequest) String() string {
+func (x *ReportTaskOutputResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportTaskResultRequest) ProtoMessage() {}
+func (*ReportTaskOutputResponse) ProtoMessage() {}
 
-func (x *ReportTaskResultRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[27]
+func (x *ReportTaskOutputResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2340,89 +5886,117 @@ func (x *ReportTaskResultRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportTaskResultRequest.ProtoReflect.Descriptor instead.
-func (*ReportTaskResultRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use ReportTaskOutputResponse.ProtoReflect.Descriptor instead.
+func (*ReportTaskOutputResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *ReportTaskResultRequest) GetTaskId() string {
+func (x *ReportTaskOutputResponse) GetSuccess() bool {
 	if x != nil {
-		return x.TaskId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *ReportTaskResultRequest) GetWorkerId() string {
-	if x != nil {
-		return x.WorkerId
-	}
-	return ""
+// 处理函数的执行进度
+type TaskProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	WorkerId      string                 `protobuf:"bytes,2,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Percent       int32                  `protobuf:"varint,3,opt,name=percent,proto3" json:"percent,omitempty"` // 0-100
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportTaskResultRequest) GetStatus() ExecutionStatus {
+func (x *TaskProgress) Reset() {
+	*x = TaskProgress{}
+	mi := &file_api_grpc_job_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskProgress) ProtoMessage() {}
+
+func (x *TaskProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[68]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ExecutionStatus_PENDING
+	return mi.MessageOf(x)
 }
 
-func (x *ReportTaskResultRequest) GetOutput() string {
+// Deprecated: Use TaskProgress.ProtoReflect.Descriptor instead.
+func (*TaskProgress) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *TaskProgress) GetTaskId() string {
 	if x != nil {
-		return x.Output
+		return x.TaskId
 	}
 	return ""
 }
 
-func (x *ReportTaskResultRequest) GetError() string {
+func (x *TaskProgress) GetWorkerId() string {
 	if x != nil {
-		return x.Error
+		return x.WorkerId
 	}
 	return ""
 }
 
-func (x *ReportTaskResultRequest) GetExitCode() int32 {
+func (x *TaskProgress) GetPercent() int32 {
 	if x != nil {
-		return x.ExitCode
+		return x.Percent
 	}
 	return 0
 }
 
-func (x *ReportTaskResultRequest) GetStartedAt() *timestamppb.Timestamp {
+func (x *TaskProgress) GetMessage() string {
 	if x != nil {
-		return x.StartedAt
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-func (x *ReportTaskResultRequest) GetFinishedAt() *timestamppb.Timestamp {
+func (x *TaskProgress) GetTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.FinishedAt
+		return x.Time
 	}
 	return nil
 }
 
-type ReportTaskResultResponse struct {
+type ReportTaskProgressResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportTaskResultResponse) Reset() {
-	*x = ReportTaskResultResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[28]
+func (x *ReportTaskProgressResponse) Reset() {
+	*x = ReportTaskProgressResponse{}
+	mi := &file_api_grpc_job_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportTaskResultResponse) String() string {
+func (x *ReportTaskProgressResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportTaskResultResponse) ProtoMessage() {}
+func (*ReportTaskProgressResponse) ProtoMessage() {}
 
-func (x *ReportTaskResultResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[28]
+func (x *ReportTaskProgressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_job_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2433,12 +6007,12 @@ func (x *ReportTaskResultResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportTaskResultResponse.ProtoReflect.Descriptor instead.
-func (*ReportTaskResultResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use ReportTaskProgressResponse.ProtoReflect.Descriptor instead.
+func (*ReportTaskProgressResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *ReportTaskResultResponse) GetSuccess() bool {
+func (x *ReportTaskProgressResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
@@ -2456,7 +6030,7 @@ type LoginRequest struct {
 
 func (x *LoginRequest) Reset() {
 	*x = LoginRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[29]
+	mi := &file_api_grpc_job_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2468,7 +6042,7 @@ func (x *LoginRequest) String() string {
 func (*LoginRequest) ProtoMessage() {}
 
 func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[29]
+	mi := &file_api_grpc_job_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2481,7 +6055,7 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
 func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{29}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *LoginRequest) GetUsername() string {
@@ -2512,7 +6086,7 @@ type LoginResponse struct {
 
 func (x *LoginResponse) Reset() {
 	*x = LoginResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[30]
+	mi := &file_api_grpc_job_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2524,7 +6098,7 @@ func (x *LoginResponse) String() string {
 func (*LoginResponse) ProtoMessage() {}
 
 func (x *LoginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[30]
+	mi := &file_api_grpc_job_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2537,7 +6111,7 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
 func (*LoginResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{30}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{71}
 }
 
 func (x *LoginResponse) GetAccessToken() string {
@@ -2591,7 +6165,7 @@ type LogoutRequest struct {
 
 func (x *LogoutRequest) Reset() {
 	*x = LogoutRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[31]
+	mi := &file_api_grpc_job_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2603,7 +6177,7 @@ func (x *LogoutRequest) String() string {
 func (*LogoutRequest) ProtoMessage() {}
 
 func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[31]
+	mi := &file_api_grpc_job_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2616,7 +6190,7 @@ func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
 func (*LogoutRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{31}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{72}
 }
 
 func (x *LogoutRequest) GetToken() string {
@@ -2635,7 +6209,7 @@ type LogoutResponse struct {
 
 func (x *LogoutResponse) Reset() {
 	*x = LogoutResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[32]
+	mi := &file_api_grpc_job_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2647,7 +6221,7 @@ func (x *LogoutResponse) String() string {
 func (*LogoutResponse) ProtoMessage() {}
 
 func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[32]
+	mi := &file_api_grpc_job_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2660,7 +6234,7 @@ func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
 func (*LogoutResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{32}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{73}
 }
 
 func (x *LogoutResponse) GetSuccess() bool {
@@ -2679,7 +6253,7 @@ type RefreshTokenRequest struct {
 
 func (x *RefreshTokenRequest) Reset() {
 	*x = RefreshTokenRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[33]
+	mi := &file_api_grpc_job_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2691,7 +6265,7 @@ func (x *RefreshTokenRequest) String() string {
 func (*RefreshTokenRequest) ProtoMessage() {}
 
 func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[33]
+	mi := &file_api_grpc_job_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2704,7 +6278,7 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
 func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{33}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{74}
 }
 
 func (x *RefreshTokenRequest) GetRefreshToken() string {
@@ -2725,7 +6299,7 @@ type RefreshTokenResponse struct {
 
 func (x *RefreshTokenResponse) Reset() {
 	*x = RefreshTokenResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[34]
+	mi := &file_api_grpc_job_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2737,7 +6311,7 @@ func (x *RefreshTokenResponse) String() string {
 func (*RefreshTokenResponse) ProtoMessage() {}
 
 func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[34]
+	mi := &file_api_grpc_job_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2750,7 +6324,7 @@ func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
 func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{34}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{75}
 }
 
 func (x *RefreshTokenResponse) GetAccessToken() string {
@@ -2783,7 +6357,7 @@ type GetUserInfoRequest struct {
 
 func (x *GetUserInfoRequest) Reset() {
 	*x = GetUserInfoRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[35]
+	mi := &file_api_grpc_job_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2795,7 +6369,7 @@ func (x *GetUserInfoRequest) String() string {
 func (*GetUserInfoRequest) ProtoMessage() {}
 
 func (x *GetUserInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[35]
+	mi := &file_api_grpc_job_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2808,7 +6382,7 @@ func (x *GetUserInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetUserInfoRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{35}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{76}
 }
 
 func (x *GetUserInfoRequest) GetUserId() string {
@@ -2827,7 +6401,7 @@ type GetUserInfoResponse struct {
 
 func (x *GetUserInfoResponse) Reset() {
 	*x = GetUserInfoResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[36]
+	mi := &file_api_grpc_job_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2839,7 +6413,7 @@ func (x *GetUserInfoResponse) String() string {
 func (*GetUserInfoResponse) ProtoMessage() {}
 
 func (x *GetUserInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[36]
+	mi := &file_api_grpc_job_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2852,7 +6426,7 @@ func (x *GetUserInfoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserInfoResponse.ProtoReflect.Descriptor instead.
 func (*GetUserInfoResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{36}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{77}
 }
 
 func (x *GetUserInfoResponse) GetUser() *User {
@@ -2871,7 +6445,7 @@ type GetUserPermissionsRequest struct {
 
 func (x *GetUserPermissionsRequest) Reset() {
 	*x = GetUserPermissionsRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[37]
+	mi := &file_api_grpc_job_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2883,7 +6457,7 @@ func (x *GetUserPermissionsRequest) String() string {
 func (*GetUserPermissionsRequest) ProtoMessage() {}
 
 func (x *GetUserPermissionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[37]
+	mi := &file_api_grpc_job_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2896,7 +6470,7 @@ func (x *GetUserPermissionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserPermissionsRequest.ProtoReflect.Descriptor instead.
 func (*GetUserPermissionsRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{37}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{78}
 }
 
 func (x *GetUserPermissionsRequest) GetUserId() string {
@@ -2915,7 +6489,7 @@ type GetUserPermissionsResponse struct {
 
 func (x *GetUserPermissionsResponse) Reset() {
 	*x = GetUserPermissionsResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[38]
+	mi := &file_api_grpc_job_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2927,7 +6501,7 @@ func (x *GetUserPermissionsResponse) String() string {
 func (*GetUserPermissionsResponse) ProtoMessage() {}
 
 func (x *GetUserPermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[38]
+	mi := &file_api_grpc_job_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2940,7 +6514,7 @@ func (x *GetUserPermissionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserPermissionsResponse.ProtoReflect.Descriptor instead.
 func (*GetUserPermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{38}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{79}
 }
 
 func (x *GetUserPermissionsResponse) GetPermissions() []*Permission {
@@ -2966,7 +6540,7 @@ type CreateUserRequest struct {
 
 func (x *CreateUserRequest) Reset() {
 	*x = CreateUserRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[39]
+	mi := &file_api_grpc_job_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2978,7 +6552,7 @@ func (x *CreateUserRequest) String() string {
 func (*CreateUserRequest) ProtoMessage() {}
 
 func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[39]
+	mi := &file_api_grpc_job_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2991,7 +6565,7 @@ func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
 func (*CreateUserRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{39}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{80}
 }
 
 func (x *CreateUserRequest) GetUsername() string {
@@ -3052,7 +6626,7 @@ type CreateUserResponse struct {
 
 func (x *CreateUserResponse) Reset() {
 	*x = CreateUserResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[40]
+	mi := &file_api_grpc_job_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3064,7 +6638,7 @@ func (x *CreateUserResponse) String() string {
 func (*CreateUserResponse) ProtoMessage() {}
 
 func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[40]
+	mi := &file_api_grpc_job_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3077,7 +6651,7 @@ func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
 func (*CreateUserResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{40}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{81}
 }
 
 func (x *CreateUserResponse) GetUser() *User {
@@ -3096,7 +6670,7 @@ type GetUserRequest struct {
 
 func (x *GetUserRequest) Reset() {
 	*x = GetUserRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[41]
+	mi := &file_api_grpc_job_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3108,7 +6682,7 @@ func (x *GetUserRequest) String() string {
 func (*GetUserRequest) ProtoMessage() {}
 
 func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[41]
+	mi := &file_api_grpc_job_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3121,7 +6695,7 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{41}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{82}
 }
 
 func (x *GetUserRequest) GetId() string {
@@ -3140,7 +6714,7 @@ type GetUserResponse struct {
 
 func (x *GetUserResponse) Reset() {
 	*x = GetUserResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[42]
+	mi := &file_api_grpc_job_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3152,7 +6726,7 @@ func (x *GetUserResponse) String() string {
 func (*GetUserResponse) ProtoMessage() {}
 
 func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[42]
+	mi := &file_api_grpc_job_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3165,7 +6739,7 @@ func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
 func (*GetUserResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{42}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{83}
 }
 
 func (x *GetUserResponse) GetUser() *User {
@@ -3188,7 +6762,7 @@ type ListUsersRequest struct {
 
 func (x *ListUsersRequest) Reset() {
 	*x = ListUsersRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[43]
+	mi := &file_api_grpc_job_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3200,7 +6774,7 @@ func (x *ListUsersRequest) String() string {
 func (*ListUsersRequest) ProtoMessage() {}
 
 func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[43]
+	mi := &file_api_grpc_job_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3213,7 +6787,7 @@ func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
 func (*ListUsersRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{43}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{84}
 }
 
 func (x *ListUsersRequest) GetPage() int32 {
@@ -3261,7 +6835,7 @@ type ListUsersResponse struct {
 
 func (x *ListUsersResponse) Reset() {
 	*x = ListUsersResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[44]
+	mi := &file_api_grpc_job_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3273,7 +6847,7 @@ func (x *ListUsersResponse) String() string {
 func (*ListUsersResponse) ProtoMessage() {}
 
 func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[44]
+	mi := &file_api_grpc_job_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3286,7 +6860,7 @@ func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
 func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{44}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{85}
 }
 
 func (x *ListUsersResponse) GetUsers() []*User {
@@ -3319,7 +6893,7 @@ type UpdateUserRequest struct {
 
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[45]
+	mi := &file_api_grpc_job_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3331,7 +6905,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[45]
+	mi := &file_api_grpc_job_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3344,7 +6918,7 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{45}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{86}
 }
 
 func (x *UpdateUserRequest) GetId() string {
@@ -3412,7 +6986,7 @@ type UpdateUserResponse struct {
 
 func (x *UpdateUserResponse) Reset() {
 	*x = UpdateUserResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[46]
+	mi := &file_api_grpc_job_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3424,7 +6998,7 @@ func (x *UpdateUserResponse) String() string {
 func (*UpdateUserResponse) ProtoMessage() {}
 
 func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[46]
+	mi := &file_api_grpc_job_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3437,7 +7011,7 @@ func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{46}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{87}
 }
 
 func (x *UpdateUserResponse) GetUser() *User {
@@ -3456,7 +7030,7 @@ type DeleteUserRequest struct {
 
 func (x *DeleteUserRequest) Reset() {
 	*x = DeleteUserRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[47]
+	mi := &file_api_grpc_job_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3468,7 +7042,7 @@ func (x *DeleteUserRequest) String() string {
 func (*DeleteUserRequest) ProtoMessage() {}
 
 func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[47]
+	mi := &file_api_grpc_job_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3481,7 +7055,7 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{47}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{88}
 }
 
 func (x *DeleteUserRequest) GetId() string {
@@ -3500,7 +7074,7 @@ type DeleteUserResponse struct {
 
 func (x *DeleteUserResponse) Reset() {
 	*x = DeleteUserResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[48]
+	mi := &file_api_grpc_job_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3512,7 +7086,7 @@ func (x *DeleteUserResponse) String() string {
 func (*DeleteUserResponse) ProtoMessage() {}
 
 func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[48]
+	mi := &file_api_grpc_job_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3525,7 +7099,7 @@ func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
 func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{48}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{89}
 }
 
 func (x *DeleteUserResponse) GetSuccess() bool {
@@ -3546,7 +7120,7 @@ type ChangePasswordRequest struct {
 
 func (x *ChangePasswordRequest) Reset() {
 	*x = ChangePasswordRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[49]
+	mi := &file_api_grpc_job_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3558,7 +7132,7 @@ func (x *ChangePasswordRequest) String() string {
 func (*ChangePasswordRequest) ProtoMessage() {}
 
 func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[49]
+	mi := &file_api_grpc_job_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3571,7 +7145,7 @@ func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
 func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{49}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{90}
 }
 
 func (x *ChangePasswordRequest) GetUserId() string {
@@ -3604,7 +7178,7 @@ type ChangePasswordResponse struct {
 
 func (x *ChangePasswordResponse) Reset() {
 	*x = ChangePasswordResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[50]
+	mi := &file_api_grpc_job_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3616,7 +7190,7 @@ func (x *ChangePasswordResponse) String() string {
 func (*ChangePasswordResponse) ProtoMessage() {}
 
 func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[50]
+	mi := &file_api_grpc_job_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3629,7 +7203,7 @@ func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
 func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{50}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{91}
 }
 
 func (x *ChangePasswordResponse) GetSuccess() bool {
@@ -3649,7 +7223,7 @@ type AssignUserRolesRequest struct {
 
 func (x *AssignUserRolesRequest) Reset() {
 	*x = AssignUserRolesRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[51]
+	mi := &file_api_grpc_job_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3661,7 +7235,7 @@ func (x *AssignUserRolesRequest) String() string {
 func (*AssignUserRolesRequest) ProtoMessage() {}
 
 func (x *AssignUserRolesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[51]
+	mi := &file_api_grpc_job_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3674,7 +7248,7 @@ func (x *AssignUserRolesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignUserRolesRequest.ProtoReflect.Descriptor instead.
 func (*AssignUserRolesRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{51}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{92}
 }
 
 func (x *AssignUserRolesRequest) GetUserId() string {
@@ -3700,7 +7274,7 @@ type AssignUserRolesResponse struct {
 
 func (x *AssignUserRolesResponse) Reset() {
 	*x = AssignUserRolesResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[52]
+	mi := &file_api_grpc_job_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3712,7 +7286,7 @@ func (x *AssignUserRolesResponse) String() string {
 func (*AssignUserRolesResponse) ProtoMessage() {}
 
 func (x *AssignUserRolesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[52]
+	mi := &file_api_grpc_job_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3725,7 +7299,7 @@ func (x *AssignUserRolesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignUserRolesResponse.ProtoReflect.Descriptor instead.
 func (*AssignUserRolesResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{52}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{93}
 }
 
 func (x *AssignUserRolesResponse) GetSuccess() bool {
@@ -3743,13 +7317,14 @@ type CreateDepartmentRequest struct {
 	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	ParentId      string                 `protobuf:"bytes,4,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
 	Sort          int32                  `protobuf:"varint,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	Sandbox       string                 `protobuf:"bytes,6,opt,name=sandbox,proto3" json:"sandbox,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateDepartmentRequest) Reset() {
 	*x = CreateDepartmentRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[53]
+	mi := &file_api_grpc_job_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3761,7 +7336,7 @@ func (x *CreateDepartmentRequest) String() string {
 func (*CreateDepartmentRequest) ProtoMessage() {}
 
 func (x *CreateDepartmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[53]
+	mi := &file_api_grpc_job_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3774,7 +7349,7 @@ func (x *CreateDepartmentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateDepartmentRequest.ProtoReflect.Descriptor instead.
 func (*CreateDepartmentRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{53}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{94}
 }
 
 func (x *CreateDepartmentRequest) GetName() string {
@@ -3812,6 +7387,13 @@ func (x *CreateDepartmentRequest) GetSort() int32 {
 	return 0
 }
 
+func (x *CreateDepartmentRequest) GetSandbox() string {
+	if x != nil {
+		return x.Sandbox
+	}
+	return ""
+}
+
 type CreateDepartmentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Department    *Department            `protobuf:"bytes,1,opt,name=department,proto3" json:"department,omitempty"`
@@ -3821,7 +7403,7 @@ type CreateDepartmentResponse struct {
 
 func (x *CreateDepartmentResponse) Reset() {
 	*x = CreateDepartmentResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[54]
+	mi := &file_api_grpc_job_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3833,7 +7415,7 @@ func (x *CreateDepartmentResponse) String() string {
 func (*CreateDepartmentResponse) ProtoMessage() {}
 
 func (x *CreateDepartmentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[54]
+	mi := &file_api_grpc_job_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3846,7 +7428,7 @@ func (x *CreateDepartmentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateDepartmentResponse.ProtoReflect.Descriptor instead.
 func (*CreateDepartmentResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{54}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{95}
 }
 
 func (x *CreateDepartmentResponse) GetDepartment() *Department {
@@ -3865,7 +7447,7 @@ type GetDepartmentRequest struct {
 
 func (x *GetDepartmentRequest) Reset() {
 	*x = GetDepartmentRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[55]
+	mi := &file_api_grpc_job_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3877,7 +7459,7 @@ func (x *GetDepartmentRequest) String() string {
 func (*GetDepartmentRequest) ProtoMessage() {}
 
 func (x *GetDepartmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[55]
+	mi := &file_api_grpc_job_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3890,7 +7472,7 @@ func (x *GetDepartmentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDepartmentRequest.ProtoReflect.Descriptor instead.
 func (*GetDepartmentRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{55}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{96}
 }
 
 func (x *GetDepartmentRequest) GetId() string {
@@ -3909,7 +7491,7 @@ type GetDepartmentResponse struct {
 
 func (x *GetDepartmentResponse) Reset() {
 	*x = GetDepartmentResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[56]
+	mi := &file_api_grpc_job_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3921,7 +7503,7 @@ func (x *GetDepartmentResponse) String() string {
 func (*GetDepartmentResponse) ProtoMessage() {}
 
 func (x *GetDepartmentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[56]
+	mi := &file_api_grpc_job_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3934,7 +7516,7 @@ func (x *GetDepartmentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDepartmentResponse.ProtoReflect.Descriptor instead.
 func (*GetDepartmentResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{56}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{97}
 }
 
 func (x *GetDepartmentResponse) GetDepartment() *Department {
@@ -3956,7 +7538,7 @@ type ListDepartmentsRequest struct {
 
 func (x *ListDepartmentsRequest) Reset() {
 	*x = ListDepartmentsRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[57]
+	mi := &file_api_grpc_job_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3968,7 +7550,7 @@ func (x *ListDepartmentsRequest) String() string {
 func (*ListDepartmentsRequest) ProtoMessage() {}
 
 func (x *ListDepartmentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[57]
+	mi := &file_api_grpc_job_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3981,7 +7563,7 @@ func (x *ListDepartmentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDepartmentsRequest.ProtoReflect.Descriptor instead.
 func (*ListDepartmentsRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{57}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{98}
 }
 
 func (x *ListDepartmentsRequest) GetPage() int32 {
@@ -4022,7 +7604,7 @@ type ListDepartmentsResponse struct {
 
 func (x *ListDepartmentsResponse) Reset() {
 	*x = ListDepartmentsResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[58]
+	mi := &file_api_grpc_job_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4034,7 +7616,7 @@ func (x *ListDepartmentsResponse) String() string {
 func (*ListDepartmentsResponse) ProtoMessage() {}
 
 func (x *ListDepartmentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[58]
+	mi := &file_api_grpc_job_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4047,7 +7629,7 @@ func (x *ListDepartmentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDepartmentsResponse.ProtoReflect.Descriptor instead.
 func (*ListDepartmentsResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{58}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{99}
 }
 
 func (x *ListDepartmentsResponse) GetDepartments() []*Department {
@@ -4073,13 +7655,14 @@ type UpdateDepartmentRequest struct {
 	ParentId      string                 `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
 	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
 	Sort          int32                  `protobuf:"varint,7,opt,name=sort,proto3" json:"sort,omitempty"`
+	Sandbox       string                 `protobuf:"bytes,8,opt,name=sandbox,proto3" json:"sandbox,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateDepartmentRequest) Reset() {
 	*x = UpdateDepartmentRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[59]
+	mi := &file_api_grpc_job_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4091,7 +7674,7 @@ func (x *UpdateDepartmentRequest) String() string {
 func (*UpdateDepartmentRequest) ProtoMessage() {}
 
 func (x *UpdateDepartmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[59]
+	mi := &file_api_grpc_job_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4104,7 +7687,7 @@ func (x *UpdateDepartmentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDepartmentRequest.ProtoReflect.Descriptor instead.
 func (*UpdateDepartmentRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{59}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{100}
 }
 
 func (x *UpdateDepartmentRequest) GetId() string {
@@ -4156,6 +7739,13 @@ func (x *UpdateDepartmentRequest) GetSort() int32 {
 	return 0
 }
 
+func (x *UpdateDepartmentRequest) GetSandbox() string {
+	if x != nil {
+		return x.Sandbox
+	}
+	return ""
+}
+
 type UpdateDepartmentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Department    *Department            `protobuf:"bytes,1,opt,name=department,proto3" json:"department,omitempty"`
@@ -4165,7 +7755,7 @@ type UpdateDepartmentResponse struct {
 
 func (x *UpdateDepartmentResponse) Reset() {
 	*x = UpdateDepartmentResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[60]
+	mi := &file_api_grpc_job_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4177,7 +7767,7 @@ func (x *UpdateDepartmentResponse) String() string {
 func (*UpdateDepartmentResponse) ProtoMessage() {}
 
 func (x *UpdateDepartmentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[60]
+	mi := &file_api_grpc_job_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4190,7 +7780,7 @@ func (x *UpdateDepartmentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDepartmentResponse.ProtoReflect.Descriptor instead.
 func (*UpdateDepartmentResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{60}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{101}
 }
 
 func (x *UpdateDepartmentResponse) GetDepartment() *Department {
@@ -4209,7 +7799,7 @@ type DeleteDepartmentRequest struct {
 
 func (x *DeleteDepartmentRequest) Reset() {
 	*x = DeleteDepartmentRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[61]
+	mi := &file_api_grpc_job_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4221,7 +7811,7 @@ func (x *DeleteDepartmentRequest) String() string {
 func (*DeleteDepartmentRequest) ProtoMessage() {}
 
 func (x *DeleteDepartmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[61]
+	mi := &file_api_grpc_job_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4234,7 +7824,7 @@ func (x *DeleteDepartmentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteDepartmentRequest.ProtoReflect.Descriptor instead.
 func (*DeleteDepartmentRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{61}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{102}
 }
 
 func (x *DeleteDepartmentRequest) GetId() string {
@@ -4253,7 +7843,7 @@ type DeleteDepartmentResponse struct {
 
 func (x *DeleteDepartmentResponse) Reset() {
 	*x = DeleteDepartmentResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[62]
+	mi := &file_api_grpc_job_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4265,7 +7855,7 @@ func (x *DeleteDepartmentResponse) String() string {
 func (*DeleteDepartmentResponse) ProtoMessage() {}
 
 func (x *DeleteDepartmentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[62]
+	mi := &file_api_grpc_job_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4278,7 +7868,7 @@ func (x *DeleteDepartmentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteDepartmentResponse.ProtoReflect.Descriptor instead.
 func (*DeleteDepartmentResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{62}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{103}
 }
 
 func (x *DeleteDepartmentResponse) GetSuccess() bool {
@@ -4297,7 +7887,7 @@ type GetDepartmentTreeRequest struct {
 
 func (x *GetDepartmentTreeRequest) Reset() {
 	*x = GetDepartmentTreeRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[63]
+	mi := &file_api_grpc_job_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4309,7 +7899,7 @@ func (x *GetDepartmentTreeRequest) String() string {
 func (*GetDepartmentTreeRequest) ProtoMessage() {}
 
 func (x *GetDepartmentTreeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[63]
+	mi := &file_api_grpc_job_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4322,7 +7912,7 @@ func (x *GetDepartmentTreeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDepartmentTreeRequest.ProtoReflect.Descriptor instead.
 func (*GetDepartmentTreeRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{63}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{104}
 }
 
 func (x *GetDepartmentTreeRequest) GetParentId() string {
@@ -4341,7 +7931,7 @@ type GetDepartmentTreeResponse struct {
 
 func (x *GetDepartmentTreeResponse) Reset() {
 	*x = GetDepartmentTreeResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[64]
+	mi := &file_api_grpc_job_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4353,7 +7943,7 @@ func (x *GetDepartmentTreeResponse) String() string {
 func (*GetDepartmentTreeResponse) ProtoMessage() {}
 
 func (x *GetDepartmentTreeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[64]
+	mi := &file_api_grpc_job_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4366,7 +7956,7 @@ func (x *GetDepartmentTreeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDepartmentTreeResponse.ProtoReflect.Descriptor instead.
 func (*GetDepartmentTreeResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{64}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{105}
 }
 
 func (x *GetDepartmentTreeResponse) GetDepartments() []*Department {
@@ -4389,7 +7979,7 @@ type CreateRoleRequest struct {
 
 func (x *CreateRoleRequest) Reset() {
 	*x = CreateRoleRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[65]
+	mi := &file_api_grpc_job_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4401,7 +7991,7 @@ func (x *CreateRoleRequest) String() string {
 func (*CreateRoleRequest) ProtoMessage() {}
 
 func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[65]
+	mi := &file_api_grpc_job_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4414,7 +8004,7 @@ func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRoleRequest.ProtoReflect.Descriptor instead.
 func (*CreateRoleRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{65}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{106}
 }
 
 func (x *CreateRoleRequest) GetName() string {
@@ -4454,7 +8044,7 @@ type CreateRoleResponse struct {
 
 func (x *CreateRoleResponse) Reset() {
 	*x = CreateRoleResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[66]
+	mi := &file_api_grpc_job_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4466,7 +8056,7 @@ func (x *CreateRoleResponse) String() string {
 func (*CreateRoleResponse) ProtoMessage() {}
 
 func (x *CreateRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[66]
+	mi := &file_api_grpc_job_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4479,7 +8069,7 @@ func (x *CreateRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRoleResponse.ProtoReflect.Descriptor instead.
 func (*CreateRoleResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{66}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{107}
 }
 
 func (x *CreateRoleResponse) GetRole() *Role {
@@ -4498,7 +8088,7 @@ type GetRoleRequest struct {
 
 func (x *GetRoleRequest) Reset() {
 	*x = GetRoleRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[67]
+	mi := &file_api_grpc_job_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4510,7 +8100,7 @@ func (x *GetRoleRequest) String() string {
 func (*GetRoleRequest) ProtoMessage() {}
 
 func (x *GetRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[67]
+	mi := &file_api_grpc_job_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4523,7 +8113,7 @@ func (x *GetRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRoleRequest.ProtoReflect.Descriptor instead.
 func (*GetRoleRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{67}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{108}
 }
 
 func (x *GetRoleRequest) GetId() string {
@@ -4542,7 +8132,7 @@ type GetRoleResponse struct {
 
 func (x *GetRoleResponse) Reset() {
 	*x = GetRoleResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[68]
+	mi := &file_api_grpc_job_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4554,7 +8144,7 @@ func (x *GetRoleResponse) String() string {
 func (*GetRoleResponse) ProtoMessage() {}
 
 func (x *GetRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[68]
+	mi := &file_api_grpc_job_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4567,7 +8157,7 @@ func (x *GetRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRoleResponse.ProtoReflect.Descriptor instead.
 func (*GetRoleResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{68}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{109}
 }
 
 func (x *GetRoleResponse) GetRole() *Role {
@@ -4589,7 +8179,7 @@ type ListRolesRequest struct {
 
 func (x *ListRolesRequest) Reset() {
 	*x = ListRolesRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[69]
+	mi := &file_api_grpc_job_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4601,7 +8191,7 @@ func (x *ListRolesRequest) String() string {
 func (*ListRolesRequest) ProtoMessage() {}
 
 func (x *ListRolesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[69]
+	mi := &file_api_grpc_job_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4614,7 +8204,7 @@ func (x *ListRolesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRolesRequest.ProtoReflect.Descriptor instead.
 func (*ListRolesRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{69}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{110}
 }
 
 func (x *ListRolesRequest) GetPage() int32 {
@@ -4655,7 +8245,7 @@ type ListRolesResponse struct {
 
 func (x *ListRolesResponse) Reset() {
 	*x = ListRolesResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[70]
+	mi := &file_api_grpc_job_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4667,7 +8257,7 @@ func (x *ListRolesResponse) String() string {
 func (*ListRolesResponse) ProtoMessage() {}
 
 func (x *ListRolesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[70]
+	mi := &file_api_grpc_job_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4680,7 +8270,7 @@ func (x *ListRolesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRolesResponse.ProtoReflect.Descriptor instead.
 func (*ListRolesResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{70}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{111}
 }
 
 func (x *ListRolesResponse) GetRoles() []*Role {
@@ -4710,7 +8300,7 @@ type UpdateRoleRequest struct {
 
 func (x *UpdateRoleRequest) Reset() {
 	*x = UpdateRoleRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[71]
+	mi := &file_api_grpc_job_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4722,7 +8312,7 @@ func (x *UpdateRoleRequest) String() string {
 func (*UpdateRoleRequest) ProtoMessage() {}
 
 func (x *UpdateRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[71]
+	mi := &file_api_grpc_job_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4735,7 +8325,7 @@ func (x *UpdateRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRoleRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRoleRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{71}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{112}
 }
 
 func (x *UpdateRoleRequest) GetId() string {
@@ -4782,7 +8372,7 @@ type UpdateRoleResponse struct {
 
 func (x *UpdateRoleResponse) Reset() {
 	*x = UpdateRoleResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[72]
+	mi := &file_api_grpc_job_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4794,7 +8384,7 @@ func (x *UpdateRoleResponse) String() string {
 func (*UpdateRoleResponse) ProtoMessage() {}
 
 func (x *UpdateRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[72]
+	mi := &file_api_grpc_job_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4807,7 +8397,7 @@ func (x *UpdateRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRoleResponse.ProtoReflect.Descriptor instead.
 func (*UpdateRoleResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{72}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{113}
 }
 
 func (x *UpdateRoleResponse) GetRole() *Role {
@@ -4826,7 +8416,7 @@ type DeleteRoleRequest struct {
 
 func (x *DeleteRoleRequest) Reset() {
 	*x = DeleteRoleRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[73]
+	mi := &file_api_grpc_job_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4838,7 +8428,7 @@ func (x *DeleteRoleRequest) String() string {
 func (*DeleteRoleRequest) ProtoMessage() {}
 
 func (x *DeleteRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[73]
+	mi := &file_api_grpc_job_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4851,7 +8441,7 @@ func (x *DeleteRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRoleRequest.ProtoReflect.Descriptor instead.
 func (*DeleteRoleRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{73}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{114}
 }
 
 func (x *DeleteRoleRequest) GetId() string {
@@ -4870,7 +8460,7 @@ type DeleteRoleResponse struct {
 
 func (x *DeleteRoleResponse) Reset() {
 	*x = DeleteRoleResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[74]
+	mi := &file_api_grpc_job_proto_msgTypes[115]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4882,7 +8472,7 @@ func (x *DeleteRoleResponse) String() string {
 func (*DeleteRoleResponse) ProtoMessage() {}
 
 func (x *DeleteRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[74]
+	mi := &file_api_grpc_job_proto_msgTypes[115]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4895,7 +8485,7 @@ func (x *DeleteRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRoleResponse.ProtoReflect.Descriptor instead.
 func (*DeleteRoleResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{74}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{115}
 }
 
 func (x *DeleteRoleResponse) GetSuccess() bool {
@@ -4915,7 +8505,7 @@ type AssignPermissionsRequest struct {
 
 func (x *AssignPermissionsRequest) Reset() {
 	*x = AssignPermissionsRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[75]
+	mi := &file_api_grpc_job_proto_msgTypes[116]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4927,7 +8517,7 @@ func (x *AssignPermissionsRequest) String() string {
 func (*AssignPermissionsRequest) ProtoMessage() {}
 
 func (x *AssignPermissionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[75]
+	mi := &file_api_grpc_job_proto_msgTypes[116]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4940,7 +8530,7 @@ func (x *AssignPermissionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignPermissionsRequest.ProtoReflect.Descriptor instead.
 func (*AssignPermissionsRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{75}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{116}
 }
 
 func (x *AssignPermissionsRequest) GetRoleId() string {
@@ -4966,7 +8556,7 @@ type AssignPermissionsResponse struct {
 
 func (x *AssignPermissionsResponse) Reset() {
 	*x = AssignPermissionsResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[76]
+	mi := &file_api_grpc_job_proto_msgTypes[117]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4978,7 +8568,7 @@ func (x *AssignPermissionsResponse) String() string {
 func (*AssignPermissionsResponse) ProtoMessage() {}
 
 func (x *AssignPermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[76]
+	mi := &file_api_grpc_job_proto_msgTypes[117]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4991,7 +8581,7 @@ func (x *AssignPermissionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignPermissionsResponse.ProtoReflect.Descriptor instead.
 func (*AssignPermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{76}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{117}
 }
 
 func (x *AssignPermissionsResponse) GetSuccess() bool {
@@ -5019,7 +8609,7 @@ type CreatePermissionRequest struct {
 
 func (x *CreatePermissionRequest) Reset() {
 	*x = CreatePermissionRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[77]
+	mi := &file_api_grpc_job_proto_msgTypes[118]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5031,7 +8621,7 @@ func (x *CreatePermissionRequest) String() string {
 func (*CreatePermissionRequest) ProtoMessage() {}
 
 func (x *CreatePermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[77]
+	mi := &file_api_grpc_job_proto_msgTypes[118]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5044,7 +8634,7 @@ func (x *CreatePermissionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePermissionRequest.ProtoReflect.Descriptor instead.
 func (*CreatePermissionRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{77}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{118}
 }
 
 func (x *CreatePermissionRequest) GetName() string {
@@ -5119,7 +8709,7 @@ type CreatePermissionResponse struct {
 
 func (x *CreatePermissionResponse) Reset() {
 	*x = CreatePermissionResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[78]
+	mi := &file_api_grpc_job_proto_msgTypes[119]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5131,7 +8721,7 @@ func (x *CreatePermissionResponse) String() string {
 func (*CreatePermissionResponse) ProtoMessage() {}
 
 func (x *CreatePermissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[78]
+	mi := &file_api_grpc_job_proto_msgTypes[119]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5144,7 +8734,7 @@ func (x *CreatePermissionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePermissionResponse.ProtoReflect.Descriptor instead.
 func (*CreatePermissionResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{78}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{119}
 }
 
 func (x *CreatePermissionResponse) GetPermission() *Permission {
@@ -5163,7 +8753,7 @@ type GetPermissionRequest struct {
 
 func (x *GetPermissionRequest) Reset() {
 	*x = GetPermissionRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[79]
+	mi := &file_api_grpc_job_proto_msgTypes[120]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5175,7 +8765,7 @@ func (x *GetPermissionRequest) String() string {
 func (*GetPermissionRequest) ProtoMessage() {}
 
 func (x *GetPermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[79]
+	mi := &file_api_grpc_job_proto_msgTypes[120]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5188,7 +8778,7 @@ func (x *GetPermissionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPermissionRequest.ProtoReflect.Descriptor instead.
 func (*GetPermissionRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{79}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{120}
 }
 
 func (x *GetPermissionRequest) GetId() string {
@@ -5207,7 +8797,7 @@ type GetPermissionResponse struct {
 
 func (x *GetPermissionResponse) Reset() {
 	*x = GetPermissionResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[80]
+	mi := &file_api_grpc_job_proto_msgTypes[121]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5219,7 +8809,7 @@ func (x *GetPermissionResponse) String() string {
 func (*GetPermissionResponse) ProtoMessage() {}
 
 func (x *GetPermissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[80]
+	mi := &file_api_grpc_job_proto_msgTypes[121]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5232,7 +8822,7 @@ func (x *GetPermissionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPermissionResponse.ProtoReflect.Descriptor instead.
 func (*GetPermissionResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{80}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{121}
 }
 
 func (x *GetPermissionResponse) GetPermission() *Permission {
@@ -5255,7 +8845,7 @@ type ListPermissionsRequest struct {
 
 func (x *ListPermissionsRequest) Reset() {
 	*x = ListPermissionsRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[81]
+	mi := &file_api_grpc_job_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5267,7 +8857,7 @@ func (x *ListPermissionsRequest) String() string {
 func (*ListPermissionsRequest) ProtoMessage() {}
 
 func (x *ListPermissionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[81]
+	mi := &file_api_grpc_job_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5280,7 +8870,7 @@ func (x *ListPermissionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPermissionsRequest.ProtoReflect.Descriptor instead.
 func (*ListPermissionsRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{81}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{122}
 }
 
 func (x *ListPermissionsRequest) GetPage() int32 {
@@ -5328,7 +8918,7 @@ type ListPermissionsResponse struct {
 
 func (x *ListPermissionsResponse) Reset() {
 	*x = ListPermissionsResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[82]
+	mi := &file_api_grpc_job_proto_msgTypes[123]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5340,7 +8930,7 @@ func (x *ListPermissionsResponse) String() string {
 func (*ListPermissionsResponse) ProtoMessage() {}
 
 func (x *ListPermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[82]
+	mi := &file_api_grpc_job_proto_msgTypes[123]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5353,7 +8943,7 @@ func (x *ListPermissionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPermissionsResponse.ProtoReflect.Descriptor instead.
 func (*ListPermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{82}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{123}
 }
 
 func (x *ListPermissionsResponse) GetPermissions() []*Permission {
@@ -5389,7 +8979,7 @@ type UpdatePermissionRequest struct {
 
 func (x *UpdatePermissionRequest) Reset() {
 	*x = UpdatePermissionRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[83]
+	mi := &file_api_grpc_job_proto_msgTypes[124]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5401,7 +8991,7 @@ func (x *UpdatePermissionRequest) String() string {
 func (*UpdatePermissionRequest) ProtoMessage() {}
 
 func (x *UpdatePermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[83]
+	mi := &file_api_grpc_job_proto_msgTypes[124]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5414,7 +9004,7 @@ func (x *UpdatePermissionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePermissionRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePermissionRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{83}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{124}
 }
 
 func (x *UpdatePermissionRequest) GetId() string {
@@ -5503,7 +9093,7 @@ type UpdatePermissionResponse struct {
 
 func (x *UpdatePermissionResponse) Reset() {
 	*x = UpdatePermissionResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[84]
+	mi := &file_api_grpc_job_proto_msgTypes[125]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5515,7 +9105,7 @@ func (x *UpdatePermissionResponse) String() string {
 func (*UpdatePermissionResponse) ProtoMessage() {}
 
 func (x *UpdatePermissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[84]
+	mi := &file_api_grpc_job_proto_msgTypes[125]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5528,7 +9118,7 @@ func (x *UpdatePermissionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePermissionResponse.ProtoReflect.Descriptor instead.
 func (*UpdatePermissionResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{84}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{125}
 }
 
 func (x *UpdatePermissionResponse) GetPermission() *Permission {
@@ -5547,7 +9137,7 @@ type DeletePermissionRequest struct {
 
 func (x *DeletePermissionRequest) Reset() {
 	*x = DeletePermissionRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[85]
+	mi := &file_api_grpc_job_proto_msgTypes[126]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5559,7 +9149,7 @@ func (x *DeletePermissionRequest) String() string {
 func (*DeletePermissionRequest) ProtoMessage() {}
 
 func (x *DeletePermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[85]
+	mi := &file_api_grpc_job_proto_msgTypes[126]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5572,7 +9162,7 @@ func (x *DeletePermissionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePermissionRequest.ProtoReflect.Descriptor instead.
 func (*DeletePermissionRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{85}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{126}
 }
 
 func (x *DeletePermissionRequest) GetId() string {
@@ -5591,7 +9181,7 @@ type DeletePermissionResponse struct {
 
 func (x *DeletePermissionResponse) Reset() {
 	*x = DeletePermissionResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[86]
+	mi := &file_api_grpc_job_proto_msgTypes[127]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5603,7 +9193,7 @@ func (x *DeletePermissionResponse) String() string {
 func (*DeletePermissionResponse) ProtoMessage() {}
 
 func (x *DeletePermissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[86]
+	mi := &file_api_grpc_job_proto_msgTypes[127]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5616,7 +9206,7 @@ func (x *DeletePermissionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePermissionResponse.ProtoReflect.Descriptor instead.
 func (*DeletePermissionResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{86}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{127}
 }
 
 func (x *DeletePermissionResponse) GetSuccess() bool {
@@ -5635,7 +9225,7 @@ type GetPermissionTreeRequest struct {
 
 func (x *GetPermissionTreeRequest) Reset() {
 	*x = GetPermissionTreeRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[87]
+	mi := &file_api_grpc_job_proto_msgTypes[128]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5647,7 +9237,7 @@ func (x *GetPermissionTreeRequest) String() string {
 func (*GetPermissionTreeRequest) ProtoMessage() {}
 
 func (x *GetPermissionTreeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[87]
+	mi := &file_api_grpc_job_proto_msgTypes[128]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5660,7 +9250,7 @@ func (x *GetPermissionTreeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPermissionTreeRequest.ProtoReflect.Descriptor instead.
 func (*GetPermissionTreeRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{87}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{128}
 }
 
 func (x *GetPermissionTreeRequest) GetParentId() string {
@@ -5679,7 +9269,7 @@ type GetPermissionTreeResponse struct {
 
 func (x *GetPermissionTreeResponse) Reset() {
 	*x = GetPermissionTreeResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[88]
+	mi := &file_api_grpc_job_proto_msgTypes[129]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5691,7 +9281,7 @@ func (x *GetPermissionTreeResponse) String() string {
 func (*GetPermissionTreeResponse) ProtoMessage() {}
 
 func (x *GetPermissionTreeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[88]
+	mi := &file_api_grpc_job_proto_msgTypes[129]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5704,7 +9294,7 @@ func (x *GetPermissionTreeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPermissionTreeResponse.ProtoReflect.Descriptor instead.
 func (*GetPermissionTreeResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{88}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{129}
 }
 
 func (x *GetPermissionTreeResponse) GetPermissions() []*Permission {
@@ -5726,7 +9316,7 @@ type AnalyzeJobRequest struct {
 
 func (x *AnalyzeJobRequest) Reset() {
 	*x = AnalyzeJobRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[89]
+	mi := &file_api_grpc_job_proto_msgTypes[130]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5738,7 +9328,7 @@ func (x *AnalyzeJobRequest) String() string {
 func (*AnalyzeJobRequest) ProtoMessage() {}
 
 func (x *AnalyzeJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[89]
+	mi := &file_api_grpc_job_proto_msgTypes[130]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5751,7 +9341,7 @@ func (x *AnalyzeJobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnalyzeJobRequest.ProtoReflect.Descriptor instead.
 func (*AnalyzeJobRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{89}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{130}
 }
 
 func (x *AnalyzeJobRequest) GetJobId() string {
@@ -5787,7 +9377,7 @@ type AnalyzeJobResponse struct {
 
 func (x *AnalyzeJobResponse) Reset() {
 	*x = AnalyzeJobResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[90]
+	mi := &file_api_grpc_job_proto_msgTypes[131]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5799,7 +9389,7 @@ func (x *AnalyzeJobResponse) String() string {
 func (*AnalyzeJobResponse) ProtoMessage() {}
 
 func (x *AnalyzeJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[90]
+	mi := &file_api_grpc_job_proto_msgTypes[131]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5812,7 +9402,7 @@ func (x *AnalyzeJobResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnalyzeJobResponse.ProtoReflect.Descriptor instead.
 func (*AnalyzeJobResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{90}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{131}
 }
 
 func (x *AnalyzeJobResponse) GetAnalysis() string {
@@ -5854,7 +9444,7 @@ type OptimizeScheduleRequest struct {
 
 func (x *OptimizeScheduleRequest) Reset() {
 	*x = OptimizeScheduleRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[91]
+	mi := &file_api_grpc_job_proto_msgTypes[132]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5866,7 +9456,7 @@ func (x *OptimizeScheduleRequest) String() string {
 func (*OptimizeScheduleRequest) ProtoMessage() {}
 
 func (x *OptimizeScheduleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[91]
+	mi := &file_api_grpc_job_proto_msgTypes[132]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5879,7 +9469,7 @@ func (x *OptimizeScheduleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OptimizeScheduleRequest.ProtoReflect.Descriptor instead.
 func (*OptimizeScheduleRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{91}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{132}
 }
 
 func (x *OptimizeScheduleRequest) GetJobIds() []string {
@@ -5913,7 +9503,7 @@ type OptimizeScheduleResponse struct {
 
 func (x *OptimizeScheduleResponse) Reset() {
 	*x = OptimizeScheduleResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[92]
+	mi := &file_api_grpc_job_proto_msgTypes[133]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5925,7 +9515,7 @@ func (x *OptimizeScheduleResponse) String() string {
 func (*OptimizeScheduleResponse) ProtoMessage() {}
 
 func (x *OptimizeScheduleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[92]
+	mi := &file_api_grpc_job_proto_msgTypes[133]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5938,7 +9528,7 @@ func (x *OptimizeScheduleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OptimizeScheduleResponse.ProtoReflect.Descriptor instead.
 func (*OptimizeScheduleResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{92}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{133}
 }
 
 func (x *OptimizeScheduleResponse) GetOptimizations() []*ScheduleOptimization {
@@ -5967,7 +9557,7 @@ type ScheduleOptimization struct {
 
 func (x *ScheduleOptimization) Reset() {
 	*x = ScheduleOptimization{}
-	mi := &file_api_grpc_job_proto_msgTypes[93]
+	mi := &file_api_grpc_job_proto_msgTypes[134]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5979,7 +9569,7 @@ func (x *ScheduleOptimization) String() string {
 func (*ScheduleOptimization) ProtoMessage() {}
 
 func (x *ScheduleOptimization) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[93]
+	mi := &file_api_grpc_job_proto_msgTypes[134]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5992,7 +9582,7 @@ func (x *ScheduleOptimization) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleOptimization.ProtoReflect.Descriptor instead.
 func (*ScheduleOptimization) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{93}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{134}
 }
 
 func (x *ScheduleOptimization) GetJobId() string {
@@ -6033,7 +9623,7 @@ type GetAIRecommendationsRequest struct {
 
 func (x *GetAIRecommendationsRequest) Reset() {
 	*x = GetAIRecommendationsRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[94]
+	mi := &file_api_grpc_job_proto_msgTypes[135]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6045,7 +9635,7 @@ func (x *GetAIRecommendationsRequest) String() string {
 func (*GetAIRecommendationsRequest) ProtoMessage() {}
 
 func (x *GetAIRecommendationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[94]
+	mi := &file_api_grpc_job_proto_msgTypes[135]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6058,7 +9648,7 @@ func (x *GetAIRecommendationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAIRecommendationsRequest.ProtoReflect.Descriptor instead.
 func (*GetAIRecommendationsRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{94}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{135}
 }
 
 func (x *GetAIRecommendationsRequest) GetType() string {
@@ -6084,7 +9674,7 @@ type GetAIRecommendationsResponse struct {
 
 func (x *GetAIRecommendationsResponse) Reset() {
 	*x = GetAIRecommendationsResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[95]
+	mi := &file_api_grpc_job_proto_msgTypes[136]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6096,7 +9686,7 @@ func (x *GetAIRecommendationsResponse) String() string {
 func (*GetAIRecommendationsResponse) ProtoMessage() {}
 
 func (x *GetAIRecommendationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[95]
+	mi := &file_api_grpc_job_proto_msgTypes[136]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6109,7 +9699,7 @@ func (x *GetAIRecommendationsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAIRecommendationsResponse.ProtoReflect.Descriptor instead.
 func (*GetAIRecommendationsResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{95}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{136}
 }
 
 func (x *GetAIRecommendationsResponse) GetRecommendations() []*AIRecommendation {
@@ -6132,7 +9722,7 @@ type AIRecommendation struct {
 
 func (x *AIRecommendation) Reset() {
 	*x = AIRecommendation{}
-	mi := &file_api_grpc_job_proto_msgTypes[96]
+	mi := &file_api_grpc_job_proto_msgTypes[137]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6144,7 +9734,7 @@ func (x *AIRecommendation) String() string {
 func (*AIRecommendation) ProtoMessage() {}
 
 func (x *AIRecommendation) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[96]
+	mi := &file_api_grpc_job_proto_msgTypes[137]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6157,7 +9747,7 @@ func (x *AIRecommendation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AIRecommendation.ProtoReflect.Descriptor instead.
 func (*AIRecommendation) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{96}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{137}
 }
 
 func (x *AIRecommendation) GetType() string {
@@ -6205,7 +9795,7 @@ type ListToolsRequest struct {
 
 func (x *ListToolsRequest) Reset() {
 	*x = ListToolsRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[97]
+	mi := &file_api_grpc_job_proto_msgTypes[138]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6217,7 +9807,7 @@ func (x *ListToolsRequest) String() string {
 func (*ListToolsRequest) ProtoMessage() {}
 
 func (x *ListToolsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[97]
+	mi := &file_api_grpc_job_proto_msgTypes[138]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6230,7 +9820,7 @@ func (x *ListToolsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListToolsRequest.ProtoReflect.Descriptor instead.
 func (*ListToolsRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{97}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{138}
 }
 
 func (x *ListToolsRequest) GetCategory() string {
@@ -6249,7 +9839,7 @@ type ListToolsResponse struct {
 
 func (x *ListToolsResponse) Reset() {
 	*x = ListToolsResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[98]
+	mi := &file_api_grpc_job_proto_msgTypes[139]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6261,7 +9851,7 @@ func (x *ListToolsResponse) String() string {
 func (*ListToolsResponse) ProtoMessage() {}
 
 func (x *ListToolsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[98]
+	mi := &file_api_grpc_job_proto_msgTypes[139]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6274,7 +9864,7 @@ func (x *ListToolsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListToolsResponse.ProtoReflect.Descriptor instead.
 func (*ListToolsResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{98}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{139}
 }
 
 func (x *ListToolsResponse) GetTools() []*MCPTool {
@@ -6296,7 +9886,7 @@ type MCPTool struct {
 
 func (x *MCPTool) Reset() {
 	*x = MCPTool{}
-	mi := &file_api_grpc_job_proto_msgTypes[99]
+	mi := &file_api_grpc_job_proto_msgTypes[140]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6308,7 +9898,7 @@ func (x *MCPTool) String() string {
 func (*MCPTool) ProtoMessage() {}
 
 func (x *MCPTool) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[99]
+	mi := &file_api_grpc_job_proto_msgTypes[140]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6321,7 +9911,7 @@ func (x *MCPTool) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MCPTool.ProtoReflect.Descriptor instead.
 func (*MCPTool) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{99}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{140}
 }
 
 func (x *MCPTool) GetName() string {
@@ -6362,7 +9952,7 @@ type CallToolRequest struct {
 
 func (x *CallToolRequest) Reset() {
 	*x = CallToolRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[100]
+	mi := &file_api_grpc_job_proto_msgTypes[141]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6374,7 +9964,7 @@ func (x *CallToolRequest) String() string {
 func (*CallToolRequest) ProtoMessage() {}
 
 func (x *CallToolRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[100]
+	mi := &file_api_grpc_job_proto_msgTypes[141]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6387,7 +9977,7 @@ func (x *CallToolRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallToolRequest.ProtoReflect.Descriptor instead.
 func (*CallToolRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{100}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{141}
 }
 
 func (x *CallToolRequest) GetToolName() string {
@@ -6415,7 +10005,7 @@ type CallToolResponse struct {
 
 func (x *CallToolResponse) Reset() {
 	*x = CallToolResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[101]
+	mi := &file_api_grpc_job_proto_msgTypes[142]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6427,7 +10017,7 @@ func (x *CallToolResponse) String() string {
 func (*CallToolResponse) ProtoMessage() {}
 
 func (x *CallToolResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[101]
+	mi := &file_api_grpc_job_proto_msgTypes[142]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6440,7 +10030,7 @@ func (x *CallToolResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallToolResponse.ProtoReflect.Descriptor instead.
 func (*CallToolResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{101}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{142}
 }
 
 func (x *CallToolResponse) GetSuccess() bool {
@@ -6474,7 +10064,7 @@ type GetResourcesRequest struct {
 
 func (x *GetResourcesRequest) Reset() {
 	*x = GetResourcesRequest{}
-	mi := &file_api_grpc_job_proto_msgTypes[102]
+	mi := &file_api_grpc_job_proto_msgTypes[143]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6486,7 +10076,7 @@ func (x *GetResourcesRequest) String() string {
 func (*GetResourcesRequest) ProtoMessage() {}
 
 func (x *GetResourcesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[102]
+	mi := &file_api_grpc_job_proto_msgTypes[143]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6499,7 +10089,7 @@ func (x *GetResourcesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetResourcesRequest.ProtoReflect.Descriptor instead.
 func (*GetResourcesRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{102}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{143}
 }
 
 func (x *GetResourcesRequest) GetType() string {
@@ -6525,7 +10115,7 @@ type GetResourcesResponse struct {
 
 func (x *GetResourcesResponse) Reset() {
 	*x = GetResourcesResponse{}
-	mi := &file_api_grpc_job_proto_msgTypes[103]
+	mi := &file_api_grpc_job_proto_msgTypes[144]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6537,7 +10127,7 @@ func (x *GetResourcesResponse) String() string {
 func (*GetResourcesResponse) ProtoMessage() {}
 
 func (x *GetResourcesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[103]
+	mi := &file_api_grpc_job_proto_msgTypes[144]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6550,7 +10140,7 @@ func (x *GetResourcesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetResourcesResponse.ProtoReflect.Descriptor instead.
 func (*GetResourcesResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{103}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{144}
 }
 
 func (x *GetResourcesResponse) GetResources() []*MCPResource {
@@ -6573,7 +10163,7 @@ type MCPResource struct {
 
 func (x *MCPResource) Reset() {
 	*x = MCPResource{}
-	mi := &file_api_grpc_job_proto_msgTypes[104]
+	mi := &file_api_grpc_job_proto_msgTypes[145]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6585,7 +10175,7 @@ func (x *MCPResource) String() string {
 func (*MCPResource) ProtoMessage() {}
 
 func (x *MCPResource) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_job_proto_msgTypes[104]
+	mi := &file_api_grpc_job_proto_msgTypes[145]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6598,7 +10188,7 @@ func (x *MCPResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MCPResource.ProtoReflect.Descriptor instead.
 func (*MCPResource) Descriptor() ([]byte, []int) {
-	return file_api_grpc_job_proto_rawDescGZIP(), []int{104}
+	return file_api_grpc_job_proto_rawDescGZIP(), []int{145}
 }
 
 func (x *MCPResource) GetUri() string {
@@ -6640,7 +10230,7 @@ var File_api_grpc_job_proto protoreflect.FileDescriptor
 
 const file_api_grpc_job_proto_rawDesc = "" +
 	"\n" +
-	"\x12api/grpc/job.proto\x12\bapi.grpc\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb1\x05\n" +
+	"\x12api/grpc/job.proto\x12\bapi.grpc\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe4\f\n" +
 	"\x03Job\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -6664,10 +10254,74 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"department\x18\x0f \x01(\v2\x14.api.grpc.DepartmentR\n" +
 	"department\x12(\n" +
 	"\acreator\x18\x10 \x01(\v2\x0e.api.grpc.UserR\acreator\x127\n" +
-	"\fai_schedules\x18\x11 \x03(\v2\x14.api.grpc.AIScheduleR\vaiSchedules\x1a9\n" +
+	"\fai_schedules\x18\x11 \x03(\v2\x14.api.grpc.AIScheduleR\vaiSchedules\x12\x1d\n" +
+	"\n" +
+	"on_success\x18\x12 \x03(\tR\tonSuccess\x12\x1d\n" +
+	"\n" +
+	"on_failure\x18\x13 \x03(\tR\tonFailure\x12\x1b\n" +
+	"\ton_finish\x18\x14 \x03(\tR\bonFinish\x12 \n" +
+	"\fsla_start_by\x18\x15 \x01(\tR\n" +
+	"slaStartBy\x12\"\n" +
+	"\rsla_finish_by\x18\x16 \x01(\tR\vslaFinishBy\x12(\n" +
+	"\x10sla_max_duration\x18\x17 \x01(\x05R\x0eslaMaxDuration\x12)\n" +
+	"\x10cron_description\x18\x18 \x01(\tR\x0fcronDescription\x12\x1a\n" +
+	"\brevision\x18\x19 \x01(\x05R\brevision\x12\x1f\n" +
+	"\vtemplate_id\x18\x1a \x01(\tR\n" +
+	"templateId\x12J\n" +
+	"\x0ftemplate_values\x18\x1b \x03(\v2!.api.grpc.Job.TemplateValuesEntryR\x0etemplateValues\x12)\n" +
+	"\x10template_version\x18\x1c \x01(\x05R\x0ftemplateVersion\x12\x1d\n" +
+	"\n" +
+	"managed_by\x18\x1d \x01(\tR\tmanagedBy\x121\n" +
+	"\x06labels\x18\x1e \x03(\v2\x19.api.grpc.Job.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06paused\x18\x1f \x01(\bR\x06paused\x12=\n" +
+	"\fpaused_until\x18  \x01(\v2\x1a.google.protobuf.TimestampR\vpausedUntil\x12\x1b\n" +
+	"\texec_mode\x18! \x01(\tR\bexecMode\x12\x12\n" +
+	"\x04args\x18\" \x03(\tR\x04args\x12 \n" +
+	"\vinterpreter\x18# \x01(\tR\vinterpreter\x12\x19\n" +
+	"\bwork_dir\x18$ \x01(\tR\aworkDir\x12\x14\n" +
+	"\x05umask\x18% \x01(\tR\x05umask\x12\x12\n" +
+	"\x04type\x18& \x01(\tR\x04type\x12\x16\n" +
+	"\x06config\x18' \x01(\tR\x06config\x12\x16\n" +
+	"\x06limits\x18( \x01(\tR\x06limits\x12\x18\n" +
+	"\asandbox\x18) \x01(\tR\asandbox\x1a9\n" +
 	"\vParamsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc8\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aA\n" +
+	"\x13TemplateValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb2\x01\n" +
+	"\rTemplateParam\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\brequired\x18\x03 \x01(\bR\brequired\x12#\n" +
+	"\rdefault_value\x18\x04 \x01(\tR\fdefaultValue\x12\x18\n" +
+	"\apattern\x18\x05 \x01(\tR\apattern\x12\x10\n" +
+	"\x03raw\x18\x06 \x01(\bR\x03raw\"\xab\x03\n" +
+	"\vJobTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\acommand\x18\x04 \x01(\tR\acommand\x12/\n" +
+	"\x06params\x18\x05 \x03(\v2\x17.api.grpc.TemplateParamR\x06params\x12\x18\n" +
+	"\atimeout\x18\x06 \x01(\x05R\atimeout\x12%\n" +
+	"\x0eretry_attempts\x18\a \x01(\x05R\rretryAttempts\x12\x18\n" +
+	"\aversion\x18\b \x01(\x05R\aversion\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
+	"\tjob_count\x18\f \x01(\x03R\bjobCount\"\x8c\x01\n" +
+	"\x11TemplateJobChange\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x19\n" +
+	"\bjob_name\x18\x02 \x01(\tR\ajobName\x12/\n" +
+	"\achanges\x18\x03 \x03(\v2\x15.api.grpc.FieldChangeR\achanges\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xbe\x03\n" +
 	"\fJobExecution\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
 	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x1b\n" +
@@ -6679,7 +10333,30 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"finishedAt\x12\x16\n" +
 	"\x06output\x18\a \x01(\tR\x06output\x12\x14\n" +
 	"\x05error\x18\b \x01(\tR\x05error\x12\x1b\n" +
-	"\texit_code\x18\t \x01(\x05R\bexitCode\"\xfb\x02\n" +
+	"\texit_code\x18\t \x01(\x05R\bexitCode\x122\n" +
+	"\x15upstream_execution_id\x18\n" +
+	" \x01(\tR\x13upstreamExecutionId\x12\x1f\n" +
+	"\vchain_depth\x18\v \x01(\x05R\n" +
+	"chainDepth\x12\x1f\n" +
+	"\vrevision_id\x18\f \x01(\tR\n" +
+	"revisionId\"\xb5\x02\n" +
+	"\vJobRevision\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x1a\n" +
+	"\brevision\x18\x03 \x01(\x05R\brevision\x12\x1f\n" +
+	"\vchange_type\x18\x04 \x01(\tR\n" +
+	"changeType\x12)\n" +
+	"\bsnapshot\x18\x05 \x01(\v2\r.api.grpc.JobR\bsnapshot\x12#\n" +
+	"\rrollback_from\x18\x06 \x01(\x05R\frollbackFrom\x12\x18\n" +
+	"\acomment\x18\a \x01(\tR\acomment\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"]\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1b\n" +
+	"\told_value\x18\x02 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x03 \x01(\tR\bnewValue\"\xfb\x02\n" +
 	"\x06Worker\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x0e\n" +
@@ -6711,7 +10388,7 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"\n" +
 	"department\x18\f \x01(\v2\x14.api.grpc.DepartmentR\n" +
 	"department\x12$\n" +
-	"\x05roles\x18\r \x03(\v2\x0e.api.grpc.RoleR\x05roles\"\x85\x03\n" +
+	"\x05roles\x18\r \x03(\v2\x0e.api.grpc.RoleR\x05roles\"\x9f\x03\n" +
 	"\n" +
 	"Department\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
@@ -6727,7 +10404,8 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12,\n" +
 	"\x06parent\x18\n" +
 	" \x01(\v2\x14.api.grpc.DepartmentR\x06parent\x120\n" +
-	"\bchildren\x18\v \x03(\v2\x14.api.grpc.DepartmentR\bchildren\"\xa6\x02\n" +
+	"\bchildren\x18\v \x03(\v2\x14.api.grpc.DepartmentR\bchildren\x12\x18\n" +
+	"\asandbox\x18\f \x01(\tR\asandbox\"\xa6\x02\n" +
 	"\x04Role\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -6772,7 +10450,7 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf3\x02\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x9b\a\n" +
 	"\x10CreateJobRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
@@ -6782,16 +10460,39 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"\x0eretry_attempts\x18\x06 \x01(\x05R\rretryAttempts\x12\x18\n" +
 	"\atimeout\x18\a \x01(\x05R\atimeout\x12\x1a\n" +
 	"\bpriority\x18\b \x01(\x05R\bpriority\x12#\n" +
-	"\rdepartment_id\x18\t \x01(\tR\fdepartmentId\x1a9\n" +
+	"\rdepartment_id\x18\t \x01(\tR\fdepartmentId\x12\x1d\n" +
+	"\n" +
+	"on_success\x18\n" +
+	" \x03(\tR\tonSuccess\x12\x1d\n" +
+	"\n" +
+	"on_failure\x18\v \x03(\tR\tonFailure\x12\x1b\n" +
+	"\ton_finish\x18\f \x03(\tR\bonFinish\x12 \n" +
+	"\fsla_start_by\x18\r \x01(\tR\n" +
+	"slaStartBy\x12\"\n" +
+	"\rsla_finish_by\x18\x0e \x01(\tR\vslaFinishBy\x12(\n" +
+	"\x10sla_max_duration\x18\x0f \x01(\x05R\x0eslaMaxDuration\x12>\n" +
+	"\x06labels\x18\x10 \x03(\v2&.api.grpc.CreateJobRequest.LabelsEntryR\x06labels\x12\x1b\n" +
+	"\texec_mode\x18\x11 \x01(\tR\bexecMode\x12\x12\n" +
+	"\x04args\x18\x12 \x03(\tR\x04args\x12 \n" +
+	"\vinterpreter\x18\x13 \x01(\tR\vinterpreter\x12\x19\n" +
+	"\bwork_dir\x18\x14 \x01(\tR\aworkDir\x12\x14\n" +
+	"\x05umask\x18\x15 \x01(\tR\x05umask\x12\x12\n" +
+	"\x04type\x18\x16 \x01(\tR\x04type\x12\x16\n" +
+	"\x06config\x18\x17 \x01(\tR\x06config\x12\x16\n" +
+	"\x06limits\x18\x18 \x01(\tR\x06limits\x12\x18\n" +
+	"\asandbox\x18\x19 \x01(\tR\asandbox\x1a9\n" +
 	"\vParamsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"4\n" +
 	"\x11CreateJobResponse\x12\x1f\n" +
 	"\x03job\x18\x01 \x01(\v2\r.api.grpc.JobR\x03job\"\x1f\n" +
 	"\rGetJobRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"1\n" +
 	"\x0eGetJobResponse\x12\x1f\n" +
-	"\x03job\x18\x01 \x01(\v2\r.api.grpc.JobR\x03job\"\xb1\x01\n" +
+	"\x03job\x18\x01 \x01(\v2\r.api.grpc.JobR\x03job\"\xf3\x02\n" +
 	"\x0fListJobsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
 	"\x04size\x18\x02 \x01(\x05R\x04size\x12\x18\n" +
@@ -6799,10 +10500,18 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"\aenabled\x18\x04 \x01(\bR\aenabled\x12#\n" +
 	"\rdepartment_id\x18\x05 \x01(\tR\fdepartmentId\x12\x1d\n" +
 	"\n" +
-	"created_by\x18\x06 \x01(\tR\tcreatedBy\"K\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\x12%\n" +
+	"\x0elabel_selector\x18\a \x01(\tR\rlabelSelector\x12\x14\n" +
+	"\x05state\x18\b \x01(\tR\x05state\x12&\n" +
+	"\x0flast_run_status\x18\t \x01(\tR\rlastRunStatus\x12#\n" +
+	"\rschedule_type\x18\n" +
+	" \x01(\tR\fscheduleType\x12\x17\n" +
+	"\asort_by\x18\v \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\f \x01(\tR\tsortOrder\"K\n" +
 	"\x10ListJobsResponse\x12!\n" +
 	"\x04jobs\x18\x01 \x03(\v2\r.api.grpc.JobR\x04jobs\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total\"\x9d\x03\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\xc5\a\n" +
 	"\x10UpdateJobRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -6815,9 +10524,31 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"\atimeout\x18\t \x01(\x05R\atimeout\x12\x1a\n" +
 	"\bpriority\x18\n" +
 	" \x01(\x05R\bpriority\x12#\n" +
-	"\rdepartment_id\x18\v \x01(\tR\fdepartmentId\x1a9\n" +
+	"\rdepartment_id\x18\v \x01(\tR\fdepartmentId\x12\x1d\n" +
+	"\n" +
+	"on_success\x18\f \x03(\tR\tonSuccess\x12\x1d\n" +
+	"\n" +
+	"on_failure\x18\r \x03(\tR\tonFailure\x12\x1b\n" +
+	"\ton_finish\x18\x0e \x03(\tR\bonFinish\x12 \n" +
+	"\fsla_start_by\x18\x0f \x01(\tR\n" +
+	"slaStartBy\x12\"\n" +
+	"\rsla_finish_by\x18\x10 \x01(\tR\vslaFinishBy\x12(\n" +
+	"\x10sla_max_duration\x18\x11 \x01(\x05R\x0eslaMaxDuration\x12>\n" +
+	"\x06labels\x18\x12 \x03(\v2&.api.grpc.UpdateJobRequest.LabelsEntryR\x06labels\x12\x1b\n" +
+	"\texec_mode\x18\x13 \x01(\tR\bexecMode\x12\x12\n" +
+	"\x04args\x18\x14 \x03(\tR\x04args\x12 \n" +
+	"\vinterpreter\x18\x15 \x01(\tR\vinterpreter\x12\x19\n" +
+	"\bwork_dir\x18\x16 \x01(\tR\aworkDir\x12\x14\n" +
+	"\x05umask\x18\x17 \x01(\tR\x05umask\x12\x12\n" +
+	"\x04type\x18\x18 \x01(\tR\x04type\x12\x16\n" +
+	"\x06config\x18\x19 \x01(\tR\x06config\x12\x16\n" +
+	"\x06limits\x18\x1a \x01(\tR\x06limits\x12\x18\n" +
+	"\asandbox\x18\x1b \x01(\tR\asandbox\x1a9\n" +
 	"\vParamsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"4\n" +
 	"\x11UpdateJobResponse\x12\x1f\n" +
 	"\x03job\x18\x01 \x01(\v2\r.api.grpc.JobR\x03job\"\"\n" +
@@ -6832,39 +10563,188 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"7\n" +
 	"\x12TriggerJobResponse\x12!\n" +
-	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\"\xf3\x01\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\"S\n" +
+	"\x16CancelExecutionRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\\\n" +
+	"\x17CancelExecutionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12'\n" +
+	"\x0fworker_notified\x18\x02 \x01(\bR\x0eworkerNotified\"X\n" +
+	"\x17ListJobRevisionsRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\"e\n" +
+	"\x18ListJobRevisionsResponse\x123\n" +
+	"\trevisions\x18\x01 \x03(\v2\x15.api.grpc.JobRevisionR\trevisions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"v\n" +
+	"\x17DiffJobRevisionsRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12#\n" +
+	"\rfrom_revision\x18\x02 \x01(\x05R\ffromRevision\x12\x1f\n" +
+	"\vto_revision\x18\x03 \x01(\x05R\n" +
+	"toRevision\"\x91\x01\n" +
+	"\x18DiffJobRevisionsResponse\x12#\n" +
+	"\rfrom_revision\x18\x01 \x01(\x05R\ffromRevision\x12\x1f\n" +
+	"\vto_revision\x18\x02 \x01(\x05R\n" +
+	"toRevision\x12/\n" +
+	"\achanges\x18\x03 \x03(\v2\x15.api.grpc.FieldChangeR\achanges\"a\n" +
+	"\x12RollbackJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x05R\brevision\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"i\n" +
+	"\x13RollbackJobResponse\x12\x1f\n" +
+	"\x03job\x18\x01 \x01(\v2\r.api.grpc.JobR\x03job\x121\n" +
+	"\brevision\x18\x02 \x01(\v2\x15.api.grpc.JobRevisionR\brevision\"\xdc\x01\n" +
+	"\x18CreateJobTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\acommand\x18\x03 \x01(\tR\acommand\x12/\n" +
+	"\x06params\x18\x04 \x03(\v2\x17.api.grpc.TemplateParamR\x06params\x12\x18\n" +
+	"\atimeout\x18\x05 \x01(\x05R\atimeout\x12%\n" +
+	"\x0eretry_attempts\x18\x06 \x01(\x05R\rretryAttempts\"N\n" +
+	"\x19CreateJobTemplateResponse\x121\n" +
+	"\btemplate\x18\x01 \x01(\v2\x15.api.grpc.JobTemplateR\btemplate\"'\n" +
+	"\x15GetJobTemplateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"K\n" +
+	"\x16GetJobTemplateResponse\x121\n" +
+	"\btemplate\x18\x01 \x01(\v2\x15.api.grpc.JobTemplateR\btemplate\"[\n" +
+	"\x17ListJobTemplatesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12\x18\n" +
+	"\akeyword\x18\x03 \x01(\tR\akeyword\"e\n" +
+	"\x18ListJobTemplatesResponse\x123\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x15.api.grpc.JobTemplateR\ttemplates\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\xa3\x02\n" +
+	"\x18UpdateJobTemplateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\acommand\x18\x04 \x01(\tR\acommand\x12/\n" +
+	"\x06params\x18\x05 \x03(\v2\x17.api.grpc.TemplateParamR\x06params\x12\x18\n" +
+	"\atimeout\x18\x06 \x01(\x05R\atimeout\x12%\n" +
+	"\x0eretry_attempts\x18\a \x01(\x05R\rretryAttempts\x12\x1c\n" +
+	"\tpropagate\x18\b \x01(\bR\tpropagate\x12\x17\n" +
+	"\adry_run\x18\t \x01(\bR\x06dryRun\"\x90\x01\n" +
+	"\x19UpdateJobTemplateResponse\x121\n" +
+	"\btemplate\x18\x01 \x01(\v2\x15.api.grpc.JobTemplateR\btemplate\x12@\n" +
+	"\raffected_jobs\x18\x02 \x03(\v2\x1b.api.grpc.TemplateJobChangeR\faffectedJobs\"*\n" +
+	"\x18DeleteJobTemplateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"5\n" +
+	"\x19DeleteJobTemplateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xd3\x02\n" +
+	"\x1dInstantiateJobTemplateRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04cron\x18\x04 \x01(\tR\x04cron\x12K\n" +
+	"\x06values\x18\x05 \x03(\v23.api.grpc.InstantiateJobTemplateRequest.ValuesEntryR\x06values\x12#\n" +
+	"\rdepartment_id\x18\x06 \x01(\tR\fdepartmentId\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\x05R\bpriority\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"A\n" +
+	"\x1eInstantiateJobTemplateResponse\x12\x1f\n" +
+	"\x03job\x18\x01 \x01(\v2\r.api.grpc.JobR\x03job\"\xf9\x01\n" +
+	"\x17BulkJobOperationRequest\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x17\n" +
+	"\ajob_ids\x18\x02 \x03(\tR\x06jobIds\x121\n" +
+	"\x06filter\x18\x03 \x01(\v2\x19.api.grpc.ListJobsRequestR\x06filter\x12\x16\n" +
+	"\x06atomic\x18\x04 \x01(\bR\x06atomic\x12#\n" +
+	"\rdepartment_id\x18\x05 \x01(\tR\fdepartmentId\x12=\n" +
+	"\fpaused_until\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vpausedUntil\"\x94\x01\n" +
+	"\rBulkJobResult\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x19\n" +
+	"\bjob_name\x18\x02 \x01(\tR\ajobName\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12!\n" +
+	"\fexecution_id\x18\x05 \x01(\tR\vexecutionId\"\x9e\x01\n" +
+	"\x18BulkJobOperationResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.api.grpc.BulkJobResultR\aresults\x12\x1c\n" +
+	"\tsucceeded\x18\x02 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12\x19\n" +
+	"\baudit_id\x18\x04 \x01(\tR\aauditId\"\\\n" +
+	"\x13ValidateCronRequest\x12\x12\n" +
+	"\x04cron\x18\x01 \x01(\tR\x04cron\x12\x12\n" +
+	"\x04lang\x18\x02 \x01(\tR\x04lang\x12\x1d\n" +
+	"\n" +
+	"next_count\x18\x03 \x01(\x05R\tnextCount\"\xe5\x01\n" +
+	"\x14ValidateCronResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12%\n" +
+	"\x0eerror_position\x18\x03 \x01(\x05R\rerrorPosition\x12\x1f\n" +
+	"\verror_field\x18\x04 \x01(\tR\n" +
+	"errorField\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x127\n" +
+	"\tnext_runs\x18\x06 \x03(\v2\x1a.google.protobuf.TimestampR\bnextRuns\"\x8f\x02\n" +
 	"\x15RegisterWorkerRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
 	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x12\n" +
 	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x1a\n" +
 	"\bcapacity\x18\x04 \x01(\x05R\bcapacity\x12I\n" +
-	"\bmetadata\x18\x05 \x03(\v2-.api.grpc.RegisterWorkerRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x05 \x03(\v2-.api.grpc.RegisterWorkerRequest.MetadataEntryR\bmetadata\x12\x1a\n" +
+	"\bhandlers\x18\x06 \x03(\tR\bhandlers\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"5\n" +
 	"\x16RegisterWorkerResponse\x12\x1b\n" +
-	"\tworker_id\x18\x01 \x01(\tR\bworkerId\"\x82\x01\n" +
+	"\tworker_id\x18\x01 \x01(\tR\bworkerId\"\xad\x01\n" +
 	"\x10HeartbeatRequest\x12\x1b\n" +
 	"\tworker_id\x18\x01 \x01(\tR\bworkerId\x12!\n" +
 	"\fcurrent_load\x18\x02 \x01(\x05R\vcurrentLoad\x12.\n" +
-	"\x06status\x18\x03 \x01(\x0e2\x16.api.grpc.WorkerStatusR\x06status\"-\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x16.api.grpc.WorkerStatusR\x06status\x12)\n" +
+	"\x04host\x18\x04 \x01(\v2\x15.api.grpc.HostMetricsR\x04host\"\x9c\x03\n" +
+	"\vHostMetrics\x12\x1f\n" +
+	"\vcpu_percent\x18\x01 \x01(\x01R\n" +
+	"cpuPercent\x12\x1b\n" +
+	"\tcpu_count\x18\x02 \x01(\x05R\bcpuCount\x12\x14\n" +
+	"\x05load1\x18\x03 \x01(\x01R\x05load1\x12\x14\n" +
+	"\x05load5\x18\x04 \x01(\x01R\x05load5\x12\x16\n" +
+	"\x06load15\x18\x05 \x01(\x01R\x06load15\x12,\n" +
+	"\x12memory_total_bytes\x18\x06 \x01(\x03R\x10memoryTotalBytes\x124\n" +
+	"\x16memory_available_bytes\x18\a \x01(\x03R\x14memoryAvailableBytes\x12(\n" +
+	"\x10disk_total_bytes\x18\b \x01(\x03R\x0ediskTotalBytes\x12&\n" +
+	"\x0fdisk_free_bytes\x18\t \x01(\x03R\rdiskFreeBytes\x12.\n" +
+	"\x13host_uptime_seconds\x18\n" +
+	" \x01(\x01R\x11hostUptimeSeconds\x12%\n" +
+	"\x0euptime_seconds\x18\v \x01(\x01R\ruptimeSeconds\"]\n" +
 	"\x11HeartbeatResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"I\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12.\n" +
+	"\acancels\x18\x02 \x03(\v2\x14.api.grpc.CancelTaskR\acancels\"I\n" +
 	"\x0eGetTaskRequest\x12\x1b\n" +
 	"\tworker_id\x18\x01 \x01(\tR\bworkerId\x12\x1a\n" +
 	"\bcapacity\x18\x02 \x01(\x05R\bcapacity\"7\n" +
 	"\x0fGetTaskResponse\x12$\n" +
-	"\x05tasks\x18\x01 \x03(\v2\x0e.api.grpc.TaskR\x05tasks\"\xf7\x01\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x0e.api.grpc.TaskR\x05tasks\"\xc9\x04\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
 	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x18\n" +
 	"\acommand\x18\x03 \x01(\tR\acommand\x122\n" +
 	"\x06params\x18\x04 \x03(\v2\x1a.api.grpc.Task.ParamsEntryR\x06params\x12\x18\n" +
 	"\atimeout\x18\x05 \x01(\x05R\atimeout\x12%\n" +
-	"\x0eretry_attempts\x18\x06 \x01(\x05R\rretryAttempts\x1a9\n" +
+	"\x0eretry_attempts\x18\x06 \x01(\x05R\rretryAttempts\x12 \n" +
+	"\vtraceparent\x18\a \x01(\tR\vtraceparent\x12\x1b\n" +
+	"\texec_mode\x18\b \x01(\tR\bexecMode\x12\x12\n" +
+	"\x04args\x18\t \x03(\tR\x04args\x12 \n" +
+	"\vinterpreter\x18\n" +
+	" \x01(\tR\vinterpreter\x12\x19\n" +
+	"\bwork_dir\x18\v \x01(\tR\aworkDir\x12\x14\n" +
+	"\x05umask\x18\f \x01(\tR\x05umask\x12\x12\n" +
+	"\x04type\x18\r \x01(\tR\x04type\x12\x16\n" +
+	"\x06config\x18\x0e \x01(\tR\x06config\x124\n" +
+	"\n" +
+	"connection\x18\x0f \x01(\v2\x14.api.grpc.ConnectionR\n" +
+	"connection\x12\x16\n" +
+	"\x06limits\x18\x10 \x01(\tR\x06limits\x12\x18\n" +
+	"\asandbox\x18\x11 \x01(\tR\asandbox\x12\x16\n" +
+	"\x06redact\x18\x12 \x03(\tR\x06redact\x1a9\n" +
 	"\vParamsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc5\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"J\n" +
+	"\n" +
+	"Connection\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06driver\x18\x02 \x01(\tR\x06driver\x12\x10\n" +
+	"\x03dsn\x18\x03 \x01(\tR\x03dsn\"\xe8\x03\n" +
 	"\x17ReportTaskResultRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1b\n" +
 	"\tworker_id\x18\x02 \x01(\tR\bworkerId\x121\n" +
@@ -6875,8 +10755,56 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"\n" +
 	"started_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
 	"\vfinished_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"finishedAt\"4\n" +
+	"finishedAt\x12 \n" +
+	"\vtraceparent\x18\t \x01(\tR\vtraceparent\x12\x16\n" +
+	"\x06result\x18\n" +
+	" \x01(\tR\x06result\x12 \n" +
+	"\vtermination\x18\v \x01(\tR\vtermination\x12$\n" +
+	"\x0epeak_rss_bytes\x18\f \x01(\x03R\fpeakRssBytes\x12\x1f\n" +
+	"\vcpu_seconds\x18\r \x01(\x01R\n" +
+	"cpuSeconds\"4\n" +
 	"\x18ReportTaskResultResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xcf\x02\n" +
+	"\rWorkerMessage\x12-\n" +
+	"\x05hello\x18\x01 \x01(\v2\x15.api.grpc.WorkerHelloH\x00R\x05hello\x12:\n" +
+	"\theartbeat\x18\x02 \x01(\v2\x1a.api.grpc.HeartbeatRequestH\x00R\theartbeat\x12%\n" +
+	"\x03ack\x18\x03 \x01(\v2\x11.api.grpc.TaskAckH\x00R\x03ack\x12;\n" +
+	"\x06result\x18\x04 \x01(\v2!.api.grpc.ReportTaskResultRequestH\x00R\x06result\x12.\n" +
+	"\x06output\x18\x05 \x01(\v2\x14.api.grpc.TaskOutputH\x00R\x06output\x124\n" +
+	"\bprogress\x18\x06 \x01(\v2\x16.api.grpc.TaskProgressH\x00R\bprogressB\t\n" +
+	"\apayload\"F\n" +
+	"\vWorkerHello\x12\x1b\n" +
+	"\tworker_id\x18\x01 \x01(\tR\bworkerId\x12\x1a\n" +
+	"\bcapacity\x18\x02 \x01(\x05R\bcapacity\"V\n" +
+	"\aTaskAck\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\bR\baccepted\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"s\n" +
+	"\x10SchedulerMessage\x12$\n" +
+	"\x04task\x18\x01 \x01(\v2\x0e.api.grpc.TaskH\x00R\x04task\x12.\n" +
+	"\x06cancel\x18\x02 \x01(\v2\x14.api.grpc.CancelTaskH\x00R\x06cancelB\t\n" +
+	"\apayload\"=\n" +
+	"\n" +
+	"CancelTask\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xc8\x01\n" +
+	"\n" +
+	"TaskOutput\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1b\n" +
+	"\tworker_id\x18\x02 \x01(\tR\bworkerId\x12.\n" +
+	"\x06stream\x18\x03 \x01(\x0e2\x16.api.grpc.OutputStreamR\x06stream\x12\x10\n" +
+	"\x03seq\x18\x04 \x01(\x03R\x03seq\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\x12.\n" +
+	"\x04time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"4\n" +
+	"\x18ReportTaskOutputResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xa8\x01\n" +
+	"\fTaskProgress\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1b\n" +
+	"\tworker_id\x18\x02 \x01(\tR\bworkerId\x12\x18\n" +
+	"\apercent\x18\x03 \x01(\x05R\apercent\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12.\n" +
+	"\x04time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"6\n" +
+	"\x1aReportTaskProgressResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"F\n" +
 	"\fLoginRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
@@ -6957,13 +10885,14 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
 	"\brole_ids\x18\x02 \x03(\tR\aroleIds\"3\n" +
 	"\x17AssignUserRolesResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x94\x01\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xae\x01\n" +
 	"\x17CreateDepartmentRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
 	"\tparent_id\x18\x04 \x01(\tR\bparentId\x12\x12\n" +
-	"\x04sort\x18\x05 \x01(\x05R\x04sort\"P\n" +
+	"\x04sort\x18\x05 \x01(\x05R\x04sort\x12\x18\n" +
+	"\asandbox\x18\x06 \x01(\tR\asandbox\"P\n" +
 	"\x18CreateDepartmentResponse\x124\n" +
 	"\n" +
 	"department\x18\x01 \x01(\v2\x14.api.grpc.DepartmentR\n" +
@@ -6981,7 +10910,7 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"\x06status\x18\x04 \x01(\tR\x06status\"g\n" +
 	"\x17ListDepartmentsResponse\x126\n" +
 	"\vdepartments\x18\x01 \x03(\v2\x14.api.grpc.DepartmentR\vdepartments\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total\"\xbc\x01\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\xd6\x01\n" +
 	"\x17UpdateDepartmentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -6989,7 +10918,8 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1b\n" +
 	"\tparent_id\x18\x05 \x01(\tR\bparentId\x12\x16\n" +
 	"\x06status\x18\x06 \x01(\tR\x06status\x12\x12\n" +
-	"\x04sort\x18\a \x01(\x05R\x04sort\"P\n" +
+	"\x04sort\x18\a \x01(\x05R\x04sort\x12\x18\n" +
+	"\asandbox\x18\b \x01(\tR\asandbox\"P\n" +
 	"\x18UpdateDepartmentResponse\x124\n" +
 	"\n" +
 	"department\x18\x01 \x01(\v2\x14.api.grpc.DepartmentR\n" +
@@ -7175,13 +11105,18 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"\n" +
 	"\x06FAILED\x10\x03\x12\v\n" +
 	"\aTIMEOUT\x10\x04\x12\r\n" +
-	"\tCANCELLED\x10\x05*B\n" +
+	"\tCANCELLED\x10\x05*&\n" +
+	"\fOutputStream\x12\n" +
+	"\n" +
+	"\x06STDOUT\x10\x00\x12\n" +
+	"\n" +
+	"\x06STDERR\x10\x01*B\n" +
 	"\fWorkerStatus\x12\v\n" +
 	"\aOFFLINE\x10\x00\x12\n" +
 	"\n" +
 	"\x06ONLINE\x10\x01\x12\b\n" +
 	"\x04BUSY\x10\x02\x12\x0f\n" +
-	"\vMAINTENANCE\x10\x032\xa7\x03\n" +
+	"\vMAINTENANCE\x10\x032\xe2\v\n" +
 	"\n" +
 	"JobService\x12D\n" +
 	"\tCreateJob\x12\x1a.api.grpc.CreateJobRequest\x1a\x1b.api.grpc.CreateJobResponse\x12;\n" +
@@ -7190,12 +11125,27 @@ const file_api_grpc_job_proto_rawDesc = "" +
 	"\tUpdateJob\x12\x1a.api.grpc.UpdateJobRequest\x1a\x1b.api.grpc.UpdateJobResponse\x12D\n" +
 	"\tDeleteJob\x12\x1a.api.grpc.DeleteJobRequest\x1a\x1b.api.grpc.DeleteJobResponse\x12G\n" +
 	"\n" +
-	"TriggerJob\x12\x1b.api.grpc.TriggerJobRequest\x1a\x1c.api.grpc.TriggerJobResponse2\xc8\x02\n" +
+	"TriggerJob\x12\x1b.api.grpc.TriggerJobRequest\x1a\x1c.api.grpc.TriggerJobResponse\x12V\n" +
+	"\x0fCancelExecution\x12 .api.grpc.CancelExecutionRequest\x1a!.api.grpc.CancelExecutionResponse\x12M\n" +
+	"\fValidateCron\x12\x1d.api.grpc.ValidateCronRequest\x1a\x1e.api.grpc.ValidateCronResponse\x12Y\n" +
+	"\x10ListJobRevisions\x12!.api.grpc.ListJobRevisionsRequest\x1a\".api.grpc.ListJobRevisionsResponse\x12Y\n" +
+	"\x10DiffJobRevisions\x12!.api.grpc.DiffJobRevisionsRequest\x1a\".api.grpc.DiffJobRevisionsResponse\x12J\n" +
+	"\vRollbackJob\x12\x1c.api.grpc.RollbackJobRequest\x1a\x1d.api.grpc.RollbackJobResponse\x12\\\n" +
+	"\x11CreateJobTemplate\x12\".api.grpc.CreateJobTemplateRequest\x1a#.api.grpc.CreateJobTemplateResponse\x12S\n" +
+	"\x0eGetJobTemplate\x12\x1f.api.grpc.GetJobTemplateRequest\x1a .api.grpc.GetJobTemplateResponse\x12Y\n" +
+	"\x10ListJobTemplates\x12!.api.grpc.ListJobTemplatesRequest\x1a\".api.grpc.ListJobTemplatesResponse\x12\\\n" +
+	"\x11UpdateJobTemplate\x12\".api.grpc.UpdateJobTemplateRequest\x1a#.api.grpc.UpdateJobTemplateResponse\x12\\\n" +
+	"\x11DeleteJobTemplate\x12\".api.grpc.DeleteJobTemplateRequest\x1a#.api.grpc.DeleteJobTemplateResponse\x12k\n" +
+	"\x16InstantiateJobTemplate\x12'.api.grpc.InstantiateJobTemplateRequest\x1a(.api.grpc.InstantiateJobTemplateResponse\x12Y\n" +
+	"\x10BulkJobOperation\x12!.api.grpc.BulkJobOperationRequest\x1a\".api.grpc.BulkJobOperationResponse2\xae\x04\n" +
 	"\x10SchedulerService\x12S\n" +
 	"\x0eRegisterWorker\x12\x1f.api.grpc.RegisterWorkerRequest\x1a .api.grpc.RegisterWorkerResponse\x12D\n" +
 	"\tHeartbeat\x12\x1a.api.grpc.HeartbeatRequest\x1a\x1b.api.grpc.HeartbeatResponse\x12>\n" +
 	"\aGetTask\x12\x18.api.grpc.GetTaskRequest\x1a\x19.api.grpc.GetTaskResponse\x12Y\n" +
-	"\x10ReportTaskResult\x12!.api.grpc.ReportTaskResultRequest\x1a\".api.grpc.ReportTaskResultResponse2\x80\x03\n" +
+	"\x10ReportTaskResult\x12!.api.grpc.ReportTaskResultRequest\x1a\".api.grpc.ReportTaskResultResponse\x12B\n" +
+	"\aConnect\x12\x17.api.grpc.WorkerMessage\x1a\x1a.api.grpc.SchedulerMessage(\x010\x01\x12L\n" +
+	"\x10ReportTaskOutput\x12\x14.api.grpc.TaskOutput\x1a\".api.grpc.ReportTaskOutputResponse\x12R\n" +
+	"\x12ReportTaskProgress\x12\x16.api.grpc.TaskProgress\x1a$.api.grpc.ReportTaskProgressResponse2\x80\x03\n" +
 	"\vAuthService\x128\n" +
 	"\x05Login\x12\x16.api.grpc.LoginRequest\x1a\x17.api.grpc.LoginResponse\x12;\n" +
 	"\x06Logout\x12\x17.api.grpc.LogoutRequest\x1a\x18.api.grpc.LogoutResponse\x12M\n" +
@@ -7260,303 +11210,422 @@ func file_api_grpc_job_proto_rawDescGZIP() []byte {
 	return file_api_grpc_job_proto_rawDescData
 }
 
-var file_api_grpc_job_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_grpc_job_proto_msgTypes = make([]protoimpl.MessageInfo, 117)
+var file_api_grpc_job_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_grpc_job_proto_msgTypes = make([]protoimpl.MessageInfo, 163)
 var file_api_grpc_job_proto_goTypes = []any{
-	(ExecutionStatus)(0),                 // 0: api.grpc.ExecutionStatus
-	(WorkerStatus)(0),                    // 1: api.grpc.WorkerStatus
-	(*Job)(nil),                          // 2: api.grpc.Job
-	(*JobExecution)(nil),                 // 3: api.grpc.JobExecution
-	(*Worker)(nil),                       // 4: api.grpc.Worker
-	(*User)(nil),                         // 5: api.grpc.User
-	(*Department)(nil),                   // 6: api.grpc.Department
-	(*Role)(nil),                         // 7: api.grpc.Role
-	(*Permission)(nil),                   // 8: api.grpc.Permission
-	(*AISchedule)(nil),                   // 9: api.grpc.AISchedule
-	(*CreateJobRequest)(nil),             // 10: api.grpc.CreateJobRequest
-	(*CreateJobResponse)(nil),            // 11: api.grpc.CreateJobResponse
-	(*GetJobRequest)(nil),                // 12: api.grpc.GetJobRequest
-	(*GetJobResponse)(nil),               // 13: api.grpc.GetJobResponse
-	(*ListJobsRequest)(nil),              // 14: api.grpc.ListJobsRequest
-	(*ListJobsResponse)(nil),             // 15: api.grpc.ListJobsResponse
-	(*UpdateJobRequest)(nil),             // 16: api.grpc.UpdateJobRequest
-	(*UpdateJobResponse)(nil),            // 17: api.grpc.UpdateJobResponse
-	(*DeleteJobRequest)(nil),             // 18: api.grpc.DeleteJobRequest
-	(*DeleteJobResponse)(nil),            // 19: api.grpc.DeleteJobResponse
-	(*TriggerJobRequest)(nil),            // 20: api.grpc.TriggerJobRequest
-	(*TriggerJobResponse)(nil),           // 21: api.grpc.TriggerJobResponse
-	(*RegisterWorkerRequest)(nil),        // 22: api.grpc.RegisterWorkerRequest
-	(*RegisterWorkerResponse)(nil),       // 23: api.grpc.RegisterWorkerResponse
-	(*HeartbeatRequest)(nil),             // 24: api.grpc.HeartbeatRequest
-	(*HeartbeatResponse)(nil),            // 25: api.grpc.HeartbeatResponse
-	(*GetTaskRequest)(nil),               // 26: api.grpc.GetTaskRequest
-	(*GetTaskResponse)(nil),              // 27: api.grpc.GetTaskResponse
-	(*Task)(nil),                         // 28: api.grpc.Task
-	(*ReportTaskResultRequest)(nil),      // 29: api.grpc.ReportTaskResultRequest
-	(*ReportTaskResultResponse)(nil),     // 30: api.grpc.ReportTaskResultResponse
-	(*LoginRequest)(nil),                 // 31: api.grpc.LoginRequest
-	(*LoginResponse)(nil),                // 32: api.grpc.LoginResponse
-	(*LogoutRequest)(nil),                // 33: api.grpc.LogoutRequest
-	(*LogoutResponse)(nil),               // 34: api.grpc.LogoutResponse
-	(*RefreshTokenRequest)(nil),          // 35: api.grpc.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),         // 36: api.grpc.RefreshTokenResponse
-	(*GetUserInfoRequest)(nil),           // 37: api.grpc.GetUserInfoRequest
-	(*GetUserInfoResponse)(nil),          // 38: api.grpc.GetUserInfoResponse
-	(*GetUserPermissionsRequest)(nil),    // 39: api.grpc.GetUserPermissionsRequest
-	(*GetUserPermissionsResponse)(nil),   // 40: api.grpc.GetUserPermissionsResponse
-	(*CreateUserRequest)(nil),            // 41: api.grpc.CreateUserRequest
-	(*CreateUserResponse)(nil),           // 42: api.grpc.CreateUserResponse
-	(*GetUserRequest)(nil),               // 43: api.grpc.GetUserRequest
-	(*GetUserResponse)(nil),              // 44: api.grpc.GetUserResponse
-	(*ListUsersRequest)(nil),             // 45: api.grpc.ListUsersRequest
-	(*ListUsersResponse)(nil),            // 46: api.grpc.ListUsersResponse
-	(*UpdateUserRequest)(nil),            // 47: api.grpc.UpdateUserRequest
-	(*UpdateUserResponse)(nil),           // 48: api.grpc.UpdateUserResponse
-	(*DeleteUserRequest)(nil),            // 49: api.grpc.DeleteUserRequest
-	(*DeleteUserResponse)(nil),           // 50: api.grpc.DeleteUserResponse
-	(*ChangePasswordRequest)(nil),        // 51: api.grpc.ChangePasswordRequest
-	(*ChangePasswordResponse)(nil),       // 52: api.grpc.ChangePasswordResponse
-	(*AssignUserRolesRequest)(nil),       // 53: api.grpc.AssignUserRolesRequest
-	(*AssignUserRolesResponse)(nil),      // 54: api.grpc.AssignUserRolesResponse
-	(*CreateDepartmentRequest)(nil),      // 55: api.grpc.CreateDepartmentRequest
-	(*CreateDepartmentResponse)(nil),     // 56: api.grpc.CreateDepartmentResponse
-	(*GetDepartmentRequest)(nil),         // 57: api.grpc.GetDepartmentRequest
-	(*GetDepartmentResponse)(nil),        // 58: api.grpc.GetDepartmentResponse
-	(*ListDepartmentsRequest)(nil),       // 59: api.grpc.ListDepartmentsRequest
-	(*ListDepartmentsResponse)(nil),      // 60: api.grpc.ListDepartmentsResponse
-	(*UpdateDepartmentRequest)(nil),      // 61: api.grpc.UpdateDepartmentRequest
-	(*UpdateDepartmentResponse)(nil),     // 62: api.grpc.UpdateDepartmentResponse
-	(*DeleteDepartmentRequest)(nil),      // 63: api.grpc.DeleteDepartmentRequest
-	(*DeleteDepartmentResponse)(nil),     // 64: api.grpc.DeleteDepartmentResponse
-	(*GetDepartmentTreeRequest)(nil),     // 65: api.grpc.GetDepartmentTreeRequest
-	(*GetDepartmentTreeResponse)(nil),    // 66: api.grpc.GetDepartmentTreeResponse
-	(*CreateRoleRequest)(nil),            // 67: api.grpc.CreateRoleRequest
-	(*CreateRoleResponse)(nil),           // 68: api.grpc.CreateRoleResponse
-	(*GetRoleRequest)(nil),               // 69: api.grpc.GetRoleRequest
-	(*GetRoleResponse)(nil),              // 70: api.grpc.GetRoleResponse
-	(*ListRolesRequest)(nil),             // 71: api.grpc.ListRolesRequest
-	(*ListRolesResponse)(nil),            // 72: api.grpc.ListRolesResponse
-	(*UpdateRoleRequest)(nil),            // 73: api.grpc.UpdateRoleRequest
-	(*UpdateRoleResponse)(nil),           // 74: api.grpc.UpdateRoleResponse
-	(*DeleteRoleRequest)(nil),            // 75: api.grpc.DeleteRoleRequest
-	(*DeleteRoleResponse)(nil),           // 76: api.grpc.DeleteRoleResponse
-	(*AssignPermissionsRequest)(nil),     // 77: api.grpc.AssignPermissionsRequest
-	(*AssignPermissionsResponse)(nil),    // 78: api.grpc.AssignPermissionsResponse
-	(*CreatePermissionRequest)(nil),      // 79: api.grpc.CreatePermissionRequest
-	(*CreatePermissionResponse)(nil),     // 80: api.grpc.CreatePermissionResponse
-	(*GetPermissionRequest)(nil),         // 81: api.grpc.GetPermissionRequest
-	(*GetPermissionResponse)(nil),        // 82: api.grpc.GetPermissionResponse
-	(*ListPermissionsRequest)(nil),       // 83: api.grpc.ListPermissionsRequest
-	(*ListPermissionsResponse)(nil),      // 84: api.grpc.ListPermissionsResponse
-	(*UpdatePermissionRequest)(nil),      // 85: api.grpc.UpdatePermissionRequest
-	(*UpdatePermissionResponse)(nil),     // 86: api.grpc.UpdatePermissionResponse
-	(*DeletePermissionRequest)(nil),      // 87: api.grpc.DeletePermissionRequest
-	(*DeletePermissionResponse)(nil),     // 88: api.grpc.DeletePermissionResponse
-	(*GetPermissionTreeRequest)(nil),     // 89: api.grpc.GetPermissionTreeRequest
-	(*GetPermissionTreeResponse)(nil),    // 90: api.grpc.GetPermissionTreeResponse
-	(*AnalyzeJobRequest)(nil),            // 91: api.grpc.AnalyzeJobRequest
-	(*AnalyzeJobResponse)(nil),           // 92: api.grpc.AnalyzeJobResponse
-	(*OptimizeScheduleRequest)(nil),      // 93: api.grpc.OptimizeScheduleRequest
-	(*OptimizeScheduleResponse)(nil),     // 94: api.grpc.OptimizeScheduleResponse
-	(*ScheduleOptimization)(nil),         // 95: api.grpc.ScheduleOptimization
-	(*GetAIRecommendationsRequest)(nil),  // 96: api.grpc.GetAIRecommendationsRequest
-	(*GetAIRecommendationsResponse)(nil), // 97: api.grpc.GetAIRecommendationsResponse
-	(*AIRecommendation)(nil),             // 98: api.grpc.AIRecommendation
-	(*ListToolsRequest)(nil),             // 99: api.grpc.ListToolsRequest
-	(*ListToolsResponse)(nil),            // 100: api.grpc.ListToolsResponse
-	(*MCPTool)(nil),                      // 101: api.grpc.MCPTool
-	(*CallToolRequest)(nil),              // 102: api.grpc.CallToolRequest
-	(*CallToolResponse)(nil),             // 103: api.grpc.CallToolResponse
-	(*GetResourcesRequest)(nil),          // 104: api.grpc.GetResourcesRequest
-	(*GetResourcesResponse)(nil),         // 105: api.grpc.GetResourcesResponse
-	(*MCPResource)(nil),                  // 106: api.grpc.MCPResource
-	nil,                                  // 107: api.grpc.Job.ParamsEntry
-	nil,                                  // 108: api.grpc.Worker.MetadataEntry
-	nil,                                  // 109: api.grpc.CreateJobRequest.ParamsEntry
-	nil,                                  // 110: api.grpc.UpdateJobRequest.ParamsEntry
-	nil,                                  // 111: api.grpc.TriggerJobRequest.ParamsEntry
-	nil,                                  // 112: api.grpc.RegisterWorkerRequest.MetadataEntry
-	nil,                                  // 113: api.grpc.Task.ParamsEntry
-	nil,                                  // 114: api.grpc.AnalyzeJobRequest.MetadataEntry
-	nil,                                  // 115: api.grpc.OptimizeScheduleRequest.ConstraintsEntry
-	nil,                                  // 116: api.grpc.GetAIRecommendationsRequest.ContextEntry
-	nil,                                  // 117: api.grpc.MCPTool.ParametersEntry
-	nil,                                  // 118: api.grpc.CallToolRequest.ArgumentsEntry
-	(*timestamppb.Timestamp)(nil),        // 119: google.protobuf.Timestamp
+	(ExecutionStatus)(0),                   // 0: api.grpc.ExecutionStatus
+	(OutputStream)(0),                      // 1: api.grpc.OutputStream
+	(WorkerStatus)(0),                      // 2: api.grpc.WorkerStatus
+	(*Job)(nil),                            // 3: api.grpc.Job
+	(*TemplateParam)(nil),                  // 4: api.grpc.TemplateParam
+	(*JobTemplate)(nil),                    // 5: api.grpc.JobTemplate
+	(*TemplateJobChange)(nil),              // 6: api.grpc.TemplateJobChange
+	(*JobExecution)(nil),                   // 7: api.grpc.JobExecution
+	(*JobRevision)(nil),                    // 8: api.grpc.JobRevision
+	(*FieldChange)(nil),                    // 9: api.grpc.FieldChange
+	(*Worker)(nil),                         // 10: api.grpc.Worker
+	(*User)(nil),                           // 11: api.grpc.User
+	(*Department)(nil),                     // 12: api.grpc.Department
+	(*Role)(nil),                           // 13: api.grpc.Role
+	(*Permission)(nil),                     // 14: api.grpc.Permission
+	(*AISchedule)(nil),                     // 15: api.grpc.AISchedule
+	(*CreateJobRequest)(nil),               // 16: api.grpc.CreateJobRequest
+	(*CreateJobResponse)(nil),              // 17: api.grpc.CreateJobResponse
+	(*GetJobRequest)(nil),                  // 18: api.grpc.GetJobRequest
+	(*GetJobResponse)(nil),                 // 19: api.grpc.GetJobResponse
+	(*ListJobsRequest)(nil),                // 20: api.grpc.ListJobsRequest
+	(*ListJobsResponse)(nil),               // 21: api.grpc.ListJobsResponse
+	(*UpdateJobRequest)(nil),               // 22: api.grpc.UpdateJobRequest
+	(*UpdateJobResponse)(nil),              // 23: api.grpc.UpdateJobResponse
+	(*DeleteJobRequest)(nil),               // 24: api.grpc.DeleteJobRequest
+	(*DeleteJobResponse)(nil),              // 25: api.grpc.DeleteJobResponse
+	(*TriggerJobRequest)(nil),              // 26: api.grpc.TriggerJobRequest
+	(*TriggerJobResponse)(nil),             // 27: api.grpc.TriggerJobResponse
+	(*CancelExecutionRequest)(nil),         // 28: api.grpc.CancelExecutionRequest
+	(*CancelExecutionResponse)(nil),        // 29: api.grpc.CancelExecutionResponse
+	(*ListJobRevisionsRequest)(nil),        // 30: api.grpc.ListJobRevisionsRequest
+	(*ListJobRevisionsResponse)(nil),       // 31: api.grpc.ListJobRevisionsResponse
+	(*DiffJobRevisionsRequest)(nil),        // 32: api.grpc.DiffJobRevisionsRequest
+	(*DiffJobRevisionsResponse)(nil),       // 33: api.grpc.DiffJobRevisionsResponse
+	(*RollbackJobRequest)(nil),             // 34: api.grpc.RollbackJobRequest
+	(*RollbackJobResponse)(nil),            // 35: api.grpc.RollbackJobResponse
+	(*CreateJobTemplateRequest)(nil),       // 36: api.grpc.CreateJobTemplateRequest
+	(*CreateJobTemplateResponse)(nil),      // 37: api.grpc.CreateJobTemplateResponse
+	(*GetJobTemplateRequest)(nil),          // 38: api.grpc.GetJobTemplateRequest
+	(*GetJobTemplateResponse)(nil),         // 39: api.grpc.GetJobTemplateResponse
+	(*ListJobTemplatesRequest)(nil),        // 40: api.grpc.ListJobTemplatesRequest
+	(*ListJobTemplatesResponse)(nil),       // 41: api.grpc.ListJobTemplatesResponse
+	(*UpdateJobTemplateRequest)(nil),       // 42: api.grpc.UpdateJobTemplateRequest
+	(*UpdateJobTemplateResponse)(nil),      // 43: api.grpc.UpdateJobTemplateResponse
+	(*DeleteJobTemplateRequest)(nil),       // 44: api.grpc.DeleteJobTemplateRequest
+	(*DeleteJobTemplateResponse)(nil),      // 45: api.grpc.DeleteJobTemplateResponse
+	(*InstantiateJobTemplateRequest)(nil),  // 46: api.grpc.InstantiateJobTemplateRequest
+	(*InstantiateJobTemplateResponse)(nil), // 47: api.grpc.InstantiateJobTemplateResponse
+	(*BulkJobOperationRequest)(nil),        // 48: api.grpc.BulkJobOperationRequest
+	(*BulkJobResult)(nil),                  // 49: api.grpc.BulkJobResult
+	(*BulkJobOperationResponse)(nil),       // 50: api.grpc.BulkJobOperationResponse
+	(*ValidateCronRequest)(nil),            // 51: api.grpc.ValidateCronRequest
+	(*ValidateCronResponse)(nil),           // 52: api.grpc.ValidateCronResponse
+	(*RegisterWorkerRequest)(nil),          // 53: api.grpc.RegisterWorkerRequest
+	(*RegisterWorkerResponse)(nil),         // 54: api.grpc.RegisterWorkerResponse
+	(*HeartbeatRequest)(nil),               // 55: api.grpc.HeartbeatRequest
+	(*HostMetrics)(nil),                    // 56: api.grpc.HostMetrics
+	(*HeartbeatResponse)(nil),              // 57: api.grpc.HeartbeatResponse
+	(*GetTaskRequest)(nil),                 // 58: api.grpc.GetTaskRequest
+	(*GetTaskResponse)(nil),                // 59: api.grpc.GetTaskResponse
+	(*Task)(nil),                           // 60: api.grpc.Task
+	(*Connection)(nil),                     // 61: api.grpc.Connection
+	(*ReportTaskResultRequest)(nil),        // 62: api.grpc.ReportTaskResultRequest
+	(*ReportTaskResultResponse)(nil),       // 63: api.grpc.ReportTaskResultResponse
+	(*WorkerMessage)(nil),                  // 64: api.grpc.WorkerMessage
+	(*WorkerHello)(nil),                    // 65: api.grpc.WorkerHello
+	(*TaskAck)(nil),                        // 66: api.grpc.TaskAck
+	(*SchedulerMessage)(nil),               // 67: api.grpc.SchedulerMessage
+	(*CancelTask)(nil),                     // 68: api.grpc.CancelTask
+	(*TaskOutput)(nil),                     // 69: api.grpc.TaskOutput
+	(*ReportTaskOutputResponse)(nil),       // 70: api.grpc.ReportTaskOutputResponse
+	(*TaskProgress)(nil),                   // 71: api.grpc.TaskProgress
+	(*ReportTaskProgressResponse)(nil),     // 72: api.grpc.ReportTaskProgressResponse
+	(*LoginRequest)(nil),                   // 73: api.grpc.LoginRequest
+	(*LoginResponse)(nil),                  // 74: api.grpc.LoginResponse
+	(*LogoutRequest)(nil),                  // 75: api.grpc.LogoutRequest
+	(*LogoutResponse)(nil),                 // 76: api.grpc.LogoutResponse
+	(*RefreshTokenRequest)(nil),            // 77: api.grpc.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),           // 78: api.grpc.RefreshTokenResponse
+	(*GetUserInfoRequest)(nil),             // 79: api.grpc.GetUserInfoRequest
+	(*GetUserInfoResponse)(nil),            // 80: api.grpc.GetUserInfoResponse
+	(*GetUserPermissionsRequest)(nil),      // 81: api.grpc.GetUserPermissionsRequest
+	(*GetUserPermissionsResponse)(nil),     // 82: api.grpc.GetUserPermissionsResponse
+	(*CreateUserRequest)(nil),              // 83: api.grpc.CreateUserRequest
+	(*CreateUserResponse)(nil),             // 84: api.grpc.CreateUserResponse
+	(*GetUserRequest)(nil),                 // 85: api.grpc.GetUserRequest
+	(*GetUserResponse)(nil),                // 86: api.grpc.GetUserResponse
+	(*ListUsersRequest)(nil),               // 87: api.grpc.ListUsersRequest
+	(*ListUsersResponse)(nil),              // 88: api.grpc.ListUsersResponse
+	(*UpdateUserRequest)(nil),              // 89: api.grpc.UpdateUserRequest
+	(*UpdateUserResponse)(nil),             // 90: api.grpc.UpdateUserResponse
+	(*DeleteUserRequest)(nil),              // 91: api.grpc.DeleteUserRequest
+	(*DeleteUserResponse)(nil),             // 92: api.grpc.DeleteUserResponse
+	(*ChangePasswordRequest)(nil),          // 93: api.grpc.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),         // 94: api.grpc.ChangePasswordResponse
+	(*AssignUserRolesRequest)(nil),         // 95: api.grpc.AssignUserRolesRequest
+	(*AssignUserRolesResponse)(nil),        // 96: api.grpc.AssignUserRolesResponse
+	(*CreateDepartmentRequest)(nil),        // 97: api.grpc.CreateDepartmentRequest
+	(*CreateDepartmentResponse)(nil),       // 98: api.grpc.CreateDepartmentResponse
+	(*GetDepartmentRequest)(nil),           // 99: api.grpc.GetDepartmentRequest
+	(*GetDepartmentResponse)(nil),          // 100: api.grpc.GetDepartmentResponse
+	(*ListDepartmentsRequest)(nil),         // 101: api.grpc.ListDepartmentsRequest
+	(*ListDepartmentsResponse)(nil),        // 102: api.grpc.ListDepartmentsResponse
+	(*UpdateDepartmentRequest)(nil),        // 103: api.grpc.UpdateDepartmentRequest
+	(*UpdateDepartmentResponse)(nil),       // 104: api.grpc.UpdateDepartmentResponse
+	(*DeleteDepartmentRequest)(nil),        // 105: api.grpc.DeleteDepartmentRequest
+	(*DeleteDepartmentResponse)(nil),       // 106: api.grpc.DeleteDepartmentResponse
+	(*GetDepartmentTreeRequest)(nil),       // 107: api.grpc.GetDepartmentTreeRequest
+	(*GetDepartmentTreeResponse)(nil),      // 108: api.grpc.GetDepartmentTreeResponse
+	(*CreateRoleRequest)(nil),              // 109: api.grpc.CreateRoleRequest
+	(*CreateRoleResponse)(nil),             // 110: api.grpc.CreateRoleResponse
+	(*GetRoleRequest)(nil),                 // 111: api.grpc.GetRoleRequest
+	(*GetRoleResponse)(nil),                // 112: api.grpc.GetRoleResponse
+	(*ListRolesRequest)(nil),               // 113: api.grpc.ListRolesRequest
+	(*ListRolesResponse)(nil),              // 114: api.grpc.ListRolesResponse
+	(*UpdateRoleRequest)(nil),              // 115: api.grpc.UpdateRoleRequest
+	(*UpdateRoleResponse)(nil),             // 116: api.grpc.UpdateRoleResponse
+	(*DeleteRoleRequest)(nil),              // 117: api.grpc.DeleteRoleRequest
+	(*DeleteRoleResponse)(nil),             // 118: api.grpc.DeleteRoleResponse
+	(*AssignPermissionsRequest)(nil),       // 119: api.grpc.AssignPermissionsRequest
+	(*AssignPermissionsResponse)(nil),      // 120: api.grpc.AssignPermissionsResponse
+	(*CreatePermissionRequest)(nil),        // 121: api.grpc.CreatePermissionRequest
+	(*CreatePermissionResponse)(nil),       // 122: api.grpc.CreatePermissionResponse
+	(*GetPermissionRequest)(nil),           // 123: api.grpc.GetPermissionRequest
+	(*GetPermissionResponse)(nil),          // 124: api.grpc.GetPermissionResponse
+	(*ListPermissionsRequest)(nil),         // 125: api.grpc.ListPermissionsRequest
+	(*ListPermissionsResponse)(nil),        // 126: api.grpc.ListPermissionsResponse
+	(*UpdatePermissionRequest)(nil),        // 127: api.grpc.UpdatePermissionRequest
+	(*UpdatePermissionResponse)(nil),       // 128: api.grpc.UpdatePermissionResponse
+	(*DeletePermissionRequest)(nil),        // 129: api.grpc.DeletePermissionRequest
+	(*DeletePermissionResponse)(nil),       // 130: api.grpc.DeletePermissionResponse
+	(*GetPermissionTreeRequest)(nil),       // 131: api.grpc.GetPermissionTreeRequest
+	(*GetPermissionTreeResponse)(nil),      // 132: api.grpc.GetPermissionTreeResponse
+	(*AnalyzeJobRequest)(nil),              // 133: api.grpc.AnalyzeJobRequest
+	(*AnalyzeJobResponse)(nil),             // 134: api.grpc.AnalyzeJobResponse
+	(*OptimizeScheduleRequest)(nil),        // 135: api.grpc.OptimizeScheduleRequest
+	(*OptimizeScheduleResponse)(nil),       // 136: api.grpc.OptimizeScheduleResponse
+	(*ScheduleOptimization)(nil),           // 137: api.grpc.ScheduleOptimization
+	(*GetAIRecommendationsRequest)(nil),    // 138: api.grpc.GetAIRecommendationsRequest
+	(*GetAIRecommendationsResponse)(nil),   // 139: api.grpc.GetAIRecommendationsResponse
+	(*AIRecommendation)(nil),               // 140: api.grpc.AIRecommendation
+	(*ListToolsRequest)(nil),               // 141: api.grpc.ListToolsRequest
+	(*ListToolsResponse)(nil),              // 142: api.grpc.ListToolsResponse
+	(*MCPTool)(nil),                        // 143: api.grpc.MCPTool
+	(*CallToolRequest)(nil),                // 144: api.grpc.CallToolRequest
+	(*CallToolResponse)(nil),               // 145: api.grpc.CallToolResponse
+	(*GetResourcesRequest)(nil),            // 146: api.grpc.GetResourcesRequest
+	(*GetResourcesResponse)(nil),           // 147: api.grpc.GetResourcesResponse
+	(*MCPResource)(nil),                    // 148: api.grpc.MCPResource
+	nil,                                    // 149: api.grpc.Job.ParamsEntry
+	nil,                                    // 150: api.grpc.Job.TemplateValuesEntry
+	nil,                                    // 151: api.grpc.Job.LabelsEntry
+	nil,                                    // 152: api.grpc.Worker.MetadataEntry
+	nil,                                    // 153: api.grpc.CreateJobRequest.ParamsEntry
+	nil,                                    // 154: api.grpc.CreateJobRequest.LabelsEntry
+	nil,                                    // 155: api.grpc.UpdateJobRequest.ParamsEntry
+	nil,                                    // 156: api.grpc.UpdateJobRequest.LabelsEntry
+	nil,                                    // 157: api.grpc.TriggerJobRequest.ParamsEntry
+	nil,                                    // 158: api.grpc.InstantiateJobTemplateRequest.ValuesEntry
+	nil,                                    // 159: api.grpc.RegisterWorkerRequest.MetadataEntry
+	nil,                                    // 160: api.grpc.Task.ParamsEntry
+	nil,                                    // 161: api.grpc.AnalyzeJobRequest.MetadataEntry
+	nil,                                    // 162: api.grpc.OptimizeScheduleRequest.ConstraintsEntry
+	nil,                                    // 163: api.grpc.GetAIRecommendationsRequest.ContextEntry
+	nil,                                    // 164: api.grpc.MCPTool.ParametersEntry
+	nil,                                    // 165: api.grpc.CallToolRequest.ArgumentsEntry
+	(*timestamppb.Timestamp)(nil),          // 166: google.protobuf.Timestamp
 }
 var file_api_grpc_job_proto_depIdxs = []int32{
-	107, // 0: api.grpc.Job.params:type_name -> api.grpc.Job.ParamsEntry
-	119, // 1: api.grpc.Job.created_at:type_name -> google.protobuf.Timestamp
-	119, // 2: api.grpc.Job.updated_at:type_name -> google.protobuf.Timestamp
-	6,   // 3: api.grpc.Job.department:type_name -> api.grpc.Department
-	5,   // 4: api.grpc.Job.creator:type_name -> api.grpc.User
-	9,   // 5: api.grpc.Job.ai_schedules:type_name -> api.grpc.AISchedule
-	0,   // 6: api.grpc.JobExecution.status:type_name -> api.grpc.ExecutionStatus
-	119, // 7: api.grpc.JobExecution.started_at:type_name -> google.protobuf.Timestamp
-	119, // 8: api.grpc.JobExecution.finished_at:type_name -> google.protobuf.Timestamp
-	1,   // 9: api.grpc.Worker.status:type_name -> api.grpc.WorkerStatus
-	119, // 10: api.grpc.Worker.last_heartbeat:type_name -> google.protobuf.Timestamp
-	108, // 11: api.grpc.Worker.metadata:type_name -> api.grpc.Worker.MetadataEntry
-	119, // 12: api.grpc.User.created_at:type_name -> google.protobuf.Timestamp
-	119, // 13: api.grpc.User.updated_at:type_name -> google.protobuf.Timestamp
-	119, // 14: api.grpc.User.last_login_at:type_name -> google.protobuf.Timestamp
-	6,   // 15: api.grpc.User.department:type_name -> api.grpc.Department
-	7,   // 16: api.grpc.User.roles:type_name -> api.grpc.Role
-	119, // 17: api.grpc.Department.created_at:type_name -> google.protobuf.Timestamp
-	119, // 18: api.grpc.Department.updated_at:type_name -> google.protobuf.Timestamp
-	6,   // 19: api.grpc.Department.parent:type_name -> api.grpc.Department
-	6,   // 20: api.grpc.Department.children:type_name -> api.grpc.Department
-	119, // 21: api.grpc.Role.created_at:type_name -> google.protobuf.Timestamp
-	119, // 22: api.grpc.Role.updated_at:type_name -> google.protobuf.Timestamp
-	8,   // 23: api.grpc.Role.permissions:type_name -> api.grpc.Permission
-	119, // 24: api.grpc.Permission.created_at:type_name -> google.protobuf.Timestamp
-	119, // 25: api.grpc.Permission.updated_at:type_name -> google.protobuf.Timestamp
-	8,   // 26: api.grpc.Permission.parent:type_name -> api.grpc.Permission
-	8,   // 27: api.grpc.Permission.children:type_name -> api.grpc.Permission
-	119, // 28: api.grpc.AISchedule.created_at:type_name -> google.protobuf.Timestamp
-	119, // 29: api.grpc.AISchedule.updated_at:type_name -> google.protobuf.Timestamp
-	109, // 30: api.grpc.CreateJobRequest.params:type_name -> api.grpc.CreateJobRequest.ParamsEntry
-	2,   // 31: api.grpc.CreateJobResponse.job:type_name -> api.grpc.Job
-	2,   // 32: api.grpc.GetJobResponse.job:type_name -> api.grpc.Job
-	2,   // 33: api.grpc.ListJobsResponse.jobs:type_name -> api.grpc.Job
-	110, // 34: api.grpc.UpdateJobRequest.params:type_name -> api.grpc.UpdateJobRequest.ParamsEntry
-	2,   // 35: api.grpc.UpdateJobResponse.job:type_name -> api.grpc.Job
-	111, // 36: api.grpc.TriggerJobRequest.params:type_name -> api.grpc.TriggerJobRequest.ParamsEntry
-	112, // 37: api.grpc.RegisterWorkerRequest.metadata:type_name -> api.grpc.RegisterWorkerRequest.MetadataEntry
-	1,   // 38: api.grpc.HeartbeatRequest.status:type_name -> api.grpc.WorkerStatus
-	28,  // 39: api.grpc.GetTaskResponse.tasks:type_name -> api.grpc.Task
-	113, // 40: api.grpc.Task.params:type_name -> api.grpc.Task.ParamsEntry
-	0,   // 41: api.grpc.ReportTaskResultRequest.status:type_name -> api.grpc.ExecutionStatus
-	119, // 42: api.grpc.ReportTaskResultRequest.started_at:type_name -> google.protobuf.Timestamp
-	119, // 43: api.grpc.ReportTaskResultRequest.finished_at:type_name -> google.protobuf.Timestamp
-	5,   // 44: api.grpc.LoginResponse.user:type_name -> api.grpc.User
-	8,   // 45: api.grpc.LoginResponse.permissions:type_name -> api.grpc.Permission
-	5,   // 46: api.grpc.GetUserInfoResponse.user:type_name -> api.grpc.User
-	8,   // 47: api.grpc.GetUserPermissionsResponse.permissions:type_name -> api.grpc.Permission
-	5,   // 48: api.grpc.CreateUserResponse.user:type_name -> api.grpc.User
-	5,   // 49: api.grpc.GetUserResponse.user:type_name -> api.grpc.User
-	5,   // 50: api.grpc.ListUsersResponse.users:type_name -> api.grpc.User
-	5,   // 51: api.grpc.UpdateUserResponse.user:type_name -> api.grpc.User
-	6,   // 52: api.grpc.CreateDepartmentResponse.department:type_name -> api.grpc.Department
-	6,   // 53: api.grpc.GetDepartmentResponse.department:type_name -> api.grpc.Department
-	6,   // 54: api.grpc.ListDepartmentsResponse.departments:type_name -> api.grpc.Department
-	6,   // 55: api.grpc.UpdateDepartmentResponse.department:type_name -> api.grpc.Department
-	6,   // 56: api.grpc.GetDepartmentTreeResponse.departments:type_name -> api.grpc.Department
-	7,   // 57: api.grpc.CreateRoleResponse.role:type_name -> api.grpc.Role
-	7,   // 58: api.grpc.GetRoleResponse.role:type_name -> api.grpc.Role
-	7,   // 59: api.grpc.ListRolesResponse.roles:type_name -> api.grpc.Role
-	7,   // 60: api.grpc.UpdateRoleResponse.role:type_name -> api.grpc.Role
-	8,   // 61: api.grpc.CreatePermissionResponse.permission:type_name -> api.grpc.Permission
-	8,   // 62: api.grpc.GetPermissionResponse.permission:type_name -> api.grpc.Permission
-	8,   // 63: api.grpc.ListPermissionsResponse.permissions:type_name -> api.grpc.Permission
-	8,   // 64: api.grpc.UpdatePermissionResponse.permission:type_name -> api.grpc.Permission
-	8,   // 65: api.grpc.GetPermissionTreeResponse.permissions:type_name -> api.grpc.Permission
-	114, // 66: api.grpc.AnalyzeJobRequest.metadata:type_name -> api.grpc.AnalyzeJobRequest.MetadataEntry
-	115, // 67: api.grpc.OptimizeScheduleRequest.constraints:type_name -> api.grpc.OptimizeScheduleRequest.ConstraintsEntry
-	95,  // 68: api.grpc.OptimizeScheduleResponse.optimizations:type_name -> api.grpc.ScheduleOptimization
-	116, // 69: api.grpc.GetAIRecommendationsRequest.context:type_name -> api.grpc.GetAIRecommendationsRequest.ContextEntry
-	98,  // 70: api.grpc.GetAIRecommendationsResponse.recommendations:type_name -> api.grpc.AIRecommendation
-	101, // 71: api.grpc.ListToolsResponse.tools:type_name -> api.grpc.MCPTool
-	117, // 72: api.grpc.MCPTool.parameters:type_name -> api.grpc.MCPTool.ParametersEntry
-	118, // 73: api.grpc.CallToolRequest.arguments:type_name -> api.grpc.CallToolRequest.ArgumentsEntry
-	106, // 74: api.grpc.GetResourcesResponse.resources:type_name -> api.grpc.MCPResource
-	10,  // 75: api.grpc.JobService.CreateJob:input_type -> api.grpc.CreateJobRequest
-	12,  // 76: api.grpc.JobService.GetJob:input_type -> api.grpc.GetJobRequest
-	14,  // 77: api.grpc.JobService.ListJobs:input_type -> api.grpc.ListJobsRequest
-	16,  // 78: api.grpc.JobService.UpdateJob:input_type -> api.grpc.UpdateJobRequest
-	18,  // 79: api.grpc.JobService.DeleteJob:input_type -> api.grpc.DeleteJobRequest
-	20,  // 80: api.grpc.JobService.TriggerJob:input_type -> api.grpc.TriggerJobRequest
-	22,  // 81: api.grpc.SchedulerService.RegisterWorker:input_type -> api.grpc.RegisterWorkerRequest
-	24,  // 82: api.grpc.SchedulerService.Heartbeat:input_type -> api.grpc.HeartbeatRequest
-	26,  // 83: api.grpc.SchedulerService.GetTask:input_type -> api.grpc.GetTaskRequest
-	29,  // 84: api.grpc.SchedulerService.ReportTaskResult:input_type -> api.grpc.ReportTaskResultRequest
-	31,  // 85: api.grpc.AuthService.Login:input_type -> api.grpc.LoginRequest
-	33,  // 86: api.grpc.AuthService.Logout:input_type -> api.grpc.LogoutRequest
-	35,  // 87: api.grpc.AuthService.RefreshToken:input_type -> api.grpc.RefreshTokenRequest
-	37,  // 88: api.grpc.AuthService.GetUserInfo:input_type -> api.grpc.GetUserInfoRequest
-	39,  // 89: api.grpc.AuthService.GetUserPermissions:input_type -> api.grpc.GetUserPermissionsRequest
-	41,  // 90: api.grpc.UserService.CreateUser:input_type -> api.grpc.CreateUserRequest
-	43,  // 91: api.grpc.UserService.GetUser:input_type -> api.grpc.GetUserRequest
-	45,  // 92: api.grpc.UserService.ListUsers:input_type -> api.grpc.ListUsersRequest
-	47,  // 93: api.grpc.UserService.UpdateUser:input_type -> api.grpc.UpdateUserRequest
-	49,  // 94: api.grpc.UserService.DeleteUser:input_type -> api.grpc.DeleteUserRequest
-	51,  // 95: api.grpc.UserService.ChangePassword:input_type -> api.grpc.ChangePasswordRequest
-	53,  // 96: api.grpc.UserService.AssignUserRoles:input_type -> api.grpc.AssignUserRolesRequest
-	55,  // 97: api.grpc.DepartmentService.CreateDepartment:input_type -> api.grpc.CreateDepartmentRequest
-	57,  // 98: api.grpc.DepartmentService.GetDepartment:input_type -> api.grpc.GetDepartmentRequest
-	59,  // 99: api.grpc.DepartmentService.ListDepartments:input_type -> api.grpc.ListDepartmentsRequest
-	61,  // 100: api.grpc.DepartmentService.UpdateDepartment:input_type -> api.grpc.UpdateDepartmentRequest
-	63,  // 101: api.grpc.DepartmentService.DeleteDepartment:input_type -> api.grpc.DeleteDepartmentRequest
-	65,  // 102: api.grpc.DepartmentService.GetDepartmentTree:input_type -> api.grpc.GetDepartmentTreeRequest
-	67,  // 103: api.grpc.RoleService.CreateRole:input_type -> api.grpc.CreateRoleRequest
-	69,  // 104: api.grpc.RoleService.GetRole:input_type -> api.grpc.GetRoleRequest
-	71,  // 105: api.grpc.RoleService.ListRoles:input_type -> api.grpc.ListRolesRequest
-	73,  // 106: api.grpc.RoleService.UpdateRole:input_type -> api.grpc.UpdateRoleRequest
-	75,  // 107: api.grpc.RoleService.DeleteRole:input_type -> api.grpc.DeleteRoleRequest
-	77,  // 108: api.grpc.RoleService.AssignPermissions:input_type -> api.grpc.AssignPermissionsRequest
-	79,  // 109: api.grpc.PermissionService.CreatePermission:input_type -> api.grpc.CreatePermissionRequest
-	81,  // 110: api.grpc.PermissionService.GetPermission:input_type -> api.grpc.GetPermissionRequest
-	83,  // 111: api.grpc.PermissionService.ListPermissions:input_type -> api.grpc.ListPermissionsRequest
-	85,  // 112: api.grpc.PermissionService.UpdatePermission:input_type -> api.grpc.UpdatePermissionRequest
-	87,  // 113: api.grpc.PermissionService.DeletePermission:input_type -> api.grpc.DeletePermissionRequest
-	89,  // 114: api.grpc.PermissionService.GetPermissionTree:input_type -> api.grpc.GetPermissionTreeRequest
-	91,  // 115: api.grpc.AISchedulerService.AnalyzeJob:input_type -> api.grpc.AnalyzeJobRequest
-	93,  // 116: api.grpc.AISchedulerService.OptimizeSchedule:input_type -> api.grpc.OptimizeScheduleRequest
-	96,  // 117: api.grpc.AISchedulerService.GetAIRecommendations:input_type -> api.grpc.GetAIRecommendationsRequest
-	99,  // 118: api.grpc.MCPService.ListTools:input_type -> api.grpc.ListToolsRequest
-	102, // 119: api.grpc.MCPService.CallTool:input_type -> api.grpc.CallToolRequest
-	104, // 120: api.grpc.MCPService.GetResources:input_type -> api.grpc.GetResourcesRequest
-	11,  // 121: api.grpc.JobService.CreateJob:output_type -> api.grpc.CreateJobResponse
-	13,  // 122: api.grpc.JobService.GetJob:output_type -> api.grpc.GetJobResponse
-	15,  // 123: api.grpc.JobService.ListJobs:output_type -> api.grpc.ListJobsResponse
-	17,  // 124: api.grpc.JobService.UpdateJob:output_type -> api.grpc.UpdateJobResponse
-	19,  // 125: api.grpc.JobService.DeleteJob:output_type -> api.grpc.DeleteJobResponse
-	21,  // 126: api.grpc.JobService.TriggerJob:output_type -> api.grpc.TriggerJobResponse
-	23,  // 127: api.grpc.SchedulerService.RegisterWorker:output_type -> api.grpc.RegisterWorkerResponse
-	25,  // 128: api.grpc.SchedulerService.Heartbeat:output_type -> api.grpc.HeartbeatResponse
-	27,  // 129: api.grpc.SchedulerService.GetTask:output_type -> api.grpc.GetTaskResponse
-	30,  // 130: api.grpc.SchedulerService.ReportTaskResult:output_type -> api.grpc.ReportTaskResultResponse
-	32,  // 131: api.grpc.AuthService.Login:output_type -> api.grpc.LoginResponse
-	34,  // 132: api.grpc.AuthService.Logout:output_type -> api.grpc.LogoutResponse
-	36,  // 133: api.grpc.AuthService.RefreshToken:output_type -> api.grpc.RefreshTokenResponse
-	38,  // 134: api.grpc.AuthService.GetUserInfo:output_type -> api.grpc.GetUserInfoResponse
-	40,  // 135: api.grpc.AuthService.GetUserPermissions:output_type -> api.grpc.GetUserPermissionsResponse
-	42,  // 136: api.grpc.UserService.CreateUser:output_type -> api.grpc.CreateUserResponse
-	44,  // 137: api.grpc.UserService.GetUser:output_type -> api.grpc.GetUserResponse
-	46,  // 138: api.grpc.UserService.ListUsers:output_type -> api.grpc.ListUsersResponse
-	48,  // 139: api.grpc.UserService.UpdateUser:output_type -> api.grpc.UpdateUserResponse
-	50,  // 140: api.grpc.UserService.DeleteUser:output_type -> api.grpc.DeleteUserResponse
-	52,  // 141: api.grpc.UserService.ChangePassword:output_type -> api.grpc.ChangePasswordResponse
-	54,  // 142: api.grpc.UserService.AssignUserRoles:output_type -> api.grpc.AssignUserRolesResponse
-	56,  // 143: api.grpc.DepartmentService.CreateDepartment:output_type -> api.grpc.CreateDepartmentResponse
-	58,  // 144: api.grpc.DepartmentService.GetDepartment:output_type -> api.grpc.GetDepartmentResponse
-	60,  // 145: api.grpc.DepartmentService.ListDepartments:output_type -> api.grpc.ListDepartmentsResponse
-	62,  // 146: api.grpc.DepartmentService.UpdateDepartment:output_type -> api.grpc.UpdateDepartmentResponse
-	64,  // 147: api.grpc.DepartmentService.DeleteDepartment:output_type -> api.grpc.DeleteDepartmentResponse
-	66,  // 148: api.grpc.DepartmentService.GetDepartmentTree:output_type -> api.grpc.GetDepartmentTreeResponse
-	68,  // 149: api.grpc.RoleService.CreateRole:output_type -> api.grpc.CreateRoleResponse
-	70,  // 150: api.grpc.RoleService.GetRole:output_type -> api.grpc.GetRoleResponse
-	72,  // 151: api.grpc.RoleService.ListRoles:output_type -> api.grpc.ListRolesResponse
-	74,  // 152: api.grpc.RoleService.UpdateRole:output_type -> api.grpc.UpdateRoleResponse
-	76,  // 153: api.grpc.RoleService.DeleteRole:output_type -> api.grpc.DeleteRoleResponse
-	78,  // 154: api.grpc.RoleService.AssignPermissions:output_type -> api.grpc.AssignPermissionsResponse
-	80,  // 155: api.grpc.PermissionService.CreatePermission:output_type -> api.grpc.CreatePermissionResponse
-	82,  // 156: api.grpc.PermissionService.GetPermission:output_type -> api.grpc.GetPermissionResponse
-	84,  // 157: api.grpc.PermissionService.ListPermissions:output_type -> api.grpc.ListPermissionsResponse
-	86,  // 158: api.grpc.PermissionService.UpdatePermission:output_type -> api.grpc.UpdatePermissionResponse
-	88,  // 159: api.grpc.PermissionService.DeletePermission:output_type -> api.grpc.DeletePermissionResponse
-	90,  // 160: api.grpc.PermissionService.GetPermissionTree:output_type -> api.grpc.GetPermissionTreeResponse
-	92,  // 161: api.grpc.AISchedulerService.AnalyzeJob:output_type -> api.grpc.AnalyzeJobResponse
-	94,  // 162: api.grpc.AISchedulerService.OptimizeSchedule:output_type -> api.grpc.OptimizeScheduleResponse
-	97,  // 163: api.grpc.AISchedulerService.GetAIRecommendations:output_type -> api.grpc.GetAIRecommendationsResponse
-	100, // 164: api.grpc.MCPService.ListTools:output_type -> api.grpc.ListToolsResponse
-	103, // 165: api.grpc.MCPService.CallTool:output_type -> api.grpc.CallToolResponse
-	105, // 166: api.grpc.MCPService.GetResources:output_type -> api.grpc.GetResourcesResponse
-	121, // [121:167] is the sub-list for method output_type
-	75,  // [75:121] is the sub-list for method input_type
-	75,  // [75:75] is the sub-list for extension type_name
-	75,  // [75:75] is the sub-list for extension extendee
-	0,   // [0:75] is the sub-list for field type_name
+	149, // 0: api.grpc.Job.params:type_name -> api.grpc.Job.ParamsEntry
+	166, // 1: api.grpc.Job.created_at:type_name -> google.protobuf.Timestamp
+	166, // 2: api.grpc.Job.updated_at:type_name -> google.protobuf.Timestamp
+	12,  // 3: api.grpc.Job.department:type_name -> api.grpc.Department
+	11,  // 4: api.grpc.Job.creator:type_name -> api.grpc.User
+	15,  // 5: api.grpc.Job.ai_schedules:type_name -> api.grpc.AISchedule
+	150, // 6: api.grpc.Job.template_values:type_name -> api.grpc.Job.TemplateValuesEntry
+	151, // 7: api.grpc.Job.labels:type_name -> api.grpc.Job.LabelsEntry
+	166, // 8: api.grpc.Job.paused_until:type_name -> google.protobuf.Timestamp
+	4,   // 9: api.grpc.JobTemplate.params:type_name -> api.grpc.TemplateParam
+	166, // 10: api.grpc.JobTemplate.created_at:type_name -> google.protobuf.Timestamp
+	166, // 11: api.grpc.JobTemplate.updated_at:type_name -> google.protobuf.Timestamp
+	9,   // 12: api.grpc.TemplateJobChange.changes:type_name -> api.grpc.FieldChange
+	0,   // 13: api.grpc.JobExecution.status:type_name -> api.grpc.ExecutionStatus
+	166, // 14: api.grpc.JobExecution.started_at:type_name -> google.protobuf.Timestamp
+	166, // 15: api.grpc.JobExecution.finished_at:type_name -> google.protobuf.Timestamp
+	3,   // 16: api.grpc.JobRevision.snapshot:type_name -> api.grpc.Job
+	166, // 17: api.grpc.JobRevision.created_at:type_name -> google.protobuf.Timestamp
+	2,   // 18: api.grpc.Worker.status:type_name -> api.grpc.WorkerStatus
+	166, // 19: api.grpc.Worker.last_heartbeat:type_name -> google.protobuf.Timestamp
+	152, // 20: api.grpc.Worker.metadata:type_name -> api.grpc.Worker.MetadataEntry
+	166, // 21: api.grpc.User.created_at:type_name -> google.protobuf.Timestamp
+	166, // 22: api.grpc.User.updated_at:type_name -> google.protobuf.Timestamp
+	166, // 23: api.grpc.User.last_login_at:type_name -> google.protobuf.Timestamp
+	12,  // 24: api.grpc.User.department:type_name -> api.grpc.Department
+	13,  // 25: api.grpc.User.roles:type_name -> api.grpc.Role
+	166, // 26: api.grpc.Department.created_at:type_name -> google.protobuf.Timestamp
+	166, // 27: api.grpc.Department.updated_at:type_name -> google.protobuf.Timestamp
+	12,  // 28: api.grpc.Department.parent:type_name -> api.grpc.Department
+	12,  // 29: api.grpc.Department.children:type_name -> api.grpc.Department
+	166, // 30: api.grpc.Role.created_at:type_name -> google.protobuf.Timestamp
+	166, // 31: api.grpc.Role.updated_at:type_name -> google.protobuf.Timestamp
+	14,  // 32: api.grpc.Role.permissions:type_name -> api.grpc.Permission
+	166, // 33: api.grpc.Permission.created_at:type_name -> google.protobuf.Timestamp
+	166, // 34: api.grpc.Permission.updated_at:type_name -> google.protobuf.Timestamp
+	14,  // 35: api.grpc.Permission.parent:type_name -> api.grpc.Permission
+	14,  // 36: api.grpc.Permission.children:type_name -> api.grpc.Permission
+	166, // 37: api.grpc.AISchedule.created_at:type_name -> google.protobuf.Timestamp
+	166, // 38: api.grpc.AISchedule.updated_at:type_name -> google.protobuf.Timestamp
+	153, // 39: api.grpc.CreateJobRequest.params:type_name -> api.grpc.CreateJobRequest.ParamsEntry
+	154, // 40: api.grpc.CreateJobRequest.labels:type_name -> api.grpc.CreateJobRequest.LabelsEntry
+	3,   // 41: api.grpc.CreateJobResponse.job:type_name -> api.grpc.Job
+	3,   // 42: api.grpc.GetJobResponse.job:type_name -> api.grpc.Job
+	3,   // 43: api.grpc.ListJobsResponse.jobs:type_name -> api.grpc.Job
+	155, // 44: api.grpc.UpdateJobRequest.params:type_name -> api.grpc.UpdateJobRequest.ParamsEntry
+	156, // 45: api.grpc.UpdateJobRequest.labels:type_name -> api.grpc.UpdateJobRequest.LabelsEntry
+	3,   // 46: api.grpc.UpdateJobResponse.job:type_name -> api.grpc.Job
+	157, // 47: api.grpc.TriggerJobRequest.params:type_name -> api.grpc.TriggerJobRequest.ParamsEntry
+	8,   // 48: api.grpc.ListJobRevisionsResponse.revisions:type_name -> api.grpc.JobRevision
+	9,   // 49: api.grpc.DiffJobRevisionsResponse.changes:type_name -> api.grpc.FieldChange
+	3,   // 50: api.grpc.RollbackJobResponse.job:type_name -> api.grpc.Job
+	8,   // 51: api.grpc.RollbackJobResponse.revision:type_name -> api.grpc.JobRevision
+	4,   // 52: api.grpc.CreateJobTemplateRequest.params:type_name -> api.grpc.TemplateParam
+	5,   // 53: api.grpc.CreateJobTemplateResponse.template:type_name -> api.grpc.JobTemplate
+	5,   // 54: api.grpc.GetJobTemplateResponse.template:type_name -> api.grpc.JobTemplate
+	5,   // 55: api.grpc.ListJobTemplatesResponse.templates:type_name -> api.grpc.JobTemplate
+	4,   // 56: api.grpc.UpdateJobTemplateRequest.params:type_name -> api.grpc.TemplateParam
+	5,   // 57: api.grpc.UpdateJobTemplateResponse.template:type_name -> api.grpc.JobTemplate
+	6,   // 58: api.grpc.UpdateJobTemplateResponse.affected_jobs:type_name -> api.grpc.TemplateJobChange
+	158, // 59: api.grpc.InstantiateJobTemplateRequest.values:type_name -> api.grpc.InstantiateJobTemplateRequest.ValuesEntry
+	3,   // 60: api.grpc.InstantiateJobTemplateResponse.job:type_name -> api.grpc.Job
+	20,  // 61: api.grpc.BulkJobOperationRequest.filter:type_name -> api.grpc.ListJobsRequest
+	166, // 62: api.grpc.BulkJobOperationRequest.paused_until:type_name -> google.protobuf.Timestamp
+	49,  // 63: api.grpc.BulkJobOperationResponse.results:type_name -> api.grpc.BulkJobResult
+	166, // 64: api.grpc.ValidateCronResponse.next_runs:type_name -> google.protobuf.Timestamp
+	159, // 65: api.grpc.RegisterWorkerRequest.metadata:type_name -> api.grpc.RegisterWorkerRequest.MetadataEntry
+	2,   // 66: api.grpc.HeartbeatRequest.status:type_name -> api.grpc.WorkerStatus
+	56,  // 67: api.grpc.HeartbeatRequest.host:type_name -> api.grpc.HostMetrics
+	68,  // 68: api.grpc.HeartbeatResponse.cancels:type_name -> api.grpc.CancelTask
+	60,  // 69: api.grpc.GetTaskResponse.tasks:type_name -> api.grpc.Task
+	160, // 70: api.grpc.Task.params:type_name -> api.grpc.Task.ParamsEntry
+	61,  // 71: api.grpc.Task.connection:type_name -> api.grpc.Connection
+	0,   // 72: api.grpc.ReportTaskResultRequest.status:type_name -> api.grpc.ExecutionStatus
+	166, // 73: api.grpc.ReportTaskResultRequest.started_at:type_name -> google.protobuf.Timestamp
+	166, // 74: api.grpc.ReportTaskResultRequest.finished_at:type_name -> google.protobuf.Timestamp
+	65,  // 75: api.grpc.WorkerMessage.hello:type_name -> api.grpc.WorkerHello
+	55,  // 76: api.grpc.WorkerMessage.heartbeat:type_name -> api.grpc.HeartbeatRequest
+	66,  // 77: api.grpc.WorkerMessage.ack:type_name -> api.grpc.TaskAck
+	62,  // 78: api.grpc.WorkerMessage.result:type_name -> api.grpc.ReportTaskResultRequest
+	69,  // 79: api.grpc.WorkerMessage.output:type_name -> api.grpc.TaskOutput
+	71,  // 80: api.grpc.WorkerMessage.progress:type_name -> api.grpc.TaskProgress
+	60,  // 81: api.grpc.SchedulerMessage.task:type_name -> api.grpc.Task
+	68,  // 82: api.grpc.SchedulerMessage.cancel:type_name -> api.grpc.CancelTask
+	1,   // 83: api.grpc.TaskOutput.stream:type_name -> api.grpc.OutputStream
+	166, // 84: api.grpc.TaskOutput.time:type_name -> google.protobuf.Timestamp
+	166, // 85: api.grpc.TaskProgress.time:type_name -> google.protobuf.Timestamp
+	11,  // 86: api.grpc.LoginResponse.user:type_name -> api.grpc.User
+	14,  // 87: api.grpc.LoginResponse.permissions:type_name -> api.grpc.Permission
+	11,  // 88: api.grpc.GetUserInfoResponse.user:type_name -> api.grpc.User
+	14,  // 89: api.grpc.GetUserPermissionsResponse.permissions:type_name -> api.grpc.Permission
+	11,  // 90: api.grpc.CreateUserResponse.user:type_name -> api.grpc.User
+	11,  // 91: api.grpc.GetUserResponse.user:type_name -> api.grpc.User
+	11,  // 92: api.grpc.ListUsersResponse.users:type_name -> api.grpc.User
+	11,  // 93: api.grpc.UpdateUserResponse.user:type_name -> api.grpc.User
+	12,  // 94: api.grpc.CreateDepartmentResponse.department:type_name -> api.grpc.Department
+	12,  // 95: api.grpc.GetDepartmentResponse.department:type_name -> api.grpc.Department
+	12,  // 96: api.grpc.ListDepartmentsResponse.departments:type_name -> api.grpc.Department
+	12,  // 97: api.grpc.UpdateDepartmentResponse.department:type_name -> api.grpc.Department
+	12,  // 98: api.grpc.GetDepartmentTreeResponse.departments:type_name -> api.grpc.Department
+	13,  // 99: api.grpc.CreateRoleResponse.role:type_name -> api.grpc.Role
+	13,  // 100: api.grpc.GetRoleResponse.role:type_name -> api.grpc.Role
+	13,  // 101: api.grpc.ListRolesResponse.roles:type_name -> api.grpc.Role
+	13,  // 102: api.grpc.UpdateRoleResponse.role:type_name -> api.grpc.Role
+	14,  // 103: api.grpc.CreatePermissionResponse.permission:type_name -> api.grpc.Permission
+	14,  // 104: api.grpc.GetPermissionResponse.permission:type_name -> api.grpc.Permission
+	14,  // 105: api.grpc.ListPermissionsResponse.permissions:type_name -> api.grpc.Permission
+	14,  // 106: api.grpc.UpdatePermissionResponse.permission:type_name -> api.grpc.Permission
+	14,  // 107: api.grpc.GetPermissionTreeResponse.permissions:type_name -> api.grpc.Permission
+	161, // 108: api.grpc.AnalyzeJobRequest.metadata:type_name -> api.grpc.AnalyzeJobRequest.MetadataEntry
+	162, // 109: api.grpc.OptimizeScheduleRequest.constraints:type_name -> api.grpc.OptimizeScheduleRequest.ConstraintsEntry
+	137, // 110: api.grpc.OptimizeScheduleResponse.optimizations:type_name -> api.grpc.ScheduleOptimization
+	163, // 111: api.grpc.GetAIRecommendationsRequest.context:type_name -> api.grpc.GetAIRecommendationsRequest.ContextEntry
+	140, // 112: api.grpc.GetAIRecommendationsResponse.recommendations:type_name -> api.grpc.AIRecommendation
+	143, // 113: api.grpc.ListToolsResponse.tools:type_name -> api.grpc.MCPTool
+	164, // 114: api.grpc.MCPTool.parameters:type_name -> api.grpc.MCPTool.ParametersEntry
+	165, // 115: api.grpc.CallToolRequest.arguments:type_name -> api.grpc.CallToolRequest.ArgumentsEntry
+	148, // 116: api.grpc.GetResourcesResponse.resources:type_name -> api.grpc.MCPResource
+	16,  // 117: api.grpc.JobService.CreateJob:input_type -> api.grpc.CreateJobRequest
+	18,  // 118: api.grpc.JobService.GetJob:input_type -> api.grpc.GetJobRequest
+	20,  // 119: api.grpc.JobService.ListJobs:input_type -> api.grpc.ListJobsRequest
+	22,  // 120: api.grpc.JobService.UpdateJob:input_type -> api.grpc.UpdateJobRequest
+	24,  // 121: api.grpc.JobService.DeleteJob:input_type -> api.grpc.DeleteJobRequest
+	26,  // 122: api.grpc.JobService.TriggerJob:input_type -> api.grpc.TriggerJobRequest
+	28,  // 123: api.grpc.JobService.CancelExecution:input_type -> api.grpc.CancelExecutionRequest
+	51,  // 124: api.grpc.JobService.ValidateCron:input_type -> api.grpc.ValidateCronRequest
+	30,  // 125: api.grpc.JobService.ListJobRevisions:input_type -> api.grpc.ListJobRevisionsRequest
+	32,  // 126: api.grpc.JobService.DiffJobRevisions:input_type -> api.grpc.DiffJobRevisionsRequest
+	34,  // 127: api.grpc.JobService.RollbackJob:input_type -> api.grpc.RollbackJobRequest
+	36,  // 128: api.grpc.JobService.CreateJobTemplate:input_type -> api.grpc.CreateJobTemplateRequest
+	38,  // 129: api.grpc.JobService.GetJobTemplate:input_type -> api.grpc.GetJobTemplateRequest
+	40,  // 130: api.grpc.JobService.ListJobTemplates:input_type -> api.grpc.ListJobTemplatesRequest
+	42,  // 131: api.grpc.JobService.UpdateJobTemplate:input_type -> api.grpc.UpdateJobTemplateRequest
+	44,  // 132: api.grpc.JobService.DeleteJobTemplate:input_type -> api.grpc.DeleteJobTemplateRequest
+	46,  // 133: api.grpc.JobService.InstantiateJobTemplate:input_type -> api.grpc.InstantiateJobTemplateRequest
+	48,  // 134: api.grpc.JobService.BulkJobOperation:input_type -> api.grpc.BulkJobOperationRequest
+	53,  // 135: api.grpc.SchedulerService.RegisterWorker:input_type -> api.grpc.RegisterWorkerRequest
+	55,  // 136: api.grpc.SchedulerService.Heartbeat:input_type -> api.grpc.HeartbeatRequest
+	58,  // 137: api.grpc.SchedulerService.GetTask:input_type -> api.grpc.GetTaskRequest
+	62,  // 138: api.grpc.SchedulerService.ReportTaskResult:input_type -> api.grpc.ReportTaskResultRequest
+	64,  // 139: api.grpc.SchedulerService.Connect:input_type -> api.grpc.WorkerMessage
+	69,  // 140: api.grpc.SchedulerService.ReportTaskOutput:input_type -> api.grpc.TaskOutput
+	71,  // 141: api.grpc.SchedulerService.ReportTaskProgress:input_type -> api.grpc.TaskProgress
+	73,  // 142: api.grpc.AuthService.Login:input_type -> api.grpc.LoginRequest
+	75,  // 143: api.grpc.AuthService.Logout:input_type -> api.grpc.LogoutRequest
+	77,  // 144: api.grpc.AuthService.RefreshToken:input_type -> api.grpc.RefreshTokenRequest
+	79,  // 145: api.grpc.AuthService.GetUserInfo:input_type -> api.grpc.GetUserInfoRequest
+	81,  // 146: api.grpc.AuthService.GetUserPermissions:input_type -> api.grpc.GetUserPermissionsRequest
+	83,  // 147: api.grpc.UserService.CreateUser:input_type -> api.grpc.CreateUserRequest
+	85,  // 148: api.grpc.UserService.GetUser:input_type -> api.grpc.GetUserRequest
+	87,  // 149: api.grpc.UserService.ListUsers:input_type -> api.grpc.ListUsersRequest
+	89,  // 150: api.grpc.UserService.UpdateUser:input_type -> api.grpc.UpdateUserRequest
+	91,  // 151: api.grpc.UserService.DeleteUser:input_type -> api.grpc.DeleteUserRequest
+	93,  // 152: api.grpc.UserService.ChangePassword:input_type -> api.grpc.ChangePasswordRequest
+	95,  // 153: api.grpc.UserService.AssignUserRoles:input_type -> api.grpc.AssignUserRolesRequest
+	97,  // 154: api.grpc.DepartmentService.CreateDepartment:input_type -> api.grpc.CreateDepartmentRequest
+	99,  // 155: api.grpc.DepartmentService.GetDepartment:input_type -> api.grpc.GetDepartmentRequest
+	101, // 156: api.grpc.DepartmentService.ListDepartments:input_type -> api.grpc.ListDepartmentsRequest
+	103, // 157: api.grpc.DepartmentService.UpdateDepartment:input_type -> api.grpc.UpdateDepartmentRequest
+	105, // 158: api.grpc.DepartmentService.DeleteDepartment:input_type -> api.grpc.DeleteDepartmentRequest
+	107, // 159: api.grpc.DepartmentService.GetDepartmentTree:input_type -> api.grpc.GetDepartmentTreeRequest
+	109, // 160: api.grpc.RoleService.CreateRole:input_type -> api.grpc.CreateRoleRequest
+	111, // 161: api.grpc.RoleService.GetRole:input_type -> api.grpc.GetRoleRequest
+	113, // 162: api.grpc.RoleService.ListRoles:input_type -> api.grpc.ListRolesRequest
+	115, // 163: api.grpc.RoleService.UpdateRole:input_type -> api.grpc.UpdateRoleRequest
+	117, // 164: api.grpc.RoleService.DeleteRole:input_type -> api.grpc.DeleteRoleRequest
+	119, // 165: api.grpc.RoleService.AssignPermissions:input_type -> api.grpc.AssignPermissionsRequest
+	121, // 166: api.grpc.PermissionService.CreatePermission:input_type -> api.grpc.CreatePermissionRequest
+	123, // 167: api.grpc.PermissionService.GetPermission:input_type -> api.grpc.GetPermissionRequest
+	125, // 168: api.grpc.PermissionService.ListPermissions:input_type -> api.grpc.ListPermissionsRequest
+	127, // 169: api.grpc.PermissionService.UpdatePermission:input_type -> api.grpc.UpdatePermissionRequest
+	129, // 170: api.grpc.PermissionService.DeletePermission:input_type -> api.grpc.DeletePermissionRequest
+	131, // 171: api.grpc.PermissionService.GetPermissionTree:input_type -> api.grpc.GetPermissionTreeRequest
+	133, // 172: api.grpc.AISchedulerService.AnalyzeJob:input_type -> api.grpc.AnalyzeJobRequest
+	135, // 173: api.grpc.AISchedulerService.OptimizeSchedule:input_type -> api.grpc.OptimizeScheduleRequest
+	138, // 174: api.grpc.AISchedulerService.GetAIRecommendations:input_type -> api.grpc.GetAIRecommendationsRequest
+	141, // 175: api.grpc.MCPService.ListTools:input_type -> api.grpc.ListToolsRequest
+	144, // 176: api.grpc.MCPService.CallTool:input_type -> api.grpc.CallToolRequest
+	146, // 177: api.grpc.MCPService.GetResources:input_type -> api.grpc.GetResourcesRequest
+	17,  // 178: api.grpc.JobService.CreateJob:output_type -> api.grpc.CreateJobResponse
+	19,  // 179: api.grpc.JobService.GetJob:output_type -> api.grpc.GetJobResponse
+	21,  // 180: api.grpc.JobService.ListJobs:output_type -> api.grpc.ListJobsResponse
+	23,  // 181: api.grpc.JobService.UpdateJob:output_type -> api.grpc.UpdateJobResponse
+	25,  // 182: api.grpc.JobService.DeleteJob:output_type -> api.grpc.DeleteJobResponse
+	27,  // 183: api.grpc.JobService.TriggerJob:output_type -> api.grpc.TriggerJobResponse
+	29,  // 184: api.grpc.JobService.CancelExecution:output_type -> api.grpc.CancelExecutionResponse
+	52,  // 185: api.grpc.JobService.ValidateCron:output_type -> api.grpc.ValidateCronResponse
+	31,  // 186: api.grpc.JobService.ListJobRevisions:output_type -> api.grpc.ListJobRevisionsResponse
+	33,  // 187: api.grpc.JobService.DiffJobRevisions:output_type -> api.grpc.DiffJobRevisionsResponse
+	35,  // 188: api.grpc.JobService.RollbackJob:output_type -> api.grpc.RollbackJobResponse
+	37,  // 189: api.grpc.JobService.CreateJobTemplate:output_type -> api.grpc.CreateJobTemplateResponse
+	39,  // 190: api.grpc.JobService.GetJobTemplate:output_type -> api.grpc.GetJobTemplateResponse
+	41,  // 191: api.grpc.JobService.ListJobTemplates:output_type -> api.grpc.ListJobTemplatesResponse
+	43,  // 192: api.grpc.JobService.UpdateJobTemplate:output_type -> api.grpc.UpdateJobTemplateResponse
+	45,  // 193: api.grpc.JobService.DeleteJobTemplate:output_type -> api.grpc.DeleteJobTemplateResponse
+	47,  // 194: api.grpc.JobService.InstantiateJobTemplate:output_type -> api.grpc.InstantiateJobTemplateResponse
+	50,  // 195: api.grpc.JobService.BulkJobOperation:output_type -> api.grpc.BulkJobOperationResponse
+	54,  // 196: api.grpc.SchedulerService.RegisterWorker:output_type -> api.grpc.RegisterWorkerResponse
+	57,  // 197: api.grpc.SchedulerService.Heartbeat:output_type -> api.grpc.HeartbeatResponse
+	59,  // 198: api.grpc.SchedulerService.GetTask:output_type -> api.grpc.GetTaskResponse
+	63,  // 199: api.grpc.SchedulerService.ReportTaskResult:output_type -> api.grpc.ReportTaskResultResponse
+	67,  // 200: api.grpc.SchedulerService.Connect:output_type -> api.grpc.SchedulerMessage
+	70,  // 201: api.grpc.SchedulerService.ReportTaskOutput:output_type -> api.grpc.ReportTaskOutputResponse
+	72,  // 202: api.grpc.SchedulerService.ReportTaskProgress:output_type -> api.grpc.ReportTaskProgressResponse
+	74,  // 203: api.grpc.AuthService.Login:output_type -> api.grpc.LoginResponse
+	76,  // 204: api.grpc.AuthService.Logout:output_type -> api.grpc.LogoutResponse
+	78,  // 205: api.grpc.AuthService.RefreshToken:output_type -> api.grpc.RefreshTokenResponse
+	80,  // 206: api.grpc.AuthService.GetUserInfo:output_type -> api.grpc.GetUserInfoResponse
+	82,  // 207: api.grpc.AuthService.GetUserPermissions:output_type -> api.grpc.GetUserPermissionsResponse
+	84,  // 208: api.grpc.UserService.CreateUser:output_type -> api.grpc.CreateUserResponse
+	86,  // 209: api.grpc.UserService.GetUser:output_type -> api.grpc.GetUserResponse
+	88,  // 210: api.grpc.UserService.ListUsers:output_type -> api.grpc.ListUsersResponse
+	90,  // 211: api.grpc.UserService.UpdateUser:output_type -> api.grpc.UpdateUserResponse
+	92,  // 212: api.grpc.UserService.DeleteUser:output_type -> api.grpc.DeleteUserResponse
+	94,  // 213: api.grpc.UserService.ChangePassword:output_type -> api.grpc.ChangePasswordResponse
+	96,  // 214: api.grpc.UserService.AssignUserRoles:output_type -> api.grpc.AssignUserRolesResponse
+	98,  // 215: api.grpc.DepartmentService.CreateDepartment:output_type -> api.grpc.CreateDepartmentResponse
+	100, // 216: api.grpc.DepartmentService.GetDepartment:output_type -> api.grpc.GetDepartmentResponse
+	102, // 217: api.grpc.DepartmentService.ListDepartments:output_type -> api.grpc.ListDepartmentsResponse
+	104, // 218: api.grpc.DepartmentService.UpdateDepartment:output_type -> api.grpc.UpdateDepartmentResponse
+	106, // 219: api.grpc.DepartmentService.DeleteDepartment:output_type -> api.grpc.DeleteDepartmentResponse
+	108, // 220: api.grpc.DepartmentService.GetDepartmentTree:output_type -> api.grpc.GetDepartmentTreeResponse
+	110, // 221: api.grpc.RoleService.CreateRole:output_type -> api.grpc.CreateRoleResponse
+	112, // 222: api.grpc.RoleService.GetRole:output_type -> api.grpc.GetRoleResponse
+	114, // 223: api.grpc.RoleService.ListRoles:output_type -> api.grpc.ListRolesResponse
+	116, // 224: api.grpc.RoleService.UpdateRole:output_type -> api.grpc.UpdateRoleResponse
+	118, // 225: api.grpc.RoleService.DeleteRole:output_type -> api.grpc.DeleteRoleResponse
+	120, // 226: api.grpc.RoleService.AssignPermissions:output_type -> api.grpc.AssignPermissionsResponse
+	122, // 227: api.grpc.PermissionService.CreatePermission:output_type -> api.grpc.CreatePermissionResponse
+	124, // 228: api.grpc.PermissionService.GetPermission:output_type -> api.grpc.GetPermissionResponse
+	126, // 229: api.grpc.PermissionService.ListPermissions:output_type -> api.grpc.ListPermissionsResponse
+	128, // 230: api.grpc.PermissionService.UpdatePermission:output_type -> api.grpc.UpdatePermissionResponse
+	130, // 231: api.grpc.PermissionService.DeletePermission:output_type -> api.grpc.DeletePermissionResponse
+	132, // 232: api.grpc.PermissionService.GetPermissionTree:output_type -> api.grpc.GetPermissionTreeResponse
+	134, // 233: api.grpc.AISchedulerService.AnalyzeJob:output_type -> api.grpc.AnalyzeJobResponse
+	136, // 234: api.grpc.AISchedulerService.OptimizeSchedule:output_type -> api.grpc.OptimizeScheduleResponse
+	139, // 235: api.grpc.AISchedulerService.GetAIRecommendations:output_type -> api.grpc.GetAIRecommendationsResponse
+	142, // 236: api.grpc.MCPService.ListTools:output_type -> api.grpc.ListToolsResponse
+	145, // 237: api.grpc.MCPService.CallTool:output_type -> api.grpc.CallToolResponse
+	147, // 238: api.grpc.MCPService.GetResources:output_type -> api.grpc.GetResourcesResponse
+	178, // [178:239] is the sub-list for method output_type
+	117, // [117:178] is the sub-list for method input_type
+	117, // [117:117] is the sub-list for extension type_name
+	117, // [117:117] is the sub-list for extension extendee
+	0,   // [0:117] is the sub-list for field type_name
 }
 
 func init() { file_api_grpc_job_proto_init() }
@@ -7564,13 +11633,25 @@ func file_api_grpc_job_proto_init() {
 	if File_api_grpc_job_proto != nil {
 		return
 	}
+	file_api_grpc_job_proto_msgTypes[61].OneofWrappers = []any{
+		(*WorkerMessage_Hello)(nil),
+		(*WorkerMessage_Heartbeat)(nil),
+		(*WorkerMessage_Ack)(nil),
+		(*WorkerMessage_Result)(nil),
+		(*WorkerMessage_Output)(nil),
+		(*WorkerMessage_Progress)(nil),
+	}
+	file_api_grpc_job_proto_msgTypes[64].OneofWrappers = []any{
+		(*SchedulerMessage_Task)(nil),
+		(*SchedulerMessage_Cancel)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_grpc_job_proto_rawDesc), len(file_api_grpc_job_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   117,
+			NumEnums:      3,
+			NumMessages:   163,
 			NumExtensions: 0,
 			NumServices:   9,
 		},
@@ -19,12 +19,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	JobService_CreateJob_FullMethodName  = "/api.grpc.JobService/CreateJob"
-	JobService_GetJob_FullMethodName     = "/api.grpc.JobService/GetJob"
-	JobService_ListJobs_FullMethodName   = "/api.grpc.JobService/ListJobs"
-	JobService_UpdateJob_FullMethodName  = "/api.grpc.JobService/UpdateJob"
-	JobService_DeleteJob_FullMethodName  = "/api.grpc.JobService/DeleteJob"
-	JobService_TriggerJob_FullMethodName = "/api.grpc.JobService/TriggerJob"
+	JobService_CreateJob_FullMethodName              = "/api.grpc.JobService/CreateJob"
+	JobService_GetJob_FullMethodName                 = "/api.grpc.JobService/GetJob"
+	JobService_ListJobs_FullMethodName               = "/api.grpc.JobService/ListJobs"
+	JobService_UpdateJob_FullMethodName              = "/api.grpc.JobService/UpdateJob"
+	JobService_DeleteJob_FullMethodName              = "/api.grpc.JobService/DeleteJob"
+	JobService_TriggerJob_FullMethodName             = "/api.grpc.JobService/TriggerJob"
+	JobService_CancelExecution_FullMethodName        = "/api.grpc.JobService/CancelExecution"
+	JobService_ValidateCron_FullMethodName           = "/api.grpc.JobService/ValidateCron"
+	JobService_ListJobRevisions_FullMethodName       = "/api.grpc.JobService/ListJobRevisions"
+	JobService_DiffJobRevisions_FullMethodName       = "/api.grpc.JobService/DiffJobRevisions"
+	JobService_RollbackJob_FullMethodName            = "/api.grpc.JobService/RollbackJob"
+	JobService_CreateJobTemplate_FullMethodName      = "/api.grpc.JobService/CreateJobTemplate"
+	JobService_GetJobTemplate_FullMethodName         = "/api.grpc.JobService/GetJobTemplate"
+	JobService_ListJobTemplates_FullMethodName       = "/api.grpc.JobService/ListJobTemplates"
+	JobService_UpdateJobTemplate_FullMethodName      = "/api.grpc.JobService/UpdateJobTemplate"
+	JobService_DeleteJobTemplate_FullMethodName      = "/api.grpc.JobService/DeleteJobTemplate"
+	JobService_InstantiateJobTemplate_FullMethodName = "/api.grpc.JobService/InstantiateJobTemplate"
+	JobService_BulkJobOperation_FullMethodName       = "/api.grpc.JobService/BulkJobOperation"
 )
 
 // JobServiceClient is the client API for JobService service.
@@ -39,6 +51,18 @@ type JobServiceClient interface {
 	UpdateJob(ctx context.Context, in *UpdateJobRequest, opts ...grpc.CallOption) (*UpdateJobResponse, error)
 	DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error)
 	TriggerJob(ctx context.Context, in *TriggerJobRequest, opts ...grpc.CallOption) (*TriggerJobResponse, error)
+	CancelExecution(ctx context.Context, in *CancelExecutionRequest, opts ...grpc.CallOption) (*CancelExecutionResponse, error)
+	ValidateCron(ctx context.Context, in *ValidateCronRequest, opts ...grpc.CallOption) (*ValidateCronResponse, error)
+	ListJobRevisions(ctx context.Context, in *ListJobRevisionsRequest, opts ...grpc.CallOption) (*ListJobRevisionsResponse, error)
+	DiffJobRevisions(ctx context.Context, in *DiffJobRevisionsRequest, opts ...grpc.CallOption) (*DiffJobRevisionsResponse, error)
+	RollbackJob(ctx context.Context, in *RollbackJobRequest, opts ...grpc.CallOption) (*RollbackJobResponse, error)
+	CreateJobTemplate(ctx context.Context, in *CreateJobTemplateRequest, opts ...grpc.CallOption) (*CreateJobTemplateResponse, error)
+	GetJobTemplate(ctx context.Context, in *GetJobTemplateRequest, opts ...grpc.CallOption) (*GetJobTemplateResponse, error)
+	ListJobTemplates(ctx context.Context, in *ListJobTemplatesRequest, opts ...grpc.CallOption) (*ListJobTemplatesResponse, error)
+	UpdateJobTemplate(ctx context.Context, in *UpdateJobTemplateRequest, opts ...grpc.CallOption) (*UpdateJobTemplateResponse, error)
+	DeleteJobTemplate(ctx context.Context, in *DeleteJobTemplateRequest, opts ...grpc.CallOption) (*DeleteJobTemplateResponse, error)
+	InstantiateJobTemplate(ctx context.Context, in *InstantiateJobTemplateRequest, opts ...grpc.CallOption) (*InstantiateJobTemplateResponse, error)
+	BulkJobOperation(ctx context.Context, in *BulkJobOperationRequest, opts ...grpc.CallOption) (*BulkJobOperationResponse, error)
 }
 
 type jobServiceClient struct {
@@ -109,6 +133,126 @@ func (c *jobServiceClient) TriggerJob(ctx context.Context, in *TriggerJobRequest
 	return out, nil
 }
 
+func (c *jobServiceClient) CancelExecution(ctx context.Context, in *CancelExecutionRequest, opts ...grpc.CallOption) (*CancelExecutionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelExecutionResponse)
+	err := c.cc.Invoke(ctx, JobService_CancelExecution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ValidateCron(ctx context.Context, in *ValidateCronRequest, opts ...grpc.CallOption) (*ValidateCronResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateCronResponse)
+	err := c.cc.Invoke(ctx, JobService_ValidateCron_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ListJobRevisions(ctx context.Context, in *ListJobRevisionsRequest, opts ...grpc.CallOption) (*ListJobRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJobRevisionsResponse)
+	err := c.cc.Invoke(ctx, JobService_ListJobRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) DiffJobRevisions(ctx context.Context, in *DiffJobRevisionsRequest, opts ...grpc.CallOption) (*DiffJobRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffJobRevisionsResponse)
+	err := c.cc.Invoke(ctx, JobService_DiffJobRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) RollbackJob(ctx context.Context, in *RollbackJobRequest, opts ...grpc.CallOption) (*RollbackJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackJobResponse)
+	err := c.cc.Invoke(ctx, JobService_RollbackJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) CreateJobTemplate(ctx context.Context, in *CreateJobTemplateRequest, opts ...grpc.CallOption) (*CreateJobTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateJobTemplateResponse)
+	err := c.cc.Invoke(ctx, JobService_CreateJobTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) GetJobTemplate(ctx context.Context, in *GetJobTemplateRequest, opts ...grpc.CallOption) (*GetJobTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetJobTemplateResponse)
+	err := c.cc.Invoke(ctx, JobService_GetJobTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ListJobTemplates(ctx context.Context, in *ListJobTemplatesRequest, opts ...grpc.CallOption) (*ListJobTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJobTemplatesResponse)
+	err := c.cc.Invoke(ctx, JobService_ListJobTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) UpdateJobTemplate(ctx context.Context, in *UpdateJobTemplateRequest, opts ...grpc.CallOption) (*UpdateJobTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateJobTemplateResponse)
+	err := c.cc.Invoke(ctx, JobService_UpdateJobTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) DeleteJobTemplate(ctx context.Context, in *DeleteJobTemplateRequest, opts ...grpc.CallOption) (*DeleteJobTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteJobTemplateResponse)
+	err := c.cc.Invoke(ctx, JobService_DeleteJobTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) InstantiateJobTemplate(ctx context.Context, in *InstantiateJobTemplateRequest, opts ...grpc.CallOption) (*InstantiateJobTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InstantiateJobTemplateResponse)
+	err := c.cc.Invoke(ctx, JobService_InstantiateJobTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) BulkJobOperation(ctx context.Context, in *BulkJobOperationRequest, opts ...grpc.CallOption) (*BulkJobOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkJobOperationResponse)
+	err := c.cc.Invoke(ctx, JobService_BulkJobOperation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // JobServiceServer is the server API for JobService service.
 // All implementations must embed UnimplementedJobServiceServer
 // for forward compatibility.
@@ -121,6 +265,18 @@ type JobServiceServer interface {
 	UpdateJob(context.Context, *UpdateJobRequest) (*UpdateJobResponse, error)
 	DeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error)
 	TriggerJob(context.Context, *TriggerJobRequest) (*TriggerJobResponse, error)
+	CancelExecution(context.Context, *CancelExecutionRequest) (*CancelExecutionResponse, error)
+	ValidateCron(context.Context, *ValidateCronRequest) (*ValidateCronResponse, error)
+	ListJobRevisions(context.Context, *ListJobRevisionsRequest) (*ListJobRevisionsResponse, error)
+	DiffJobRevisions(context.Context, *DiffJobRevisionsRequest) (*DiffJobRevisionsResponse, error)
+	RollbackJob(context.Context, *RollbackJobRequest) (*RollbackJobResponse, error)
+	CreateJobTemplate(context.Context, *CreateJobTemplateRequest) (*CreateJobTemplateResponse, error)
+	GetJobTemplate(context.Context, *GetJobTemplateRequest) (*GetJobTemplateResponse, error)
+	ListJobTemplates(context.Context, *ListJobTemplatesRequest) (*ListJobTemplatesResponse, error)
+	UpdateJobTemplate(context.Context, *UpdateJobTemplateRequest) (*UpdateJobTemplateResponse, error)
+	DeleteJobTemplate(context.Context, *DeleteJobTemplateRequest) (*DeleteJobTemplateResponse, error)
+	InstantiateJobTemplate(context.Context, *InstantiateJobTemplateRequest) (*InstantiateJobTemplateResponse, error)
+	BulkJobOperation(context.Context, *BulkJobOperationRequest) (*BulkJobOperationResponse, error)
 	mustEmbedUnimplementedJobServiceServer()
 }
 
@@ -149,6 +305,42 @@ func (UnimplementedJobServiceServer) DeleteJob(context.Context, *DeleteJobReques
 func (UnimplementedJobServiceServer) TriggerJob(context.Context, *TriggerJobRequest) (*TriggerJobResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TriggerJob not implemented")
 }
+func (UnimplementedJobServiceServer) CancelExecution(context.Context, *CancelExecutionRequest) (*CancelExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelExecution not implemented")
+}
+func (UnimplementedJobServiceServer) ValidateCron(context.Context, *ValidateCronRequest) (*ValidateCronResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateCron not implemented")
+}
+func (UnimplementedJobServiceServer) ListJobRevisions(context.Context, *ListJobRevisionsRequest) (*ListJobRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobRevisions not implemented")
+}
+func (UnimplementedJobServiceServer) DiffJobRevisions(context.Context, *DiffJobRevisionsRequest) (*DiffJobRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffJobRevisions not implemented")
+}
+func (UnimplementedJobServiceServer) RollbackJob(context.Context, *RollbackJobRequest) (*RollbackJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackJob not implemented")
+}
+func (UnimplementedJobServiceServer) CreateJobTemplate(context.Context, *CreateJobTemplateRequest) (*CreateJobTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateJobTemplate not implemented")
+}
+func (UnimplementedJobServiceServer) GetJobTemplate(context.Context, *GetJobTemplateRequest) (*GetJobTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobTemplate not implemented")
+}
+func (UnimplementedJobServiceServer) ListJobTemplates(context.Context, *ListJobTemplatesRequest) (*ListJobTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobTemplates not implemented")
+}
+func (UnimplementedJobServiceServer) UpdateJobTemplate(context.Context, *UpdateJobTemplateRequest) (*UpdateJobTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateJobTemplate not implemented")
+}
+func (UnimplementedJobServiceServer) DeleteJobTemplate(context.Context, *DeleteJobTemplateRequest) (*DeleteJobTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteJobTemplate not implemented")
+}
+func (UnimplementedJobServiceServer) InstantiateJobTemplate(context.Context, *InstantiateJobTemplateRequest) (*InstantiateJobTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstantiateJobTemplate not implemented")
+}
+func (UnimplementedJobServiceServer) BulkJobOperation(context.Context, *BulkJobOperationRequest) (*BulkJobOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkJobOperation not implemented")
+}
 func (UnimplementedJobServiceServer) mustEmbedUnimplementedJobServiceServer() {}
 func (UnimplementedJobServiceServer) testEmbeddedByValue()                    {}
 
@@ -278,6 +470,222 @@ func _JobService_TriggerJob_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobService_CancelExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).CancelExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_CancelExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).CancelExecution(ctx, req.(*CancelExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ValidateCron_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateCronRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ValidateCron(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_ValidateCron_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ValidateCron(ctx, req.(*ValidateCronRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ListJobRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ListJobRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_ListJobRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ListJobRevisions(ctx, req.(*ListJobRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_DiffJobRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffJobRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).DiffJobRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_DiffJobRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).DiffJobRevisions(ctx, req.(*DiffJobRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_RollbackJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).RollbackJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_RollbackJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).RollbackJob(ctx, req.(*RollbackJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_CreateJobTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJobTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).CreateJobTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_CreateJobTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).CreateJobTemplate(ctx, req.(*CreateJobTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_GetJobTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).GetJobTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_GetJobTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).GetJobTemplate(ctx, req.(*GetJobTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ListJobTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ListJobTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_ListJobTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ListJobTemplates(ctx, req.(*ListJobTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_UpdateJobTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateJobTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).UpdateJobTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_UpdateJobTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).UpdateJobTemplate(ctx, req.(*UpdateJobTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_DeleteJobTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).DeleteJobTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_DeleteJobTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).DeleteJobTemplate(ctx, req.(*DeleteJobTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_InstantiateJobTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstantiateJobTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).InstantiateJobTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_InstantiateJobTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).InstantiateJobTemplate(ctx, req.(*InstantiateJobTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_BulkJobOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkJobOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).BulkJobOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_BulkJobOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).BulkJobOperation(ctx, req.(*BulkJobOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // JobService_ServiceDesc is the grpc.ServiceDesc for JobService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -309,16 +717,67 @@ var JobService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TriggerJob",
 			Handler:    _JobService_TriggerJob_Handler,
 		},
+		{
+			MethodName: "CancelExecution",
+			Handler:    _JobService_CancelExecution_Handler,
+		},
+		{
+			MethodName: "ValidateCron",
+			Handler:    _JobService_ValidateCron_Handler,
+		},
+		{
+			MethodName: "ListJobRevisions",
+			Handler:    _JobService_ListJobRevisions_Handler,
+		},
+		{
+			MethodName: "DiffJobRevisions",
+			Handler:    _JobService_DiffJobRevisions_Handler,
+		},
+		{
+			MethodName: "RollbackJob",
+			Handler:    _JobService_RollbackJob_Handler,
+		},
+		{
+			MethodName: "CreateJobTemplate",
+			Handler:    _JobService_CreateJobTemplate_Handler,
+		},
+		{
+			MethodName: "GetJobTemplate",
+			Handler:    _JobService_GetJobTemplate_Handler,
+		},
+		{
+			MethodName: "ListJobTemplates",
+			Handler:    _JobService_ListJobTemplates_Handler,
+		},
+		{
+			MethodName: "UpdateJobTemplate",
+			Handler:    _JobService_UpdateJobTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteJobTemplate",
+			Handler:    _JobService_DeleteJobTemplate_Handler,
+		},
+		{
+			MethodName: "InstantiateJobTemplate",
+			Handler:    _JobService_InstantiateJobTemplate_Handler,
+		},
+		{
+			MethodName: "BulkJobOperation",
+			Handler:    _JobService_BulkJobOperation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/grpc/job.proto",
 }
 
 const (
-	SchedulerService_RegisterWorker_FullMethodName   = "/api.grpc.SchedulerService/RegisterWorker"
-	SchedulerService_Heartbeat_FullMethodName        = "/api.grpc.SchedulerService/Heartbeat"
-	SchedulerService_GetTask_FullMethodName          = "/api.grpc.SchedulerService/GetTask"
-	SchedulerService_ReportTaskResult_FullMethodName = "/api.grpc.SchedulerService/ReportTaskResult"
+	SchedulerService_RegisterWorker_FullMethodName     = "/api.grpc.SchedulerService/RegisterWorker"
+	SchedulerService_Heartbeat_FullMethodName          = "/api.grpc.SchedulerService/Heartbeat"
+	SchedulerService_GetTask_FullMethodName            = "/api.grpc.SchedulerService/GetTask"
+	SchedulerService_ReportTaskResult_FullMethodName   = "/api.grpc.SchedulerService/ReportTaskResult"
+	SchedulerService_Connect_FullMethodName            = "/api.grpc.SchedulerService/Connect"
+	SchedulerService_ReportTaskOutput_FullMethodName   = "/api.grpc.SchedulerService/ReportTaskOutput"
+	SchedulerService_ReportTaskProgress_FullMethodName = "/api.grpc.SchedulerService/ReportTaskProgress"
 )
 
 // SchedulerServiceClient is the client API for SchedulerService service.
@@ -331,6 +790,12 @@ type SchedulerServiceClient interface {
 	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
 	GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error)
 	ReportTaskResult(ctx context.Context, in *ReportTaskResultRequest, opts ...grpc.CallOption) (*ReportTaskResultResponse, error)
+	// 工作节点与调度器之间的长连接: 调度器推送任务和取消指令, 工作节点发送确认、心跳、输出和结果
+	Connect(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[WorkerMessage, SchedulerMessage], error)
+	// 上报运行中任务的输出片段, 未建立长连接的工作节点使用
+	ReportTaskOutput(ctx context.Context, in *TaskOutput, opts ...grpc.CallOption) (*ReportTaskOutputResponse, error)
+	// 上报进程内处理函数的执行进度, 未建立长连接的工作节点使用
+	ReportTaskProgress(ctx context.Context, in *TaskProgress, opts ...grpc.CallOption) (*ReportTaskProgressResponse, error)
 }
 
 type schedulerServiceClient struct {
@@ -381,6 +846,39 @@ func (c *schedulerServiceClient) ReportTaskResult(ctx context.Context, in *Repor
 	return out, nil
 }
 
+func (c *schedulerServiceClient) Connect(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[WorkerMessage, SchedulerMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SchedulerService_ServiceDesc.Streams[0], SchedulerService_Connect_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WorkerMessage, SchedulerMessage]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SchedulerService_ConnectClient = grpc.BidiStreamingClient[WorkerMessage, SchedulerMessage]
+
+func (c *schedulerServiceClient) ReportTaskOutput(ctx context.Context, in *TaskOutput, opts ...grpc.CallOption) (*ReportTaskOutputResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportTaskOutputResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ReportTaskOutput_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ReportTaskProgress(ctx context.Context, in *TaskProgress, opts ...grpc.CallOption) (*ReportTaskProgressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportTaskProgressResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ReportTaskProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SchedulerServiceServer is the server API for SchedulerService service.
 // All implementations must embed UnimplementedSchedulerServiceServer
 // for forward compatibility.
@@ -391,6 +889,12 @@ type SchedulerServiceServer interface {
 	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
 	GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error)
 	ReportTaskResult(context.Context, *ReportTaskResultRequest) (*ReportTaskResultResponse, error)
+	// 工作节点与调度器之间的长连接: 调度器推送任务和取消指令, 工作节点发送确认、心跳、输出和结果
+	Connect(grpc.BidiStreamingServer[WorkerMessage, SchedulerMessage]) error
+	// 上报运行中任务的输出片段, 未建立长连接的工作节点使用
+	ReportTaskOutput(context.Context, *TaskOutput) (*ReportTaskOutputResponse, error)
+	// 上报进程内处理函数的执行进度, 未建立长连接的工作节点使用
+	ReportTaskProgress(context.Context, *TaskProgress) (*ReportTaskProgressResponse, error)
 	mustEmbedUnimplementedSchedulerServiceServer()
 }
 
@@ -413,6 +917,15 @@ func (UnimplementedSchedulerServiceServer) GetTask(context.Context, *GetTaskRequ
 func (UnimplementedSchedulerServiceServer) ReportTaskResult(context.Context, *ReportTaskResultRequest) (*ReportTaskResultResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReportTaskResult not implemented")
 }
+func (UnimplementedSchedulerServiceServer) Connect(grpc.BidiStreamingServer[WorkerMessage, SchedulerMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method Connect not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ReportTaskOutput(context.Context, *TaskOutput) (*ReportTaskOutputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportTaskOutput not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ReportTaskProgress(context.Context, *TaskProgress) (*ReportTaskProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportTaskProgress not implemented")
+}
 func (UnimplementedSchedulerServiceServer) mustEmbedUnimplementedSchedulerServiceServer() {}
 func (UnimplementedSchedulerServiceServer) testEmbeddedByValue()                          {}
 
@@ -506,6 +1019,49 @@ func _SchedulerService_ReportTaskResult_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SchedulerService_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SchedulerServiceServer).Connect(&grpc.GenericServerStream[WorkerMessage, SchedulerMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SchedulerService_ConnectServer = grpc.BidiStreamingServer[WorkerMessage, SchedulerMessage]
+
+func _SchedulerService_ReportTaskOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskOutput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ReportTaskOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ReportTaskOutput_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ReportTaskOutput(ctx, req.(*TaskOutput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ReportTaskProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskProgress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ReportTaskProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ReportTaskProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ReportTaskProgress(ctx, req.(*TaskProgress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SchedulerService_ServiceDesc is the grpc.ServiceDesc for SchedulerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -529,8 +1085,23 @@ var SchedulerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReportTaskResult",
 			Handler:    _SchedulerService_ReportTaskResult_Handler,
 		},
+		{
+			MethodName: "ReportTaskOutput",
+			Handler:    _SchedulerService_ReportTaskOutput_Handler,
+		},
+		{
+			MethodName: "ReportTaskProgress",
+			Handler:    _SchedulerService_ReportTaskProgress_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _SchedulerService_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/grpc/job.proto",
 }
 
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"go-job/api/grpc"
+	"go-job/internal/job"
+	"go-job/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// BulkHandler 批量任务操作处理器
+type BulkHandler struct {
+	jobService *job.Service
+}
+
+// NewBulkHandler 创建批量任务操作处理器
+func NewBulkHandler() *BulkHandler {
+	return &BulkHandler{
+		jobService: job.NewService(),
+	}
+}
+
+// BulkFilter 批量操作的任务过滤条件, 含义与任务列表的查询参数相同
+type BulkFilter struct {
+	Keyword       string `json:"keyword"`
+	State         string `json:"state"`
+	DepartmentID  string `json:"department_id"`
+	CreatedBy     string `json:"created_by"`
+	LabelSelector string `json:"label_selector"`
+	LastRunStatus string `json:"last_run_status"`
+	ScheduleType  string `json:"schedule_type"`
+}
+
+// BulkJobRequest 批量任务操作请求, job_ids 和 filter 二选一
+type BulkJobRequest struct {
+	JobIDs       []string    `json:"job_ids"`
+	Filter       *BulkFilter `json:"filter"`
+	Atomic       bool        `json:"atomic"`
+	DepartmentID string      `json:"department_id"` // move-department 的目标部门
+	PausedUntil  *time.Time  `json:"paused_until"`  // pause 的截止时间
+}
+
+// Enable 批量启用任务
+func (h *BulkHandler) Enable(c *gin.Context) { h.handle(c, job.BulkEnable) }
+
+// Disable 批量禁用任务
+func (h *BulkHandler) Disable(c *gin.Context) { h.handle(c, job.BulkDisable) }
+
+// Pause 批量暂停任务
+func (h *BulkHandler) Pause(c *gin.Context) { h.handle(c, job.BulkPause) }
+
+// Resume 批量恢复已暂停的任务
+func (h *BulkHandler) Resume(c *gin.Context) { h.handle(c, job.BulkResume) }
+
+// Delete 批量删除任务
+func (h *BulkHandler) Delete(c *gin.Context) { h.handle(c, job.BulkDelete) }
+
+// Trigger 批量手动触发任务
+func (h *BulkHandler) Trigger(c *gin.Context) { h.handle(c, job.BulkTrigger) }
+
+// MoveDepartment 批量移动任务到其他部门
+func (h *BulkHandler) MoveDepartment(c *gin.Context) { h.handle(c, job.BulkMoveDepartment) }
+
+// handle 执行批量操作
+func (h *BulkHandler) handle(c *gin.Context, action string) {
+	var req BulkJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcReq := &grpc.BulkJobOperationRequest{
+		Action:       action,
+		JobIds:       req.JobIDs,
+		Atomic:       req.Atomic,
+		DepartmentId: req.DepartmentID,
+	}
+	if req.Filter != nil {
+		grpcReq.Filter = &grpc.ListJobsRequest{
+			Keyword:       req.Filter.Keyword,
+			State:         req.Filter.State,
+			DepartmentId:  req.Filter.DepartmentID,
+			CreatedBy:     req.Filter.CreatedBy,
+			LabelSelector: req.Filter.LabelSelector,
+			LastRunStatus: req.Filter.LastRunStatus,
+			ScheduleType:  req.Filter.ScheduleType,
+		}
+	}
+	if req.PausedUntil != nil {
+		grpcReq.PausedUntil = timestamppb.New(*req.PausedUntil)
+	}
+
+	resp, err := h.jobService.BulkJobOperation(c.Request.Context(), grpcReq)
+	if err != nil {
+		logger.WithError(err).Errorf("批量任务操作失败: %s", action)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
@@ -0,0 +1,267 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"go-job/internal/jobtype"
+	"go-job/internal/models"
+	"go-job/internal/secret"
+	"go-job/pkg/database"
+	"go-job/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ConnectionHandler 数据库连接处理器, 连接串使用密钥存储的主密钥加密保存
+type ConnectionHandler struct {
+	db    *gorm.DB
+	store *secret.Store
+}
+
+// NewConnectionHandler 创建数据库连接处理器
+func NewConnectionHandler(store *secret.Store) *ConnectionHandler {
+	return &ConnectionHandler{
+		db:    database.GetDB(),
+		store: store,
+	}
+}
+
+// ConnectionRequest 创建/更新数据库连接请求
+type ConnectionRequest struct {
+	Name         string `json:"name" binding:"required"`
+	Driver       string `json:"driver" binding:"required"`
+	DSN          string `json:"dsn"`           // 更新时为空表示不修改
+	DepartmentID string `json:"department_id"` // 为空时所有部门的任务都可以引用
+	Description  string `json:"description"`
+}
+
+// ListConnections 获取数据库连接列表, 可按部门过滤
+func (h *ConnectionHandler) ListConnections(c *gin.Context) {
+	query := h.db.Order("name")
+	if departmentID := c.Query("department_id"); departmentID != "" {
+		query = query.Where("department_id = ?", departmentID)
+	}
+
+	var connections []models.Connection
+	if err := query.Find(&connections).Error; err != nil {
+		logger.WithError(err).Error("查询数据库连接失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": connections})
+}
+
+// GetConnection 获取数据库连接
+func (h *ConnectionHandler) GetConnection(c *gin.Context) {
+	connection, ok := h.findConnection(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": connection})
+}
+
+// CreateConnection 创建数据库连接
+func (h *ConnectionHandler) CreateConnection(c *gin.Context) {
+	var req ConnectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if strings.TrimSpace(req.DSN) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少连接串 dsn"})
+		return
+	}
+	if !h.checkMasterKey(c) {
+		return
+	}
+	if err := jobtype.ValidateDriver(req.Driver); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.checkNameAvailable(c, req.Name, "") || !h.checkDepartment(c, req.DepartmentID) {
+		return
+	}
+
+	username, _ := c.Get("username")
+	createdBy, _ := username.(string)
+
+	connection := models.Connection{
+		ID:           uuid.New().String(),
+		Name:         req.Name,
+		Driver:       req.Driver,
+		DepartmentID: req.DepartmentID,
+		Description:  req.Description,
+		CreatedBy:    createdBy,
+	}
+	if err := h.store.SealConnection(&connection, req.DSN); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.db.Create(&connection).Error; err != nil {
+		logger.WithError(err).Error("创建数据库连接失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": connection})
+}
+
+// UpdateConnection 更新数据库连接
+func (h *ConnectionHandler) UpdateConnection(c *gin.Context) {
+	var req ConnectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := jobtype.ValidateDriver(req.Driver); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if strings.TrimSpace(req.DSN) != "" && !h.checkMasterKey(c) {
+		return
+	}
+
+	connection, ok := h.findConnection(c)
+	if !ok {
+		return
+	}
+	if !h.checkDepartment(c, req.DepartmentID) {
+		return
+	}
+
+	// 任务按名称引用连接, 被引用时不允许改名或修改所属部门
+	if req.Name != connection.Name {
+		if !h.checkNameAvailable(c, req.Name, connection.ID) || !h.checkUnused(c, connection.Name) {
+			return
+		}
+	} else if req.DepartmentID != connection.DepartmentID && req.DepartmentID != "" {
+		if !h.checkUnused(c, connection.Name) {
+			return
+		}
+	}
+
+	connection.Name = req.Name
+	connection.Driver = req.Driver
+	connection.DepartmentID = req.DepartmentID
+	connection.Description = req.Description
+	if strings.TrimSpace(req.DSN) != "" {
+		if err := h.store.SealConnection(connection, req.DSN); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if err := h.db.Save(connection).Error; err != nil {
+		logger.WithError(err).Error("更新数据库连接失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": connection})
+}
+
+// DeleteConnection 删除数据库连接, 仍被任务引用时拒绝删除
+func (h *ConnectionHandler) DeleteConnection(c *gin.Context) {
+	connection, ok := h.findConnection(c)
+	if !ok {
+		return
+	}
+	if !h.checkUnused(c, connection.Name) {
+		return
+	}
+
+	if err := h.db.Delete(connection).Error; err != nil {
+		logger.WithError(err).Error("删除数据库连接失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "数据库连接删除成功"})
+}
+
+// findConnection 按路径参数查询数据库连接, 失败时已写入响应
+func (h *ConnectionHandler) findConnection(c *gin.Context) (*models.Connection, bool) {
+	var connection models.Connection
+	if err := h.db.First(&connection, "id = ?", c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "数据库连接不存在"})
+			return nil, false
+		}
+		logger.WithError(err).Error("查询数据库连接失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return &connection, true
+}
+
+// checkNameAvailable 检查连接名称是否可用, 失败时已写入响应
+func (h *ConnectionHandler) checkNameAvailable(c *gin.Context, name, excludeID string) bool {
+	var count int64
+	query := h.db.Model(&models.Connection{}).Where("name = ?", name)
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		logger.WithError(err).Error("查询数据库连接失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "数据库连接名称已存在"})
+		return false
+	}
+	return true
+}
+
+// checkDepartment 检查连接所属的部门是否存在, 为空表示不限部门, 失败时已写入响应
+func (h *ConnectionHandler) checkDepartment(c *gin.Context, departmentID string) bool {
+	if departmentID == "" {
+		return true
+	}
+	var count int64
+	if err := h.db.Model(&models.Department{}).Where("id = ?", departmentID).Count(&count).Error; err != nil {
+		logger.WithError(err).Error("查询部门失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if count == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "部门不存在: " + departmentID})
+		return false
+	}
+	return true
+}
+
+// checkMasterKey 检查是否配置了加密连接串的主密钥, 失败时已写入响应
+func (h *ConnectionHandler) checkMasterKey(c *gin.Context) bool {
+	if !h.store.HasKey() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "未配置主密钥, 无法保存数据库连接, 请在 secrets.keys 中配置或设置环境变量 GO_JOB_MASTER_KEY"})
+		return false
+	}
+	return true
+}
+
+// checkUnused 检查连接是否未被任务引用, 失败时已写入响应
+func (h *ConnectionHandler) checkUnused(c *gin.Context, name string) bool {
+	var jobs []models.Job
+	if err := h.db.Select("name", "config").Where("type = ?", models.JobTypeSQL).Find(&jobs).Error; err != nil {
+		logger.WithError(err).Error("查询任务失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+
+	var users []string
+	for _, job := range jobs {
+		if cfg, err := jobtype.ParseSQL(job.Config); err == nil && cfg.Connection == name {
+			users = append(users, job.Name)
+		}
+	}
+	if len(users) > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "数据库连接仍被任务引用: " + strings.Join(users, ", ")})
+		return false
+	}
+	return true
+}
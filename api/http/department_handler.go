@@ -1,11 +1,13 @@
 package http
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
 
 	grpc "go-job/api/grpc"
 	"go-job/internal/department"
+	"go-job/internal/jobtype"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,19 +23,21 @@ func NewDepartmentHandler(service *department.DepartmentService) *DepartmentHand
 }
 
 type CreateDepartmentRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Code        string `json:"code" binding:"required"`
-	Description string `json:"description"`
-	ParentID    string `json:"parent_id"`
-	Sort        int32  `json:"sort"`
+	Name        string          `json:"name" binding:"required"`
+	Code        string          `json:"code" binding:"required"`
+	Description string          `json:"description"`
+	ParentID    string          `json:"parent_id"`
+	Sort        int32           `json:"sort"`
+	Sandbox     json.RawMessage `json:"sandbox"` // 部门任务默认的沙箱设置, JSON 对象
 }
 
 type UpdateDepartmentRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Code        string `json:"code" binding:"required"`
-	Description string `json:"description"`
-	ParentID    string `json:"parent_id"`
-	Sort        int32  `json:"sort"`
+	Name        string          `json:"name" binding:"required"`
+	Code        string          `json:"code" binding:"required"`
+	Description string          `json:"description"`
+	ParentID    string          `json:"parent_id"`
+	Sort        int32           `json:"sort"`
+	Sandbox     json.RawMessage `json:"sandbox"` // 部门任务默认的沙箱设置, JSON 对象
 }
 
 func (h *DepartmentHandler) CreateDepartment(c *gin.Context) {
@@ -42,6 +46,10 @@ func (h *DepartmentHandler) CreateDepartment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if _, err := jobtype.ParseSandbox(rawConfig(req.Sandbox)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	grpcReq := &grpc.CreateDepartmentRequest{
 		Name:        req.Name,
@@ -49,6 +57,7 @@ func (h *DepartmentHandler) CreateDepartment(c *gin.Context) {
 		Description: req.Description,
 		ParentId:    req.ParentID,
 		Sort:        req.Sort,
+		Sandbox:     rawConfig(req.Sandbox),
 	}
 
 	resp, err := h.service.CreateDepartment(c.Request.Context(), grpcReq)
@@ -112,6 +121,10 @@ func (h *DepartmentHandler) UpdateDepartment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if _, err := jobtype.ParseSandbox(rawConfig(req.Sandbox)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	grpcReq := &grpc.UpdateDepartmentRequest{
 		Id:          id,
@@ -120,6 +133,7 @@ func (h *DepartmentHandler) UpdateDepartment(c *gin.Context) {
 		Description: req.Description,
 		ParentId:    req.ParentID,
 		Sort:        req.Sort,
+		Sandbox:     rawConfig(req.Sandbox),
 	}
 
 	resp, err := h.service.UpdateDepartment(c.Request.Context(), grpcReq)
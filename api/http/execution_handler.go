@@ -1,13 +1,20 @@
 package http
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"regexp"
 	"strconv"
-	"time"
+	"strings"
 
+	"go-job/internal/logstore"
 	"go-job/internal/models"
+	"go-job/internal/scheduler"
+	"go-job/pkg/config"
 	"go-job/pkg/database"
 	"go-job/pkg/logger"
+	"go-job/pkg/websocket"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -15,13 +22,18 @@ import (
 
 // ExecutionHandler 执行记录处理器
 type ExecutionHandler struct {
-	db *gorm.DB
+	db        *gorm.DB
+	logs      *logstore.Store
+	scheduler *scheduler.Service
 }
 
-// NewExecutionHandler 创建执行记录处理器
-func NewExecutionHandler() *ExecutionHandler {
+// NewExecutionHandler 创建执行记录处理器, 取消执行时通过调度器通知工作节点
+func NewExecutionHandler(cfg *config.Config, schedulerService *scheduler.Service) *ExecutionHandler {
+	db := database.GetDB()
 	return &ExecutionHandler{
-		db: database.GetDB(),
+		db:        db,
+		logs:      logstore.New(db, cfg.Scheduler.Output),
+		scheduler: schedulerService,
 	}
 }
 
@@ -87,40 +99,304 @@ func (h *ExecutionHandler) GetExecution(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": execution})
 }
 
-// CancelExecution 取消执行记录
+// CancelExecutionRequest 取消执行请求
+type CancelExecutionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// CancelExecution 取消执行, 已下发的任务由工作节点终止进程
 func (h *ExecutionHandler) CancelExecution(c *gin.Context) {
 	id := c.Param("id")
 
-	var execution models.JobExecution
-	if err := h.db.First(&execution, "id = ?", id).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "执行记录不存在"})
+	// 请求体可以为空
+	var req CancelExecutionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		logger.WithError(err).Error("查询执行记录失败")
+	}
+
+	notified, err := h.scheduler.RequestCancel(id, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, scheduler.ErrExecutionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, scheduler.ErrExecutionFinished):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			logger.WithError(err).Error("取消执行记录失败")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "执行记录已取消",
+		"data":    gin.H{"id": id, "status": "cancelled", "worker_notified": notified},
+	})
+}
+
+// GetExecutionOutput 获取执行的实时输出片段
+//
+// 客户端先订阅 websocket 频道 execution:<id>, 再用 after 游标拉取已有片段, 按 chunk_id 去重后拼接。
+func (h *ExecutionHandler) GetExecutionOutput(c *gin.Context) {
+	id := c.Param("id")
+	after, _ := strconv.ParseUint(c.DefaultQuery("after", "0"), 10, 64)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "500"))
+	if limit <= 0 || limit > 1000 {
+		limit = 1000
+	}
+
+	stream, ok := outputStream(c)
+	if !ok {
+		return
+	}
+	execution, ok := h.findExecution(c, id)
+	if !ok {
+		return
+	}
+
+	chunks, err := h.logs.Chunks(id, stream, after, limit)
+	if err != nil {
+		logger.WithError(err).Error("查询执行输出失败")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// 检查是否可以取消
-	if execution.Status != models.ExecutionStatusPending && execution.Status != models.ExecutionStatusRunning {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "只能取消等待中或运行中的任务"})
+	next := after
+	if len(chunks) > 0 {
+		next = chunks[len(chunks)-1].ID
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"chunks":   chunks,
+			"next":     next,
+			"has_more": len(chunks) == limit,
+			"status":   execution.Status,
+			"channel":  websocket.ExecutionChannel(id),
+		},
+	})
+}
+
+// DownloadExecutionLog 下载执行的完整日志, 支持 Range 请求头按字节范围读取
+//
+// stream 为空时按到达顺序合并标准输出和标准错误; 没有日志片段的执行(旧版本工作节点或从归档恢复)返回执行记录中的输出。
+func (h *ExecutionHandler) DownloadExecutionLog(c *gin.Context) {
+	id := c.Param("id")
+	stream, ok := outputStream(c)
+	if !ok {
+		return
+	}
+	execution, ok := h.findExecution(c, id)
+	if !ok {
 		return
 	}
 
-	// 更新状态为已取消
-	if err := h.db.Model(&execution).Updates(map[string]interface{}{
-		"status":      models.ExecutionStatusCancelled,
-		"finished_at": time.Now(),
-		"error":       "用户取消执行",
-	}).Error; err != nil {
-		logger.WithError(err).Error("取消执行记录失败")
+	size, _, err := h.logs.Size(id, stream)
+	if err != nil {
+		logger.WithError(err).Error("查询执行日志失败")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	stored, ok := h.hasLog(c, id)
+	if !ok {
+		return
+	}
+	var fallback []byte
+	if !stored {
+		fallback = []byte(execution.Output)
+		size = int64(len(fallback))
+	}
+
+	start, end, partial, err := parseRange(c.GetHeader("Range"), size)
+	if err != nil {
+		c.Header("Content-Range", fmt.Sprintf("bytes */%d", size))
+		c.JSON(http.StatusRequestedRangeNotSatisfiable, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Header("Accept-Ranges", "bytes")
+	c.Header("Content-Length", strconv.FormatInt(end-start, 10))
+	if c.Query("download") != "" {
+		name := id
+		if stream != "" {
+			name += "-" + string(stream)
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".log"))
+	}
+	if partial {
+		c.Header("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, size))
+		c.Status(http.StatusPartialContent)
+	} else {
+		c.Status(http.StatusOK)
+	}
+
+	if fallback != nil {
+		c.Writer.Write(fallback[start:end])
+		return
+	}
+	if _, err := h.logs.ReadRange(id, stream, start, end-start, c.Writer); err != nil {
+		// 响应头已经发送, 只能记录日志
+		logger.WithError(err).Errorf("读取执行日志失败: %s", id)
+	}
+}
+
+// SearchExecutionLog 逐行搜索执行日志
+func (h *ExecutionHandler) SearchExecutionLog(c *gin.Context) {
+	id := c.Param("id")
+	stream, ok := outputStream(c)
+	if !ok {
+		return
+	}
+
+	pattern := c.Query("q")
+	if pattern == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "搜索内容不能为空"})
+		return
+	}
+	if len(pattern) > 1000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "搜索内容不能超过 1000 个字符"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if limit <= 0 || limit > 1000 {
+		limit = 1000
+	}
+
+	ignoreCase := c.Query("ignore_case") == "true"
+	var match func(string) bool
+	if c.Query("regex") == "true" {
+		if ignoreCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "无效的正则表达式: " + err.Error()})
+			return
+		}
+		match = re.MatchString
+	} else if ignoreCase {
+		lower := strings.ToLower(pattern)
+		match = func(line string) bool { return strings.Contains(strings.ToLower(line), lower) }
+	} else {
+		match = func(line string) bool { return strings.Contains(line, pattern) }
+	}
+
+	execution, ok := h.findExecution(c, id)
+	if !ok {
+		return
+	}
+	stored, ok := h.hasLog(c, id)
+	if !ok {
+		return
+	}
+
+	var matches []logstore.Match
+	var more bool
+	var err error
+	if !stored {
+		matches, more = logstore.SearchText(execution.Output, models.OutputStreamStdout, match, limit)
+	} else if matches, more, err = h.logs.Search(id, stream, match, limit); err != nil {
+		logger.WithError(err).Error("搜索执行日志失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if matches == nil {
+		matches = []logstore.Match{}
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "执行记录已取消",
-		"data":    gin.H{"id": id, "status": "cancelled"},
+		"data": gin.H{
+			"matches":  matches,
+			"has_more": more,
+		},
 	})
 }
+
+// findExecution 查询执行记录, 不存在或失败时写入错误响应
+func (h *ExecutionHandler) findExecution(c *gin.Context, id string) (*models.JobExecution, bool) {
+	var execution models.JobExecution
+	if err := h.db.Select("id", "status", "output").First(&execution, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "执行记录不存在"})
+			return nil, false
+		}
+		logger.WithError(err).Error("查询执行记录失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return &execution, true
+}
+
+// hasLog 执行是否有日志片段, 失败时写入错误响应
+func (h *ExecutionHandler) hasLog(c *gin.Context, id string) (bool, bool) {
+	_, count, err := h.logs.Size(id, "")
+	if err != nil {
+		logger.WithError(err).Error("查询执行日志失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false, false
+	}
+	return count > 0, true
+}
+
+// outputStream 解析 stream 参数, 无效时写入错误响应
+func outputStream(c *gin.Context) (models.OutputStream, bool) {
+	switch stream := models.OutputStream(c.Query("stream")); stream {
+	case "", models.OutputStreamStdout, models.OutputStreamStderr:
+		return stream, true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的输出流, 可选 stdout 或 stderr"})
+		return "", false
+	}
+}
+
+// parseRange 解析单个 bytes 范围, 返回左闭右开区间, 没有 Range 请求头时返回全部
+func parseRange(header string, size int64) (int64, int64, bool, error) {
+	if header == "" {
+		return 0, size, false, nil
+	}
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, 0, false, fmt.Errorf("只支持单个 bytes 范围")
+	}
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return 0, 0, false, fmt.Errorf("无效的范围: %s", spec)
+	}
+
+	var start, end int64
+	switch {
+	case first == "":
+		// bytes=-N 表示最后 N 字节
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 {
+			return 0, 0, false, fmt.Errorf("无效的范围: %s", spec)
+		}
+		if n > size {
+			n = size
+		}
+		start, end = size-n, size
+	default:
+		var err error
+		if start, err = strconv.ParseInt(first, 10, 64); err != nil || start < 0 {
+			return 0, 0, false, fmt.Errorf("无效的范围: %s", spec)
+		}
+		end = size
+		if last != "" {
+			n, err := strconv.ParseInt(last, 10, 64)
+			if err != nil || n < start {
+				return 0, 0, false, fmt.Errorf("无效的范围: %s", spec)
+			}
+			if n+1 < size {
+				end = n + 1
+			}
+		}
+	}
+	if start >= size {
+		return 0, 0, false, fmt.Errorf("范围超出日志大小 %d", size)
+	}
+	return start, end, true, nil
+}
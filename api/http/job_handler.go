@@ -1,6 +1,7 @@
 package http
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
 
@@ -27,25 +28,57 @@ func NewJobHandler() *JobHandler {
 
 // CreateJobRequest 创建任务请求
 type CreateJobRequest struct {
-	Name          string            `json:"name" binding:"required"`
-	Description   string            `json:"description"`
-	Cron          string            `json:"cron" binding:"required"`
-	Command       string            `json:"command" binding:"required"`
-	Params        map[string]string `json:"params"`
-	RetryAttempts int32             `json:"retry_attempts"`
-	Timeout       int32             `json:"timeout"`
+	Name           string            `json:"name" binding:"required"`
+	Description    string            `json:"description"`
+	Cron           string            `json:"cron" binding:"required"`
+	Command        string            `json:"command"` // command 类型必填
+	Params         map[string]string `json:"params"`
+	Type           string            `json:"type"`   // command(默认)、http、grpc、sql 或 handler
+	Config         json.RawMessage   `json:"config"` // 任务类型的配置, JSON 对象
+	ExecMode       string            `json:"exec_mode"`
+	Args           []string          `json:"args"`
+	Interpreter    string            `json:"interpreter"`
+	WorkDir        string            `json:"work_dir"`
+	Umask          string            `json:"umask"`
+	Limits         json.RawMessage   `json:"limits"`  // 资源限制, JSON 对象
+	Sandbox        json.RawMessage   `json:"sandbox"` // 沙箱设置, JSON 对象
+	RetryAttempts  int32             `json:"retry_attempts"`
+	Timeout        int32             `json:"timeout"`
+	OnSuccess      []string          `json:"on_success"`
+	OnFailure      []string          `json:"on_failure"`
+	OnFinish       []string          `json:"on_finish"`
+	SLAStartBy     string            `json:"sla_start_by"`
+	SLAFinishBy    string            `json:"sla_finish_by"`
+	SLAMaxDuration int32             `json:"sla_max_duration"`
+	Labels         map[string]string `json:"labels"`
 }
 
 // UpdateJobRequest 更新任务请求
 type UpdateJobRequest struct {
-	Name          string            `json:"name" binding:"required"`
-	Description   string            `json:"description"`
-	Cron          string            `json:"cron" binding:"required"`
-	Command       string            `json:"command" binding:"required"`
-	Params        map[string]string `json:"params"`
-	Enabled       bool              `json:"enabled"`
-	RetryAttempts int32             `json:"retry_attempts"`
-	Timeout       int32             `json:"timeout"`
+	Name           string            `json:"name" binding:"required"`
+	Description    string            `json:"description"`
+	Cron           string            `json:"cron" binding:"required"`
+	Command        string            `json:"command"` // command 类型必填
+	Params         map[string]string `json:"params"`
+	Type           string            `json:"type"`   // command(默认)、http、grpc、sql 或 handler
+	Config         json.RawMessage   `json:"config"` // 任务类型的配置, JSON 对象
+	ExecMode       string            `json:"exec_mode"`
+	Args           []string          `json:"args"`
+	Interpreter    string            `json:"interpreter"`
+	WorkDir        string            `json:"work_dir"`
+	Umask          string            `json:"umask"`
+	Limits         json.RawMessage   `json:"limits"`  // 资源限制, JSON 对象
+	Sandbox        json.RawMessage   `json:"sandbox"` // 沙箱设置, JSON 对象
+	Enabled        bool              `json:"enabled"`
+	RetryAttempts  int32             `json:"retry_attempts"`
+	Timeout        int32             `json:"timeout"`
+	OnSuccess      []string          `json:"on_success"`
+	OnFailure      []string          `json:"on_failure"`
+	OnFinish       []string          `json:"on_finish"`
+	SLAStartBy     string            `json:"sla_start_by"`
+	SLAFinishBy    string            `json:"sla_finish_by"`
+	SLAMaxDuration int32             `json:"sla_max_duration"`
+	Labels         map[string]string `json:"labels"`
 }
 
 // CreateJob 创建任务
@@ -57,13 +90,29 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 	}
 
 	grpcReq := &grpc.CreateJobRequest{
-		Name:          req.Name,
-		Description:   req.Description,
-		Cron:          req.Cron,
-		Command:       req.Command,
-		Params:        req.Params,
-		RetryAttempts: req.RetryAttempts,
-		Timeout:       req.Timeout,
+		Name:           req.Name,
+		Description:    req.Description,
+		Cron:           req.Cron,
+		Command:        req.Command,
+		Params:         req.Params,
+		Type:           req.Type,
+		Config:         rawConfig(req.Config),
+		ExecMode:       req.ExecMode,
+		Args:           req.Args,
+		Interpreter:    req.Interpreter,
+		WorkDir:        req.WorkDir,
+		Umask:          req.Umask,
+		Limits:         rawConfig(req.Limits),
+		Sandbox:        rawConfig(req.Sandbox),
+		RetryAttempts:  req.RetryAttempts,
+		Timeout:        req.Timeout,
+		OnSuccess:      req.OnSuccess,
+		OnFailure:      req.OnFailure,
+		OnFinish:       req.OnFinish,
+		SlaStartBy:     req.SLAStartBy,
+		SlaFinishBy:    req.SLAFinishBy,
+		SlaMaxDuration: req.SLAMaxDuration,
+		Labels:         req.Labels,
 	}
 
 	resp, err := h.jobService.CreateJob(c.Request.Context(), grpcReq)
@@ -99,10 +148,18 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 	enabled, _ := strconv.ParseBool(c.DefaultQuery("enabled", "false"))
 
 	grpcReq := &grpc.ListJobsRequest{
-		Page:    int32(page),
-		Size:    int32(size),
-		Keyword: keyword,
-		Enabled: enabled,
+		Page:          int32(page),
+		Size:          int32(size),
+		Keyword:       keyword,
+		Enabled:       enabled,
+		DepartmentId:  c.Query("department_id"),
+		CreatedBy:     c.Query("created_by"),
+		LabelSelector: c.Query("label_selector"),
+		State:         c.Query("state"),
+		LastRunStatus: c.Query("last_run_status"),
+		ScheduleType:  c.Query("schedule_type"),
+		SortBy:        c.Query("sort_by"),
+		SortOrder:     c.Query("sort_order"),
 	}
 
 	resp, err := h.jobService.ListJobs(c.Request.Context(), grpcReq)
@@ -133,15 +190,31 @@ func (h *JobHandler) UpdateJob(c *gin.Context) {
 	}
 
 	grpcReq := &grpc.UpdateJobRequest{
-		Id:            id,
-		Name:          req.Name,
-		Description:   req.Description,
-		Cron:          req.Cron,
-		Command:       req.Command,
-		Params:        req.Params,
-		Enabled:       req.Enabled,
-		RetryAttempts: req.RetryAttempts,
-		Timeout:       req.Timeout,
+		Id:             id,
+		Name:           req.Name,
+		Description:    req.Description,
+		Cron:           req.Cron,
+		Command:        req.Command,
+		Params:         req.Params,
+		Type:           req.Type,
+		Config:         rawConfig(req.Config),
+		ExecMode:       req.ExecMode,
+		Args:           req.Args,
+		Interpreter:    req.Interpreter,
+		WorkDir:        req.WorkDir,
+		Umask:          req.Umask,
+		Limits:         rawConfig(req.Limits),
+		Sandbox:        rawConfig(req.Sandbox),
+		Enabled:        req.Enabled,
+		RetryAttempts:  req.RetryAttempts,
+		Timeout:        req.Timeout,
+		OnSuccess:      req.OnSuccess,
+		OnFailure:      req.OnFailure,
+		OnFinish:       req.OnFinish,
+		SlaStartBy:     req.SLAStartBy,
+		SlaFinishBy:    req.SLAFinishBy,
+		SlaMaxDuration: req.SLAMaxDuration,
+		Labels:         req.Labels,
 	}
 
 	resp, err := h.jobService.UpdateJob(c.Request.Context(), grpcReq)
@@ -194,6 +267,111 @@ func (h *JobHandler) TriggerJob(c *gin.Context) {
 	})
 }
 
+// ValidateCronRequest 校验 Cron 表达式请求
+type ValidateCronRequest struct {
+	Cron      string `json:"cron" binding:"required"`
+	Lang      string `json:"lang"`
+	NextCount int32  `json:"next_count"`
+}
+
+// ValidateCron 校验 Cron 表达式, 返回可读描述和接下来的运行时间
+func (h *JobHandler) ValidateCron(c *gin.Context) {
+	var req ValidateCronRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.jobService.ValidateCron(c.Request.Context(), &grpc.ValidateCronRequest{
+		Cron:      req.Cron,
+		Lang:      req.Lang,
+		NextCount: req.NextCount,
+	})
+	if err != nil {
+		logger.WithError(err).Error("校验 Cron 表达式失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+// ListJobRevisions 获取任务版本列表
+func (h *JobHandler) ListJobRevisions(c *gin.Context) {
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 32)
+	size, _ := strconv.ParseInt(c.DefaultQuery("size", "20"), 10, 32)
+
+	resp, err := h.jobService.ListJobRevisions(c.Request.Context(), &grpc.ListJobRevisionsRequest{
+		JobId: c.Param("id"),
+		Page:  int32(page),
+		Size:  int32(size),
+	})
+	if err != nil {
+		logger.WithError(err).Error("获取任务版本失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"revisions": resp.Revisions,
+			"total":     resp.Total,
+			"page":      page,
+			"size":      size,
+		},
+	})
+}
+
+// DiffJobRevisions 对比任务的两个版本, to 为空时与当前版本对比
+func (h *JobHandler) DiffJobRevisions(c *gin.Context) {
+	from, err := strconv.ParseInt(c.Query("from"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的版本号"})
+		return
+	}
+	to, _ := strconv.ParseInt(c.DefaultQuery("to", "0"), 10, 32)
+
+	resp, err := h.jobService.DiffJobRevisions(c.Request.Context(), &grpc.DiffJobRevisionsRequest{
+		JobId:        c.Param("id"),
+		FromRevision: int32(from),
+		ToRevision:   int32(to),
+	})
+	if err != nil {
+		logger.WithError(err).Error("对比任务版本失败")
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+// RollbackJob 回滚任务到指定版本
+func (h *JobHandler) RollbackJob(c *gin.Context) {
+	revision, err := strconv.ParseInt(c.Param("revision"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的版本号"})
+		return
+	}
+
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	c.ShouldBindJSON(&req)
+
+	resp, err := h.jobService.RollbackJob(c.Request.Context(), &grpc.RollbackJobRequest{
+		JobId:    c.Param("id"),
+		Revision: int32(revision),
+		Comment:  req.Comment,
+	})
+	if err != nil {
+		logger.WithError(err).Error("回滚任务失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
 // GetJobExecutions 获取任务执行记录
 func (h *JobHandler) GetJobExecutions(c *gin.Context) {
 	jobID := c.Param("id")
@@ -235,3 +413,11 @@ func (h *JobHandler) GetJobExecutions(c *gin.Context) {
 		},
 	})
 }
+
+// rawConfig 将请求中的任务配置转换为 JSON 字符串, 未提供时为空
+func rawConfig(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	return string(raw)
+}
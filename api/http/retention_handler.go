@@ -0,0 +1,249 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-job/internal/models"
+	"go-job/internal/retention"
+	"go-job/pkg/config"
+	"go-job/pkg/database"
+	"go-job/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RetentionHandler 保留策略处理器
+type RetentionHandler struct {
+	db  *gorm.DB
+	cfg config.RetentionConfig
+}
+
+// NewRetentionHandler 创建保留策略处理器
+func NewRetentionHandler(cfg *config.Config) *RetentionHandler {
+	return &RetentionHandler{
+		db:  database.GetDB(),
+		cfg: cfg.Scheduler.Retention,
+	}
+}
+
+// RetentionPolicyRequest 设置保留策略请求
+type RetentionPolicyRequest struct {
+	Scope      models.RetentionScope `json:"scope" binding:"required"`
+	ScopeID    string                `json:"scope_id"`
+	MaxAgeDays int                   `json:"max_age_days"`
+	MaxCount   int                   `json:"max_count"`
+	Archive    *bool                 `json:"archive"` // 为空时默认归档
+}
+
+// ListPolicies 获取保留策略列表
+func (h *RetentionHandler) ListPolicies(c *gin.Context) {
+	query := h.db.Model(&models.RetentionPolicy{})
+	if scope := c.Query("scope"); scope != "" {
+		query = query.Where("scope = ?", scope)
+	}
+
+	var policies []models.RetentionPolicy
+	if err := query.Order("scope").Order("scope_id").Find(&policies).Error; err != nil {
+		logger.WithError(err).Error("查询保留策略失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"policies": policies,
+			"defaults": gin.H{
+				"max_age_days": h.cfg.MaxAgeDays,
+				"max_count":    h.cfg.MaxCount,
+				"archive":      h.cfg.Archive,
+			},
+		},
+	})
+}
+
+// SetPolicy 创建或更新保留策略, 同一范围只有一条策略
+func (h *RetentionHandler) SetPolicy(c *gin.Context) {
+	var req RetentionPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy := models.RetentionPolicy{
+		Scope:      req.Scope,
+		ScopeID:    req.ScopeID,
+		MaxAgeDays: req.MaxAgeDays,
+		MaxCount:   req.MaxCount,
+		Archive:    req.Archive == nil || *req.Archive,
+	}
+	if err := retention.Validate(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.checkScope(policy.Scope, policy.ScopeID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var existing models.RetentionPolicy
+	err := h.db.Where("scope = ? AND scope_id = ?", policy.Scope, policy.ScopeID).First(&existing).Error
+	switch {
+	case err == nil:
+		updates := map[string]interface{}{
+			"max_age_days": policy.MaxAgeDays,
+			"max_count":    policy.MaxCount,
+			"archive":      policy.Archive,
+			"updated_at":   time.Now(),
+		}
+		if err := h.db.Model(&existing).Updates(updates).Error; err != nil {
+			logger.WithError(err).Error("更新保留策略失败")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		h.db.First(&existing, "id = ?", existing.ID)
+		c.JSON(http.StatusOK, gin.H{"data": existing})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		policy.ID = uuid.New().String()
+		if username, ok := c.Get("username"); ok {
+			policy.CreatedBy, _ = username.(string)
+		}
+		if err := h.db.Create(&policy).Error; err != nil {
+			logger.WithError(err).Error("创建保留策略失败")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"data": policy})
+	default:
+		logger.WithError(err).Error("查询保留策略失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// DeletePolicy 删除保留策略, 删除后回退到上一级策略
+func (h *RetentionHandler) DeletePolicy(c *gin.Context) {
+	result := h.db.Delete(&models.RetentionPolicy{}, "id = ?", c.Param("id"))
+	if result.Error != nil {
+		logger.WithError(result.Error).Error("删除保留策略失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "保留策略不存在"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "保留策略删除成功"})
+}
+
+// GetEffectivePolicy 获取对任务生效的保留策略
+func (h *RetentionHandler) GetEffectivePolicy(c *gin.Context) {
+	jobID := c.Query("job_id")
+	if jobID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "job_id 不能为空"})
+		return
+	}
+
+	var job models.Job
+	if err := h.db.Unscoped().Select("id", "department_id").First(&job, "id = ?", jobID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "任务不存在"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resolver, err := retention.LoadResolver(h.db, h.cfg)
+	if err != nil {
+		logger.WithError(err).Error("加载保留策略失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resolver.Resolve(job.ID, job.DepartmentID)})
+}
+
+// ListArchives 获取归档文件列表
+func (h *RetentionHandler) ListArchives(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "20"))
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 || size > 100 {
+		size = 20
+	}
+
+	var total int64
+	var archives []models.RetentionArchive
+	query := h.db.Model(&models.RetentionArchive{})
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := query.Order("created_at DESC").Offset((page - 1) * size).Limit(size).Find(&archives).Error; err != nil {
+		logger.WithError(err).Error("查询归档记录失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"archives": archives,
+			"total":    total,
+			"page":     page,
+			"size":     size,
+		},
+	})
+}
+
+// RestoreArchive 将归档中的执行记录导回数据库
+func (h *RetentionHandler) RestoreArchive(c *gin.Context) {
+	var archive models.RetentionArchive
+	if err := h.db.First(&archive, "id = ?", c.Param("id")).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "归档不存在"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	restored, err := retention.Restore(h.db, archive.Path, time.Now())
+	if err != nil {
+		logger.WithError(err).Errorf("恢复归档失败: %s", archive.Path)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "data": gin.H{"restored": restored}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"restored":  restored,
+			"hold_days": h.cfg.RestoreHoldDays,
+		},
+	})
+}
+
+// checkScope 检查策略指向的部门或任务是否存在
+func (h *RetentionHandler) checkScope(scope models.RetentionScope, scopeID string) error {
+	var count int64
+	switch scope {
+	case models.RetentionScopeDepartment:
+		h.db.Model(&models.Department{}).Where("id = ?", scopeID).Count(&count)
+		if count == 0 {
+			return errors.New("部门不存在: " + scopeID)
+		}
+	case models.RetentionScopeJob:
+		h.db.Model(&models.Job{}).Where("id = ?", scopeID).Count(&count)
+		if count == 0 {
+			return errors.New("任务不存在: " + scopeID)
+		}
+	}
+	return nil
+}
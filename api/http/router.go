@@ -7,8 +7,12 @@ import (
 	"go-job/internal/mcp"
 	"go-job/internal/permission"
 	"go-job/internal/role"
+	"go-job/internal/scheduler"
+	"go-job/internal/secret"
 	"go-job/internal/user"
 	"go-job/pkg/config"
+	"go-job/pkg/metrics"
+	"go-job/pkg/tracing"
 	"go-job/pkg/websocket"
 	"net/http"
 	"time"
@@ -24,6 +28,8 @@ type Services struct {
 	RoleService       *role.RoleService
 	PermissionService *permission.PermissionService
 	JobService        *job.Service
+	Scheduler         *scheduler.Service
+	Secrets           *secret.Store
 	AIScheduler       *mcp.AISchedulerService
 	MCPService        *mcp.MCPService
 	WSHub             *websocket.Hub
@@ -37,10 +43,17 @@ func NewRouter(cfg *config.Config, services *Services) *gin.Engine {
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 	router.Use(corsMiddleware())
+	router.Use(metrics.GinMiddleware())
+	router.Use(tracing.GinMiddleware())
 
 	// 健康检查
 	router.GET("/health", healthCheck)
 
+	// Prometheus 指标
+	if cfg.Metrics.Enabled {
+		router.GET(cfg.Metrics.Path, gin.WrapH(metrics.Handler()))
+	}
+
 	// WebSocket连接
 	router.GET("/ws", func(c *gin.Context) {
 		services.WSHub.HandleWebSocket(c)
@@ -126,28 +139,94 @@ func NewRouter(cfg *config.Config, services *Services) *gin.Engine {
 			jobHandler := NewJobHandler()
 			jobs.POST("", requirePermission("job:create"), jobHandler.CreateJob)
 			jobs.GET("", requirePermission("job:read"), jobHandler.ListJobs)
+			jobs.POST("/validate-cron", requirePermission("job:read"), jobHandler.ValidateCron)
+
+			// 声明式任务配置
+			specHandler := NewSpecHandler()
+			jobs.GET("/export", requirePermission("job:read"), specHandler.ExportJobs)
+			jobs.POST("/plan", requirePermission("job:read"), specHandler.PlanJobs)
+			jobs.POST("/apply", requirePermission("job:update"), specHandler.ApplyJobs)
+
+			// 批量操作
+			bulkHandler := NewBulkHandler()
+			jobs.POST("/bulk/enable", requirePermission("job:update"), bulkHandler.Enable)
+			jobs.POST("/bulk/disable", requirePermission("job:update"), bulkHandler.Disable)
+			jobs.POST("/bulk/pause", requirePermission("job:update"), bulkHandler.Pause)
+			jobs.POST("/bulk/resume", requirePermission("job:update"), bulkHandler.Resume)
+			jobs.POST("/bulk/delete", requirePermission("job:delete"), bulkHandler.Delete)
+			jobs.POST("/bulk/trigger", requirePermission("job:execute"), bulkHandler.Trigger)
+			jobs.POST("/bulk/move-department", requirePermission("job:update"), bulkHandler.MoveDepartment)
+
 			jobs.GET("/:id", requirePermission("job:read"), jobHandler.GetJob)
 			jobs.PUT("/:id", requirePermission("job:update"), jobHandler.UpdateJob)
 			jobs.DELETE("/:id", requirePermission("job:delete"), jobHandler.DeleteJob)
 			jobs.POST("/:id/trigger", requirePermission("job:execute"), jobHandler.TriggerJob)
 			jobs.GET("/:id/executions", requirePermission("job:read"), jobHandler.GetJobExecutions)
+			jobs.GET("/:id/revisions", requirePermission("job:read"), jobHandler.ListJobRevisions)
+			jobs.GET("/:id/revisions/diff", requirePermission("job:read"), jobHandler.DiffJobRevisions)
+			jobs.POST("/:id/revisions/:revision/rollback", requirePermission("job:update"), jobHandler.RollbackJob)
+
+			// 任务模板
+			templateHandler := NewTemplateHandler()
+			jobs.GET("/templates", requirePermission("job:read"), templateHandler.ListTemplates)
+			jobs.POST("/templates", requirePermission("job:create"), templateHandler.CreateTemplate)
+			jobs.GET("/templates/:template_id", requirePermission("job:read"), templateHandler.GetTemplate)
+			jobs.PUT("/templates/:template_id", requirePermission("job:update"), templateHandler.UpdateTemplate)
+			jobs.DELETE("/templates/:template_id", requirePermission("job:delete"), templateHandler.DeleteTemplate)
+			jobs.POST("/templates/:template_id/instantiate", requirePermission("job:create"), templateHandler.InstantiateTemplate)
+
+			// 事件触发器
+			triggerHandler := NewTriggerHandler()
+			jobs.GET("/:id/triggers", requirePermission("job:read"), triggerHandler.ListTriggers)
+			jobs.POST("/:id/triggers", requirePermission("job:update"), triggerHandler.CreateTrigger)
+			jobs.PUT("/:id/triggers/:trigger_id", requirePermission("job:update"), triggerHandler.UpdateTrigger)
+			jobs.DELETE("/:id/triggers/:trigger_id", requirePermission("job:update"), triggerHandler.DeleteTrigger)
+
+			// SLA 违约记录
+			jobs.GET("/:id/sla-misses", requirePermission("job:read"), NewSLAHandler(cfg).ListSLAMisses)
 		}
 
 		// 执行记录
 		executions := private.Group("/executions")
 		{
-			executionHandler := NewExecutionHandler()
+			executionHandler := NewExecutionHandler(cfg, services.Scheduler)
 			executions.GET("", requirePermission("execution:read"), executionHandler.ListExecutions)
 			executions.GET("/:id", requirePermission("execution:read"), executionHandler.GetExecution)
+			executions.GET("/:id/output", requirePermission("execution:read"), executionHandler.GetExecutionOutput)
+			executions.GET("/:id/log", requirePermission("execution:read"), executionHandler.DownloadExecutionLog)
+			executions.GET("/:id/log/search", requirePermission("execution:read"), executionHandler.SearchExecutionLog)
 			executions.POST("/:id/cancel", requirePermission("execution:cancel"), executionHandler.CancelExecution)
 		}
 
+		// 数据库连接管理, 供 sql 类型的任务引用
+		connections := private.Group("/connections")
+		{
+			connectionHandler := NewConnectionHandler(services.Secrets)
+			connections.GET("", requirePermission("connection:read"), connectionHandler.ListConnections)
+			connections.POST("", requirePermission("connection:create"), connectionHandler.CreateConnection)
+			connections.GET("/:id", requirePermission("connection:read"), connectionHandler.GetConnection)
+			connections.PUT("/:id", requirePermission("connection:update"), connectionHandler.UpdateConnection)
+			connections.DELETE("/:id", requirePermission("connection:delete"), connectionHandler.DeleteConnection)
+		}
+
+		// 密钥管理, 任务参数通过 ${secret:name} 引用
+		secrets := private.Group("/secrets")
+		{
+			secretHandler := NewSecretHandler(services.Secrets)
+			secrets.GET("", requirePermission("secret:read"), secretHandler.ListSecrets)
+			secrets.POST("", requirePermission("secret:create"), secretHandler.CreateSecret)
+			secrets.PUT("/:id", requirePermission("secret:update"), secretHandler.UpdateSecret)
+			secrets.DELETE("/:id", requirePermission("secret:delete"), secretHandler.DeleteSecret)
+			secrets.POST("/rotate", requirePermission("secret:rotate"), secretHandler.RotateSecrets)
+		}
+
 		// 工作节点管理
 		workers := private.Group("/workers")
 		{
 			workerHandler := NewWorkerHandler()
 			workers.GET("", requirePermission("worker:read"), workerHandler.ListWorkers)
 			workers.GET("/:id", requirePermission("worker:read"), workerHandler.GetWorker)
+			workers.GET("/:id/metrics", requirePermission("worker:read"), workerHandler.GetWorkerMetrics)
 			workers.PUT("/:id/status", requirePermission("worker:update"), workerHandler.UpdateWorkerStatus)
 		}
 
@@ -159,6 +238,22 @@ func NewRouter(cfg *config.Config, services *Services) *gin.Engine {
 			stats.GET("/jobs", requirePermission("stats:read"), statsHandler.GetJobStats)
 			stats.GET("/workers", requirePermission("stats:read"), statsHandler.GetWorkerStats)
 			stats.GET("/executions", requirePermission("stats:read"), statsHandler.GetExecutionStats)
+
+			slaHandler := NewSLAHandler(cfg)
+			stats.GET("/sla", requirePermission("stats:read"), slaHandler.GetComplianceReport)
+			stats.GET("/sla/misses", requirePermission("stats:read"), slaHandler.ListSLAMisses)
+		}
+
+		// 执行记录保留策略和归档
+		retentionGroup := private.Group("/retention")
+		{
+			retentionHandler := NewRetentionHandler(cfg)
+			retentionGroup.GET("/policies", requirePermission("retention:read"), retentionHandler.ListPolicies)
+			retentionGroup.PUT("/policies", requirePermission("retention:update"), retentionHandler.SetPolicy)
+			retentionGroup.GET("/policies/effective", requirePermission("retention:read"), retentionHandler.GetEffectivePolicy)
+			retentionGroup.DELETE("/policies/:id", requirePermission("retention:update"), retentionHandler.DeletePolicy)
+			retentionGroup.GET("/archives", requirePermission("retention:read"), retentionHandler.ListArchives)
+			retentionGroup.POST("/archives/:id/restore", requirePermission("retention:restore"), retentionHandler.RestoreArchive)
 		}
 	}
 
@@ -0,0 +1,203 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"go-job/internal/models"
+	"go-job/internal/secret"
+	"go-job/pkg/database"
+	"go-job/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SecretHandler 密钥处理器, 密钥的值只能写入, 不在接口中返回
+type SecretHandler struct {
+	db    *gorm.DB
+	store *secret.Store
+}
+
+// NewSecretHandler 创建密钥处理器
+func NewSecretHandler(store *secret.Store) *SecretHandler {
+	return &SecretHandler{
+		db:    database.GetDB(),
+		store: store,
+	}
+}
+
+// CreateSecretRequest 创建密钥请求
+type CreateSecretRequest struct {
+	Name        string             `json:"name" binding:"required"`
+	Scope       models.SecretScope `json:"scope" binding:"required"` // department 或 job
+	ScopeID     string             `json:"scope_id" binding:"required"`
+	Value       string             `json:"value" binding:"required"`
+	Description string             `json:"description"`
+}
+
+// UpdateSecretRequest 更新密钥请求
+type UpdateSecretRequest struct {
+	Value       string `json:"value"` // 为空表示不修改
+	Description string `json:"description"`
+}
+
+// ListSecrets 获取密钥列表, 可按范围过滤
+func (h *SecretHandler) ListSecrets(c *gin.Context) {
+	query := h.db.Model(&models.Secret{})
+	if scope := c.Query("scope"); scope != "" {
+		query = query.Where("scope = ?", scope)
+	}
+	if scopeID := c.Query("scope_id"); scopeID != "" {
+		query = query.Where("scope_id = ?", scopeID)
+	}
+
+	var secrets []models.Secret
+	if err := query.Order("scope").Order("scope_id").Order("name").Find(&secrets).Error; err != nil {
+		logger.WithError(err).Error("查询密钥失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": secrets})
+}
+
+// CreateSecret 创建密钥
+func (h *SecretHandler) CreateSecret(c *gin.Context) {
+	var req CreateSecretRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := secret.ValidateName(req.Name); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.checkScope(c, req.Scope, req.ScopeID) {
+		return
+	}
+
+	var count int64
+	h.db.Model(&models.Secret{}).
+		Where("name = ? AND scope = ? AND scope_id = ?", req.Name, req.Scope, req.ScopeID).
+		Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "该范围内已存在同名密钥"})
+		return
+	}
+
+	username, _ := c.Get("username")
+	createdBy, _ := username.(string)
+
+	item := models.Secret{
+		ID:          uuid.New().String(),
+		Name:        req.Name,
+		Scope:       req.Scope,
+		ScopeID:     req.ScopeID,
+		Description: req.Description,
+		CreatedBy:   createdBy,
+	}
+	if err := h.store.Seal(&item, req.Value); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.db.Create(&item).Error; err != nil {
+		logger.WithError(err).Error("创建密钥失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": item})
+}
+
+// UpdateSecret 更新密钥的值或说明, 名称和范围不能修改
+func (h *SecretHandler) UpdateSecret(c *gin.Context) {
+	var req UpdateSecretRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var item models.Secret
+	if err := h.db.First(&item, "id = ?", c.Param("id")).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "密钥不存在"})
+			return
+		}
+		logger.WithError(err).Error("查询密钥失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	item.Description = req.Description
+	if req.Value != "" {
+		// 更新值时同时更换数据密钥
+		if err := h.store.Seal(&item, req.Value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if err := h.db.Save(&item).Error; err != nil {
+		logger.WithError(err).Error("更新密钥失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": item})
+}
+
+// DeleteSecret 删除密钥, 引用它的任务在下次执行时失败
+func (h *SecretHandler) DeleteSecret(c *gin.Context) {
+	result := h.db.Delete(&models.Secret{}, "id = ?", c.Param("id"))
+	if result.Error != nil {
+		logger.WithError(result.Error).Error("删除密钥失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "密钥不存在"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "密钥删除成功"})
+}
+
+// RotateSecrets 用当前主密钥重新加密所有数据密钥, 完成后可以从配置中删除旧主密钥
+func (h *SecretHandler) RotateSecrets(c *gin.Context) {
+	rotated, err := h.store.Rotate()
+	if err != nil {
+		logger.WithError(err).Error("轮换主密钥失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "rotated": rotated})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"rotated": rotated, "active_key": h.store.ActiveKey()}})
+}
+
+// checkScope 检查密钥的作用范围, 失败时已写入响应
+func (h *SecretHandler) checkScope(c *gin.Context, scope models.SecretScope, scopeID string) bool {
+	var model interface{}
+	var notFound string
+	switch scope {
+	case models.SecretScopeDepartment:
+		model, notFound = &models.Department{}, "部门不存在: "
+	case models.SecretScopeJob:
+		model, notFound = &models.Job{}, "任务不存在: "
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的密钥范围: " + string(scope) + ", 可选 department 或 job"})
+		return false
+	}
+
+	var count int64
+	if err := h.db.Model(model).Where("id = ?", scopeID).Count(&count).Error; err != nil {
+		logger.WithError(err).Error("查询密钥范围失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if count == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": notFound + scopeID})
+		return false
+	}
+	return true
+}
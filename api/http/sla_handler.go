@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-job/internal/models"
+	"go-job/internal/sla"
+	"go-job/pkg/config"
+	"go-job/pkg/database"
+	"go-job/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SLAHandler SLA 处理器
+type SLAHandler struct {
+	db       *gorm.DB
+	location *time.Location
+}
+
+// NewSLAHandler 创建 SLA 处理器, 日期按调度器时区计算
+func NewSLAHandler(cfg *config.Config) *SLAHandler {
+	location, err := time.LoadLocation(cfg.Scheduler.Timezone)
+	if err != nil {
+		location = time.Local
+	}
+
+	return &SLAHandler{
+		db:       database.GetDB(),
+		location: location,
+	}
+}
+
+// GetComplianceReport 获取按任务和部门统计的 SLA 达成报告
+func (h *SLAHandler) GetComplianceReport(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的统计天数"})
+		return
+	}
+
+	to := time.Now().In(h.location)
+	from := to.AddDate(0, 0, -days)
+
+	report, err := sla.Report(h.db, h.location, from, to, c.Query("department_id"))
+	if err != nil {
+		logger.WithError(err).Error("统计 SLA 达成情况失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// ListSLAMisses 获取 SLA 违约记录
+func (h *SLAHandler) ListSLAMisses(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "20"))
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 || size > 100 {
+		size = 20
+	}
+
+	query := h.db.Model(&models.SLAMiss{})
+	if jobID := c.Param("id"); jobID != "" {
+		query = query.Where("job_id = ?", jobID)
+	} else if jobID := c.Query("job_id"); jobID != "" {
+		query = query.Where("job_id = ?", jobID)
+	}
+	if departmentID := c.Query("department_id"); departmentID != "" {
+		query = query.Where("department_id = ?", departmentID)
+	}
+	if missType := c.Query("type"); missType != "" {
+		query = query.Where("type = ?", missType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.WithError(err).Error("查询 SLA 违约记录失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var misses []models.SLAMiss
+	if err := query.Preload("Job").Order("detected_at DESC").
+		Offset((page - 1) * size).Limit(size).Find(&misses).Error; err != nil {
+		logger.WithError(err).Error("查询 SLA 违约记录失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"misses": misses,
+			"total":  total,
+			"page":   page,
+			"size":   size,
+		},
+	})
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+
+	"go-job/internal/job"
+	"go-job/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+// maxSpecSize 任务配置文件的最大长度
+const maxSpecSize = 4 << 20
+
+// SpecHandler 声明式任务配置处理器
+type SpecHandler struct {
+	jobService *job.Service
+}
+
+// NewSpecHandler 创建声明式任务配置处理器
+func NewSpecHandler() *SpecHandler {
+	return &SpecHandler{
+		jobService: job.NewService(),
+	}
+}
+
+// ExportJobs 导出任务配置, format 为 yaml(默认) 或 json
+func (h *SpecHandler) ExportJobs(c *gin.Context) {
+	includeUnmanaged, _ := strconv.ParseBool(c.DefaultQuery("include_unmanaged", "false"))
+
+	spec, err := h.jobService.ExportSpec(c.Query("owner"), c.Query("department_id"), includeUnmanaged)
+	if err != nil {
+		logger.WithError(err).Error("导出任务配置失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch c.DefaultQuery("format", "yaml") {
+	case "json":
+		c.Header("Content-Disposition", "attachment; filename=jobs.json")
+		c.IndentedJSON(http.StatusOK, spec)
+	case "yaml":
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(spec); err != nil {
+			logger.WithError(err).Error("导出任务配置失败")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename=jobs.yaml")
+		c.Data(http.StatusOK, "application/x-yaml; charset=utf-8", buf.Bytes())
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不支持的导出格式, 可选 yaml 或 json"})
+	}
+}
+
+// PlanJobs 计算任务配置与当前任务的差异, 不做任何修改
+func (h *SpecHandler) PlanJobs(c *gin.Context) {
+	spec, opts, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	plan, err := h.jobService.PlanSpec(spec, opts)
+	if err != nil {
+		logger.WithError(err).Error("计算任务配置差异失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": plan})
+}
+
+// ApplyJobs 应用任务配置, 存在冲突时不做任何修改并返回计划
+func (h *SpecHandler) ApplyJobs(c *gin.Context) {
+	spec, opts, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	plan, err := h.jobService.ApplySpec(c.Request.Context(), spec, opts)
+	if err != nil {
+		logger.WithError(err).Error("应用任务配置失败")
+		status := http.StatusInternalServerError
+		if plan != nil && plan.HasConflicts() {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error(), "data": plan})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": plan})
+}
+
+// parseRequest 读取请求体中的 YAML 或 JSON 配置以及 prune、adopt 选项
+func (h *SpecHandler) parseRequest(c *gin.Context) (*job.Spec, job.SpecOptions, bool) {
+	var opts job.SpecOptions
+	opts.Prune, _ = strconv.ParseBool(c.DefaultQuery("prune", "false"))
+	opts.Adopt, _ = strconv.ParseBool(c.DefaultQuery("adopt", "false"))
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxSpecSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, opts, false
+	}
+	if len(data) > maxSpecSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "任务配置过大"})
+		return nil, opts, false
+	}
+
+	spec, err := job.ParseSpec(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, opts, false
+	}
+	return spec, opts, true
+}
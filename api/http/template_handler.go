@@ -0,0 +1,205 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-job/api/grpc"
+	"go-job/internal/job"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TemplateHandler 任务模板处理器
+type TemplateHandler struct {
+	jobService *job.Service
+}
+
+// NewTemplateHandler 创建任务模板处理器
+func NewTemplateHandler() *TemplateHandler {
+	return &TemplateHandler{
+		jobService: job.NewService(),
+	}
+}
+
+// JobTemplateRequest 创建或更新任务模板请求
+type JobTemplateRequest struct {
+	Name          string                 `json:"name" binding:"required"`
+	Description   string                 `json:"description"`
+	Command       string                 `json:"command" binding:"required"`
+	Params        []models.TemplateParam `json:"params"`
+	Timeout       int32                  `json:"timeout"`
+	RetryAttempts int32                  `json:"retry_attempts"`
+}
+
+// InstantiateTemplateRequest 从模板创建任务请求
+type InstantiateTemplateRequest struct {
+	Name         string            `json:"name" binding:"required"`
+	Description  string            `json:"description"`
+	Cron         string            `json:"cron" binding:"required"`
+	Values       map[string]string `json:"values"`
+	DepartmentID string            `json:"department_id"`
+	Priority     int32             `json:"priority"`
+}
+
+// CreateTemplate 创建任务模板
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	var req JobTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.jobService.CreateJobTemplate(c.Request.Context(), &grpc.CreateJobTemplateRequest{
+		Name:          req.Name,
+		Description:   req.Description,
+		Command:       req.Command,
+		Params:        templateParamsToGrpc(req.Params),
+		Timeout:       req.Timeout,
+		RetryAttempts: req.RetryAttempts,
+	})
+	if err != nil {
+		logger.WithError(err).Error("创建任务模板失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": resp.Template})
+}
+
+// GetTemplate 获取任务模板
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	resp, err := h.jobService.GetJobTemplate(c.Request.Context(), &grpc.GetJobTemplateRequest{
+		Id: c.Param("template_id"),
+	})
+	if err != nil {
+		logger.WithError(err).Error("获取任务模板失败")
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resp.Template})
+}
+
+// ListTemplates 获取任务模板列表
+func (h *TemplateHandler) ListTemplates(c *gin.Context) {
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 32)
+	size, _ := strconv.ParseInt(c.DefaultQuery("size", "10"), 10, 32)
+
+	resp, err := h.jobService.ListJobTemplates(c.Request.Context(), &grpc.ListJobTemplatesRequest{
+		Page:    int32(page),
+		Size:    int32(size),
+		Keyword: c.Query("keyword"),
+	})
+	if err != nil {
+		logger.WithError(err).Error("获取任务模板列表失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"templates": resp.Templates,
+			"total":     resp.Total,
+			"page":      page,
+			"size":      size,
+		},
+	})
+}
+
+// UpdateTemplate 更新任务模板
+//
+// ?dry_run=true 只预览受影响的派生任务, ?propagate=true 同步更新所有派生任务。
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	var req JobTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	propagate, _ := strconv.ParseBool(c.DefaultQuery("propagate", "false"))
+
+	resp, err := h.jobService.UpdateJobTemplate(c.Request.Context(), &grpc.UpdateJobTemplateRequest{
+		Id:            c.Param("template_id"),
+		Name:          req.Name,
+		Description:   req.Description,
+		Command:       req.Command,
+		Params:        templateParamsToGrpc(req.Params),
+		Timeout:       req.Timeout,
+		RetryAttempts: req.RetryAttempts,
+		Propagate:     propagate,
+		DryRun:        dryRun,
+	})
+	if err != nil {
+		logger.WithError(err).Error("更新任务模板失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"template":      resp.Template,
+			"affected_jobs": resp.AffectedJobs,
+			"dry_run":       dryRun,
+			"propagated":    propagate && !dryRun,
+		},
+	})
+}
+
+// DeleteTemplate 删除任务模板
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	_, err := h.jobService.DeleteJobTemplate(c.Request.Context(), &grpc.DeleteJobTemplateRequest{
+		Id: c.Param("template_id"),
+	})
+	if err != nil {
+		logger.WithError(err).Error("删除任务模板失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "任务模板删除成功"})
+}
+
+// InstantiateTemplate 从模板创建任务
+func (h *TemplateHandler) InstantiateTemplate(c *gin.Context) {
+	var req InstantiateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.jobService.InstantiateJobTemplate(c.Request.Context(), &grpc.InstantiateJobTemplateRequest{
+		TemplateId:   c.Param("template_id"),
+		Name:         req.Name,
+		Description:  req.Description,
+		Cron:         req.Cron,
+		Values:       req.Values,
+		DepartmentId: req.DepartmentID,
+		Priority:     req.Priority,
+	})
+	if err != nil {
+		logger.WithError(err).Error("从模板创建任务失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": resp.Job})
+}
+
+// templateParamsToGrpc 转换模板参数定义
+func templateParamsToGrpc(params []models.TemplateParam) []*grpc.TemplateParam {
+	result := make([]*grpc.TemplateParam, 0, len(params))
+	for _, param := range params {
+		result = append(result, &grpc.TemplateParam{
+			Name:         param.Name,
+			Description:  param.Description,
+			Required:     param.Required,
+			DefaultValue: param.DefaultValue,
+			Pattern:      param.Pattern,
+			Raw:          param.Raw,
+		})
+	}
+	return result
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"net/http"
+
+	"go-job/internal/models"
+	"go-job/internal/trigger"
+	"go-job/pkg/database"
+	"go-job/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TriggerHandler 事件触发器处理器
+type TriggerHandler struct {
+	db *gorm.DB
+}
+
+// NewTriggerHandler 创建事件触发器处理器
+func NewTriggerHandler() *TriggerHandler {
+	return &TriggerHandler{
+		db: database.GetDB(),
+	}
+}
+
+// TriggerRequest 创建/更新事件触发器请求
+type TriggerRequest struct {
+	Type          string `json:"type" binding:"required"`
+	Source        string `json:"source" binding:"required"`
+	ConsumerGroup string `json:"consumer_group"`
+	Filter        string `json:"filter"`
+	Enabled       *bool  `json:"enabled"`
+}
+
+// ListTriggers 获取任务的事件触发器
+func (h *TriggerHandler) ListTriggers(c *gin.Context) {
+	jobID := c.Param("id")
+
+	var triggers []models.JobTrigger
+	if err := h.db.Where("job_id = ?", jobID).Order("created_at DESC").Find(&triggers).Error; err != nil {
+		logger.WithError(err).Error("查询事件触发器失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"triggers": triggers,
+			"types":    trigger.Types(),
+		},
+	})
+}
+
+// CreateTrigger 创建事件触发器
+func (h *TriggerHandler) CreateTrigger(c *gin.Context) {
+	jobID := c.Param("id")
+
+	var req TriggerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var job models.Job
+	if err := h.db.First(&job, "id = ?", jobID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "任务不存在"})
+			return
+		}
+		logger.WithError(err).Error("查询任务失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	username, _ := c.Get("username")
+	createdBy, _ := username.(string)
+
+	t := models.JobTrigger{
+		ID:            uuid.New().String(),
+		JobID:         job.ID,
+		Type:          models.TriggerType(req.Type),
+		Source:        req.Source,
+		ConsumerGroup: req.ConsumerGroup,
+		Filter:        req.Filter,
+		Enabled:       req.Enabled == nil || *req.Enabled,
+		CreatedBy:     createdBy,
+	}
+
+	if err := trigger.Validate(t); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.db.Create(&t).Error; err != nil {
+		logger.WithError(err).Error("创建事件触发器失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": t})
+}
+
+// UpdateTrigger 更新事件触发器
+func (h *TriggerHandler) UpdateTrigger(c *gin.Context) {
+	jobID := c.Param("id")
+	triggerID := c.Param("trigger_id")
+
+	var req TriggerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var t models.JobTrigger
+	if err := h.db.First(&t, "id = ? AND job_id = ?", triggerID, jobID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "事件触发器不存在"})
+			return
+		}
+		logger.WithError(err).Error("查询事件触发器失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	t.Type = models.TriggerType(req.Type)
+	t.Source = req.Source
+	t.ConsumerGroup = req.ConsumerGroup
+	t.Filter = req.Filter
+	if req.Enabled != nil {
+		t.Enabled = *req.Enabled
+	}
+
+	if err := trigger.Validate(t); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.db.Save(&t).Error; err != nil {
+		logger.WithError(err).Error("更新事件触发器失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": t})
+}
+
+// DeleteTrigger 删除事件触发器
+func (h *TriggerHandler) DeleteTrigger(c *gin.Context) {
+	jobID := c.Param("id")
+	triggerID := c.Param("trigger_id")
+
+	result := h.db.Delete(&models.JobTrigger{}, "id = ? AND job_id = ?", triggerID, jobID)
+	if result.Error != nil {
+		logger.WithError(result.Error).Error("删除事件触发器失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "事件触发器不存在"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "事件触发器删除成功"})
+}
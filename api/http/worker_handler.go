@@ -3,6 +3,7 @@ package http
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"go-job/internal/models"
 	"go-job/pkg/database"
@@ -81,6 +82,33 @@ func (h *WorkerHandler) GetWorker(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": worker})
 }
 
+// GetWorkerMetrics 获取工作节点主机指标的历史记录, hours 为最近的小时数, 默认 1
+func (h *WorkerHandler) GetWorkerMetrics(c *gin.Context) {
+	id := c.Param("id")
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "1"))
+	if err != nil || hours <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的 hours 参数"})
+		return
+	}
+
+	var count int64
+	h.db.Model(&models.Worker{}).Where("id = ?", id).Count(&count)
+	if count == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "工作节点不存在"})
+		return
+	}
+
+	var history []models.WorkerMetric
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+	if err := h.db.Where("worker_id = ? AND created_at >= ?", id, since).Order("created_at").Find(&history).Error; err != nil {
+		logger.WithError(err).Error("查询工作节点指标历史失败")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": history})
+}
+
 // UpdateWorkerStatusRequest 更新工作节点状态请求
 type UpdateWorkerStatusRequest struct {
 	Status string `json:"status" binding:"required"`
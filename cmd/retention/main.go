@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go-job/internal/logstore"
+	"go-job/internal/models"
+	"go-job/internal/retention"
+	"go-job/pkg/config"
+	"go-job/pkg/database"
+	"go-job/pkg/logger"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const usage = `用法: retention [-config 配置文件] <命令> [参数]
+
+命令:
+  run              按保留策略立即执行一次清理和归档
+  list             列出归档文件
+  restore <文件>   将归档中的执行记录导回数据库, 用于排查问题
+`
+
+func main() {
+	var configPath = flag.String("config", "configs/config.yaml", "配置文件路径")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// 加载配置
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		logrus.Fatalf("加载配置失败: %v", err)
+	}
+
+	// 初始化日志
+	if err := logger.Init(cfg); err != nil {
+		logrus.Fatalf("初始化日志失败: %v", err)
+	}
+
+	// 初始化数据库
+	if err := database.Init(cfg); err != nil {
+		logrus.Fatalf("初始化数据库失败: %v", err)
+	}
+	db := database.GetDB()
+
+	switch flag.Arg(0) {
+	case "run":
+		result, err := retention.NewCleaner(db, cfg.Scheduler.Retention, logstore.New(db, cfg.Scheduler.Output)).Run(time.Now())
+		if err != nil {
+			logrus.WithError(err).Error("清理失败")
+		}
+		if result != nil {
+			fmt.Printf("删除执行记录 %d 条, 其中归档 %d 条, 删除调度记录 %d 条\n", result.Executions, result.Archived, result.Schedules)
+			if result.Archive != nil {
+				fmt.Printf("归档文件: %s\n", result.Archive.Path)
+			}
+		}
+		if err != nil {
+			os.Exit(1)
+		}
+
+	case "list":
+		var archives []models.RetentionArchive
+		if err := db.Order("created_at DESC").Find(&archives).Error; err != nil {
+			logrus.Fatalf("查询归档记录失败: %v", err)
+		}
+		for _, a := range archives {
+			restored := "-"
+			if a.RestoredAt != nil {
+				restored = a.RestoredAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%s\t%s\t%d 条\t恢复于 %s\t%s\n", a.ID, a.CreatedAt.Format(time.RFC3339), a.Records, restored, a.Path)
+		}
+
+	case "restore":
+		if flag.NArg() < 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		restored, err := retention.Restore(db, flag.Arg(1), time.Now())
+		fmt.Printf("已恢复 %d 条执行记录, %d 天内不会被再次清理\n", restored, cfg.Scheduler.Retention.RestoreHoldDays)
+		if err != nil {
+			logrus.Fatalf("恢复归档失败: %v", err)
+		}
+
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
@@ -3,12 +3,16 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"go-job/internal/worker"
 	"go-job/pkg/config"
 	"go-job/pkg/logger"
+	"go-job/pkg/tracing"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -16,6 +20,7 @@ import (
 func main() {
 	var configPath = flag.String("config", "configs/config.yaml", "配置文件路径")
 	var workerName = flag.String("name", "", "工作节点名称")
+	var metricsPort = flag.Int("metrics-port", 0, "指标端口, 为 0 时使用配置文件中的 metrics.workerPort")
 	flag.Parse()
 
 	// 加载配置
@@ -31,11 +36,36 @@ func main() {
 
 	logrus.Info("启动 Go-Job Worker 节点...")
 
+	// 初始化链路追踪
+	if err := tracing.Init(cfg, "go-job-worker"); err != nil {
+		logrus.Fatalf("初始化链路追踪失败: %v", err)
+	}
+
 	// 创建工作节点
 	workerInstance := worker.NewWorker(cfg)
 	if *workerName != "" {
 		workerInstance.SetName(*workerName)
 	}
+	if *metricsPort != 0 {
+		workerInstance.SetPort(int32(*metricsPort))
+	}
+
+	// 启动指标服务
+	var metricsServer *http.Server
+	if cfg.Metrics.Enabled {
+		mux := http.NewServeMux()
+		mux.Handle(cfg.Metrics.Path, workerInstance.MetricsHandler())
+		metricsServer = &http.Server{
+			Addr:    fmt.Sprintf(":%d", workerInstance.Port()),
+			Handler: mux,
+		}
+		go func() {
+			logrus.Infof("工作节点指标服务启动在 %s%s", metricsServer.Addr, cfg.Metrics.Path)
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logrus.WithError(err).Error("工作节点指标服务启动失败")
+			}
+		}()
+	}
 
 	// 创建上下文
 	ctx, cancel := context.WithCancel(context.Background())
@@ -55,5 +85,13 @@ func main() {
 	logrus.Info("正在停止工作节点...")
 	cancel()
 	workerInstance.Stop()
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if metricsServer != nil {
+		metricsServer.Shutdown(shutdownCtx)
+	}
+	if err := tracing.Shutdown(shutdownCtx); err != nil {
+		logrus.WithError(err).Error("导出剩余追踪数据失败")
+	}
+	shutdownCancel()
 	logrus.Info("工作节点已停止")
 }
@@ -2,6 +2,7 @@ package department
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
@@ -10,6 +11,7 @@ import (
 	"gorm.io/gorm"
 
 	grpc "go-job/api/grpc"
+	"go-job/internal/jobtype"
 	"go-job/internal/models"
 )
 
@@ -41,6 +43,11 @@ func (s *DepartmentService) CreateDepartment(ctx context.Context, req *grpc.Crea
 		}
 	}
 
+	sandbox, err := normalizeSandbox(req.GetSandbox())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	// 创建部门
 	dept := models.Department{
 		ID:          uuid.New().String(),
@@ -49,6 +56,7 @@ func (s *DepartmentService) CreateDepartment(ctx context.Context, req *grpc.Crea
 		Description: req.GetDescription(),
 		Status:      models.DeptStatusActive,
 		Sort:        int(req.GetSort()),
+		Sandbox:     sandbox,
 	}
 
 	if req.GetParentId() != "" {
@@ -160,12 +168,18 @@ func (s *DepartmentService) UpdateDepartment(ctx context.Context, req *grpc.Upda
 		}
 	}
 
+	sandbox, err := normalizeSandbox(req.GetSandbox())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	// 更新部门信息
 	updates := map[string]interface{}{
 		"name":        req.GetName(),
 		"code":        req.GetCode(),
 		"description": req.GetDescription(),
 		"sort":        int(req.GetSort()),
+		"sandbox":     sandbox,
 	}
 
 	if req.GetParentId() != "" {
@@ -283,6 +297,7 @@ func (s *DepartmentService) modelToGrpcDepartment(dept *models.Department) *grpc
 		Description: dept.Description,
 		Status:      string(dept.Status),
 		Sort:        int32(dept.Sort),
+		Sandbox:     dept.Sandbox,
 		CreatedAt:   timestamppb.New(dept.CreatedAt),
 		UpdatedAt:   timestamppb.New(dept.UpdatedAt),
 	}
@@ -311,3 +326,13 @@ func (s *DepartmentService) modelToGrpcDepartment(dept *models.Department) *grpc
 
 	return grpcDept
 }
+
+// normalizeSandbox 校验部门任务默认的沙箱设置, 返回规范化的 JSON, 未设置任何项时为空
+func normalizeSandbox(raw string) (string, error) {
+	sandbox, err := jobtype.ParseSandbox(raw)
+	if err != nil || sandbox.Empty() {
+		return "", err
+	}
+	data, _ := json.Marshal(sandbox)
+	return string(data), nil
+}
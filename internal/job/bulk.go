@@ -0,0 +1,292 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 批量操作
+const (
+	BulkEnable         = "enable"
+	BulkDisable        = "disable"
+	BulkPause          = "pause"
+	BulkResume         = "resume"
+	BulkDelete         = "delete"
+	BulkTrigger        = "trigger"
+	BulkMoveDepartment = "move-department"
+)
+
+// maxBulkJobs 单次批量操作的任务数上限
+const maxBulkJobs = 1000
+
+// BulkJobOperation 对一组任务执行批量操作, 无论成败都只写一条审计记录
+//
+// 任务通过 job_ids 指定或按 filter 选择。atomic 时所有任务在同一事务中处理, 任一失败则全部回滚;
+// 否则逐个处理并返回每个任务的结果。
+func (s *Service) BulkJobOperation(ctx context.Context, req *grpc.BulkJobOperationRequest) (*grpc.BulkJobOperationResponse, error) {
+	action := req.GetAction()
+	logger.Infof("批量任务操作: %s (指定任务 %d 个, atomic=%t)", action, len(req.GetJobIds()), req.GetAtomic())
+
+	switch action {
+	case BulkEnable, BulkDisable, BulkResume, BulkDelete, BulkTrigger:
+	case BulkPause:
+		if until := req.GetPausedUntil(); until != nil && !until.AsTime().After(time.Now()) {
+			return nil, fmt.Errorf("暂停截止时间必须晚于当前时间")
+		}
+	case BulkMoveDepartment:
+		if req.GetDepartmentId() == "" {
+			return nil, fmt.Errorf("目标部门不能为空")
+		}
+		var count int64
+		if err := s.db.Model(&models.Department{}).Where("id = ?", req.GetDepartmentId()).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("查询部门失败: %w", err)
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("部门不存在: %s", req.GetDepartmentId())
+		}
+	default:
+		return nil, fmt.Errorf("不支持的批量操作: %s", action)
+	}
+
+	results, jobs, err := s.bulkTargets(req)
+	if err != nil {
+		return nil, err
+	}
+
+	operator := getUserFromContext(ctx)
+	if req.GetAtomic() {
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			for i, result := range results {
+				if result.Error != "" {
+					return fmt.Errorf("%s", result.Error)
+				}
+				if err := s.applyBulkAction(ctx, tx, req, jobs[i], result, operator); err != nil {
+					result.Error = err.Error()
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			for _, result := range results {
+				if result.Error == "" {
+					result.Error = "事务已回滚"
+				}
+				result.Success = false
+				result.ExecutionId = ""
+			}
+		}
+	} else {
+		for i, result := range results {
+			if result.Error != "" {
+				continue
+			}
+			err := s.db.Transaction(func(tx *gorm.DB) error {
+				return s.applyBulkAction(ctx, tx, req, jobs[i], result, operator)
+			})
+			if err != nil {
+				result.Error = err.Error()
+				result.Success = false
+				result.ExecutionId = ""
+			}
+		}
+	}
+
+	resp := &grpc.BulkJobOperationResponse{Results: results}
+	for _, result := range results {
+		if result.Success {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	resp.AuditId = s.recordBulkAudit(req, resp, operator)
+
+	logger.Infof("批量任务操作完成: %s (成功 %d, 失败 %d)", action, resp.Succeeded, resp.Failed)
+
+	return resp, nil
+}
+
+// bulkTargets 确定批量操作的任务, 指定的任务不存在时在结果中标记错误
+func (s *Service) bulkTargets(req *grpc.BulkJobOperationRequest) ([]*grpc.BulkJobResult, []*models.Job, error) {
+	ids := models.NormalizeJobIDs(req.GetJobIds())
+	filter := req.GetFilter()
+
+	var found []models.Job
+	switch {
+	case len(ids) > 0 && filter != nil:
+		return nil, nil, fmt.Errorf("job_ids 和 filter 只能指定一个")
+	case len(ids) > 0:
+		if len(ids) > maxBulkJobs {
+			return nil, nil, fmt.Errorf("单次最多操作 %d 个任务", maxBulkJobs)
+		}
+		if err := s.db.Where("id IN ?", ids).Find(&found).Error; err != nil {
+			return nil, nil, fmt.Errorf("查询任务失败: %w", err)
+		}
+	case filter != nil:
+		if isEmptyFilter(filter) {
+			return nil, nil, fmt.Errorf("过滤条件不能为空")
+		}
+		query, err := filterJobs(s.db.Model(&models.Job{}), filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := query.Order("jobs.name").Limit(maxBulkJobs + 1).Find(&found).Error; err != nil {
+			return nil, nil, fmt.Errorf("查询任务失败: %w", err)
+		}
+		if len(found) > maxBulkJobs {
+			return nil, nil, fmt.Errorf("匹配的任务超过 %d 个, 请缩小过滤范围", maxBulkJobs)
+		}
+		for _, job := range found {
+			ids = append(ids, job.ID)
+		}
+	default:
+		return nil, nil, fmt.Errorf("需要指定 job_ids 或 filter")
+	}
+
+	byID := make(map[string]*models.Job, len(found))
+	for i := range found {
+		byID[found[i].ID] = &found[i]
+	}
+
+	results := make([]*grpc.BulkJobResult, 0, len(ids))
+	jobs := make([]*models.Job, 0, len(ids))
+	for _, id := range ids {
+		job, ok := byID[id]
+		result := &grpc.BulkJobResult{JobId: id}
+		if ok {
+			result.JobName = job.Name
+		} else {
+			result.Error = fmt.Sprintf("任务不存在: %s", id)
+		}
+		results = append(results, result)
+		jobs = append(jobs, job)
+	}
+	return results, jobs, nil
+}
+
+// applyBulkAction 在事务中对单个任务执行操作
+func (s *Service) applyBulkAction(ctx context.Context, tx *gorm.DB, req *grpc.BulkJobOperationRequest, job *models.Job, result *grpc.BulkJobResult, operator string) error {
+	var updates map[string]interface{}
+	switch req.GetAction() {
+	case BulkEnable:
+		updates = map[string]interface{}{"enabled": true}
+	case BulkDisable:
+		updates = map[string]interface{}{"enabled": false}
+	case BulkPause:
+		var until *time.Time
+		if req.GetPausedUntil() != nil {
+			t := req.GetPausedUntil().AsTime()
+			until = &t
+		}
+		updates = map[string]interface{}{"paused": true, "paused_until": until}
+	case BulkResume:
+		updates = map[string]interface{}{"paused": false, "paused_until": nil}
+	case BulkMoveDepartment:
+		updates = map[string]interface{}{"department_id": req.GetDepartmentId()}
+	case BulkDelete:
+		if err := tx.Delete(&models.Job{}, "id = ?", job.ID).Error; err != nil {
+			return fmt.Errorf("删除任务失败: %w", err)
+		}
+	case BulkTrigger:
+		if !job.Enabled {
+			return fmt.Errorf("任务已禁用")
+		}
+		execution, err := createManualRun(ctx, tx, job)
+		if err != nil {
+			return err
+		}
+		result.ExecutionId = execution.ID
+	}
+
+	if updates != nil {
+		updates["updated_at"] = time.Now()
+		if err := tx.Model(&models.Job{}).Where("id = ?", job.ID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("更新任务失败: %w", err)
+		}
+
+		// 启用状态和部门属于任务定义, 记录版本
+		if req.GetAction() == BulkEnable || req.GetAction() == BulkDisable || req.GetAction() == BulkMoveDepartment {
+			if job.Revision == 0 {
+				if _, err := recordRevision(tx, job, models.RevisionChangeBaseline, job.CreatedBy, "", 0); err != nil {
+					return err
+				}
+			}
+			var updated models.Job
+			if err := tx.First(&updated, "id = ?", job.ID).Error; err != nil {
+				return fmt.Errorf("查询更新后的任务失败: %w", err)
+			}
+			if _, err := recordRevision(tx, &updated, models.RevisionChangeUpdate, operator, "批量操作: "+req.GetAction(), 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	result.Success = true
+	return nil
+}
+
+// recordBulkAudit 为批量操作写一条审计记录, 返回记录 ID, 写入失败时只记录日志
+func (s *Service) recordBulkAudit(req *grpc.BulkJobOperationRequest, resp *grpc.BulkJobOperationResponse, operator string) string {
+	ids := make([]string, 0, len(resp.Results))
+	for _, result := range resp.Results {
+		ids = append(ids, result.JobId)
+	}
+	idsJSON, _ := json.Marshal(ids)
+
+	detail := map[string]interface{}{
+		"atomic":    req.GetAtomic(),
+		"succeeded": resp.Succeeded,
+		"failed":    resp.Failed,
+		"results":   resp.Results,
+	}
+	if req.GetFilter() != nil {
+		detail["filter"] = req.GetFilter()
+	}
+	if req.GetDepartmentId() != "" {
+		detail["department_id"] = req.GetDepartmentId()
+	}
+	if req.GetPausedUntil() != nil {
+		detail["paused_until"] = req.GetPausedUntil().AsTime()
+	}
+	detailJSON, _ := json.Marshal(detail)
+
+	result := "success"
+	switch {
+	case resp.Succeeded == 0 && resp.Failed > 0:
+		result = "failed"
+	case resp.Failed > 0:
+		result = "partial"
+	}
+
+	audit := &models.AuditLog{
+		ID:           uuid.New().String(),
+		Action:       "job.bulk_" + req.GetAction(),
+		ResourceType: "job",
+		ResourceIDs:  string(idsJSON),
+		Detail:       string(detailJSON),
+		Result:       result,
+		Operator:     operator,
+	}
+	if err := s.db.Create(audit).Error; err != nil {
+		logger.WithError(err).Error("保存批量操作审计记录失败")
+		return ""
+	}
+	return audit.ID
+}
+
+// isEmptyFilter 过滤条件是否为空, 空条件会匹配全部任务
+func isEmptyFilter(filter *grpc.ListJobsRequest) bool {
+	return filter.GetKeyword() == "" && !filter.GetEnabled() && filter.GetDepartmentId() == "" &&
+		filter.GetCreatedBy() == "" && filter.GetLabelSelector() == "" && filter.GetState() == "" &&
+		filter.GetLastRunStatus() == "" && filter.GetScheduleType() == ""
+}
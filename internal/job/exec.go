@@ -0,0 +1,177 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-job/internal/jobtype"
+	"go-job/internal/models"
+	"go-job/internal/secret"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// umaskPattern 三位或四位八进制数
+var umaskPattern = regexp.MustCompile(`^0?[0-7]{3}$`)
+
+// execSettings 任务的执行方式
+type execSettings struct {
+	Type        models.JobType
+	Config      string
+	Mode        models.ExecMode
+	Command     string
+	Args        []string
+	Interpreter string
+	WorkDir     string
+	Umask       string
+	Limits      string
+	Sandbox     string
+}
+
+// normalize 校验执行方式, 未指定类型时为 command, 未指定模式时使用 shell
+func (e *execSettings) normalize() error {
+	e.Type = models.JobType(jobtype.Normalize(string(e.Type)))
+	e.Config = strings.TrimSpace(e.Config)
+	if err := jobtype.Validate(string(e.Type), e.Config); err != nil {
+		return err
+	}
+	if e.Mode == "" {
+		e.Mode = models.ExecModeShell
+	}
+
+	limits, err := jobtype.ParseLimits(e.Limits)
+	if err != nil {
+		return err
+	}
+	e.Limits = ""
+	if limits != nil && *limits != (jobtype.Limits{}) {
+		raw, _ := json.Marshal(limits)
+		e.Limits = string(raw)
+	}
+
+	sandbox, err := jobtype.ParseSandbox(e.Sandbox)
+	if err != nil {
+		return err
+	}
+	e.Sandbox = ""
+	if !sandbox.Empty() {
+		raw, _ := json.Marshal(sandbox)
+		e.Sandbox = string(raw)
+	}
+
+	// 其他类型的任务由 config 描述, 不使用命令相关的字段
+	if e.Type != models.JobTypeCommand {
+		if e.Command != "" || len(e.Args) > 0 || e.Interpreter != "" || e.WorkDir != "" || e.Umask != "" || e.Mode != models.ExecModeShell {
+			return fmt.Errorf("%s 类型的任务不使用 command、args 等命令相关的字段", e.Type)
+		}
+		if e.Limits != "" {
+			return fmt.Errorf("%s 类型的任务在工作节点进程内执行, 不支持资源限制", e.Type)
+		}
+		if e.Sandbox != "" {
+			return fmt.Errorf("%s 类型的任务在工作节点进程内执行, 不支持沙箱设置", e.Type)
+		}
+		return nil
+	}
+
+	switch e.Mode {
+	case models.ExecModeExec:
+		if strings.TrimSpace(e.Command) != e.Command || strings.ContainsAny(e.Command, " \t\n") {
+			return fmt.Errorf("exec 模式的 command 只能是程序路径, 参数请放在 args 中")
+		}
+		if e.Interpreter != "" {
+			return fmt.Errorf("exec 模式不使用解释器")
+		}
+	case models.ExecModeShell, models.ExecModeScript:
+	default:
+		return fmt.Errorf("无效的执行模式: %s, 可选 exec、shell 或 script", e.Mode)
+	}
+
+	if strings.TrimSpace(e.Command) == "" {
+		return fmt.Errorf("命令不能为空")
+	}
+	if e.WorkDir != "" && !filepath.IsAbs(e.WorkDir) {
+		return fmt.Errorf("工作目录必须是绝对路径: %s", e.WorkDir)
+	}
+	if e.WorkDir != "" && sandbox.UseTempWorkDir() {
+		return fmt.Errorf("work_dir 和沙箱设置 temp_work_dir 不能同时指定")
+	}
+	if e.Umask != "" && !umaskPattern.MatchString(e.Umask) {
+		return fmt.Errorf("无效的 umask: %s, 应为八进制数, 如 022", e.Umask)
+	}
+	return nil
+}
+
+// apply 将执行方式写入任务
+func (e *execSettings) apply(job *models.Job) {
+	job.Type = e.Type
+	job.Config = e.Config
+	job.Command = e.Command
+	job.ExecMode = e.Mode
+	job.Args = encodeArgs(e.Args)
+	job.Interpreter = e.Interpreter
+	job.WorkDir = e.WorkDir
+	job.Umask = e.Umask
+	job.Limits = e.Limits
+	job.Sandbox = e.Sandbox
+}
+
+// updates 转换为任务更新字段
+func (e *execSettings) updates() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        e.Type,
+		"config":      e.Config,
+		"command":     e.Command,
+		"exec_mode":   e.Mode,
+		"args":        encodeArgs(e.Args),
+		"interpreter": e.Interpreter,
+		"work_dir":    e.WorkDir,
+		"umask":       e.Umask,
+		"limits":      e.Limits,
+		"sandbox":     e.Sandbox,
+	}
+}
+
+// checkConnection 检查 sql 类型任务引用的数据库连接是否存在, 且任务所在的部门可以使用
+func (s *Service) checkConnection(e *execSettings, departmentID string) error {
+	if e.Type != models.JobTypeSQL {
+		return nil
+	}
+	cfg, err := jobtype.ParseSQL(e.Config)
+	if err != nil {
+		return err
+	}
+
+	_, err = secret.FindConnection(s.db, cfg.Connection, departmentID)
+	return err
+}
+
+// encodeArgs 将参数列表序列化为 JSON 数组, 空列表返回空字符串
+func encodeArgs(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	raw, _ := json.Marshal(args)
+	return string(raw)
+}
+
+// decodeArgs 解析任务的参数列表
+func decodeArgs(raw string) []string {
+	var args []string
+	if raw != "" {
+		json.Unmarshal([]byte(raw), &args)
+	}
+	return args
+}
+
+// typeOf 返回任务类型, 添加任务类型前创建的版本快照中为空, 按 command 处理
+func typeOf(jobType models.JobType) models.JobType {
+	return models.JobType(jobtype.Normalize(string(jobType)))
+}
+
+// execModeOf 返回任务的执行模式, 命令以外类型的任务在添加执行模式前创建的版本快照中为空, 按 shell 处理
+func execModeOf(mode models.ExecMode) models.ExecMode {
+	if mode == "" {
+		return models.ExecModeShell
+	}
+	return mode
+}
@@ -0,0 +1,168 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/models"
+	"go-job/pkg/labels"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// encodeLabels 校验并序列化标签, 没有标签时返回空字符串
+func encodeLabels(set map[string]string) (string, error) {
+	if len(set) == 0 {
+		return "", nil
+	}
+	if err := labels.Validate(set); err != nil {
+		return "", err
+	}
+	raw, _ := json.Marshal(set)
+	return string(raw), nil
+}
+
+// decodeLabels 解析任务的标签
+func decodeLabels(raw string) map[string]string {
+	set := make(map[string]string)
+	if raw != "" {
+		json.Unmarshal([]byte(raw), &set)
+	}
+	return set
+}
+
+// formatLabels 按键排序格式化标签, 用于版本对比
+func formatLabels(set map[string]string) string {
+	parts := make([]string, 0, len(set))
+	for _, key := range labels.Keys(set) {
+		parts = append(parts, key+"="+set[key])
+	}
+	return strings.Join(parts, ",")
+}
+
+// syncLabels 按任务当前的标签重建 job_labels 中的查询副本
+func syncLabels(tx *gorm.DB, jobID, raw string) error {
+	if err := tx.Where("job_id = ?", jobID).Delete(&models.JobLabel{}).Error; err != nil {
+		return fmt.Errorf("更新任务标签失败: %w", err)
+	}
+
+	set := decodeLabels(raw)
+	if len(set) == 0 {
+		return nil
+	}
+
+	rows := make([]models.JobLabel, 0, len(set))
+	for _, key := range labels.Keys(set) {
+		rows = append(rows, models.JobLabel{JobID: jobID, Name: key, Value: set[key]})
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("更新任务标签失败: %w", err)
+	}
+	return nil
+}
+
+// lastRunStatusSQL 任务最近一次执行的状态
+const lastRunStatusSQL = "(SELECT je.status FROM job_executions je WHERE je.job_id = jobs.id AND je.deleted_at IS NULL ORDER BY je.created_at DESC LIMIT 1)"
+
+// lastRunAtSQL 任务最近一次执行的创建时间
+const lastRunAtSQL = "(SELECT MAX(je.created_at) FROM job_executions je WHERE je.job_id = jobs.id AND je.deleted_at IS NULL)"
+
+// filterJobs 按列表请求中的条件过滤任务
+func filterJobs(query *gorm.DB, req *grpc.ListJobsRequest) (*gorm.DB, error) {
+	// 关键字搜索
+	if keyword := req.GetKeyword(); keyword != "" {
+		query = query.Where("jobs.name LIKE ? OR jobs.description LIKE ?", "%"+keyword+"%", "%"+keyword+"%")
+	}
+
+	// 启用状态过滤, enabled 为兼容旧客户端保留
+	switch req.GetState() {
+	case "":
+		if req.GetEnabled() {
+			query = query.Where("jobs.enabled = ?", true)
+		}
+	case "enabled":
+		query = query.Where("jobs.enabled = ?", true)
+	case "disabled":
+		query = query.Where("jobs.enabled = ?", false)
+	default:
+		return nil, fmt.Errorf("无效的启用状态: %s, 可选 enabled 或 disabled", req.GetState())
+	}
+
+	if departmentID := req.GetDepartmentId(); departmentID != "" {
+		query = query.Where("jobs.department_id = ?", departmentID)
+	}
+	if createdBy := req.GetCreatedBy(); createdBy != "" {
+		query = query.Where("jobs.created_by = ?", createdBy)
+	}
+
+	switch status := req.GetLastRunStatus(); status {
+	case "":
+	case "none":
+		query = query.Where("NOT EXISTS (SELECT 1 FROM job_executions je WHERE je.job_id = jobs.id AND je.deleted_at IS NULL)")
+	default:
+		query = query.Where(lastRunStatusSQL+" = ?", status)
+	}
+
+	switch req.GetScheduleType() {
+	case "":
+	case "cron":
+		query = query.Where("jobs.cron NOT LIKE ?", "%@every%")
+	case "interval":
+		query = query.Where("jobs.cron LIKE ?", "%@every%")
+	case "event":
+		query = query.Where("EXISTS (SELECT 1 FROM job_triggers jt WHERE jt.job_id = jobs.id AND jt.enabled = ? AND jt.deleted_at IS NULL)", true)
+	default:
+		return nil, fmt.Errorf("无效的调度类型: %s, 可选 cron、interval 或 event", req.GetScheduleType())
+	}
+
+	selector, err := labels.Parse(req.GetLabelSelector())
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range selector {
+		const exists = "EXISTS (SELECT 1 FROM job_labels jl WHERE jl.job_id = jobs.id AND jl.name = ?"
+		switch r.Operator {
+		case labels.Exists:
+			query = query.Where(exists+")", r.Key)
+		case labels.DoesNotExist:
+			query = query.Where("NOT "+exists+")", r.Key)
+		case labels.Equals, labels.In:
+			query = query.Where(exists+" AND jl.value IN ?)", r.Key, r.Values)
+		case labels.NotEquals, labels.NotIn:
+			query = query.Where("NOT "+exists+" AND jl.value IN ?)", r.Key, r.Values)
+		}
+	}
+
+	return query, nil
+}
+
+// sortJobs 按列表请求中的排序选项排序, 默认按创建时间倒序
+func sortJobs(query *gorm.DB, sortBy, sortOrder string) (*gorm.DB, error) {
+	direction := "DESC"
+	switch strings.ToLower(sortOrder) {
+	case "", "desc":
+	case "asc":
+		direction = "ASC"
+	default:
+		return nil, fmt.Errorf("无效的排序方向: %s, 可选 asc 或 desc", sortOrder)
+	}
+
+	var column string
+	switch sortBy {
+	case "", "created_at":
+		column = "jobs.created_at"
+	case "updated_at":
+		column = "jobs.updated_at"
+	case "name":
+		column = "jobs.name"
+	case "priority":
+		column = "jobs.priority"
+	case "last_run":
+		column = lastRunAtSQL
+	default:
+		return nil, fmt.Errorf("无效的排序字段: %s, 可选 name、created_at、updated_at、priority、last_run", sortBy)
+	}
+
+	return query.Order(column + " " + direction).Order("jobs.id"), nil
+}
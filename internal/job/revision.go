@@ -0,0 +1,452 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/models"
+	"go-job/internal/sla"
+	"go-job/pkg/cronexpr"
+	"go-job/pkg/logger"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// jobSnapshot 版本快照中保存的任务定义
+type jobSnapshot struct {
+	Name           string            `json:"name"`
+	Description    string            `json:"description"`
+	Cron           string            `json:"cron"`
+	Command        string            `json:"command"`
+	Params         map[string]string `json:"params"`
+	Type           models.JobType    `json:"type,omitempty"`
+	Config         string            `json:"config,omitempty"`
+	ExecMode       models.ExecMode   `json:"exec_mode,omitempty"`
+	Args           []string          `json:"args,omitempty"`
+	Interpreter    string            `json:"interpreter,omitempty"`
+	WorkDir        string            `json:"work_dir,omitempty"`
+	Umask          string            `json:"umask,omitempty"`
+	Limits         string            `json:"limits,omitempty"`
+	Sandbox        string            `json:"sandbox,omitempty"`
+	Enabled        bool              `json:"enabled"`
+	RetryAttempts  int               `json:"retry_attempts"`
+	Timeout        int               `json:"timeout"`
+	Priority       int               `json:"priority"`
+	DepartmentID   string            `json:"department_id"`
+	OnSuccess      []string          `json:"on_success"`
+	OnFailure      []string          `json:"on_failure"`
+	OnFinish       []string          `json:"on_finish"`
+	SLAStartBy     string            `json:"sla_start_by"`
+	SLAFinishBy    string            `json:"sla_finish_by"`
+	SLAMaxDuration int               `json:"sla_max_duration"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// snapshotOf 生成任务定义快照
+func snapshotOf(job *models.Job) jobSnapshot {
+	params := make(map[string]string)
+	if job.Params != "" {
+		json.Unmarshal([]byte(job.Params), &params)
+	}
+
+	return jobSnapshot{
+		Name:           job.Name,
+		Description:    job.Description,
+		Cron:           job.Cron,
+		Command:        job.Command,
+		Params:         params,
+		Type:           typeOf(job.Type),
+		Config:         job.Config,
+		ExecMode:       execModeOf(job.ExecMode),
+		Args:           decodeArgs(job.Args),
+		Interpreter:    job.Interpreter,
+		WorkDir:        job.WorkDir,
+		Umask:          job.Umask,
+		Limits:         job.Limits,
+		Sandbox:        job.Sandbox,
+		Enabled:        job.Enabled,
+		RetryAttempts:  job.RetryAttempts,
+		Timeout:        job.Timeout,
+		Priority:       job.Priority,
+		DepartmentID:   job.DepartmentID,
+		OnSuccess:      models.DecodeJobIDs(job.OnSuccess),
+		OnFailure:      models.DecodeJobIDs(job.OnFailure),
+		OnFinish:       models.DecodeJobIDs(job.OnFinish),
+		SLAStartBy:     job.SLAStartBy,
+		SLAFinishBy:    job.SLAFinishBy,
+		SLAMaxDuration: job.SLAMaxDuration,
+		Labels:         decodeLabels(job.Labels),
+	}
+}
+
+// updates 将快照转换为任务更新字段, 包含零值字段
+func (snap jobSnapshot) updates() map[string]interface{} {
+	paramsJSON, _ := json.Marshal(snap.Params)
+	labelsJSON, _ := encodeLabels(snap.Labels)
+	return map[string]interface{}{
+		"name":             snap.Name,
+		"description":      snap.Description,
+		"cron":             snap.Cron,
+		"command":          snap.Command,
+		"params":           string(paramsJSON),
+		"type":             typeOf(snap.Type),
+		"config":           snap.Config,
+		"exec_mode":        execModeOf(snap.ExecMode),
+		"args":             encodeArgs(snap.Args),
+		"interpreter":      snap.Interpreter,
+		"work_dir":         snap.WorkDir,
+		"umask":            snap.Umask,
+		"limits":           snap.Limits,
+		"sandbox":          snap.Sandbox,
+		"enabled":          snap.Enabled,
+		"retry_attempts":   snap.RetryAttempts,
+		"timeout":          snap.Timeout,
+		"priority":         snap.Priority,
+		"department_id":    snap.DepartmentID,
+		"on_success":       encodeJobIDs(snap.OnSuccess),
+		"on_failure":       encodeJobIDs(snap.OnFailure),
+		"on_finish":        encodeJobIDs(snap.OnFinish),
+		"sla_start_by":     snap.SLAStartBy,
+		"sla_finish_by":    snap.SLAFinishBy,
+		"sla_max_duration": snap.SLAMaxDuration,
+		"labels":           labelsJSON,
+		"updated_at":       time.Now(),
+	}
+}
+
+// fields 按固定顺序展开快照字段, 用于版本对比
+func (snap jobSnapshot) fields() [][2]string {
+	return [][2]string{
+		{"name", snap.Name},
+		{"description", snap.Description},
+		{"cron", snap.Cron},
+		{"command", snap.Command},
+		{"type", string(typeOf(snap.Type))},
+		{"config", snap.Config},
+		{"exec_mode", string(execModeOf(snap.ExecMode))},
+		{"args", encodeArgs(snap.Args)},
+		{"interpreter", snap.Interpreter},
+		{"work_dir", snap.WorkDir},
+		{"umask", snap.Umask},
+		{"limits", snap.Limits},
+		{"sandbox", snap.Sandbox},
+		{"enabled", strconv.FormatBool(snap.Enabled)},
+		{"retry_attempts", strconv.Itoa(snap.RetryAttempts)},
+		{"timeout", strconv.Itoa(snap.Timeout)},
+		{"priority", strconv.Itoa(snap.Priority)},
+		{"department_id", snap.DepartmentID},
+		{"on_success", strings.Join(snap.OnSuccess, ",")},
+		{"on_failure", strings.Join(snap.OnFailure, ",")},
+		{"on_finish", strings.Join(snap.OnFinish, ",")},
+		{"sla_start_by", snap.SLAStartBy},
+		{"sla_finish_by", snap.SLAFinishBy},
+		{"sla_max_duration", strconv.Itoa(snap.SLAMaxDuration)},
+		{"labels", formatLabels(snap.Labels)},
+	}
+}
+
+// encodeJobIDs 将任务 ID 列表序列化为 JSON 数组, 空列表返回空字符串
+func encodeJobIDs(ids []string) string {
+	if len(ids) == 0 {
+		return ""
+	}
+	raw, _ := json.Marshal(ids)
+	return string(raw)
+}
+
+// recordRevision 在事务中为任务保存新版本, 与最新版本内容相同时不重复保存
+func recordRevision(tx *gorm.DB, job *models.Job, changeType models.RevisionChangeType, createdBy, comment string, rollbackFrom int) (*models.JobRevision, error) {
+	raw, err := json.Marshal(snapshotOf(job))
+	if err != nil {
+		return nil, fmt.Errorf("序列化任务快照失败: %w", err)
+	}
+
+	var latest models.JobRevision
+	err = tx.Where("job_id = ?", job.ID).Order("revision DESC").First(&latest).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("查询任务版本失败: %w", err)
+	}
+	if err == nil && latest.Snapshot == string(raw) && changeType == models.RevisionChangeUpdate {
+		return &latest, nil
+	}
+
+	revision := &models.JobRevision{
+		ID:           uuid.New().String(),
+		JobID:        job.ID,
+		Revision:     latest.Revision + 1,
+		ChangeType:   changeType,
+		Snapshot:     string(raw),
+		RollbackFrom: rollbackFrom,
+		Comment:      comment,
+		CreatedBy:    createdBy,
+	}
+	if err := tx.Create(revision).Error; err != nil {
+		return nil, fmt.Errorf("保存任务版本失败: %w", err)
+	}
+
+	if err := tx.Model(&models.Job{}).Where("id = ?", job.ID).Update("revision", revision.Revision).Error; err != nil {
+		return nil, fmt.Errorf("更新任务版本号失败: %w", err)
+	}
+	job.Revision = revision.Revision
+
+	return revision, nil
+}
+
+// CurrentRevisionID 返回任务最新版本的 ID, 没有版本记录时返回空字符串
+func CurrentRevisionID(db *gorm.DB, jobID string) string {
+	var revision models.JobRevision
+	if err := db.Select("id").Where("job_id = ?", jobID).Order("revision DESC").First(&revision).Error; err != nil {
+		return ""
+	}
+	return revision.ID
+}
+
+// ListJobRevisions 获取任务版本列表, 按版本号倒序
+func (s *Service) ListJobRevisions(ctx context.Context, req *grpc.ListJobRevisionsRequest) (*grpc.ListJobRevisionsResponse, error) {
+	page := int(req.GetPage())
+	size := int(req.GetSize())
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 {
+		size = 20
+	}
+
+	query := s.db.Model(&models.JobRevision{}).Where("job_id = ?", req.GetJobId())
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("查询任务版本失败: %w", err)
+	}
+
+	var revisions []models.JobRevision
+	if err := query.Order("revision DESC").Offset((page - 1) * size).Limit(size).Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("查询任务版本失败: %w", err)
+	}
+
+	grpcRevisions := make([]*grpc.JobRevision, 0, len(revisions))
+	for i := range revisions {
+		grpcRevisions = append(grpcRevisions, revisionToGrpc(&revisions[i]))
+	}
+
+	return &grpc.ListJobRevisionsResponse{
+		Revisions: grpcRevisions,
+		Total:     total,
+	}, nil
+}
+
+// DiffJobRevisions 对比任务的两个版本
+func (s *Service) DiffJobRevisions(ctx context.Context, req *grpc.DiffJobRevisionsRequest) (*grpc.DiffJobRevisionsResponse, error) {
+	from, err := s.getRevision(req.GetJobId(), int(req.GetFromRevision()))
+	if err != nil {
+		return nil, err
+	}
+
+	toRevision := int(req.GetToRevision())
+	if toRevision == 0 {
+		var job models.Job
+		if err := s.db.Select("id", "revision").First(&job, "id = ?", req.GetJobId()).Error; err != nil {
+			return nil, fmt.Errorf("任务不存在: %s", req.GetJobId())
+		}
+		toRevision = job.Revision
+	}
+	to, err := s.getRevision(req.GetJobId(), toRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpc.DiffJobRevisionsResponse{
+		FromRevision: int32(from.Revision),
+		ToRevision:   int32(to.Revision),
+		Changes:      diffSnapshots(decodeSnapshot(from.Snapshot), decodeSnapshot(to.Snapshot)),
+	}, nil
+}
+
+// RollbackJob 将任务定义回滚到指定版本, 回滚本身会生成一个新版本
+func (s *Service) RollbackJob(ctx context.Context, req *grpc.RollbackJobRequest) (*grpc.RollbackJobResponse, error) {
+	logger.Infof("回滚任务: %s 到版本 %d", req.GetJobId(), req.GetRevision())
+
+	target, err := s.getRevision(req.GetJobId(), int(req.GetRevision()))
+	if err != nil {
+		return nil, err
+	}
+	snap := decodeSnapshot(target.Snapshot)
+
+	// 回滚前重新校验, 避免恢复已经失效的配置
+	if err := cronexpr.Validate(snap.Cron); err != nil {
+		return nil, fmt.Errorf("目标版本的 Cron 表达式无效: %w", err)
+	}
+	if err := sla.Validate(snap.SLAStartBy, snap.SLAFinishBy, snap.SLAMaxDuration); err != nil {
+		return nil, err
+	}
+	execution := execSettings{
+		Type:        snap.Type,
+		Config:      snap.Config,
+		Mode:        snap.ExecMode,
+		Command:     snap.Command,
+		Args:        snap.Args,
+		Interpreter: snap.Interpreter,
+		WorkDir:     snap.WorkDir,
+		Umask:       snap.Umask,
+		Limits:      snap.Limits,
+		Sandbox:     snap.Sandbox,
+	}
+	if err := execution.normalize(); err != nil {
+		return nil, fmt.Errorf("目标版本的执行方式无效: %w", err)
+	}
+	if _, err := s.encodeChainHooks(req.GetJobId(), snap.OnSuccess, snap.OnFailure, snap.OnFinish); err != nil {
+		return nil, fmt.Errorf("目标版本的链式触发配置无效: %w", err)
+	}
+
+	var job models.Job
+	var revision *models.JobRevision
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Job{}).Where("id = ?", req.GetJobId()).Updates(snap.updates()).Error; err != nil {
+			return fmt.Errorf("回滚任务失败: %w", err)
+		}
+		if err := tx.First(&job, "id = ?", req.GetJobId()).Error; err != nil {
+			return fmt.Errorf("查询回滚后的任务失败: %w", err)
+		}
+		if err := syncLabels(tx, job.ID, job.Labels); err != nil {
+			return err
+		}
+
+		comment := req.GetComment()
+		if comment == "" {
+			comment = fmt.Sprintf("回滚到版本 %d", target.Revision)
+		}
+		revision, err = recordRevision(tx, &job, models.RevisionChangeRollback, getUserFromContext(ctx), comment, target.Revision)
+		return err
+	})
+	if err != nil {
+		logger.WithError(err).Errorf("回滚任务失败: %s", req.GetJobId())
+		return nil, err
+	}
+
+	logger.Infof("任务回滚成功: %s (版本 %d -> %d)", job.ID, target.Revision, revision.Revision)
+
+	return &grpc.RollbackJobResponse{
+		Job:      s.modelToGrpc(&job),
+		Revision: revisionToGrpc(revision),
+	}, nil
+}
+
+// getRevision 查询任务的指定版本
+func (s *Service) getRevision(jobID string, number int) (*models.JobRevision, error) {
+	var revision models.JobRevision
+	if err := s.db.First(&revision, "job_id = ? AND revision = ?", jobID, number).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("任务版本不存在: %s@%d", jobID, number)
+		}
+		return nil, fmt.Errorf("查询任务版本失败: %w", err)
+	}
+	return &revision, nil
+}
+
+// decodeSnapshot 解析版本快照
+func decodeSnapshot(raw string) jobSnapshot {
+	var snap jobSnapshot
+	json.Unmarshal([]byte(raw), &snap)
+	// 添加执行模式前的命令任务直接执行拆分后的命令, 与迁移后的任务保持一致
+	if snap.ExecMode == "" && typeOf(snap.Type) == models.JobTypeCommand {
+		snap.ExecMode = models.ExecModeExec
+		snap.Command, snap.Args = models.SplitLegacyCommand(snap.Command)
+	}
+	return snap
+}
+
+// diffSnapshots 对比两个快照, 参数按键逐个对比
+func diffSnapshots(from, to jobSnapshot) []*grpc.FieldChange {
+	var changes []*grpc.FieldChange
+
+	toFields := to.fields()
+	for i, field := range from.fields() {
+		if field[1] != toFields[i][1] {
+			changes = append(changes, &grpc.FieldChange{
+				Field:    field[0],
+				OldValue: field[1],
+				NewValue: toFields[i][1],
+			})
+		}
+	}
+
+	keys := make(map[string]bool)
+	for key := range from.Params {
+		keys[key] = true
+	}
+	for key := range to.Params {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		oldValue, newValue := from.Params[key], to.Params[key]
+		if oldValue != newValue {
+			changes = append(changes, &grpc.FieldChange{
+				Field:    "params." + key,
+				OldValue: oldValue,
+				NewValue: newValue,
+			})
+		}
+	}
+
+	return changes
+}
+
+// revisionToGrpc 将版本模型转换为 gRPC 消息
+func revisionToGrpc(revision *models.JobRevision) *grpc.JobRevision {
+	snap := decodeSnapshot(revision.Snapshot)
+
+	return &grpc.JobRevision{
+		Id:         revision.ID,
+		JobId:      revision.JobID,
+		Revision:   int32(revision.Revision),
+		ChangeType: string(revision.ChangeType),
+		Snapshot: &grpc.Job{
+			Id:              revision.JobID,
+			Name:            snap.Name,
+			Description:     snap.Description,
+			Cron:            snap.Cron,
+			CronDescription: describeCron(snap.Cron),
+			Command:         snap.Command,
+			Params:          snap.Params,
+			Type:            string(typeOf(snap.Type)),
+			Config:          snap.Config,
+			ExecMode:        string(execModeOf(snap.ExecMode)),
+			Args:            snap.Args,
+			Interpreter:     snap.Interpreter,
+			WorkDir:         snap.WorkDir,
+			Umask:           snap.Umask,
+			Limits:          snap.Limits,
+			Sandbox:         snap.Sandbox,
+			Enabled:         snap.Enabled,
+			RetryAttempts:   int32(snap.RetryAttempts),
+			Timeout:         int32(snap.Timeout),
+			Priority:        int32(snap.Priority),
+			DepartmentId:    snap.DepartmentID,
+			OnSuccess:       snap.OnSuccess,
+			OnFailure:       snap.OnFailure,
+			OnFinish:        snap.OnFinish,
+			SlaStartBy:      snap.SLAStartBy,
+			SlaFinishBy:     snap.SLAFinishBy,
+			SlaMaxDuration:  int32(snap.SLAMaxDuration),
+			Labels:          snap.Labels,
+			Revision:        int32(revision.Revision),
+		},
+		RollbackFrom: int32(revision.RollbackFrom),
+		Comment:      revision.Comment,
+		CreatedBy:    revision.CreatedBy,
+		CreatedAt:    timestamppb.New(revision.CreatedAt),
+	}
+}
@@ -0,0 +1,167 @@
+package job
+
+import (
+	"encoding/json"
+	"go-job/api/grpc"
+	"go-job/internal/models"
+	"reflect"
+	"testing"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	base := jobSnapshot{
+		Name:          "report",
+		Cron:          "0 2 * * *",
+		Command:       "./report.sh",
+		Type:          models.JobTypeCommand,
+		ExecMode:      models.ExecModeShell,
+		Params:        map[string]string{"REGION": "cn", "DAYS": "7"},
+		Enabled:       true,
+		RetryAttempts: 3,
+		Timeout:       300,
+		OnSuccess:     []string{"job-a"},
+		Labels:        map[string]string{"team": "bi"},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*jobSnapshot)
+		want   []*grpc.FieldChange
+	}{
+		{"没有变化", func(*jobSnapshot) {}, nil},
+		{
+			"字段按固定顺序输出",
+			func(s *jobSnapshot) {
+				s.Timeout = 600
+				s.Cron = "0 3 * * *"
+				s.Enabled = false
+			},
+			[]*grpc.FieldChange{
+				{Field: "cron", OldValue: "0 2 * * *", NewValue: "0 3 * * *"},
+				{Field: "enabled", OldValue: "true", NewValue: "false"},
+				{Field: "timeout", OldValue: "300", NewValue: "600"},
+			},
+		},
+		{
+			"参数按键排序对比",
+			func(s *jobSnapshot) {
+				s.Params = map[string]string{"REGION": "us", "BATCH": "10"}
+			},
+			[]*grpc.FieldChange{
+				{Field: "params.BATCH", OldValue: "", NewValue: "10"},
+				{Field: "params.DAYS", OldValue: "7", NewValue: ""},
+				{Field: "params.REGION", OldValue: "cn", NewValue: "us"},
+			},
+		},
+		{
+			"列表、参数和标签",
+			func(s *jobSnapshot) {
+				s.Args = []string{"--full"}
+				s.OnSuccess = []string{"job-a", "job-b"}
+				s.Labels = map[string]string{"team": "bi", "env": "prod"}
+			},
+			[]*grpc.FieldChange{
+				{Field: "args", OldValue: "", NewValue: `["--full"]`},
+				{Field: "on_success", OldValue: "job-a", NewValue: "job-a,job-b"},
+				{Field: "labels", OldValue: "team=bi", NewValue: "env=prod,team=bi"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to := base
+			tt.modify(&to)
+			if got := diffSnapshots(base, to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffSnapshots = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	job := &models.Job{
+		Name:          "sync",
+		Cron:          "*/5 * * * *",
+		Command:       "/usr/bin/rsync",
+		Params:        `{"TARGET":"backup"}`,
+		Type:          models.JobTypeCommand,
+		ExecMode:      models.ExecModeExec,
+		Args:          `["-a","/data/"]`,
+		Enabled:       false,
+		RetryAttempts: 0,
+		Timeout:       60,
+		OnFailure:     `["job-alert"]`,
+		Labels:        `{"team":"ops"}`,
+	}
+	snap := snapshotOf(job)
+	raw, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatalf("序列化快照失败: %v", err)
+	}
+	decoded := decodeSnapshot(string(raw))
+	if changes := diffSnapshots(snap, decoded); len(changes) > 0 {
+		t.Errorf("快照解析后与原快照不一致: %v", changes)
+	}
+
+	// 回滚时零值字段同样需要写回
+	updates := decoded.updates()
+	for field, want := range map[string]interface{}{
+		"enabled":        false,
+		"retry_attempts": 0,
+		"description":    "",
+		"exec_mode":      models.ExecModeExec,
+		"args":           `["-a","/data/"]`,
+		"on_failure":     `["job-alert"]`,
+		"on_success":     "",
+	} {
+		if got := updates[field]; !reflect.DeepEqual(got, want) {
+			t.Errorf("updates[%s] = %#v, 期望 %#v", field, got, want)
+		}
+	}
+}
+
+func TestDecodeLegacySnapshot(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		wantType models.JobType
+		wantMode models.ExecMode
+		command  string
+		args     []string
+	}{
+		{
+			"添加任务类型和执行模式前的快照按旧方式拆分命令",
+			`{"name":"a","command":"backup.sh  --full /data"}`,
+			models.JobTypeCommand, models.ExecModeExec, "backup.sh", []string{"--full", "/data"},
+		},
+		{
+			"只有单个程序",
+			`{"name":"a","type":"command","command":"true"}`,
+			models.JobTypeCommand, models.ExecModeExec, "true", []string{},
+		},
+		{
+			"已有执行模式时保持不变",
+			`{"name":"a","command":"echo $HOME","exec_mode":"shell"}`,
+			models.JobTypeCommand, models.ExecModeShell, "echo $HOME", nil,
+		},
+		{
+			"其他类型不拆分命令",
+			`{"name":"a","type":"http","config":"{}"}`,
+			models.JobTypeHTTP, "", "", nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap := decodeSnapshot(tt.raw)
+			if got := typeOf(snap.Type); got != tt.wantType {
+				t.Errorf("type = %q, 期望 %q", got, tt.wantType)
+			}
+			if snap.ExecMode != tt.wantMode {
+				t.Errorf("exec_mode = %q, 期望 %q", snap.ExecMode, tt.wantMode)
+			}
+			if snap.Command != tt.command || !reflect.DeepEqual(snap.Args, tt.args) {
+				t.Errorf("command, args = %q, %q, 期望 %q, %q", snap.Command, snap.Args, tt.command, tt.args)
+			}
+		})
+	}
+}
@@ -3,12 +3,15 @@ package job
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go-job/api/grpc"
 	"go-job/internal/models"
+	"go-job/internal/sla"
+	"go-job/pkg/cronexpr"
 	"go-job/pkg/database"
 	"go-job/pkg/logger"
-	"strconv"
+	"go-job/pkg/tracing"
 	"strings"
 	"time"
 
@@ -34,30 +37,10 @@ func NewService() *Service {
 func (s *Service) CreateJob(ctx context.Context, req *grpc.CreateJobRequest) (*grpc.CreateJobResponse, error) {
 	logger.Infof("创建任务: %s", req.GetName())
 
-	// 验证 Cron 表达式
-	if err := validateCron(req.GetCron()); err != nil {
-		return nil, fmt.Errorf("无效的 Cron 表达式: %w", err)
-	}
-
-	// 转换参数为 JSON
-	paramsJSON, _ := json.Marshal(req.GetParams())
-
-	job := &models.Job{
-		ID:            uuid.New().String(),
-		Name:          req.GetName(),
-		Description:   req.GetDescription(),
-		Cron:          req.GetCron(),
-		Command:       req.GetCommand(),
-		Params:        string(paramsJSON),
-		Enabled:       true,
-		RetryAttempts: int(req.GetRetryAttempts()),
-		Timeout:       int(req.GetTimeout()),
-		CreatedBy:     getUserFromContext(ctx), // 从上下文获取用户信息
-	}
-
-	if err := s.db.Create(job).Error; err != nil {
+	job, err := s.createJob(ctx, req, nil, "")
+	if err != nil {
 		logger.WithError(err).Error("创建任务失败")
-		return nil, fmt.Errorf("创建任务失败: %w", err)
+		return nil, err
 	}
 
 	// 转换为 gRPC 消息
@@ -70,6 +53,91 @@ func (s *Service) CreateJob(ctx context.Context, req *grpc.CreateJobRequest) (*g
 	}, nil
 }
 
+// createJob 校验任务定义并在同一事务中保存任务、标签和首个版本
+//
+// prepare 在保存前补充请求中没有的字段, 例如模板来源; comment 为首个版本的说明。
+func (s *Service) createJob(ctx context.Context, req *grpc.CreateJobRequest, prepare func(job *models.Job), comment string) (*models.Job, error) {
+	// 验证 Cron 表达式
+	if err := cronexpr.Validate(req.GetCron()); err != nil {
+		return nil, fmt.Errorf("无效的 Cron 表达式: %w", err)
+	}
+
+	// 转换参数为 JSON
+	paramsJSON, _ := json.Marshal(req.GetParams())
+
+	if err := sla.Validate(req.GetSlaStartBy(), req.GetSlaFinishBy(), int(req.GetSlaMaxDuration())); err != nil {
+		return nil, err
+	}
+
+	execution := execSettings{
+		Type:        models.JobType(req.GetType()),
+		Config:      req.GetConfig(),
+		Mode:        models.ExecMode(req.GetExecMode()),
+		Command:     req.GetCommand(),
+		Args:        req.GetArgs(),
+		Interpreter: req.GetInterpreter(),
+		WorkDir:     req.GetWorkDir(),
+		Umask:       req.GetUmask(),
+		Limits:      req.GetLimits(),
+		Sandbox:     req.GetSandbox(),
+	}
+	if err := execution.normalize(); err != nil {
+		return nil, err
+	}
+
+	jobID := uuid.New().String()
+	hooks, err := s.encodeChainHooks(jobID, req.GetOnSuccess(), req.GetOnFailure(), req.GetOnFinish())
+	if err != nil {
+		return nil, err
+	}
+	labelsJSON, err := encodeLabels(req.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.Job{
+		ID:             jobID,
+		Name:           req.GetName(),
+		Description:    req.GetDescription(),
+		Cron:           req.GetCron(),
+		Command:        req.GetCommand(),
+		Params:         string(paramsJSON),
+		Enabled:        true,
+		RetryAttempts:  int(req.GetRetryAttempts()),
+		Timeout:        int(req.GetTimeout()),
+		OnSuccess:      hooks[0],
+		OnFailure:      hooks[1],
+		OnFinish:       hooks[2],
+		SLAStartBy:     req.GetSlaStartBy(),
+		SLAFinishBy:    req.GetSlaFinishBy(),
+		SLAMaxDuration: int(req.GetSlaMaxDuration()),
+		Labels:         labelsJSON,
+		CreatedBy:      getUserFromContext(ctx), // 从上下文获取用户信息
+	}
+	execution.apply(job)
+	if prepare != nil {
+		prepare(job)
+	}
+	if err := s.checkConnection(&execution, job.DepartmentID); err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(job).Error; err != nil {
+			return fmt.Errorf("创建任务失败: %w", err)
+		}
+		if err := syncLabels(tx, job.ID, job.Labels); err != nil {
+			return err
+		}
+		_, err := recordRevision(tx, job, models.RevisionChangeCreate, job.CreatedBy, comment, 0)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
 // GetJob 获取任务
 func (s *Service) GetJob(ctx context.Context, req *grpc.GetJobRequest) (*grpc.GetJobResponse, error) {
 	var job models.Job
@@ -98,16 +166,9 @@ func (s *Service) ListJobs(ctx context.Context, req *grpc.ListJobsRequest) (*grp
 		size = 10
 	}
 
-	query := s.db.Model(&models.Job{})
-
-	// 关键字搜索
-	if keyword := req.GetKeyword(); keyword != "" {
-		query = query.Where("name LIKE ? OR description LIKE ?", "%"+keyword+"%", "%"+keyword+"%")
-	}
-
-	// 启用状态过滤
-	if req.GetEnabled() {
-		query = query.Where("enabled = ?", true)
+	query, err := filterJobs(s.db.Model(&models.Job{}), req)
+	if err != nil {
+		return nil, err
 	}
 
 	// 获取总数
@@ -119,7 +180,11 @@ func (s *Service) ListJobs(ctx context.Context, req *grpc.ListJobsRequest) (*grp
 	// 分页查询
 	var jobs []models.Job
 	offset := (page - 1) * size
-	if err := query.Offset(int(offset)).Limit(int(size)).Order("created_at DESC").Find(&jobs).Error; err != nil {
+	query, err = sortJobs(query, req.GetSortBy(), req.GetSortOrder())
+	if err != nil {
+		return nil, err
+	}
+	if err := query.Offset(int(offset)).Limit(int(size)).Find(&jobs).Error; err != nil {
 		return nil, fmt.Errorf("查询任务列表失败: %w", err)
 	}
 
@@ -140,7 +205,7 @@ func (s *Service) UpdateJob(ctx context.Context, req *grpc.UpdateJobRequest) (*g
 	logger.Infof("更新任务: %s", req.GetId())
 
 	// 验证 Cron 表达式
-	if err := validateCron(req.GetCron()); err != nil {
+	if err := cronexpr.Validate(req.GetCron()); err != nil {
 		return nil, fmt.Errorf("无效的 Cron 表达式: %w", err)
 	}
 
@@ -156,27 +221,86 @@ func (s *Service) UpdateJob(ctx context.Context, req *grpc.UpdateJobRequest) (*g
 	// 转换参数为 JSON
 	paramsJSON, _ := json.Marshal(req.GetParams())
 
+	if err := sla.Validate(req.GetSlaStartBy(), req.GetSlaFinishBy(), int(req.GetSlaMaxDuration())); err != nil {
+		return nil, err
+	}
+
+	execution := execSettings{
+		Type:        models.JobType(req.GetType()),
+		Config:      req.GetConfig(),
+		Mode:        models.ExecMode(req.GetExecMode()),
+		Command:     req.GetCommand(),
+		Args:        req.GetArgs(),
+		Interpreter: req.GetInterpreter(),
+		WorkDir:     req.GetWorkDir(),
+		Umask:       req.GetUmask(),
+		Limits:      req.GetLimits(),
+		Sandbox:     req.GetSandbox(),
+	}
+	if err := execution.normalize(); err != nil {
+		return nil, err
+	}
+	if err := s.checkConnection(&execution, job.DepartmentID); err != nil {
+		return nil, err
+	}
+
+	hooks, err := s.encodeChainHooks(job.ID, req.GetOnSuccess(), req.GetOnFailure(), req.GetOnFinish())
+	if err != nil {
+		return nil, err
+	}
+	labelsJSON, err := encodeLabels(req.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+
 	// 更新字段
 	updates := map[string]interface{}{
-		"name":           req.GetName(),
-		"description":    req.GetDescription(),
-		"cron":           req.GetCron(),
-		"command":        req.GetCommand(),
-		"params":         string(paramsJSON),
-		"enabled":        req.GetEnabled(),
-		"retry_attempts": req.GetRetryAttempts(),
-		"timeout":        req.GetTimeout(),
-		"updated_at":     time.Now(),
-	}
-
-	if err := s.db.Model(&job).Updates(updates).Error; err != nil {
-		logger.WithError(err).Error("更新任务失败")
-		return nil, fmt.Errorf("更新任务失败: %w", err)
-	}
+		"name":             req.GetName(),
+		"description":      req.GetDescription(),
+		"cron":             req.GetCron(),
+		"params":           string(paramsJSON),
+		"enabled":          req.GetEnabled(),
+		"retry_attempts":   req.GetRetryAttempts(),
+		"timeout":          req.GetTimeout(),
+		"on_success":       hooks[0],
+		"on_failure":       hooks[1],
+		"on_finish":        hooks[2],
+		"sla_start_by":     req.GetSlaStartBy(),
+		"sla_finish_by":    req.GetSlaFinishBy(),
+		"sla_max_duration": req.GetSlaMaxDuration(),
+		"labels":           labelsJSON,
+		"updated_at":       time.Now(),
+	}
+	for key, value := range execution.updates() {
+		updates[key] = value
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// 启用版本记录前创建的任务, 先保存修改前的定义作为基线版本
+		if job.Revision == 0 {
+			if _, err := recordRevision(tx, &job, models.RevisionChangeBaseline, job.CreatedBy, "", 0); err != nil {
+				return err
+			}
+		}
 
-	// 重新查询更新后的任务
-	if err := s.db.First(&job, "id = ?", req.GetId()).Error; err != nil {
-		return nil, fmt.Errorf("查询更新后的任务失败: %w", err)
+		if err := tx.Model(&job).Updates(updates).Error; err != nil {
+			return fmt.Errorf("更新任务失败: %w", err)
+		}
+		if err := syncLabels(tx, job.ID, labelsJSON); err != nil {
+			return err
+		}
+
+		// 重新查询更新后的任务
+		if err := tx.First(&job, "id = ?", req.GetId()).Error; err != nil {
+			return fmt.Errorf("查询更新后的任务失败: %w", err)
+		}
+
+		_, err := recordRevision(tx, &job, models.RevisionChangeUpdate, getUserFromContext(ctx), "", 0)
+		return err
+	})
+	if err != nil {
+		logger.WithError(err).Error("更新任务失败")
+		return nil, err
 	}
 
 	grpcJob := s.modelToGrpc(&job)
@@ -223,15 +347,33 @@ func (s *Service) TriggerJob(ctx context.Context, req *grpc.TriggerJobRequest) (
 		return nil, fmt.Errorf("查询任务失败: %w", err)
 	}
 
+	execution, err := createManualRun(ctx, s.db, &job)
+	if err != nil {
+		logger.WithError(err).Error("手动触发任务失败")
+		return nil, err
+	}
+
+	logger.Infof("任务手动触发成功: %s (执行ID: %s)", job.ID, execution.ID)
+
+	return &grpc.TriggerJobResponse{
+		ExecutionId: execution.ID,
+	}, nil
+}
+
+// createManualRun 为手动触发创建执行记录和调度记录, 运行沿用请求的追踪上下文
+func createManualRun(ctx context.Context, tx *gorm.DB, job *models.Job) (*models.JobExecution, error) {
+	span := tracing.SpanFromContext(ctx)
+
 	// 创建执行记录
 	execution := &models.JobExecution{
-		ID:     uuid.New().String(),
-		JobID:  job.ID,
-		Status: models.ExecutionStatusPending,
+		ID:         uuid.New().String(),
+		JobID:      job.ID,
+		Status:     models.ExecutionStatusPending,
+		RevisionID: CurrentRevisionID(tx, job.ID),
+		TraceID:    span.TraceID(),
 	}
 
-	if err := s.db.Create(execution).Error; err != nil {
-		logger.WithError(err).Error("创建执行记录失败")
+	if err := tx.Create(execution).Error; err != nil {
 		return nil, fmt.Errorf("创建执行记录失败: %w", err)
 	}
 
@@ -242,17 +384,48 @@ func (s *Service) TriggerJob(ctx context.Context, req *grpc.TriggerJobRequest) (
 		ScheduledAt: time.Now(),
 		Status:      models.ScheduleStatusPending,
 		ExecutionID: execution.ID,
+		TraceParent: span.Traceparent(),
 	}
 
-	if err := s.db.Create(schedule).Error; err != nil {
-		logger.WithError(err).Error("创建调度记录失败")
+	if err := tx.Create(schedule).Error; err != nil {
 		return nil, fmt.Errorf("创建调度记录失败: %w", err)
 	}
 
-	logger.Infof("任务手动触发成功: %s (执行ID: %s)", job.ID, execution.ID)
+	return execution, nil
+}
 
-	return &grpc.TriggerJobResponse{
-		ExecutionId: execution.ID,
+// ValidateCron 校验 Cron 表达式并返回可读描述和接下来的运行时间
+func (s *Service) ValidateCron(ctx context.Context, req *grpc.ValidateCronRequest) (*grpc.ValidateCronResponse, error) {
+	expr, err := cronexpr.Parse(req.GetCron())
+	if err != nil {
+		resp := &grpc.ValidateCronResponse{
+			Valid: false,
+			Error: err.Error(),
+		}
+		var fieldErr *cronexpr.FieldError
+		if errors.As(err, &fieldErr) {
+			resp.ErrorPosition = int32(fieldErr.Position)
+			resp.ErrorField = fieldErr.Field
+		}
+		return resp, nil
+	}
+
+	count := int(req.GetNextCount())
+	if count <= 0 {
+		count = 5
+	} else if count > 50 {
+		count = 50
+	}
+
+	var nextRuns []*timestamppb.Timestamp
+	for _, t := range expr.Next(time.Now(), count) {
+		nextRuns = append(nextRuns, timestamppb.New(t))
+	}
+
+	return &grpc.ValidateCronResponse{
+		Valid:       true,
+		Description: expr.Describe(req.GetLang()),
+		NextRuns:    nextRuns,
 	}, nil
 }
 
@@ -262,194 +435,92 @@ func (s *Service) modelToGrpc(job *models.Job) *grpc.Job {
 	if job.Params != "" {
 		json.Unmarshal([]byte(job.Params), &params)
 	}
-
-	return &grpc.Job{
-		Id:            job.ID,
-		Name:          job.Name,
-		Description:   job.Description,
-		Cron:          job.Cron,
-		Command:       job.Command,
-		Params:        params,
-		Enabled:       job.Enabled,
-		RetryAttempts: int32(job.RetryAttempts),
-		Timeout:       int32(job.Timeout),
-		CreatedAt:     timestamppb.New(job.CreatedAt),
-		UpdatedAt:     timestamppb.New(job.UpdatedAt),
-		CreatedBy:     job.CreatedBy,
-	}
-}
-
-// validateCron 验证 Cron 表达式
-func validateCron(cronExpr string) error {
-	// 详细的 Cron 表达式验证
-	if cronExpr == "" {
-		return fmt.Errorf("cron 表达式不能为空")
-	}
-
-	// 使用 cron 库验证表达式
-	// 支持标准的 5 字段格式: 分 时 日 月 周
-	// 也支持扩展的 6 字段格式: 秒 分 时 日 月 周
-	fields := strings.Fields(cronExpr)
-
-	// 检查字段数量
-	if len(fields) != 5 && len(fields) != 6 {
-		return fmt.Errorf("cron 表达式必须包含 5 或 6 个字段，当前有 %d 个字段", len(fields))
+	var templateValues map[string]string
+	if job.TemplateValues != "" {
+		json.Unmarshal([]byte(job.TemplateValues), &templateValues)
 	}
-
-	// 基本字段验证
-	for i, field := range fields {
-		if strings.TrimSpace(field) == "" {
-			return fmt.Errorf("第 %d 个字段不能为空", i+1)
-		}
-
-		// 检查特殊字符
-		if !isValidCronField(field) {
-			return fmt.Errorf("第 %d 个字段包含无效字符: %s", i+1, field)
-		}
+	var pausedUntil *timestamppb.Timestamp
+	if job.PausedUntil != nil {
+		pausedUntil = timestamppb.New(*job.PausedUntil)
 	}
 
-	// 基本范围验证
-	if len(fields) == 5 {
-		// 标准格式: 分 时 日 月 周
-		ranges := []struct {
-			min, max int
-			name     string
-		}{
-			{0, 59, "分钟"},
-			{0, 23, "小时"},
-			{1, 31, "日"},
-			{1, 12, "月"},
-			{0, 7, "周"}, // 0 和 7 都表示周日
-		}
-
-		for i, r := range ranges {
-			if err := validateCronFieldRange(fields[i], r.min, r.max, r.name); err != nil {
-				return err
-			}
-		}
-	} else {
-		// 6字段格式: 秒 分 时 日 月 周
-		ranges := []struct {
-			min, max int
-			name     string
-		}{
-			{0, 59, "秒"},
-			{0, 59, "分钟"},
-			{0, 23, "小时"},
-			{1, 31, "日"},
-			{1, 12, "月"},
-			{0, 7, "周"}, // 0 和 7 都表示周日
-		}
-
-		for i, r := range ranges {
-			if err := validateCronFieldRange(fields[i], r.min, r.max, r.name); err != nil {
-				return err
-			}
-		}
+	return &grpc.Job{
+		Id:              job.ID,
+		Name:            job.Name,
+		Description:     job.Description,
+		Cron:            job.Cron,
+		Command:         job.Command,
+		Params:          params,
+		Type:            string(typeOf(job.Type)),
+		Config:          job.Config,
+		ExecMode:        string(execModeOf(job.ExecMode)),
+		Args:            decodeArgs(job.Args),
+		Interpreter:     job.Interpreter,
+		WorkDir:         job.WorkDir,
+		Umask:           job.Umask,
+		Limits:          job.Limits,
+		Sandbox:         job.Sandbox,
+		Enabled:         job.Enabled,
+		RetryAttempts:   int32(job.RetryAttempts),
+		Timeout:         int32(job.Timeout),
+		CreatedAt:       timestamppb.New(job.CreatedAt),
+		UpdatedAt:       timestamppb.New(job.UpdatedAt),
+		CreatedBy:       job.CreatedBy,
+		OnSuccess:       models.DecodeJobIDs(job.OnSuccess),
+		OnFailure:       models.DecodeJobIDs(job.OnFailure),
+		OnFinish:        models.DecodeJobIDs(job.OnFinish),
+		SlaStartBy:      job.SLAStartBy,
+		SlaFinishBy:     job.SLAFinishBy,
+		SlaMaxDuration:  int32(job.SLAMaxDuration),
+		Revision:        int32(job.Revision),
+		TemplateId:      job.TemplateID,
+		TemplateValues:  templateValues,
+		TemplateVersion: int32(job.TemplateVersion),
+		ManagedBy:       job.ManagedBy,
+		Labels:          decodeLabels(job.Labels),
+		Paused:          job.Paused,
+		PausedUntil:     pausedUntil,
 	}
-
-	return nil
 }
 
-// isValidCronField 检查字段是否包含有效的 cron 字符
-func isValidCronField(field string) bool {
-	// 允许的字符: 数字、*、?、-、,、/、L、W、#
-	validChars := "0123456789*?-,/LW#"
-	for _, char := range field {
-		found := false
-		for _, validChar := range validChars {
-			if char == validChar {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
+// encodeChainHooks 校验并序列化链式触发的下游任务列表
+//
+// 返回值依次对应成功、失败、结束三个钩子, 下游任务必须存在且不能是任务自身。
+func (s *Service) encodeChainHooks(jobID string, lists ...[]string) ([3]string, error) {
+	var encoded [3]string
+	for i, ids := range lists {
+		ids = models.NormalizeJobIDs(ids)
+		if len(ids) == 0 {
+			continue
 		}
-	}
-	return true
-}
 
-// validateCronFieldRange 验证字段的数值范围
-func validateCronFieldRange(field string, min, max int, fieldName string) error {
-	// 跳过特殊字符
-	if field == "*" || field == "?" {
-		return nil
-	}
-
-	// 处理范围表达式 (如 1-5)
-	if strings.Contains(field, "-") {
-		parts := strings.Split(field, "-")
-		if len(parts) == 2 {
-			start, err1 := strconv.Atoi(parts[0])
-			end, err2 := strconv.Atoi(parts[1])
-			if err1 != nil || err2 != nil {
-				return fmt.Errorf("%s字段范围格式错误: %s", fieldName, field)
-			}
-			if start < min || start > max || end < min || end > max {
-				return fmt.Errorf("%s字段范围超出有效范围 [%d-%d]: %s", fieldName, min, max, field)
-			}
-			if start > end {
-				return fmt.Errorf("%s字段范围开始值不能大于结束值: %s", fieldName, field)
+		for _, id := range ids {
+			if id == jobID {
+				return encoded, fmt.Errorf("链式触发不能指向任务自身: %s", id)
 			}
-			return nil
 		}
-	}
 
-	// 处理列表表达式 (如 1,3,5)
-	if strings.Contains(field, ",") {
-		values := strings.Split(field, ",")
-		for _, value := range values {
-			if err := validateSingleCronValue(strings.TrimSpace(value), min, max, fieldName); err != nil {
-				return err
-			}
+		var count int64
+		if err := s.db.Model(&models.Job{}).Where("id IN ?", ids).Count(&count).Error; err != nil {
+			return encoded, fmt.Errorf("查询下游任务失败: %w", err)
 		}
-		return nil
-	}
-
-	// 处理步长表达式 (如 */5 或 0-30/5)
-	if strings.Contains(field, "/") {
-		parts := strings.Split(field, "/")
-		if len(parts) == 2 {
-			step, err := strconv.Atoi(parts[1])
-			if err != nil || step <= 0 {
-				return fmt.Errorf("%s字段步长值无效: %s", fieldName, field)
-			}
-			// 验证基础部分
-			if parts[0] != "*" {
-				return validateSingleCronValue(parts[0], min, max, fieldName)
-			}
-			return nil
+		if int(count) != len(ids) {
+			return encoded, fmt.Errorf("下游任务不存在: %s", strings.Join(ids, ","))
 		}
-	}
 
-	// 验证单个值
-	return validateSingleCronValue(field, min, max, fieldName)
-}
-
-// validateSingleCronValue 验证单个数值
-func validateSingleCronValue(value string, min, max int, fieldName string) error {
-	// 跳过特殊字符
-	if value == "*" || value == "?" || value == "L" || value == "W" {
-		return nil
-	}
-
-	// 处理包含特殊字符的值 (如 L, W, #)
-	if strings.Contains(value, "L") || strings.Contains(value, "W") || strings.Contains(value, "#") {
-		// 这些特殊字符的验证比较复杂，这里只做基本检查
-		return nil
+		raw, _ := json.Marshal(ids)
+		encoded[i] = string(raw)
 	}
+	return encoded, nil
+}
 
-	num, err := strconv.Atoi(value)
+// describeCron 返回 cron 表达式的中文描述, 无法解析时返回空字符串
+func describeCron(expr string) string {
+	description, err := cronexpr.Describe(expr, cronexpr.LangZH)
 	if err != nil {
-		return fmt.Errorf("%s字段包含非数字值: %s", fieldName, value)
-	}
-
-	if num < min || num > max {
-		return fmt.Errorf("%s字段值超出有效范围 [%d-%d]: %d", fieldName, min, max, num)
+		return ""
 	}
-
-	return nil
+	return description
 }
 
 // getUserFromContext 从上下文获取用户信息
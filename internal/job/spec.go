@@ -0,0 +1,555 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/jobtype"
+	"go-job/internal/models"
+	"go-job/pkg/cronexpr"
+	"go-job/pkg/labels"
+	"go-job/pkg/logger"
+	"strings"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// SpecVersion 当前支持的声明式配置版本
+const SpecVersion = 1
+
+// Spec 声明式任务配置文档, 支持 YAML 和 JSON
+//
+//	version: 1
+//	owner: billing-repo
+//	jobs:
+//	  - name: nightly-report
+//	    department: finance
+//	    schedule:
+//	      cron: "0 2 * * *"
+//	    command: ./report.sh
+//	    params:
+//	      REGION: cn
+type Spec struct {
+	Version int       `yaml:"version" json:"version"`
+	Owner   string    `yaml:"owner" json:"owner"` // 所有者标记, 应用时写入任务的 managed_by
+	Jobs    []JobSpec `yaml:"jobs" json:"jobs"`
+}
+
+// JobSpec 单个任务的声明式定义
+type JobSpec struct {
+	Name          string                 `yaml:"name" json:"name"`
+	Description   string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	Department    string                 `yaml:"department,omitempty" json:"department,omitempty"` // 部门编码或 ID
+	Schedule      ScheduleSpec           `yaml:"schedule" json:"schedule"`
+	Command       string                 `yaml:"command" json:"command"`
+	Params        map[string]string      `yaml:"params,omitempty" json:"params,omitempty"`
+	Type          string                 `yaml:"type,omitempty" json:"type,omitempty"`           // command(默认)、http、grpc、sql 或 handler
+	Config        map[string]interface{} `yaml:"config,omitempty" json:"config,omitempty"`       // 任务类型的配置
+	ExecMode      string                 `yaml:"exec_mode,omitempty" json:"exec_mode,omitempty"` // exec、shell(默认)或 script
+	Args          []string               `yaml:"args,omitempty" json:"args,omitempty"`
+	Interpreter   string                 `yaml:"interpreter,omitempty" json:"interpreter,omitempty"`
+	WorkDir       string                 `yaml:"work_dir,omitempty" json:"work_dir,omitempty"`
+	Umask         string                 `yaml:"umask,omitempty" json:"umask,omitempty"`
+	Limits        map[string]interface{} `yaml:"limits,omitempty" json:"limits,omitempty"`                 // 资源限制, 只支持 command 类型
+	Sandbox       map[string]interface{} `yaml:"sandbox,omitempty" json:"sandbox,omitempty"`               // 运行身份、工作目录和环境变量策略, 只支持 command 类型
+	Timeout       int                    `yaml:"timeout,omitempty" json:"timeout,omitempty"`               // 秒, 默认 300
+	RetryAttempts *int                   `yaml:"retry_attempts,omitempty" json:"retry_attempts,omitempty"` // 默认 3
+	Priority      int                    `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Labels        map[string]string      `yaml:"labels,omitempty" json:"labels,omitempty"`
+}
+
+// ScheduleSpec 调度配置
+type ScheduleSpec struct {
+	Cron    string `yaml:"cron" json:"cron"`
+	Enabled *bool  `yaml:"enabled,omitempty" json:"enabled,omitempty"` // 默认启用
+}
+
+// SpecOptions 计划和应用选项
+type SpecOptions struct {
+	Prune bool // 删除归属于当前 owner 但不在配置中的任务
+	Adopt bool // 接管同名的手工任务, 否则视为冲突
+}
+
+// PlanAction 计划中的操作
+type PlanAction string
+
+const (
+	PlanCreate    PlanAction = "create"
+	PlanUpdate    PlanAction = "update"
+	PlanDelete    PlanAction = "delete"
+	PlanUnchanged PlanAction = "unchanged"
+	PlanConflict  PlanAction = "conflict"
+)
+
+// PlanItem 单个任务的计划
+type PlanItem struct {
+	Action  PlanAction          `json:"action"`
+	Name    string              `json:"name"`
+	JobID   string              `json:"job_id,omitempty"`
+	Changes []*grpc.FieldChange `json:"changes,omitempty"`
+	Reason  string              `json:"reason,omitempty"`
+
+	current *models.Job
+	desired *models.Job
+}
+
+// Plan 配置与数据库的差异
+type Plan struct {
+	Owner   string             `json:"owner"`
+	Items   []*PlanItem        `json:"items"`
+	Summary map[PlanAction]int `json:"summary"`
+}
+
+// HasConflicts 是否存在冲突
+func (p *Plan) HasConflicts() bool {
+	return p.Summary[PlanConflict] > 0
+}
+
+// ParseSpec 解析并校验 YAML 或 JSON 格式的配置, 未知字段视为错误
+func ParseSpec(data []byte) (*Spec, error) {
+	var spec Spec
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("解析任务配置失败: %w", err)
+	}
+
+	if spec.Version != SpecVersion {
+		return nil, fmt.Errorf("不支持的配置版本: %d, 当前版本为 %d", spec.Version, SpecVersion)
+	}
+	if strings.TrimSpace(spec.Owner) == "" {
+		return nil, fmt.Errorf("配置缺少 owner")
+	}
+
+	names := make(map[string]bool, len(spec.Jobs))
+	for i, item := range spec.Jobs {
+		if strings.TrimSpace(item.Name) == "" {
+			return nil, fmt.Errorf("第 %d 个任务缺少名称", i+1)
+		}
+		if names[item.Name] {
+			return nil, fmt.Errorf("任务名称重复: %s", item.Name)
+		}
+		names[item.Name] = true
+
+		if jobtype.Normalize(item.Type) == jobtype.Command && strings.TrimSpace(item.Command) == "" {
+			return nil, fmt.Errorf("任务 %s 缺少命令", item.Name)
+		}
+		execution := item.execSettings()
+		if err := execution.normalize(); err != nil {
+			return nil, fmt.Errorf("任务 %s 的执行方式无效: %w", item.Name, err)
+		}
+		if err := cronexpr.Validate(item.Schedule.Cron); err != nil {
+			return nil, fmt.Errorf("任务 %s 的 Cron 表达式无效: %w", item.Name, err)
+		}
+		if item.Timeout < 0 {
+			return nil, fmt.Errorf("任务 %s 的超时时间不能为负数", item.Name)
+		}
+		if item.RetryAttempts != nil && *item.RetryAttempts < 0 {
+			return nil, fmt.Errorf("任务 %s 的重试次数不能为负数", item.Name)
+		}
+		if err := labels.Validate(item.Labels); err != nil {
+			return nil, fmt.Errorf("任务 %s 的标签无效: %w", item.Name, err)
+		}
+	}
+
+	return &spec, nil
+}
+
+// ExportSpec 导出任务配置
+//
+// owner 为空时导出全部任务; 否则只导出归属于 owner 的任务, includeUnmanaged 时包含手工任务,
+// 便于配合 Adopt 选项把已有任务纳入配置管理。
+func (s *Service) ExportSpec(owner, departmentID string, includeUnmanaged bool) (*Spec, error) {
+	query := s.db.Model(&models.Job{})
+	if owner != "" {
+		if includeUnmanaged {
+			query = query.Where("managed_by = ? OR managed_by = '' OR managed_by IS NULL", owner)
+		} else {
+			query = query.Where("managed_by = ?", owner)
+		}
+	}
+	if departmentID != "" {
+		query = query.Where("department_id = ?", departmentID)
+	}
+
+	var jobs []models.Job
+	if err := query.Order("name").Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("查询任务失败: %w", err)
+	}
+
+	codes, err := s.departmentCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &Spec{
+		Version: SpecVersion,
+		Owner:   owner,
+		Jobs:    make([]JobSpec, 0, len(jobs)),
+	}
+	for i := range jobs {
+		job := &jobs[i]
+		snap := snapshotOf(job)
+		enabled := job.Enabled
+		retryAttempts := job.RetryAttempts
+
+		item := JobSpec{
+			Name:          job.Name,
+			Description:   job.Description,
+			Department:    codes[job.DepartmentID],
+			Schedule:      ScheduleSpec{Cron: job.Cron, Enabled: &enabled},
+			Command:       job.Command,
+			Timeout:       job.Timeout,
+			RetryAttempts: &retryAttempts,
+			Priority:      job.Priority,
+		}
+		if len(snap.Params) > 0 {
+			item.Params = snap.Params
+		}
+		if snap.Type != models.JobTypeCommand {
+			item.Type = string(snap.Type)
+			json.Unmarshal([]byte(snap.Config), &item.Config)
+		}
+		if snap.ExecMode != models.ExecModeShell {
+			item.ExecMode = string(snap.ExecMode)
+		}
+		item.Args = snap.Args
+		item.Interpreter = snap.Interpreter
+		item.WorkDir = snap.WorkDir
+		item.Umask = snap.Umask
+		if snap.Limits != "" {
+			json.Unmarshal([]byte(snap.Limits), &item.Limits)
+		}
+		if snap.Sandbox != "" {
+			json.Unmarshal([]byte(snap.Sandbox), &item.Sandbox)
+		}
+		if len(snap.Labels) > 0 {
+			item.Labels = snap.Labels
+		}
+		spec.Jobs = append(spec.Jobs, item)
+	}
+
+	return spec, nil
+}
+
+// PlanSpec 计算配置与数据库之间的差异, 不做任何修改
+func (s *Service) PlanSpec(spec *Spec, opts SpecOptions) (*Plan, error) {
+	return planSpec(s.db, spec, opts)
+}
+
+// ApplySpec 在同一事务中应用配置, 计划存在冲突时不做任何修改
+func (s *Service) ApplySpec(ctx context.Context, spec *Spec, opts SpecOptions) (*Plan, error) {
+	logger.Infof("应用任务配置: owner=%s, 任务数=%d, prune=%t", spec.Owner, len(spec.Jobs), opts.Prune)
+
+	user := getUserFromContext(ctx)
+	comment := fmt.Sprintf("应用声明式配置 %s", spec.Owner)
+
+	var plan *Plan
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if plan, err = planSpec(tx, spec, opts); err != nil {
+			return err
+		}
+		if plan.HasConflicts() {
+			return fmt.Errorf("任务配置存在 %d 个冲突, 未应用任何修改", plan.Summary[PlanConflict])
+		}
+
+		for _, item := range plan.Items {
+			switch item.Action {
+			case PlanCreate:
+				job := item.desired
+				job.ID = uuid.New().String()
+				job.CreatedBy = user
+				// 零值字段在创建时会被默认值覆盖, 创建后按配置补齐
+				enabled, retryAttempts := job.Enabled, job.RetryAttempts
+				if err := tx.Create(job).Error; err != nil {
+					return fmt.Errorf("创建任务 %s 失败: %w", job.Name, err)
+				}
+				if err := tx.Model(job).Updates(map[string]interface{}{
+					"enabled":        enabled,
+					"retry_attempts": retryAttempts,
+				}).Error; err != nil {
+					return fmt.Errorf("创建任务 %s 失败: %w", job.Name, err)
+				}
+				job.Enabled, job.RetryAttempts = enabled, retryAttempts
+				if err := syncLabels(tx, job.ID, job.Labels); err != nil {
+					return err
+				}
+				if _, err := recordRevision(tx, job, models.RevisionChangeCreate, user, comment, 0); err != nil {
+					return err
+				}
+				item.JobID = job.ID
+
+			case PlanUpdate:
+				current := item.current
+				if current.Revision == 0 {
+					if _, err := recordRevision(tx, current, models.RevisionChangeBaseline, current.CreatedBy, "", 0); err != nil {
+						return err
+					}
+				}
+
+				updates := snapshotOf(item.desired).updates()
+				updates["managed_by"] = item.desired.ManagedBy
+				if err := tx.Model(&models.Job{}).Where("id = ?", current.ID).Updates(updates).Error; err != nil {
+					return fmt.Errorf("更新任务 %s 失败: %w", current.Name, err)
+				}
+				var job models.Job
+				if err := tx.First(&job, "id = ?", current.ID).Error; err != nil {
+					return fmt.Errorf("查询更新后的任务失败: %w", err)
+				}
+				if err := syncLabels(tx, job.ID, job.Labels); err != nil {
+					return err
+				}
+				if _, err := recordRevision(tx, &job, models.RevisionChangeUpdate, user, comment, 0); err != nil {
+					return err
+				}
+
+			case PlanDelete:
+				if err := tx.Delete(&models.Job{}, "id = ?", item.JobID).Error; err != nil {
+					return fmt.Errorf("删除任务 %s 失败: %w", item.Name, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.WithError(err).Error("应用任务配置失败")
+		return plan, err
+	}
+
+	logger.Infof("任务配置应用成功: owner=%s, 新建 %d, 更新 %d, 删除 %d, 未变 %d", spec.Owner,
+		plan.Summary[PlanCreate], plan.Summary[PlanUpdate], plan.Summary[PlanDelete], plan.Summary[PlanUnchanged])
+
+	return plan, nil
+}
+
+// planSpec 按名称匹配配置与数据库中的任务并生成计划
+func planSpec(db *gorm.DB, spec *Spec, opts SpecOptions) (*Plan, error) {
+	names := make([]string, 0, len(spec.Jobs))
+	for _, item := range spec.Jobs {
+		names = append(names, item.Name)
+	}
+
+	var existing []models.Job
+	if len(names) > 0 {
+		if err := db.Where("name IN ?", names).Find(&existing).Error; err != nil {
+			return nil, fmt.Errorf("查询任务失败: %w", err)
+		}
+	}
+
+	departments, err := departmentIDs(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []models.Job
+	if opts.Prune {
+		if err := db.Where("managed_by = ?", spec.Owner).Order("name").Find(&owned).Error; err != nil {
+			return nil, fmt.Errorf("查询待删除任务失败: %w", err)
+		}
+	}
+	return buildPlan(spec, opts, existing, departments, owned), nil
+}
+
+// buildPlan 生成计划, existing 为与配置中任务同名的任务, departments 为部门编码和 ID 到 ID 的映射,
+// owned 为归属于配置 owner 的任务, 只在 prune 时使用
+func buildPlan(spec *Spec, opts SpecOptions, existing []models.Job, departments map[string]string, owned []models.Job) *Plan {
+	plan := &Plan{
+		Owner:   spec.Owner,
+		Items:   make([]*PlanItem, 0, len(spec.Jobs)),
+		Summary: make(map[PlanAction]int),
+	}
+
+	byName := make(map[string][]*models.Job, len(existing))
+	for i := range existing {
+		byName[existing[i].Name] = append(byName[existing[i].Name], &existing[i])
+	}
+
+	for _, js := range spec.Jobs {
+		item := &PlanItem{Name: js.Name}
+		plan.Items = append(plan.Items, item)
+
+		departmentID := ""
+		if js.Department != "" {
+			id, ok := departments[js.Department]
+			if !ok {
+				item.Action = PlanConflict
+				item.Reason = fmt.Sprintf("部门不存在: %s", js.Department)
+				continue
+			}
+			departmentID = id
+		}
+
+		matches := byName[js.Name]
+		if len(matches) > 1 {
+			item.Action = PlanConflict
+			item.Reason = fmt.Sprintf("存在 %d 个同名任务", len(matches))
+			continue
+		}
+
+		if len(matches) == 0 {
+			item.Action = PlanCreate
+			item.desired = desiredJob(&models.Job{}, js, spec.Owner, departmentID)
+			item.Changes = diffSnapshots(jobSnapshot{}, snapshotOf(item.desired))
+			continue
+		}
+
+		current := matches[0]
+		item.JobID = current.ID
+		item.current = current
+		if current.ManagedBy != spec.Owner && !(current.ManagedBy == "" && opts.Adopt) {
+			item.Action = PlanConflict
+			if current.ManagedBy == "" {
+				item.Reason = "同名任务为手工创建, 如需纳入配置管理请使用 adopt"
+			} else {
+				item.Reason = fmt.Sprintf("同名任务归属于 %s", current.ManagedBy)
+			}
+			continue
+		}
+
+		item.desired = desiredJob(current, js, spec.Owner, departmentID)
+		item.Changes = diffSnapshots(snapshotOf(current), snapshotOf(item.desired))
+		if current.ManagedBy != spec.Owner {
+			item.Changes = append(item.Changes, &grpc.FieldChange{
+				Field:    "managed_by",
+				OldValue: current.ManagedBy,
+				NewValue: spec.Owner,
+			})
+		}
+		if len(item.Changes) > 0 {
+			item.Action = PlanUpdate
+		} else {
+			item.Action = PlanUnchanged
+		}
+	}
+
+	if opts.Prune {
+		declared := make(map[string]bool, len(spec.Jobs))
+		for _, js := range spec.Jobs {
+			declared[js.Name] = true
+		}
+		for i := range owned {
+			if declared[owned[i].Name] {
+				continue
+			}
+			plan.Items = append(plan.Items, &PlanItem{
+				Action: PlanDelete,
+				Name:   owned[i].Name,
+				JobID:  owned[i].ID,
+			})
+		}
+	}
+
+	for _, item := range plan.Items {
+		plan.Summary[item.Action]++
+	}
+	return plan
+}
+
+// desiredJob 在当前任务的基础上套用配置, 配置未覆盖的字段(链式触发、SLA 等)保持不变
+func desiredJob(current *models.Job, js JobSpec, owner, departmentID string) *models.Job {
+	params := js.Params
+	if params == nil {
+		params = map[string]string{}
+	}
+	paramsJSON, _ := json.Marshal(params)
+
+	enabled := true
+	if js.Schedule.Enabled != nil {
+		enabled = *js.Schedule.Enabled
+	}
+	retryAttempts := 3
+	if js.RetryAttempts != nil {
+		retryAttempts = *js.RetryAttempts
+	}
+	timeout := js.Timeout
+	if timeout == 0 {
+		timeout = 300
+	}
+
+	job := *current
+	job.Name = js.Name
+	job.Description = js.Description
+	job.Cron = js.Schedule.Cron
+	job.Params = string(paramsJSON)
+	execution := js.execSettings()
+	_ = execution.normalize() // ParseSpec 已校验
+	execution.apply(&job)
+	job.Enabled = enabled
+	job.RetryAttempts = retryAttempts
+	job.Timeout = timeout
+	job.Priority = js.Priority
+	job.DepartmentID = departmentID
+	job.ManagedBy = owner
+	job.Labels, _ = encodeLabels(js.Labels)
+	return &job
+}
+
+// execSettings 配置中的执行方式
+func (js JobSpec) execSettings() execSettings {
+	var config string
+	if js.Config != nil {
+		raw, _ := json.Marshal(js.Config)
+		config = string(raw)
+	}
+	var limits string
+	if js.Limits != nil {
+		raw, _ := json.Marshal(js.Limits)
+		limits = string(raw)
+	}
+	var sandbox string
+	if js.Sandbox != nil {
+		raw, _ := json.Marshal(js.Sandbox)
+		sandbox = string(raw)
+	}
+	return execSettings{
+		Type:        models.JobType(js.Type),
+		Config:      config,
+		Mode:        models.ExecMode(js.ExecMode),
+		Command:     js.Command,
+		Args:        js.Args,
+		Interpreter: js.Interpreter,
+		WorkDir:     js.WorkDir,
+		Umask:       js.Umask,
+		Limits:      limits,
+		Sandbox:     sandbox,
+	}
+}
+
+// departmentCodes 部门 ID 到编码的映射, 没有编码的部门导出为 ID
+func (s *Service) departmentCodes() (map[string]string, error) {
+	var departments []models.Department
+	if err := s.db.Select("id", "code").Find(&departments).Error; err != nil {
+		return nil, fmt.Errorf("查询部门失败: %w", err)
+	}
+
+	codes := make(map[string]string, len(departments))
+	for _, department := range departments {
+		codes[department.ID] = department.Code
+		if department.Code == "" {
+			codes[department.ID] = department.ID
+		}
+	}
+	return codes, nil
+}
+
+// departmentIDs 部门编码到 ID 的映射, 没有编码的部门按 ID 引用
+func departmentIDs(db *gorm.DB) (map[string]string, error) {
+	var departments []models.Department
+	if err := db.Select("id", "code").Find(&departments).Error; err != nil {
+		return nil, fmt.Errorf("查询部门失败: %w", err)
+	}
+
+	ids := make(map[string]string, len(departments))
+	for _, department := range departments {
+		ids[department.ID] = department.ID
+		if department.Code != "" {
+			ids[department.Code] = department.ID
+		}
+	}
+	return ids, nil
+}
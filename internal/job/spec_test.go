@@ -0,0 +1,196 @@
+package job
+
+import (
+	"go-job/internal/models"
+	"reflect"
+	"testing"
+)
+
+func TestDesiredJobDefaults(t *testing.T) {
+	disabled := false
+	noRetry := 0
+	tests := []struct {
+		name    string
+		current *models.Job
+		js      JobSpec
+		check   func(t *testing.T, job *models.Job)
+	}{
+		{
+			"未指定的字段使用默认值",
+			&models.Job{},
+			JobSpec{Name: "report", Schedule: ScheduleSpec{Cron: "0 2 * * *"}, Command: "./report.sh"},
+			func(t *testing.T, job *models.Job) {
+				if !job.Enabled || job.RetryAttempts != 3 || job.Timeout != 300 {
+					t.Errorf("enabled, retry_attempts, timeout = %v, %d, %d, 期望 true, 3, 300", job.Enabled, job.RetryAttempts, job.Timeout)
+				}
+				if job.Type != models.JobTypeCommand || job.ExecMode != models.ExecModeShell {
+					t.Errorf("type, exec_mode = %q, %q, 期望 command, shell", job.Type, job.ExecMode)
+				}
+				if job.Params != "{}" {
+					t.Errorf("params = %q, 期望 {}", job.Params)
+				}
+			},
+		},
+		{
+			"显式的零值不被默认值覆盖",
+			&models.Job{},
+			JobSpec{Name: "report", Schedule: ScheduleSpec{Cron: "0 2 * * *", Enabled: &disabled}, Command: "./report.sh", RetryAttempts: &noRetry, Timeout: 30},
+			func(t *testing.T, job *models.Job) {
+				if job.Enabled || job.RetryAttempts != 0 || job.Timeout != 30 {
+					t.Errorf("enabled, retry_attempts, timeout = %v, %d, %d, 期望 false, 0, 30", job.Enabled, job.RetryAttempts, job.Timeout)
+				}
+			},
+		},
+		{
+			"配置未覆盖的字段保持不变",
+			&models.Job{ID: "job-1", OnFailure: `["job-alert"]`, SLAMaxDuration: 600, ManagedBy: ""},
+			JobSpec{Name: "report", Schedule: ScheduleSpec{Cron: "0 2 * * *"}, Command: "./report.sh", Labels: map[string]string{"team": "bi"}},
+			func(t *testing.T, job *models.Job) {
+				if job.ID != "job-1" || job.OnFailure != `["job-alert"]` || job.SLAMaxDuration != 600 {
+					t.Errorf("id, on_failure, sla_max_duration = %q, %q, %d", job.ID, job.OnFailure, job.SLAMaxDuration)
+				}
+				if job.ManagedBy != "team-a" || job.DepartmentID != "dept-1" {
+					t.Errorf("managed_by, department_id = %q, %q, 期望 team-a, dept-1", job.ManagedBy, job.DepartmentID)
+				}
+				if job.Labels != `{"team":"bi"}` {
+					t.Errorf("labels = %q", job.Labels)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := *tt.current
+			job := desiredJob(tt.current, tt.js, "team-a", "dept-1")
+			if !reflect.DeepEqual(*tt.current, current) {
+				t.Error("desiredJob 不应修改当前任务")
+			}
+			tt.check(t, job)
+		})
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+	spec := &Spec{
+		Version: SpecVersion,
+		Owner:   "team-a",
+		Jobs: []JobSpec{
+			{Name: "same", Schedule: ScheduleSpec{Cron: "0 1 * * *"}, Command: "./same.sh"},
+			{Name: "changed", Schedule: ScheduleSpec{Cron: "0 3 * * *"}, Command: "./changed.sh"},
+			{Name: "manual", Schedule: ScheduleSpec{Cron: "0 4 * * *"}, Command: "./manual.sh"},
+			{Name: "foreign", Schedule: ScheduleSpec{Cron: "0 5 * * *"}, Command: "./foreign.sh"},
+			{Name: "duplicated", Schedule: ScheduleSpec{Cron: "0 6 * * *"}, Command: "./dup.sh"},
+			{Name: "new", Department: "fin", Schedule: ScheduleSpec{Cron: "0 7 * * *"}, Command: "./new.sh"},
+			{Name: "bad-department", Department: "missing", Schedule: ScheduleSpec{Cron: "0 8 * * *"}, Command: "./bad.sh"},
+		},
+	}
+
+	same := desiredJob(&models.Job{ID: "job-same"}, spec.Jobs[0], "team-a", "")
+	changed := desiredJob(&models.Job{ID: "job-changed"}, spec.Jobs[1], "team-a", "")
+	changed.Cron = "0 2 * * *"
+	manual := desiredJob(&models.Job{ID: "job-manual"}, spec.Jobs[2], "", "")
+	existing := []models.Job{
+		*same,
+		*changed,
+		*manual,
+		{ID: "job-foreign", Name: "foreign", ManagedBy: "team-b"},
+		{ID: "job-dup-1", Name: "duplicated", ManagedBy: "team-a"},
+		{ID: "job-dup-2", Name: "duplicated"},
+	}
+	departments := map[string]string{"fin": "dept-fin", "dept-fin": "dept-fin"}
+	owned := []models.Job{
+		{ID: "job-changed", Name: "changed", ManagedBy: "team-a"},
+		{ID: "job-dup-1", Name: "duplicated", ManagedBy: "team-a"},
+		{ID: "job-old-a", Name: "old-a", ManagedBy: "team-a"},
+		{ID: "job-old-b", Name: "old-b", ManagedBy: "team-a"},
+		{ID: "job-same", Name: "same", ManagedBy: "team-a"},
+	}
+
+	tests := []struct {
+		name string
+		opts SpecOptions
+		want map[string]PlanAction
+	}{
+		{
+			"默认",
+			SpecOptions{},
+			map[string]PlanAction{
+				"same": PlanUnchanged, "changed": PlanUpdate, "manual": PlanConflict, "foreign": PlanConflict,
+				"duplicated": PlanConflict, "new": PlanCreate, "bad-department": PlanConflict,
+			},
+		},
+		{
+			"接管手工任务, 不接管其他所有者的任务",
+			SpecOptions{Adopt: true},
+			map[string]PlanAction{
+				"same": PlanUnchanged, "changed": PlanUpdate, "manual": PlanUpdate, "foreign": PlanConflict,
+				"duplicated": PlanConflict, "new": PlanCreate, "bad-department": PlanConflict,
+			},
+		},
+		{
+			"只删除归属于 owner 且不在配置中的任务",
+			SpecOptions{Prune: true},
+			map[string]PlanAction{
+				"same": PlanUnchanged, "changed": PlanUpdate, "manual": PlanConflict, "foreign": PlanConflict,
+				"duplicated": PlanConflict, "new": PlanCreate, "bad-department": PlanConflict,
+				"old-a": PlanDelete, "old-b": PlanDelete,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := buildPlan(spec, tt.opts, existing, departments, owned)
+
+			got := make(map[string]PlanAction, len(plan.Items))
+			summary := make(map[PlanAction]int)
+			for _, item := range plan.Items {
+				got[item.Name] = item.Action
+				summary[item.Action]++
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("计划 = %v, 期望 %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(plan.Summary, summary) {
+				t.Errorf("Summary = %v, 期望 %v", plan.Summary, summary)
+			}
+			if plan.HasConflicts() != (summary[PlanConflict] > 0) {
+				t.Errorf("HasConflicts = %v", plan.HasConflicts())
+			}
+
+			for _, item := range plan.Items {
+				switch item.Name {
+				case "changed":
+					want := []string{"cron"}
+					if fields := changedFields(item); !reflect.DeepEqual(fields, want) {
+						t.Errorf("changed 的变更字段 = %v, 期望 %v", fields, want)
+					}
+				case "manual":
+					if tt.opts.Adopt {
+						if fields := changedFields(item); !reflect.DeepEqual(fields, []string{"managed_by"}) {
+							t.Errorf("接管手工任务的变更字段 = %v, 期望 [managed_by]", fields)
+						}
+					} else if item.Reason == "" {
+						t.Error("冲突应说明原因")
+					}
+				case "new":
+					if item.desired.DepartmentID != "dept-fin" || item.desired.ManagedBy != "team-a" {
+						t.Errorf("新任务的 department_id, managed_by = %q, %q", item.desired.DepartmentID, item.desired.ManagedBy)
+					}
+				case "old-a", "old-b":
+					if item.JobID != "job-"+item.Name {
+						t.Errorf("删除的任务 ID = %q", item.JobID)
+					}
+				}
+			}
+		})
+	}
+}
+
+// changedFields 计划项中变更的字段名
+func changedFields(item *PlanItem) []string {
+	var fields []string
+	for _, change := range item.Changes {
+		fields = append(fields, change.Field)
+	}
+	return fields
+}
@@ -0,0 +1,485 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+var (
+	// placeholderPattern 命令中的参数占位符, 例如 {{ host }}
+	placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+	// paramNamePattern 模板参数名
+	paramNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// CreateJobTemplate 创建任务模板
+func (s *Service) CreateJobTemplate(ctx context.Context, req *grpc.CreateJobTemplateRequest) (*grpc.CreateJobTemplateResponse, error) {
+	logger.Infof("创建任务模板: %s", req.GetName())
+
+	params := paramsFromGrpc(req.GetParams())
+	if err := validateTemplate(req.GetName(), req.GetCommand(), params); err != nil {
+		return nil, err
+	}
+	schema, _ := json.Marshal(params)
+
+	template := &models.JobTemplate{
+		ID:            uuid.New().String(),
+		Name:          req.GetName(),
+		Description:   req.GetDescription(),
+		Command:       req.GetCommand(),
+		ParamsSchema:  string(schema),
+		Timeout:       int(req.GetTimeout()),
+		RetryAttempts: int(req.GetRetryAttempts()),
+		Version:       1,
+		CreatedBy:     getUserFromContext(ctx),
+	}
+	if template.Timeout <= 0 {
+		template.Timeout = 300
+	}
+
+	if err := s.db.Create(template).Error; err != nil {
+		logger.WithError(err).Error("创建任务模板失败")
+		return nil, fmt.Errorf("创建任务模板失败: %w", err)
+	}
+
+	logger.Infof("任务模板创建成功: %s (ID: %s)", template.Name, template.ID)
+
+	return &grpc.CreateJobTemplateResponse{
+		Template: templateToGrpc(template, 0),
+	}, nil
+}
+
+// GetJobTemplate 获取任务模板
+func (s *Service) GetJobTemplate(ctx context.Context, req *grpc.GetJobTemplateRequest) (*grpc.GetJobTemplateResponse, error) {
+	template, err := s.getTemplate(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpc.GetJobTemplateResponse{
+		Template: templateToGrpc(template, s.countDerivedJobs(template.ID)),
+	}, nil
+}
+
+// ListJobTemplates 获取任务模板列表
+func (s *Service) ListJobTemplates(ctx context.Context, req *grpc.ListJobTemplatesRequest) (*grpc.ListJobTemplatesResponse, error) {
+	page := int(req.GetPage())
+	size := int(req.GetSize())
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 {
+		size = 10
+	}
+
+	query := s.db.Model(&models.JobTemplate{})
+	if keyword := req.GetKeyword(); keyword != "" {
+		query = query.Where("name LIKE ? OR description LIKE ?", "%"+keyword+"%", "%"+keyword+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("查询任务模板总数失败: %w", err)
+	}
+
+	var templates []models.JobTemplate
+	if err := query.Order("name").Offset((page - 1) * size).Limit(size).Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("查询任务模板列表失败: %w", err)
+	}
+
+	grpcTemplates := make([]*grpc.JobTemplate, 0, len(templates))
+	for i := range templates {
+		grpcTemplates = append(grpcTemplates, templateToGrpc(&templates[i], s.countDerivedJobs(templates[i].ID)))
+	}
+
+	return &grpc.ListJobTemplatesResponse{
+		Templates: grpcTemplates,
+		Total:     total,
+	}, nil
+}
+
+// UpdateJobTemplate 更新任务模板
+//
+// dry_run 时只返回各派生任务将发生的变化; propagate 时在同一事务中同步所有派生任务,
+// 任一派生任务无法按新模板生成定义(例如新增了没有默认值的必填参数)则整体失败。
+func (s *Service) UpdateJobTemplate(ctx context.Context, req *grpc.UpdateJobTemplateRequest) (*grpc.UpdateJobTemplateResponse, error) {
+	logger.Infof("更新任务模板: %s", req.GetId())
+
+	template, err := s.getTemplate(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	params := paramsFromGrpc(req.GetParams())
+	if err := validateTemplate(req.GetName(), req.GetCommand(), params); err != nil {
+		return nil, err
+	}
+	schema, _ := json.Marshal(params)
+
+	template.Name = req.GetName()
+	template.Description = req.GetDescription()
+	template.Command = req.GetCommand()
+	template.ParamsSchema = string(schema)
+	template.Timeout = int(req.GetTimeout())
+	template.RetryAttempts = int(req.GetRetryAttempts())
+	template.Version++
+	if template.Timeout <= 0 {
+		template.Timeout = 300
+	}
+
+	var jobs []models.Job
+	if err := s.db.Where("template_id = ?", template.ID).Order("name").Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("查询派生任务失败: %w", err)
+	}
+
+	affected := make([]*grpc.TemplateJobChange, 0, len(jobs))
+	rendered := make([]*models.Job, len(jobs))
+	var failed []string
+	for i := range jobs {
+		change := &grpc.TemplateJobChange{JobId: jobs[i].ID, JobName: jobs[i].Name}
+
+		next, err := applyTemplate(template, &jobs[i])
+		if err != nil {
+			change.Error = err.Error()
+			failed = append(failed, jobs[i].Name)
+		} else {
+			change.Changes = diffSnapshots(snapshotOf(&jobs[i]), snapshotOf(next))
+			rendered[i] = next
+		}
+		affected = append(affected, change)
+	}
+
+	if req.GetDryRun() {
+		return &grpc.UpdateJobTemplateResponse{
+			Template:     templateToGrpc(template, int64(len(jobs))),
+			AffectedJobs: affected,
+		}, nil
+	}
+
+	if req.GetPropagate() && len(failed) > 0 {
+		return nil, fmt.Errorf("以下派生任务无法按新模板更新: %s", strings.Join(failed, ","))
+	}
+
+	user := getUserFromContext(ctx)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(template).Error; err != nil {
+			return fmt.Errorf("更新任务模板失败: %w", err)
+		}
+		if !req.GetPropagate() {
+			return nil
+		}
+
+		comment := fmt.Sprintf("同步模板 %s 版本 %d", template.Name, template.Version)
+		for i, next := range rendered {
+			job := &jobs[i]
+			if job.Revision == 0 {
+				if _, err := recordRevision(tx, job, models.RevisionChangeBaseline, job.CreatedBy, "", 0); err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Model(job).Updates(map[string]interface{}{
+				"command":          next.Command,
+				"params":           next.Params,
+				"timeout":          next.Timeout,
+				"retry_attempts":   next.RetryAttempts,
+				"template_version": template.Version,
+				"updated_at":       time.Now(),
+			}).Error; err != nil {
+				return fmt.Errorf("同步派生任务 %s 失败: %w", job.ID, err)
+			}
+			if err := tx.First(job, "id = ?", job.ID).Error; err != nil {
+				return fmt.Errorf("查询派生任务失败: %w", err)
+			}
+			if _, err := recordRevision(tx, job, models.RevisionChangeUpdate, user, comment, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.WithError(err).Error("更新任务模板失败")
+		return nil, err
+	}
+
+	logger.Infof("任务模板更新成功: %s (版本 %d, 同步派生任务: %t)", template.ID, template.Version, req.GetPropagate())
+
+	return &grpc.UpdateJobTemplateResponse{
+		Template:     templateToGrpc(template, int64(len(jobs))),
+		AffectedJobs: affected,
+	}, nil
+}
+
+// DeleteJobTemplate 删除任务模板, 仍有派生任务时不允许删除
+func (s *Service) DeleteJobTemplate(ctx context.Context, req *grpc.DeleteJobTemplateRequest) (*grpc.DeleteJobTemplateResponse, error) {
+	logger.Infof("删除任务模板: %s", req.GetId())
+
+	if count := s.countDerivedJobs(req.GetId()); count > 0 {
+		return nil, fmt.Errorf("任务模板仍有 %d 个派生任务, 无法删除", count)
+	}
+
+	result := s.db.Delete(&models.JobTemplate{}, "id = ?", req.GetId())
+	if result.Error != nil {
+		logger.WithError(result.Error).Error("删除任务模板失败")
+		return nil, fmt.Errorf("删除任务模板失败: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("任务模板不存在: %s", req.GetId())
+	}
+
+	logger.Infof("任务模板删除成功: %s", req.GetId())
+
+	return &grpc.DeleteJobTemplateResponse{
+		Success: true,
+	}, nil
+}
+
+// InstantiateJobTemplate 使用参数值从模板创建任务
+func (s *Service) InstantiateJobTemplate(ctx context.Context, req *grpc.InstantiateJobTemplateRequest) (*grpc.InstantiateJobTemplateResponse, error) {
+	logger.Infof("从模板 %s 创建任务: %s", req.GetTemplateId(), req.GetName())
+
+	template, err := s.getTemplate(req.GetTemplateId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetName() == "" {
+		return nil, fmt.Errorf("任务名称不能为空")
+	}
+
+	values := req.GetValues()
+	if values == nil {
+		values = map[string]string{}
+	}
+	command, params, err := renderTemplate(template, values)
+	if err != nil {
+		return nil, err
+	}
+	valuesJSON, _ := json.Marshal(values)
+
+	// 与直接创建的任务走同样的校验、执行配置规范化和标签索引
+	comment := fmt.Sprintf("从模板 %s 版本 %d 创建", template.Name, template.Version)
+	job, err := s.createJob(ctx, &grpc.CreateJobRequest{
+		Name:          req.GetName(),
+		Description:   req.GetDescription(),
+		Cron:          req.GetCron(),
+		Command:       command,
+		Params:        params,
+		RetryAttempts: int32(template.RetryAttempts),
+		Timeout:       int32(template.Timeout),
+	}, func(job *models.Job) {
+		job.Priority = int(req.GetPriority())
+		job.DepartmentID = req.GetDepartmentId()
+		job.TemplateID = template.ID
+		job.TemplateValues = string(valuesJSON)
+		job.TemplateVersion = template.Version
+	}, comment)
+	if err != nil {
+		logger.WithError(err).Error("从模板创建任务失败")
+		return nil, err
+	}
+
+	logger.Infof("从模板创建任务成功: %s (ID: %s)", job.Name, job.ID)
+
+	return &grpc.InstantiateJobTemplateResponse{
+		Job: s.modelToGrpc(job),
+	}, nil
+}
+
+// getTemplate 查询任务模板
+func (s *Service) getTemplate(id string) (*models.JobTemplate, error) {
+	var template models.JobTemplate
+	if err := s.db.First(&template, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("任务模板不存在: %s", id)
+		}
+		return nil, fmt.Errorf("查询任务模板失败: %w", err)
+	}
+	return &template, nil
+}
+
+// countDerivedJobs 统计模板的派生任务数
+func (s *Service) countDerivedJobs(templateID string) int64 {
+	var count int64
+	s.db.Model(&models.Job{}).Where("template_id = ?", templateID).Count(&count)
+	return count
+}
+
+// validateTemplate 校验模板定义: 参数名合法且不重复, 正则可编译, 默认值满足正则,
+// 命令中的占位符都已声明
+func validateTemplate(name, command string, params []models.TemplateParam) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("模板名称不能为空")
+	}
+	if strings.TrimSpace(command) == "" {
+		return fmt.Errorf("模板命令不能为空")
+	}
+
+	declared := make(map[string]bool, len(params))
+	for _, param := range params {
+		if !paramNamePattern.MatchString(param.Name) {
+			return fmt.Errorf("无效的参数名: %q", param.Name)
+		}
+		if declared[param.Name] {
+			return fmt.Errorf("参数名重复: %s", param.Name)
+		}
+		declared[param.Name] = true
+
+		if param.Pattern != "" {
+			re, err := regexp.Compile(param.Pattern)
+			if err != nil {
+				return fmt.Errorf("参数 %s 的正则无效: %w", param.Name, err)
+			}
+			if param.DefaultValue != "" && !re.MatchString(param.DefaultValue) {
+				return fmt.Errorf("参数 %s 的默认值不匹配正则 %s", param.Name, param.Pattern)
+			}
+		}
+	}
+
+	for _, match := range placeholderPattern.FindAllStringSubmatch(command, -1) {
+		if !declared[match[1]] {
+			return fmt.Errorf("命令中的占位符未声明: %s", match[1])
+		}
+	}
+	return nil
+}
+
+// renderTemplate 合并默认值和传入的参数值并替换命令中的占位符, 返回命令和任务参数
+//
+// 命令通过 /bin/sh -c 执行, 占位符默认替换为 shell 单引号转义后的值, 参数值中的
+// 引号、分号和 $() 等都按字面量传给命令; 声明了 raw 的参数原样替换。
+func renderTemplate(template *models.JobTemplate, values map[string]string) (string, map[string]string, error) {
+	var params []models.TemplateParam
+	if template.ParamsSchema != "" {
+		if err := json.Unmarshal([]byte(template.ParamsSchema), &params); err != nil {
+			return "", nil, fmt.Errorf("解析模板参数定义失败: %w", err)
+		}
+	}
+
+	declared := make(map[string]bool, len(params))
+	raw := make(map[string]bool)
+	resolved := make(map[string]string, len(params))
+	for _, param := range params {
+		declared[param.Name] = true
+		raw[param.Name] = param.Raw
+
+		value, ok := values[param.Name]
+		if !ok || value == "" {
+			value = param.DefaultValue
+		}
+		if value == "" {
+			if param.Required {
+				return "", nil, fmt.Errorf("缺少必填参数: %s", param.Name)
+			}
+		} else if param.Pattern != "" {
+			if matched, _ := regexp.MatchString(param.Pattern, value); !matched {
+				return "", nil, fmt.Errorf("参数 %s 的值 %q 不匹配正则 %s", param.Name, value, param.Pattern)
+			}
+		}
+		resolved[param.Name] = value
+	}
+
+	for name := range values {
+		if !declared[name] {
+			return "", nil, fmt.Errorf("模板未声明参数: %s", name)
+		}
+	}
+
+	command := placeholderPattern.ReplaceAllStringFunc(template.Command, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		if raw[name] {
+			return resolved[name]
+		}
+		return shellQuote(resolved[name])
+	})
+	return command, resolved, nil
+}
+
+// shellQuote 用单引号包裹参数值, 值中的单引号替换为 '\”
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// applyTemplate 按模板和任务保存的参数值生成新的任务定义, 不修改传入的任务
+func applyTemplate(template *models.JobTemplate, job *models.Job) (*models.Job, error) {
+	values := make(map[string]string)
+	if job.TemplateValues != "" {
+		json.Unmarshal([]byte(job.TemplateValues), &values)
+	}
+
+	command, params, err := renderTemplate(template, values)
+	if err != nil {
+		return nil, err
+	}
+	paramsJSON, _ := json.Marshal(params)
+
+	next := *job
+	next.Command = command
+	next.Params = string(paramsJSON)
+	next.Timeout = template.Timeout
+	next.RetryAttempts = template.RetryAttempts
+	next.TemplateVersion = template.Version
+	return &next, nil
+}
+
+// paramsFromGrpc 转换模板参数定义
+func paramsFromGrpc(params []*grpc.TemplateParam) []models.TemplateParam {
+	result := make([]models.TemplateParam, 0, len(params))
+	for _, param := range params {
+		result = append(result, models.TemplateParam{
+			Name:         param.GetName(),
+			Description:  param.GetDescription(),
+			Required:     param.GetRequired(),
+			DefaultValue: param.GetDefaultValue(),
+			Pattern:      param.GetPattern(),
+			Raw:          param.GetRaw(),
+		})
+	}
+	return result
+}
+
+// templateToGrpc 将模板模型转换为 gRPC 消息
+func templateToGrpc(template *models.JobTemplate, jobCount int64) *grpc.JobTemplate {
+	var params []models.TemplateParam
+	if template.ParamsSchema != "" {
+		json.Unmarshal([]byte(template.ParamsSchema), &params)
+	}
+
+	grpcParams := make([]*grpc.TemplateParam, 0, len(params))
+	for _, param := range params {
+		grpcParams = append(grpcParams, &grpc.TemplateParam{
+			Name:         param.Name,
+			Description:  param.Description,
+			Required:     param.Required,
+			DefaultValue: param.DefaultValue,
+			Pattern:      param.Pattern,
+			Raw:          param.Raw,
+		})
+	}
+
+	return &grpc.JobTemplate{
+		Id:            template.ID,
+		Name:          template.Name,
+		Description:   template.Description,
+		Command:       template.Command,
+		Params:        grpcParams,
+		Timeout:       int32(template.Timeout),
+		RetryAttempts: int32(template.RetryAttempts),
+		Version:       int32(template.Version),
+		CreatedBy:     template.CreatedBy,
+		CreatedAt:     timestamppb.New(template.CreatedAt),
+		UpdatedAt:     timestamppb.New(template.UpdatedAt),
+		JobCount:      jobCount,
+	}
+}
@@ -0,0 +1,113 @@
+package job
+
+import (
+	"encoding/json"
+	"go-job/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestValidateTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    string
+		command string
+		params  []models.TemplateParam
+		want    string // 为空表示校验通过
+	}{
+		{"合法模板", "backup", "backup.sh {{ host }} {{port}}", []models.TemplateParam{{Name: "host"}, {Name: "port", Pattern: `^\d+$`, DefaultValue: "22"}}, ""},
+		{"没有占位符", "noop", "true", nil, ""},
+		{"名称为空", " ", "true", nil, "模板名称不能为空"},
+		{"命令为空", "noop", "", nil, "模板命令不能为空"},
+		{"无效参数名", "noop", "true", []models.TemplateParam{{Name: "1host"}}, "无效的参数名"},
+		{"参数名重复", "noop", "true", []models.TemplateParam{{Name: "host"}, {Name: "host"}}, "参数名重复"},
+		{"正则无效", "noop", "true", []models.TemplateParam{{Name: "host", Pattern: "("}}, "正则无效"},
+		{"默认值不匹配", "noop", "true", []models.TemplateParam{{Name: "port", Pattern: `^\d+$`, DefaultValue: "ssh"}}, "默认值不匹配"},
+		{"占位符未声明", "noop", "echo {{ host }}", nil, "占位符未声明: host"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTemplate(tt.tmpl, tt.command, tt.params)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("validateTemplate 返回错误: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("validateTemplate 错误 = %v, 应包含 %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	params := []models.TemplateParam{
+		{Name: "host", Required: true},
+		{Name: "port", DefaultValue: "22", Pattern: `^\d+$`},
+		{Name: "flags", Raw: true},
+		{Name: "note"},
+	}
+	schema, _ := json.Marshal(params)
+	template := &models.JobTemplate{
+		Command:      "ssh -p {{port}} {{ host }} {{flags}} echo {{note}}",
+		ParamsSchema: string(schema),
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		command string
+		want    string // 期望的错误
+	}{
+		{"默认值", map[string]string{"host": "db1"}, "ssh -p '22' 'db1'  echo ''", ""},
+		{"覆盖默认值", map[string]string{"host": "db1", "port": "2222"}, "ssh -p '2222' 'db1'  echo ''", ""},
+		{"空值使用默认值", map[string]string{"host": "db1", "port": ""}, "ssh -p '22' 'db1'  echo ''", ""},
+		{"引用命令替换", map[string]string{"host": "db1; rm -rf /", "note": "$(id)"}, "ssh -p '22' 'db1; rm -rf /'  echo '$(id)'", ""},
+		{"转义单引号", map[string]string{"host": "db1", "note": "it's"}, `ssh -p '22' 'db1'  echo 'it'\''s'`, ""},
+		{"raw 参数原样替换", map[string]string{"host": "db1", "flags": "-v -t"}, "ssh -p '22' 'db1' -v -t echo ''", ""},
+		{"缺少必填参数", map[string]string{}, "", "缺少必填参数: host"},
+		{"不匹配正则", map[string]string{"host": "db1", "port": "ssh"}, "", "不匹配正则"},
+		{"未声明参数", map[string]string{"host": "db1", "user": "root"}, "", "模板未声明参数: user"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, resolved, err := renderTemplate(template, tt.values)
+			if tt.want != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("renderTemplate 错误 = %v, 应包含 %q", err, tt.want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("renderTemplate 返回错误: %v", err)
+			}
+			if command != tt.command {
+				t.Errorf("命令 = %q, 期望 %q", command, tt.command)
+			}
+			// 任务参数保存未转义的原始值
+			if host := resolved["host"]; host != tt.values["host"] {
+				t.Errorf("参数 host = %q, 期望 %q", host, tt.values["host"])
+			}
+		})
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", "''"},
+		{"plain", "'plain'"},
+		{"a b", "'a b'"},
+		{"it's", `'it'\''s'`},
+		{"''", `''\'''\'''`},
+		{"$HOME `id` \"x\"", "'$HOME `id` \"x\"'"},
+	}
+	for _, tt := range tests {
+		if got := shellQuote(tt.value); got != tt.want {
+			t.Errorf("shellQuote(%q) = %q, 期望 %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package jobtype
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"google.golang.org/grpc/codes"
+)
+
+// GRPCConfig grpc 类型任务的配置
+//
+// 方法的请求和响应类型通过服务端反射获取, request 和 metadata 的值为模板。
+type GRPCConfig struct {
+	Target     string            `json:"target"`                // 服务地址, 如 billing:9090
+	Method     string            `json:"method"`                // 完整方法名, 如 billing.v1.BillingService/CloseMonth
+	Request    json.RawMessage   `json:"request,omitempty"`     // 请求消息的 JSON 表示, 默认为空消息
+	Metadata   map[string]string `json:"metadata,omitempty"`    // 请求元数据
+	Plaintext  bool              `json:"plaintext,omitempty"`   // 不使用 TLS
+	TLS        *TLSConfig        `json:"tls,omitempty"`         // plaintext 为 false 时的 TLS 选项
+	Timeout    int               `json:"timeout,omitempty"`     // 单次调用超时(秒), 为 0 时只受任务超时限制
+	ExpectCode string            `json:"expect_code,omitempty"` // 期望的状态码, 如 OK、NOT_FOUND, 默认 OK
+
+	service    string
+	methodName string
+	code       codes.Code
+	request    *template.Template
+	metadata   map[string]*template.Template
+}
+
+// GRPCRequest 渲染后的调用
+type GRPCRequest struct {
+	Request  string
+	Metadata map[string]string
+}
+
+// ParseGRPC 解析并校验 grpc 类型任务的配置
+func ParseGRPC(config string) (*GRPCConfig, error) {
+	var cfg GRPCConfig
+	if err := decode(config, &cfg); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(cfg.Target) == "" {
+		return nil, fmt.Errorf("缺少服务地址 target")
+	}
+	var err error
+	if cfg.service, cfg.methodName, err = splitMethod(cfg.Method); err != nil {
+		return nil, err
+	}
+
+	request := strings.TrimSpace(string(cfg.Request))
+	if request == "" || request == "null" {
+		request = "{}"
+	}
+	if cfg.request, err = parseTemplate("request", request); err != nil {
+		return nil, err
+	}
+
+	cfg.metadata = make(map[string]*template.Template, len(cfg.Metadata))
+	for key, value := range cfg.Metadata {
+		if strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("元数据名称不能为空")
+		}
+		if cfg.metadata[key], err = parseTemplate("metadata "+key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.ExpectCode != "" {
+		name := strings.ToUpper(strings.TrimSpace(cfg.ExpectCode))
+		if err := cfg.code.UnmarshalJSON([]byte(strconv.Quote(name))); err != nil {
+			return nil, fmt.Errorf("无效的期望状态码: %s", cfg.ExpectCode)
+		}
+	}
+	if cfg.Timeout < 0 {
+		return nil, fmt.Errorf("调用超时不能为负数")
+	}
+	if cfg.Plaintext && cfg.TLS != nil {
+		return nil, fmt.Errorf("plaintext 和 tls 不能同时指定")
+	}
+	if cfg.TLS != nil {
+		if _, err := cfg.TLS.Config(); err != nil {
+			return nil, err
+		}
+	}
+	return &cfg, nil
+}
+
+// Service 服务的完整名称
+func (c *GRPCConfig) Service() string {
+	return c.service
+}
+
+// MethodName 方法名称
+func (c *GRPCConfig) MethodName() string {
+	return c.methodName
+}
+
+// FullMethod 调用路径, 如 /billing.v1.BillingService/CloseMonth
+func (c *GRPCConfig) FullMethod() string {
+	return "/" + c.service + "/" + c.methodName
+}
+
+// ExpectedCode 期望的状态码
+func (c *GRPCConfig) ExpectedCode() codes.Code {
+	return c.code
+}
+
+// Render 用任务参数渲染请求和元数据
+func (c *GRPCConfig) Render(data TemplateData) (*GRPCRequest, error) {
+	req := &GRPCRequest{Metadata: make(map[string]string, len(c.metadata))}
+
+	var err error
+	if req.Request, err = render(c.request, data); err != nil {
+		return nil, err
+	}
+	for key, tmpl := range c.metadata {
+		if req.Metadata[key], err = render(tmpl, data); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// splitMethod 拆分方法名, 支持 pkg.Service/Method、/pkg.Service/Method 和 pkg.Service.Method
+func splitMethod(method string) (string, string, error) {
+	method = strings.TrimPrefix(strings.TrimSpace(method), "/")
+	index := strings.LastIndex(method, "/")
+	if index < 0 {
+		index = strings.LastIndex(method, ".")
+	}
+	if index <= 0 || index == len(method)-1 {
+		return "", "", fmt.Errorf("无效的方法名: %s, 格式应为 package.Service/Method", method)
+	}
+	return method[:index], method[index+1:], nil
+}
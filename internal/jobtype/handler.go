@@ -0,0 +1,37 @@
+package jobtype
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// handlerNamePattern 处理函数名称, 如 billing.close-month
+var handlerNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:/-]{0,199}$`)
+
+// HandlerConfig handler 类型任务的配置
+type HandlerConfig struct {
+	Handler string `json:"handler"` // 工作节点注册的处理函数名称
+}
+
+// ParseHandler 解析并校验 handler 类型任务的配置
+func ParseHandler(config string) (*HandlerConfig, error) {
+	var cfg HandlerConfig
+	if err := decode(config, &cfg); err != nil {
+		return nil, err
+	}
+	if err := ValidateHandlerName(cfg.Handler); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// ValidateHandlerName 校验处理函数名称
+func ValidateHandlerName(name string) error {
+	if name == "" {
+		return fmt.Errorf("缺少处理函数名称 handler")
+	}
+	if !handlerNamePattern.MatchString(name) {
+		return fmt.Errorf("无效的处理函数名称: %s, 只能包含字母、数字和 . _ : / -", name)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package jobtype
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// HTTPConfig http 类型任务的配置
+//
+// url、headers 的值和 body 均为模板, 可引用任务参数, 如 {{.Params.date}}。
+type HTTPConfig struct {
+	Method          string            `json:"method,omitempty"` // 默认 GET
+	URL             string            `json:"url"`
+	Headers         map[string]string `json:"headers,omitempty"`
+	Body            string            `json:"body,omitempty"`
+	ExpectStatus    []int             `json:"expect_status,omitempty"`     // 期望的状态码, 为空时要求 2xx
+	ExpectBody      string            `json:"expect_body,omitempty"`       // 响应体须包含的文本
+	ExpectBodyRegex string            `json:"expect_body_regex,omitempty"` // 响应体须匹配的正则
+	Timeout         int               `json:"timeout,omitempty"`           // 单次请求超时(秒), 为 0 时只受任务超时限制
+	FollowRedirects *bool             `json:"follow_redirects,omitempty"`  // 是否跟随重定向, 默认跟随
+	TLS             *TLSConfig        `json:"tls,omitempty"`
+
+	url     *template.Template
+	headers map[string]*template.Template
+	body    *template.Template
+	pattern *regexp.Regexp
+}
+
+// HTTPRequest 渲染后的请求
+type HTTPRequest struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    string
+}
+
+// ParseHTTP 解析并校验 http 类型任务的配置
+func ParseHTTP(config string) (*HTTPConfig, error) {
+	var cfg HTTPConfig
+	if err := decode(config, &cfg); err != nil {
+		return nil, err
+	}
+
+	cfg.Method = strings.ToUpper(strings.TrimSpace(cfg.Method))
+	if cfg.Method == "" {
+		cfg.Method = http.MethodGet
+	}
+	if strings.ContainsAny(cfg.Method, " \t\r\n") {
+		return nil, fmt.Errorf("无效的请求方法: %s", cfg.Method)
+	}
+
+	if strings.TrimSpace(cfg.URL) == "" {
+		return nil, fmt.Errorf("缺少请求地址 url")
+	}
+	var err error
+	if cfg.url, err = parseTemplate("url", cfg.URL); err != nil {
+		return nil, err
+	}
+	// 不含模板的地址在保存时即可校验
+	if !strings.Contains(cfg.URL, "{{") {
+		if err := checkURL(cfg.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.headers = make(map[string]*template.Template, len(cfg.Headers))
+	for name, value := range cfg.Headers {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("请求头名称不能为空")
+		}
+		if cfg.headers[name], err = parseTemplate("header "+name, value); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.body, err = parseTemplate("body", cfg.Body); err != nil {
+		return nil, err
+	}
+
+	for _, code := range cfg.ExpectStatus {
+		if code < 100 || code > 599 {
+			return nil, fmt.Errorf("无效的期望状态码: %d", code)
+		}
+	}
+	if cfg.ExpectBodyRegex != "" {
+		if cfg.pattern, err = regexp.Compile(cfg.ExpectBodyRegex); err != nil {
+			return nil, fmt.Errorf("无效的响应体正则: %w", err)
+		}
+	}
+	if cfg.Timeout < 0 {
+		return nil, fmt.Errorf("请求超时不能为负数")
+	}
+	if cfg.TLS != nil {
+		if _, err := cfg.TLS.Config(); err != nil {
+			return nil, err
+		}
+	}
+	return &cfg, nil
+}
+
+// Render 用任务参数渲染请求
+func (c *HTTPConfig) Render(data TemplateData) (*HTTPRequest, error) {
+	req := &HTTPRequest{Method: c.Method, Headers: make(map[string]string, len(c.headers))}
+
+	var err error
+	if req.URL, err = render(c.url, data); err != nil {
+		return nil, err
+	}
+	if err := checkURL(req.URL); err != nil {
+		return nil, err
+	}
+	for name, tmpl := range c.headers {
+		if req.Headers[name], err = render(tmpl, data); err != nil {
+			return nil, err
+		}
+	}
+	if req.Body, err = render(c.body, data); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// StatusExpected 状态码是否符合期望
+func (c *HTTPConfig) StatusExpected(code int) bool {
+	if len(c.ExpectStatus) == 0 {
+		return code >= 200 && code < 300
+	}
+	for _, expected := range c.ExpectStatus {
+		if code == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckBody 校验响应体
+func (c *HTTPConfig) CheckBody(body []byte) error {
+	if c.ExpectBody != "" && !strings.Contains(string(body), c.ExpectBody) {
+		return fmt.Errorf("响应体不包含期望的内容: %s", c.ExpectBody)
+	}
+	if c.pattern != nil && !c.pattern.Match(body) {
+		return fmt.Errorf("响应体不匹配正则: %s", c.ExpectBodyRegex)
+	}
+	return nil
+}
+
+// checkURL 校验请求地址
+func checkURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("无效的请求地址: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("请求地址只支持 http 和 https: %s", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("请求地址缺少主机: %s", raw)
+	}
+	return nil
+}
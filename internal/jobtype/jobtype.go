@@ -0,0 +1,89 @@
+package jobtype
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// 任务类型, 与 models.JobType 取值一致
+const (
+	Command = "command"
+	HTTP    = "http"
+	GRPC    = "grpc"
+	SQL     = "sql"
+	Handler = "handler"
+)
+
+// TemplateData 渲染配置模板时可引用的数据, 如 {{.Params.region}}、{{.TaskID}}
+type TemplateData struct {
+	TaskID string
+	JobID  string
+	Params map[string]string
+}
+
+// Normalize 返回任务类型, 为空时为 command
+func Normalize(jobType string) string {
+	if jobType == "" {
+		return Command
+	}
+	return jobType
+}
+
+// Validate 校验任务类型及其配置
+func Validate(jobType, config string) error {
+	switch Normalize(jobType) {
+	case Command:
+		if strings.TrimSpace(config) != "" {
+			return fmt.Errorf("command 类型的任务不使用 config")
+		}
+		return nil
+	case HTTP:
+		_, err := ParseHTTP(config)
+		return err
+	case GRPC:
+		_, err := ParseGRPC(config)
+		return err
+	case SQL:
+		_, err := ParseSQL(config)
+		return err
+	case Handler:
+		_, err := ParseHandler(config)
+		return err
+	default:
+		return fmt.Errorf("无效的任务类型: %s, 可选 command、http、grpc、sql 或 handler", jobType)
+	}
+}
+
+// decode 严格解析 JSON 配置, 拼错的字段名直接报错而不是被忽略
+func decode(config string, v interface{}) error {
+	if strings.TrimSpace(config) == "" {
+		return fmt.Errorf("缺少任务配置")
+	}
+	decoder := json.NewDecoder(strings.NewReader(config))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("解析任务配置失败: %w", err)
+	}
+	return nil
+}
+
+// parseTemplate 解析配置中的模板, 引用不存在的参数时渲染失败
+func parseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s 模板无效: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// render 渲染模板
+func render(tmpl *template.Template, data TemplateData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("渲染 %s 失败: %w", tmpl.Name(), err)
+	}
+	return buf.String(), nil
+}
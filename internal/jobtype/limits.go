@@ -0,0 +1,61 @@
+package jobtype
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Limits command 类型任务的资源限制, 由 Linux 工作节点执行
+//
+// cpu_time、address_space_mb、open_files 和 processes 通过 rlimit 限制任务启动的每个进程;
+// memory_mb 和 cpus 通过 cgroup v2 限制任务的所有进程, 只在工作节点配置了可写的 cgroup 目录时生效。
+type Limits struct {
+	CPUTime        int     `json:"cpu_time,omitempty"`         // CPU 时间(秒), 超出后进程被终止
+	AddressSpaceMB int     `json:"address_space_mb,omitempty"` // 虚拟内存(MB)
+	OpenFiles      int     `json:"open_files,omitempty"`       // 打开的文件数
+	Processes      int     `json:"processes,omitempty"`        // 运行任务的用户可拥有的进程数
+	MemoryMB       int     `json:"memory_mb,omitempty"`        // 内存上限(MB), 超出时触发 OOM
+	CPUs           float64 `json:"cpus,omitempty"`             // 可使用的 CPU 核数, 如 0.5
+}
+
+// ParseLimits 解析并校验资源限制, 为空时返回 nil
+func ParseLimits(raw string) (*Limits, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var limits Limits
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&limits); err != nil {
+		return nil, fmt.Errorf("解析资源限制失败: %w", err)
+	}
+
+	for _, field := range []struct {
+		name  string
+		value int
+	}{
+		{"cpu_time", limits.CPUTime},
+		{"address_space_mb", limits.AddressSpaceMB},
+		{"open_files", limits.OpenFiles},
+		{"processes", limits.Processes},
+		{"memory_mb", limits.MemoryMB},
+	} {
+		if field.value < 0 {
+			return nil, fmt.Errorf("资源限制 %s 不能为负数", field.name)
+		}
+	}
+	if limits.CPUs < 0 || limits.CPUs > 1024 {
+		return nil, fmt.Errorf("资源限制 cpus 应在 0 到 1024 之间")
+	}
+	if limits.CPUs > 0 && limits.CPUs < 0.01 {
+		return nil, fmt.Errorf("资源限制 cpus 不能小于 0.01")
+	}
+	return &limits, nil
+}
+
+// Cgroup 是否需要通过 cgroup 限制
+func (l *Limits) Cgroup() bool {
+	return l.MemoryMB > 0 || l.CPUs > 0
+}
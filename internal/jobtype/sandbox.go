@@ -0,0 +1,128 @@
+package jobtype
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Sandbox command 类型任务的运行环境, 由工作节点执行
+//
+// 任务和所属部门都可以设置, 任务中设置的项覆盖部门的默认值。
+type Sandbox struct {
+	User        string     `json:"user,omitempty"`          // 运行任务的用户名或 uid, 为空时以工作节点的用户运行
+	Group       string     `json:"group,omitempty"`         // 组名或 gid, 为空时使用用户的主组
+	TempWorkDir *bool      `json:"temp_work_dir,omitempty"` // 在新建的临时目录中运行, 结束后删除
+	Env         *EnvPolicy `json:"env,omitempty"`           // 为空时继承工作节点的全部环境变量
+}
+
+// EnvPolicy 任务继承工作节点环境变量的策略
+//
+// 变量名支持以 * 结尾的前缀匹配, 如 LC_*。任务参数和 WORKER_ID 等工作节点信息不受策略限制。
+type EnvPolicy struct {
+	Inherit bool     `json:"inherit"`         // 继承全部环境变量, 为 false 时只继承 allow 中的变量
+	Allow   []string `json:"allow,omitempty"` // 不继承全部时仍继承的变量
+	Deny    []string `json:"deny,omitempty"`  // 不继承的变量, 优先于 inherit 和 allow
+}
+
+// ParseSandbox 解析并校验沙箱设置, 为空时返回 nil
+func ParseSandbox(raw string) (*Sandbox, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var sandbox Sandbox
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&sandbox); err != nil {
+		return nil, fmt.Errorf("解析沙箱设置失败: %w", err)
+	}
+
+	sandbox.User = strings.TrimSpace(sandbox.User)
+	sandbox.Group = strings.TrimSpace(sandbox.Group)
+	if sandbox.Group != "" && sandbox.User == "" {
+		return nil, fmt.Errorf("沙箱设置 group 需要同时指定 user")
+	}
+	if sandbox.Env != nil {
+		for _, pattern := range append(append([]string{}, sandbox.Env.Allow...), sandbox.Env.Deny...) {
+			if err := validateEnvPattern(pattern); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &sandbox, nil
+}
+
+// validateEnvPattern 校验环境变量名, 只允许在结尾使用 *
+func validateEnvPattern(pattern string) error {
+	name := strings.TrimSuffix(pattern, "*")
+	if strings.ContainsAny(name, "=*") || strings.TrimSpace(pattern) != pattern || pattern == "" {
+		return fmt.Errorf("无效的环境变量名: %q", pattern)
+	}
+	return nil
+}
+
+// Empty 是否没有设置任何项
+func (s *Sandbox) Empty() bool {
+	return s == nil || (s.User == "" && s.TempWorkDir == nil && s.Env == nil)
+}
+
+// Merge 以 defaults 为默认值合并沙箱设置, 返回新的设置
+//
+// user 和 group 作为运行身份一起覆盖, env 整体覆盖。
+func (s *Sandbox) Merge(defaults *Sandbox) *Sandbox {
+	merged := Sandbox{}
+	if defaults != nil {
+		merged = *defaults
+	}
+	if s == nil {
+		return &merged
+	}
+	if s.User != "" {
+		merged.User, merged.Group = s.User, s.Group
+	}
+	if s.TempWorkDir != nil {
+		merged.TempWorkDir = s.TempWorkDir
+	}
+	if s.Env != nil {
+		merged.Env = s.Env
+	}
+	return &merged
+}
+
+// UseTempWorkDir 是否在临时目录中运行
+func (s *Sandbox) UseTempWorkDir() bool {
+	return s != nil && s.TempWorkDir != nil && *s.TempWorkDir
+}
+
+// Filter 按策略过滤 KEY=VALUE 形式的环境变量, deny 为额外禁止继承的变量
+func (p *EnvPolicy) Filter(environ []string, deny []string) []string {
+	filtered := make([]string, 0, len(environ))
+	for _, entry := range environ {
+		name, _, _ := strings.Cut(entry, "=")
+		if MatchEnv(deny, name) {
+			continue
+		}
+		if p != nil {
+			if MatchEnv(p.Deny, name) || (!p.Inherit && !MatchEnv(p.Allow, name)) {
+				continue
+			}
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+// MatchEnv 环境变量名是否匹配任一模式
+func MatchEnv(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if pattern == name {
+			return true
+		}
+	}
+	return false
+}
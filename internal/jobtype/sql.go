@@ -0,0 +1,183 @@
+package jobtype
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// 数据库连接支持的驱动
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// defaultMaxRows 查询结果默认最多输出的行数
+const defaultMaxRows = 100
+
+// queryPattern 返回结果集的语句, 其余语句按影响行数上报
+var queryPattern = regexp.MustCompile(`(?is)^\s*(select|with|show|describe|desc|explain|pragma|values|table)\b|\breturning\b`)
+
+// SQLConfig sql 类型任务的配置
+//
+// statement 和 script 二选一; args 为 statement 的绑定参数, 值为模板。
+// 语句本身不做模板渲染, 参数值只能通过绑定参数传入。
+type SQLConfig struct {
+	Connection  string   `json:"connection"`            // 数据库连接名称
+	Statement   string   `json:"statement,omitempty"`   // 单条语句
+	Script      string   `json:"script,omitempty"`      // 以分号分隔的多条语句, 依次执行
+	Args        []string `json:"args,omitempty"`        // statement 的绑定参数
+	Transaction bool     `json:"transaction,omitempty"` // 在一个事务中执行, 任一语句失败时回滚
+	MaxRows     int      `json:"max_rows,omitempty"`    // 每个查询最多输出的行数, 默认 100
+	Timeout     int      `json:"timeout,omitempty"`     // 执行超时(秒), 为 0 时只受任务超时限制
+
+	statements []string
+	args       []*template.Template
+}
+
+// ParseSQL 解析并校验 sql 类型任务的配置
+func ParseSQL(config string) (*SQLConfig, error) {
+	var cfg SQLConfig
+	if err := decode(config, &cfg); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(cfg.Connection) == "" {
+		return nil, fmt.Errorf("缺少数据库连接 connection")
+	}
+	hasStatement := strings.TrimSpace(cfg.Statement) != ""
+	hasScript := strings.TrimSpace(cfg.Script) != ""
+	switch {
+	case hasStatement && hasScript:
+		return nil, fmt.Errorf("statement 和 script 只能指定一个")
+	case hasStatement:
+		cfg.statements = []string{strings.TrimSpace(cfg.Statement)}
+	case hasScript:
+		if len(cfg.Args) > 0 {
+			return nil, fmt.Errorf("script 不支持绑定参数 args")
+		}
+		cfg.statements = SplitStatements(cfg.Script)
+		if len(cfg.statements) == 0 {
+			return nil, fmt.Errorf("script 中没有可执行的语句")
+		}
+	default:
+		return nil, fmt.Errorf("缺少要执行的语句 statement 或 script")
+	}
+
+	cfg.args = make([]*template.Template, len(cfg.Args))
+	for i, arg := range cfg.Args {
+		var err error
+		if cfg.args[i], err = parseTemplate(fmt.Sprintf("args[%d]", i), arg); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.MaxRows < 0 {
+		return nil, fmt.Errorf("max_rows 不能为负数")
+	}
+	if cfg.MaxRows == 0 {
+		cfg.MaxRows = defaultMaxRows
+	}
+	if cfg.Timeout < 0 {
+		return nil, fmt.Errorf("执行超时不能为负数")
+	}
+	return &cfg, nil
+}
+
+// Statements 要依次执行的语句
+func (c *SQLConfig) Statements() []string {
+	return c.statements
+}
+
+// RenderArgs 用任务参数渲染绑定参数
+func (c *SQLConfig) RenderArgs(data TemplateData) ([]interface{}, error) {
+	args := make([]interface{}, len(c.args))
+	for i, tmpl := range c.args {
+		value, err := render(tmpl, data)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+	return args, nil
+}
+
+// ValidateDriver 校验数据库驱动
+func ValidateDriver(driver string) error {
+	switch driver {
+	case DriverMySQL, DriverPostgres, DriverSQLite:
+		return nil
+	default:
+		return fmt.Errorf("不支持的数据库驱动: %s, 可选 mysql、postgres 或 sqlite", driver)
+	}
+}
+
+// IsQuery 语句是否返回结果集
+func IsQuery(statement string) bool {
+	return queryPattern.MatchString(stripComments(statement))
+}
+
+// SplitStatements 按分号拆分脚本, 忽略引号、注释和 PostgreSQL $$ 块中的分号
+func SplitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); stripComments(statement) != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(script); i++ {
+		ch := script[i]
+		switch {
+		case ch == '\'' || ch == '"' || ch == '`':
+			end := closing(script, i+1, string(ch))
+			current.WriteString(script[i:end])
+			i = end - 1
+		case ch == '-' && strings.HasPrefix(script[i:], "--"):
+			end := closing(script, i+2, "\n")
+			current.WriteString(script[i:end])
+			i = end - 1
+		case ch == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := closing(script, i+2, "*/")
+			current.WriteString(script[i:end])
+			i = end - 1
+		case ch == '$' && strings.HasPrefix(script[i:], "$$"):
+			end := closing(script, i+2, "$$")
+			current.WriteString(script[i:end])
+			i = end - 1
+		case ch == ';':
+			flush()
+		default:
+			current.WriteByte(ch)
+		}
+	}
+	flush()
+	return statements
+}
+
+// closing 返回从 start 开始第一个 delim 之后的位置, 没有找到时返回脚本末尾
+func closing(script string, start int, delim string) int {
+	if index := strings.Index(script[start:], delim); index >= 0 {
+		return start + index + len(delim)
+	}
+	return len(script)
+}
+
+// stripComments 去掉语句开头的注释, 用于判断语句类型和是否为空
+func stripComments(statement string) string {
+	for {
+		statement = strings.TrimSpace(statement)
+		switch {
+		case strings.HasPrefix(statement, "--"):
+			statement = statement[closing(statement, 2, "\n"):]
+		case strings.HasPrefix(statement, "/*"):
+			statement = statement[closing(statement, 2, "*/"):]
+		default:
+			return statement
+		}
+	}
+}
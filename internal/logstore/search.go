@@ -0,0 +1,134 @@
+package logstore
+
+import (
+	"bytes"
+	"go-job/internal/models"
+	"go-job/pkg/output"
+)
+
+// maxLineBytes 搜索时单行保留的最大字节数, 超出部分不参与匹配
+const maxLineBytes = 64 * 1024
+
+// maxMatchText 搜索结果中每行返回的最大字节数
+const maxMatchText = 1024
+
+// Match 搜索命中的行
+type Match struct {
+	Stream models.OutputStream `json:"stream"`
+	Line   int64               `json:"line"`   // 在所属输出流中的行号, 从 1 开始
+	Offset int64               `json:"offset"` // 行首在所属输出流中的字节偏移
+	Text   string              `json:"text"`
+}
+
+// Search 逐行搜索日志, 最多返回 limit 条结果, 第二个返回值表示是否还有更多结果
+//
+// 行号和偏移按输出流分别计算, 与单独下载该输出流时一致。
+func (s *Store) Search(executionID string, stream models.OutputStream, match func(line string) bool, limit int) ([]Match, bool, error) {
+	sr := newSearcher(match, limit)
+	err := s.Each(executionID, stream, func(chunk *models.ExecutionOutputChunk, data []byte) bool {
+		return sr.feed(chunk.Stream, data)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	sr.finish()
+	return sr.matches, sr.more, nil
+}
+
+// SearchText 按与 Search 相同的规则搜索一段文本, 用于没有日志片段的执行记录
+func SearchText(text string, stream models.OutputStream, match func(line string) bool, limit int) ([]Match, bool) {
+	sr := newSearcher(match, limit)
+	sr.feed(stream, []byte(text))
+	sr.finish()
+	return sr.matches, sr.more
+}
+
+// lineState 每个输出流的行拼接状态
+type lineState struct {
+	partial []byte
+	line    int64
+	offset  int64
+	skipped int64 // 当前行超过 maxLineBytes 被丢弃的字节数
+}
+
+// searcher 跨片段拼接行并匹配
+type searcher struct {
+	match   func(line string) bool
+	limit   int
+	states  map[models.OutputStream]*lineState
+	order   []models.OutputStream
+	matches []Match
+	more    bool
+}
+
+func newSearcher(match func(line string) bool, limit int) *searcher {
+	return &searcher{match: match, limit: limit, states: make(map[models.OutputStream]*lineState)}
+}
+
+// feed 处理一个片段, 结果已满时返回 false
+func (sr *searcher) feed(stream models.OutputStream, data []byte) bool {
+	st := sr.states[stream]
+	if st == nil {
+		st = &lineState{}
+		sr.states[stream] = st
+		sr.order = append(sr.order, stream)
+	}
+
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		segment := data
+		if i >= 0 {
+			segment = data[:i]
+		}
+
+		if room := maxLineBytes - len(st.partial); room < len(segment) {
+			if room < 0 {
+				room = 0
+			}
+			st.skipped += int64(len(segment) - room)
+			segment = segment[:room]
+		}
+		st.partial = append(st.partial, segment...)
+
+		if i < 0 {
+			break
+		}
+		if !sr.emit(stream, st) {
+			return false
+		}
+		data = data[i+1:]
+	}
+	return true
+}
+
+// finish 处理没有以换行结尾的最后一行
+func (sr *searcher) finish() {
+	for _, stream := range sr.order {
+		if sr.more {
+			return
+		}
+		if st := sr.states[stream]; len(st.partial) > 0 || st.skipped > 0 {
+			sr.emit(stream, st)
+		}
+	}
+}
+
+func (sr *searcher) emit(stream models.OutputStream, st *lineState) bool {
+	st.line++
+	line := bytes.TrimSuffix(st.partial, []byte("\r"))
+	if sr.match(string(line)) {
+		if len(sr.matches) >= sr.limit {
+			sr.more = true
+			return false
+		}
+		if len(line) > maxMatchText {
+			line = line[:output.CompletePrefix(line[:maxMatchText])]
+		}
+		sr.matches = append(sr.matches, Match{Stream: stream, Line: st.line, Offset: st.offset, Text: string(line)})
+	}
+
+	st.offset += int64(len(st.partial)) + st.skipped + 1
+	st.partial = st.partial[:0]
+	st.skipped = 0
+	return true
+}
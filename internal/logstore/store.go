@@ -0,0 +1,239 @@
+package logstore
+
+import (
+	"fmt"
+	"go-job/internal/models"
+	"go-job/pkg/config"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 日志存储后端
+const (
+	StoreDatabase = "database"
+	StoreFile     = "file"
+)
+
+// metaColumns 不含内容的片段字段
+var metaColumns = []string{"id", "execution_id", "stream", "seq", "size", "created_at"}
+
+// Store 执行日志存储
+//
+// 片段的元数据始终保存在 execution_output_chunks 表中, 内容按配置保存在同一张表或日志目录下的文件中。
+type Store struct {
+	db  *gorm.DB
+	dir string // 为空时内容保存在数据库中
+}
+
+// New 创建日志存储
+func New(db *gorm.DB, cfg config.OutputConfig) *Store {
+	s := &Store{db: db}
+	if cfg.Store == StoreFile {
+		s.dir = cfg.Dir
+		if s.dir == "" {
+			s.dir = "data/logs"
+		}
+	}
+	return s
+}
+
+// Append 保存一个片段, 同一输出流的序号已存在时忽略并返回 false
+func (s *Store) Append(executionID string, stream models.OutputStream, seq int64, data []byte, at time.Time) (*models.ExecutionOutputChunk, bool, error) {
+	chunk := &models.ExecutionOutputChunk{
+		ExecutionID: executionID,
+		Stream:      stream,
+		Seq:         seq,
+		Size:        int64(len(data)),
+		CreatedAt:   at,
+	}
+
+	if s.dir == "" {
+		chunk.Data = string(data)
+	} else {
+		// 文件名由输出流和序号决定, 重复上报时写入相同的内容
+		path := s.chunkPath(chunk)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, false, fmt.Errorf("创建日志目录失败: %w", err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return nil, false, fmt.Errorf("写入日志文件失败: %w", err)
+		}
+	}
+
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(chunk)
+	if result.Error != nil {
+		return nil, false, fmt.Errorf("保存日志片段失败: %w", result.Error)
+	}
+	return chunk, result.RowsAffected > 0, nil
+}
+
+// Chunks 返回 ID 大于 after 的片段及其内容, stream 为空时返回所有输出流
+func (s *Store) Chunks(executionID string, stream models.OutputStream, after uint64, limit int) ([]models.ExecutionOutputChunk, error) {
+	var chunks []models.ExecutionOutputChunk
+	err := s.query(executionID, stream).
+		Where("id > ?", after).
+		Order("id").
+		Limit(limit).
+		Find(&chunks).Error
+	if err != nil {
+		return nil, fmt.Errorf("查询日志片段失败: %w", err)
+	}
+
+	if s.dir != "" {
+		for i := range chunks {
+			data, err := s.read(&chunks[i])
+			if err != nil {
+				return nil, err
+			}
+			chunks[i].Data = string(data)
+		}
+	}
+	return chunks, nil
+}
+
+// Size 返回日志的总字节数和片段数
+func (s *Store) Size(executionID string, stream models.OutputStream) (int64, int64, error) {
+	var stat struct {
+		Size  int64
+		Count int64
+	}
+	err := s.query(executionID, stream).
+		Select("COALESCE(SUM(size), 0) AS size, COUNT(*) AS count").
+		Scan(&stat).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("统计日志大小失败: %w", err)
+	}
+	return stat.Size, stat.Count, nil
+}
+
+// ReadRange 将日志中从 offset 开始的 length 字节写入 w, length 小于 0 时读到结尾
+//
+// stream 为空时按片段到达的顺序合并所有输出流。
+func (s *Store) ReadRange(executionID string, stream models.OutputStream, offset, length int64, w io.Writer) (int64, error) {
+	var metas []models.ExecutionOutputChunk
+	if err := s.query(executionID, stream).Select(metaColumns).Order("id").Find(&metas).Error; err != nil {
+		return 0, fmt.Errorf("查询日志片段失败: %w", err)
+	}
+
+	var written, pos int64
+	for i := range metas {
+		meta := &metas[i]
+		start, end := pos, pos+meta.Size
+		pos = end
+		if end <= offset {
+			continue
+		}
+		if length >= 0 && start >= offset+length {
+			break
+		}
+
+		data, err := s.read(meta)
+		if err != nil {
+			return written, err
+		}
+		from, to := int64(0), int64(len(data))
+		if offset > start {
+			from = offset - start
+		}
+		if length >= 0 && offset+length < start+to {
+			to = offset + length - start
+		}
+		if from >= to {
+			continue
+		}
+
+		n, err := w.Write(data[from:to])
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// Each 按顺序遍历日志片段, fn 返回 false 时停止
+func (s *Store) Each(executionID string, stream models.OutputStream, fn func(chunk *models.ExecutionOutputChunk, data []byte) bool) error {
+	var metas []models.ExecutionOutputChunk
+	if err := s.query(executionID, stream).Select(metaColumns).Order("id").Find(&metas).Error; err != nil {
+		return fmt.Errorf("查询日志片段失败: %w", err)
+	}
+
+	for i := range metas {
+		data, err := s.read(&metas[i])
+		if err != nil {
+			return err
+		}
+		if !fn(&metas[i], data) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Delete 删除执行的所有日志片段
+func (s *Store) Delete(executionIDs []string) error {
+	if len(executionIDs) == 0 {
+		return nil
+	}
+	if err := s.db.Where("execution_id IN ?", executionIDs).Delete(&models.ExecutionOutputChunk{}).Error; err != nil {
+		return fmt.Errorf("删除日志片段失败: %w", err)
+	}
+
+	if s.dir != "" {
+		for _, id := range executionIDs {
+			if err := os.RemoveAll(s.executionDir(id)); err != nil {
+				return fmt.Errorf("删除日志文件失败: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// read 读取片段内容
+func (s *Store) read(chunk *models.ExecutionOutputChunk) ([]byte, error) {
+	if s.dir != "" {
+		data, err := os.ReadFile(s.chunkPath(chunk))
+		if err != nil {
+			return nil, fmt.Errorf("读取日志文件失败: %w", err)
+		}
+		return data, nil
+	}
+
+	if chunk.Data != "" || chunk.Size == 0 {
+		return []byte(chunk.Data), nil
+	}
+	var data []string
+	if err := s.db.Model(&models.ExecutionOutputChunk{}).Where("id = ?", chunk.ID).Pluck("data", &data).Error; err != nil {
+		return nil, fmt.Errorf("读取日志片段失败: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("日志片段不存在: %d", chunk.ID)
+	}
+	return []byte(data[0]), nil
+}
+
+func (s *Store) query(executionID string, stream models.OutputStream) *gorm.DB {
+	query := s.db.Model(&models.ExecutionOutputChunk{}).Where("execution_id = ?", executionID)
+	if stream != "" {
+		query = query.Where("stream = ?", stream)
+	}
+	return query
+}
+
+// executionDir 执行的日志目录, 按执行 ID 前两位分散到子目录
+func (s *Store) executionDir(executionID string) string {
+	prefix := executionID
+	if len(prefix) > 2 {
+		prefix = prefix[:2]
+	}
+	return filepath.Join(s.dir, prefix, executionID)
+}
+
+func (s *Store) chunkPath(chunk *models.ExecutionOutputChunk) string {
+	return filepath.Join(s.executionDir(chunk.ExecutionID), fmt.Sprintf("%s-%08d.log", chunk.Stream, chunk.Seq))
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// DecodeJobIDs 解析以 JSON 数组保存的任务 ID 列表
+func DecodeJobIDs(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+
+	var ids []string
+	if err := json.Unmarshal([]byte(raw), &ids); err != nil {
+		return nil
+	}
+	return NormalizeJobIDs(ids)
+}
+
+// NormalizeJobIDs 去除空白与重复的任务 ID, 保持原有顺序
+func NormalizeJobIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
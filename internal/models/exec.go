@@ -0,0 +1,14 @@
+package models
+
+import "strings"
+
+// SplitLegacyCommand 按添加执行模式前的方式拆分命令: 以空白分隔, 第一项为程序, 其余为参数
+//
+// 旧版本的任务不经过 shell 直接执行, 升级后转换为 exec 模式以保持原有的行为。
+func SplitLegacyCommand(command string) (string, []string) {
+	parts := strings.Fields(command)
+	if len(parts) == 0 {
+		return command, nil
+	}
+	return parts[0], parts[1:]
+}
@@ -37,6 +37,7 @@ type Department struct {
 	ParentID    *string        `gorm:"type:varchar(36);index" json:"parent_id"`
 	Status      DeptStatus     `gorm:"type:varchar(20);default:'active'" json:"status"`
 	Sort        int            `gorm:"default:0" json:"sort"`
+	Sandbox     string         `gorm:"type:text" json:"sandbox"` // 部门任务默认的沙箱设置, JSON 对象
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
@@ -136,21 +137,44 @@ type AISchedule struct {
 
 // Job 任务模型
 type Job struct {
-	ID            string         `gorm:"primaryKey;type:varchar(36)" json:"id"`
-	Name          string         `gorm:"type:varchar(255);not null;index" json:"name"`
-	Description   string         `gorm:"type:text" json:"description"`
-	Cron          string         `gorm:"type:varchar(100);not null" json:"cron"`
-	Command       string         `gorm:"type:text;not null" json:"command"`
-	Params        string         `gorm:"type:json" json:"params"` // JSON 字符串
-	Enabled       bool           `gorm:"default:true" json:"enabled"`
-	RetryAttempts int            `gorm:"default:3" json:"retry_attempts"`
-	Timeout       int            `gorm:"default:300" json:"timeout"` // 秒
-	Priority      int            `gorm:"default:0" json:"priority"`
-	DepartmentID  string         `gorm:"type:varchar(36);index" json:"department_id"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at"`
-	CreatedBy     string         `gorm:"type:varchar(100)" json:"created_by"`
+	ID              string         `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	Name            string         `gorm:"type:varchar(255);not null;index" json:"name"`
+	Description     string         `gorm:"type:text" json:"description"`
+	Cron            string         `gorm:"type:varchar(100);not null" json:"cron"`
+	Command         string         `gorm:"type:text;not null" json:"command"`
+	Params          string         `gorm:"type:json" json:"params"`                        // JSON 字符串
+	Type            JobType        `gorm:"type:varchar(20);default:'command'" json:"type"` // 任务类型
+	Config          string         `gorm:"type:text" json:"config"`                        // 任务类型的配置, JSON 对象
+	ExecMode        ExecMode       `gorm:"type:varchar(20)" json:"exec_mode"`              // 执行模式, 旧版本的任务在迁移时转换为 exec
+	Args            string         `gorm:"type:text" json:"args"`                          // 命令参数, JSON 数组
+	Interpreter     string         `gorm:"type:varchar(255)" json:"interpreter"`           // shell 和 script 模式的解释器, 为空时使用 /bin/sh
+	WorkDir         string         `gorm:"type:varchar(500)" json:"work_dir"`              // 工作目录, 为空时使用工作节点的当前目录
+	Umask           string         `gorm:"type:varchar(4)" json:"umask"`                   // 八进制文件权限掩码, 为空时继承工作节点
+	Limits          string         `gorm:"type:text" json:"limits"`                        // 资源限制, JSON 对象, 为空时不限制
+	Sandbox         string         `gorm:"type:text" json:"sandbox"`                       // 运行身份、工作目录和环境变量策略, JSON 对象, 未设置的项使用部门的默认值
+	Enabled         bool           `gorm:"default:true" json:"enabled"`
+	RetryAttempts   int            `gorm:"default:3" json:"retry_attempts"`
+	Timeout         int            `gorm:"default:300" json:"timeout"` // 秒
+	Priority        int            `gorm:"default:0" json:"priority"`
+	DepartmentID    string         `gorm:"type:varchar(36);index" json:"department_id"`
+	OnSuccess       string         `gorm:"type:text" json:"on_success"`               // 成功后触发的任务 ID 列表, JSON 数组
+	OnFailure       string         `gorm:"type:text" json:"on_failure"`               // 失败后触发的任务 ID 列表, JSON 数组
+	OnFinish        string         `gorm:"type:text" json:"on_finish"`                // 结束后(无论成败)触发的任务 ID 列表, JSON 数组
+	SLAStartBy      string         `gorm:"type:varchar(5)" json:"sla_start_by"`       // 最晚开始时间 HH:MM, 调度器时区
+	SLAFinishBy     string         `gorm:"type:varchar(5)" json:"sla_finish_by"`      // 最晚成功完成时间 HH:MM, 调度器时区
+	SLAMaxDuration  int            `gorm:"default:0" json:"sla_max_duration"`         // 单次运行最长时长(秒), 0 表示不限制
+	Revision        int            `gorm:"default:0" json:"revision"`                 // 当前版本号
+	TemplateID      string         `gorm:"type:varchar(36);index" json:"template_id"` // 派生自的任务模板
+	TemplateValues  string         `gorm:"type:text" json:"template_values"`          // 实例化时的模板参数值, JSON 字符串
+	TemplateVersion int            `gorm:"default:0" json:"template_version"`         // 已同步的模板版本
+	ManagedBy       string         `gorm:"type:varchar(100);index" json:"managed_by"` // 声明式配置的所有者标记, 为空表示手工创建
+	Labels          string         `gorm:"type:text" json:"labels"`                   // 标签, JSON 对象; 查询用的副本保存在 job_labels
+	Paused          bool           `gorm:"default:false" json:"paused"`               // 暂停时不按计划和事件调度, 仍可手动触发
+	PausedUntil     *time.Time     `json:"paused_until"`                              // 暂停截止时间, 为空表示直到手动恢复
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	CreatedBy       string         `gorm:"type:varchar(100)" json:"created_by"`
 
 	// 关联
 	Department  *Department  `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
@@ -169,9 +193,24 @@ type JobExecution struct {
 	Output     string             `gorm:"type:longtext" json:"output"`
 	Error      string             `gorm:"type:longtext" json:"error"`
 	ExitCode   int                `json:"exit_code"`
-	CreatedAt  time.Time          `json:"created_at"`
-	UpdatedAt  time.Time          `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt     `gorm:"index" json:"deleted_at"`
+	// 任务被终止的原因, 自行结束时为空
+	Termination TerminationCause `gorm:"type:varchar(20)" json:"termination"`
+	// 资源消耗, 由工作节点在命令结束后统计
+	PeakRSS    int64   `json:"peak_rss_bytes"`
+	CPUSeconds float64 `json:"cpu_seconds"`
+	// 进程内处理函数的结构化结果和进度
+	Result          string `gorm:"type:longtext" json:"result"` // JSON
+	Progress        int    `gorm:"default:0" json:"progress"`   // 0-100
+	ProgressMessage string `gorm:"type:varchar(500)" json:"progress_message"`
+	// 链式触发信息
+	UpstreamExecutionID string         `gorm:"type:varchar(36);index" json:"upstream_execution_id"`
+	ChainDepth          int            `gorm:"default:0" json:"chain_depth"`
+	RevisionID          string         `gorm:"type:varchar(36);index" json:"revision_id"` // 执行时的任务版本
+	TraceID             string         `gorm:"type:varchar(32);index" json:"trace_id"`    // 本次运行的追踪 ID
+	RestoredAt          *time.Time     `json:"restored_at"`                               // 从归档恢复的时间, 保护期内不会被再次清理
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 
 	// 关联
 	Job    Job    `gorm:"foreignKey:JobID" json:"job,omitempty"`
@@ -188,7 +227,9 @@ type Worker struct {
 	Capacity      int            `gorm:"default:10" json:"capacity"`
 	CurrentLoad   int            `gorm:"default:0" json:"current_load"`
 	LastHeartbeat *time.Time     `json:"last_heartbeat"`
-	Metadata      string         `gorm:"type:json" json:"metadata"` // JSON 字符串
+	Metadata      string         `gorm:"type:json" json:"metadata"`     // JSON 字符串
+	Handlers      string         `gorm:"type:text" json:"handlers"`     // 提供的进程内处理函数, JSON 数组
+	HostMetrics   string         `gorm:"type:text" json:"host_metrics"` // 最近一次心跳上报的主机指标, JSON 字符串
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at"`
@@ -203,9 +244,15 @@ type JobSchedule struct {
 	Status      ScheduleStatus `gorm:"type:varchar(20);default:'pending'" json:"status"`
 	WorkerID    string         `gorm:"type:varchar(36);index" json:"worker_id"`
 	ExecutionID string         `gorm:"type:varchar(36);index" json:"execution_id"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	Params      string         `gorm:"type:text" json:"params"`                  // 本次运行的参数覆盖, JSON 字符串
+	TriggerID   string         `gorm:"type:varchar(36);index" json:"trigger_id"` // 事件触发时对应的触发器
+	// 链式触发信息
+	UpstreamExecutionID string         `gorm:"type:varchar(36);index" json:"upstream_execution_id"`
+	ChainDepth          int            `gorm:"default:0" json:"chain_depth"`
+	TraceParent         string         `gorm:"type:varchar(64)" json:"trace_parent"` // 追踪上下文, 分发和下发任务时沿用
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 
 	// 关联
 	Job       Job          `gorm:"foreignKey:JobID" json:"job,omitempty"`
@@ -213,6 +260,187 @@ type JobSchedule struct {
 	Execution JobExecution `gorm:"foreignKey:ExecutionID" json:"execution,omitempty"`
 }
 
+// JobTrigger 任务事件触发器
+type JobTrigger struct {
+	ID            string         `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	JobID         string         `gorm:"type:varchar(36);not null;index" json:"job_id"`
+	Type          TriggerType    `gorm:"type:varchar(30);not null" json:"type"`
+	Source        string         `gorm:"type:varchar(255);not null" json:"source"` // stream key 或 channel 名称
+	ConsumerGroup string         `gorm:"type:varchar(100)" json:"consumer_group"`
+	Filter        string         `gorm:"type:text" json:"filter"` // 事件字段过滤表达式
+	Enabled       bool           `gorm:"default:true" json:"enabled"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	CreatedBy     string         `gorm:"type:varchar(100)" json:"created_by"`
+
+	// 关联
+	Job *Job `gorm:"foreignKey:JobID" json:"job,omitempty"`
+}
+
+// JobLabel 任务标签, 与 Job.Labels 保持同步, 用于按标签选择器过滤任务
+type JobLabel struct {
+	JobID string `gorm:"primaryKey;type:varchar(36)" json:"job_id"`
+	Name  string `gorm:"primaryKey;type:varchar(128);index:idx_job_label" json:"name"`
+	Value string `gorm:"type:varchar(63);index:idx_job_label" json:"value"`
+}
+
+// AuditLog 操作审计记录
+type AuditLog struct {
+	ID           string    `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	Action       string    `gorm:"type:varchar(50);not null;index" json:"action"`
+	ResourceType string    `gorm:"type:varchar(50);index" json:"resource_type"`
+	ResourceIDs  string    `gorm:"type:text" json:"resource_ids"`  // 受影响的资源 ID 列表, JSON 数组
+	Detail       string    `gorm:"type:text" json:"detail"`        // 操作参数和结果, JSON 对象
+	Result       string    `gorm:"type:varchar(20)" json:"result"` // success、partial 或 failed
+	Operator     string    `gorm:"type:varchar(100);index" json:"operator"`
+	CreatedAt    time.Time `gorm:"index" json:"created_at"`
+}
+
+// JobTemplate 任务模板
+type JobTemplate struct {
+	ID            string         `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	Name          string         `gorm:"type:varchar(255);not null;uniqueIndex" json:"name"`
+	Description   string         `gorm:"type:text" json:"description"`
+	Command       string         `gorm:"type:text;not null" json:"command"` // 可包含 {{参数名}} 占位符
+	ParamsSchema  string         `gorm:"type:text" json:"params_schema"`    // 参数定义, JSON 数组
+	Timeout       int            `gorm:"default:300" json:"timeout"`        // 秒
+	RetryAttempts int            `gorm:"default:3" json:"retry_attempts"`
+	Version       int            `gorm:"default:1" json:"version"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	CreatedBy     string         `gorm:"type:varchar(100)" json:"created_by"`
+}
+
+// TemplateParam 模板参数定义
+type TemplateParam struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	Required     bool   `json:"required,omitempty"`
+	DefaultValue string `json:"default_value,omitempty"`
+	Pattern      string `json:"pattern,omitempty"` // 参数值需要匹配的正则
+	Raw          bool   `json:"raw,omitempty"`     // 原样替换占位符, 默认按 shell 单引号转义
+}
+
+// JobRevision 任务定义版本, 每次创建、更新或回滚都会保存完整快照
+type JobRevision struct {
+	ID           string             `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	JobID        string             `gorm:"type:varchar(36);not null;uniqueIndex:idx_job_revision" json:"job_id"`
+	Revision     int                `gorm:"not null;uniqueIndex:idx_job_revision" json:"revision"`
+	ChangeType   RevisionChangeType `gorm:"type:varchar(20);not null" json:"change_type"`
+	Snapshot     string             `gorm:"type:text;not null" json:"snapshot"` // 任务定义快照, JSON 字符串
+	RollbackFrom int                `gorm:"default:0" json:"rollback_from"`     // 回滚时的来源版本号
+	Comment      string             `gorm:"type:varchar(500)" json:"comment"`
+	CreatedBy    string             `gorm:"type:varchar(100)" json:"created_by"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+// SLAMiss SLA 违约记录
+type SLAMiss struct {
+	ID           string      `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	JobID        string      `gorm:"type:varchar(36);not null;uniqueIndex:idx_sla_miss" json:"job_id"`
+	Type         SLAMissType `gorm:"type:varchar(20);not null;uniqueIndex:idx_sla_miss" json:"type"`
+	SLADate      string      `gorm:"type:varchar(10);not null;uniqueIndex:idx_sla_miss;index" json:"sla_date"` // 违约所属日期 YYYY-MM-DD
+	ExecutionID  string      `gorm:"type:varchar(36);not null;default:'';uniqueIndex:idx_sla_miss" json:"execution_id"`
+	DepartmentID string      `gorm:"type:varchar(36);index" json:"department_id"`
+	Deadline     *time.Time  `json:"deadline"`
+	Expected     string      `gorm:"type:varchar(255)" json:"expected"`
+	Actual       string      `gorm:"type:varchar(255)" json:"actual"`
+	DetectedAt   time.Time   `gorm:"index" json:"detected_at"`
+	CreatedAt    time.Time   `json:"created_at"`
+
+	// 关联
+	Job *Job `gorm:"foreignKey:JobID" json:"job,omitempty"`
+}
+
+// RetentionPolicy 执行记录保留策略, 任务策略优先于部门策略, 部门策略优先于全局策略
+type RetentionPolicy struct {
+	ID         string         `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	Scope      RetentionScope `gorm:"type:varchar(20);not null;uniqueIndex:idx_retention_scope" json:"scope"`
+	ScopeID    string         `gorm:"type:varchar(36);not null;default:'';uniqueIndex:idx_retention_scope" json:"scope_id"` // 部门或任务 ID, 全局策略为空
+	MaxAgeDays int            `gorm:"default:0" json:"max_age_days"`                                                        // 执行记录保留天数, 0 表示不按时间清理
+	MaxCount   int            `gorm:"default:0" json:"max_count"`                                                           // 每个任务保留的最近执行数, 0 表示不限制
+	Archive    bool           `json:"archive"`                                                                              // 删除前是否归档
+	CreatedBy  string         `gorm:"type:varchar(100)" json:"created_by"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// RetentionArchive 清理时写出的归档文件
+type RetentionArchive struct {
+	ID         string     `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	Path       string     `gorm:"type:varchar(500);not null" json:"path"`
+	Records    int        `json:"records"`
+	OldestAt   *time.Time `json:"oldest_at"` // 归档记录中最早的创建时间
+	NewestAt   *time.Time `json:"newest_at"` // 归档记录中最晚的创建时间
+	RestoredAt *time.Time `json:"restored_at"`
+	CreatedAt  time.Time  `gorm:"index" json:"created_at"`
+}
+
+// ExecutionOutputChunk 执行日志片段, 同一输出流内按 Seq 排列, 按 ID 排列即为合并后的输出
+type ExecutionOutputChunk struct {
+	ID          uint64       `gorm:"primaryKey;autoIncrement" json:"id"`
+	ExecutionID string       `gorm:"type:varchar(36);not null;uniqueIndex:idx_output_chunk" json:"execution_id"`
+	Stream      OutputStream `gorm:"type:varchar(10);not null;uniqueIndex:idx_output_chunk" json:"stream"`
+	Seq         int64        `gorm:"not null;uniqueIndex:idx_output_chunk" json:"seq"`
+	Size        int64        `gorm:"not null;default:0" json:"size"`
+	Data        string       `gorm:"type:longtext" json:"data"` // 使用文件存储时为空, 内容保存在日志目录中
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// WorkerMetric 工作节点主机指标的历史记录, 按 scheduler.placement.historyInterval 从心跳中采样保存
+type WorkerMetric struct {
+	ID                   uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkerID             string    `gorm:"type:varchar(36);not null;index:idx_worker_metric_time" json:"worker_id"`
+	CPUPercent           float64   `json:"cpu_percent"`
+	MemoryPercent        float64   `json:"memory_percent"`
+	DiskPercent          float64   `json:"disk_percent"`
+	Load1                float64   `json:"load1"`
+	Load5                float64   `json:"load5"`
+	Load15               float64   `json:"load15"`
+	CPUCount             int       `json:"cpu_count"`
+	MemoryTotalBytes     int64     `json:"memory_total_bytes"`
+	MemoryAvailableBytes int64     `json:"memory_available_bytes"`
+	DiskTotalBytes       int64     `json:"disk_total_bytes"`
+	DiskFreeBytes        int64     `json:"disk_free_bytes"`
+	HostUptimeSeconds    float64   `json:"host_uptime_seconds"`
+	UptimeSeconds        float64   `json:"uptime_seconds"` // 工作节点进程的运行时间
+	CreatedAt            time.Time `gorm:"index:idx_worker_metric_time;index" json:"created_at"`
+}
+
+// Connection 数据库连接, sql 类型的任务按名称引用, 下发任务时把连接信息一并发给工作节点
+type Connection struct {
+	ID           string    `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	Name         string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	Driver       string    `gorm:"type:varchar(20);not null" json:"driver"`     // mysql、postgres 或 sqlite
+	DepartmentID string    `gorm:"type:varchar(36);index" json:"department_id"` // 为空时所有部门的任务都可以引用
+	Ciphertext   []byte    `gorm:"type:blob" json:"-"`                          // 数据密钥加密的连接串
+	DataKey      []byte    `gorm:"type:blob" json:"-"`                          // 主密钥加密的数据密钥
+	KeyID        string    `gorm:"type:varchar(50);index" json:"key_id"`        // 加密数据密钥的主密钥
+	Description  string    `gorm:"type:text" json:"description"`
+	CreatedBy    string    `gorm:"type:varchar(100)" json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Secret 加密保存的密钥, 任务参数通过 ${secret:name} 引用, 下发任务时解密替换
+//
+// 值由独立的数据密钥加密, 数据密钥由 KeyID 对应的主密钥加密, 值不在接口中返回。
+type Secret struct {
+	ID          string      `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	Name        string      `gorm:"type:varchar(100);not null;uniqueIndex:idx_secret_scope" json:"name"`
+	Scope       SecretScope `gorm:"type:varchar(20);not null;uniqueIndex:idx_secret_scope" json:"scope"`
+	ScopeID     string      `gorm:"type:varchar(36);not null;uniqueIndex:idx_secret_scope" json:"scope_id"` // 部门或任务 ID
+	Ciphertext  []byte      `gorm:"type:blob;not null" json:"-"`
+	DataKey     []byte      `gorm:"type:blob;not null" json:"-"`                   // 主密钥加密的数据密钥
+	KeyID       string      `gorm:"type:varchar(50);not null;index" json:"key_id"` // 加密数据密钥的主密钥
+	Description string      `gorm:"type:text" json:"description"`
+	CreatedBy   string      `gorm:"type:varchar(100)" json:"created_by"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
 // 用户状态
 type UserStatus string
 
@@ -267,6 +495,15 @@ const (
 	ExecutionStatusCancelled JobExecutionStatus = "cancelled"
 )
 
+// 任务被终止的原因
+type TerminationCause string
+
+const (
+	TerminationTimeout  TerminationCause = "timeout"  // 执行超时
+	TerminationCancel   TerminationCause = "cancel"   // 被调度器取消
+	TerminationShutdown TerminationCause = "shutdown" // 工作节点停止
+)
+
 // 工作节点状态
 type WorkerStatus string
 
@@ -289,6 +526,78 @@ const (
 	ScheduleStatusSkipped   ScheduleStatus = "skipped"
 )
 
+// 触发器类型
+type TriggerType string
+
+const (
+	TriggerTypeRedisStream TriggerType = "redis_stream"
+	TriggerTypeRedisPubSub TriggerType = "redis_pubsub"
+)
+
+// 任务版本变更类型
+type RevisionChangeType string
+
+const (
+	RevisionChangeBaseline RevisionChangeType = "baseline" // 启用版本记录前已存在的任务定义
+	RevisionChangeCreate   RevisionChangeType = "create"
+	RevisionChangeUpdate   RevisionChangeType = "update"
+	RevisionChangeRollback RevisionChangeType = "rollback"
+)
+
+// SLA 违约类型
+type SLAMissType string
+
+const (
+	SLAMissStartBy     SLAMissType = "start_by"
+	SLAMissFinishBy    SLAMissType = "finish_by"
+	SLAMissMaxDuration SLAMissType = "max_duration"
+)
+
+// 保留策略作用范围
+type RetentionScope string
+
+const (
+	RetentionScopeGlobal     RetentionScope = "global"
+	RetentionScopeDepartment RetentionScope = "department"
+	RetentionScopeJob        RetentionScope = "job"
+)
+
+// 密钥作用范围, 任务的密钥优先于所属部门及上级部门的同名密钥
+type SecretScope string
+
+const (
+	SecretScopeDepartment SecretScope = "department"
+	SecretScopeJob        SecretScope = "job"
+)
+
+// 任务类型
+type JobType string
+
+const (
+	JobTypeCommand JobType = "command" // 执行命令, 由 ExecMode 决定执行方式
+	JobTypeHTTP    JobType = "http"    // 发送 HTTP 请求, 配置见 jobtype.HTTPConfig
+	JobTypeGRPC    JobType = "grpc"    // 通过服务端反射调用一元 gRPC 方法, 配置见 jobtype.GRPCConfig
+	JobTypeSQL     JobType = "sql"     // 在数据库连接上执行 SQL, 配置见 jobtype.SQLConfig
+	JobTypeHandler JobType = "handler" // 调用工作节点注册的进程内处理函数, 配置见 jobtype.HandlerConfig
+)
+
+// 执行模式
+type ExecMode string
+
+const (
+	ExecModeExec   ExecMode = "exec"   // 直接执行 command, args 作为参数, 不经过 shell
+	ExecModeShell  ExecMode = "shell"  // 由解释器执行 command, 如 /bin/sh -c、bash -lc
+	ExecModeScript ExecMode = "script" // command 为多行脚本, 写入临时文件后执行
+)
+
+// 输出流
+type OutputStream string
+
+const (
+	OutputStreamStdout OutputStream = "stdout"
+	OutputStreamStderr OutputStream = "stderr"
+)
+
 // TableName 设置表名
 func (User) TableName() string {
 	return "users"
@@ -333,3 +642,51 @@ func (Worker) TableName() string {
 func (JobSchedule) TableName() string {
 	return "job_schedules"
 }
+
+func (JobTrigger) TableName() string {
+	return "job_triggers"
+}
+
+func (JobLabel) TableName() string {
+	return "job_labels"
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+func (JobTemplate) TableName() string {
+	return "job_templates"
+}
+
+func (JobRevision) TableName() string {
+	return "job_revisions"
+}
+
+func (SLAMiss) TableName() string {
+	return "sla_misses"
+}
+
+func (RetentionPolicy) TableName() string {
+	return "retention_policies"
+}
+
+func (RetentionArchive) TableName() string {
+	return "retention_archives"
+}
+
+func (ExecutionOutputChunk) TableName() string {
+	return "execution_output_chunks"
+}
+
+func (WorkerMetric) TableName() string {
+	return "worker_metrics"
+}
+
+func (Connection) TableName() string {
+	return "connections"
+}
+
+func (Secret) TableName() string {
+	return "secrets"
+}
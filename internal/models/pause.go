@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// IsPaused 判断任务在 now 时刻是否处于暂停状态, 到达截止时间后自动恢复
+func (j *Job) IsPaused(now time.Time) bool {
+	if !j.Paused {
+		return false
+	}
+	return j.PausedUntil == nil || now.Before(*j.PausedUntil)
+}
@@ -0,0 +1,146 @@
+package retention
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"go-job/internal/models"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// archiveVersion 归档格式版本
+const archiveVersion = 1
+
+// archivedExecution 归档中的执行记录, 不含关联对象
+type archivedExecution struct {
+	models.JobExecution
+	Job    *struct{} `json:"job,omitempty"`
+	Worker *struct{} `json:"worker,omitempty"`
+}
+
+// archiveLine 归档文件中的一行
+type archiveLine struct {
+	Version   int                `json:"version"`
+	Type      string             `json:"type"`
+	Execution *archivedExecution `json:"execution,omitempty"`
+}
+
+// archiveWriter 写 gzip 压缩的 JSONL 归档, 每批写入后落盘, 之后才删除对应记录
+type archiveWriter struct {
+	path    string
+	file    *os.File
+	gz      *gzip.Writer
+	records int
+	oldest  time.Time
+	newest  time.Time
+}
+
+// createArchive 在 dir 下创建新的归档文件
+func createArchive(dir string, now time.Time) (*archiveWriter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("创建归档目录失败: %w", err)
+	}
+
+	name := fmt.Sprintf("executions-%s-%s.jsonl.gz", now.UTC().Format("20060102T150405Z"), uuid.New().String()[:8])
+	path := filepath.Join(dir, name)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("创建归档文件失败: %w", err)
+	}
+
+	return &archiveWriter{path: path, file: file, gz: gzip.NewWriter(file)}, nil
+}
+
+// write 追加一批执行记录并落盘
+func (w *archiveWriter) write(executions []models.JobExecution) error {
+	encoder := json.NewEncoder(w.gz)
+	for i := range executions {
+		execution := &executions[i]
+		line := archiveLine{
+			Version:   archiveVersion,
+			Type:      "execution",
+			Execution: &archivedExecution{JobExecution: *execution},
+		}
+		if err := encoder.Encode(&line); err != nil {
+			return fmt.Errorf("写入归档失败: %w", err)
+		}
+
+		if w.records == 0 || execution.CreatedAt.Before(w.oldest) {
+			w.oldest = execution.CreatedAt
+		}
+		if execution.CreatedAt.After(w.newest) {
+			w.newest = execution.CreatedAt
+		}
+		w.records++
+	}
+
+	if err := w.gz.Flush(); err != nil {
+		return fmt.Errorf("写入归档失败: %w", err)
+	}
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("写入归档失败: %w", err)
+	}
+	return nil
+}
+
+// close 结束归档文件
+func (w *archiveWriter) close() error {
+	if err := w.gz.Close(); err != nil {
+		w.file.Close()
+		return fmt.Errorf("关闭归档失败: %w", err)
+	}
+	if err := w.file.Sync(); err != nil {
+		w.file.Close()
+		return fmt.Errorf("关闭归档失败: %w", err)
+	}
+	return w.file.Close()
+}
+
+// ReadArchive 逐条读取归档中的执行记录
+//
+// 清理过程中异常退出时归档可能缺少 gzip 结尾, 已完整写入的记录仍会被读出。
+func ReadArchive(path string, fn func(*models.JobExecution) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("打开归档文件失败: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("读取归档文件失败: %w", err)
+	}
+	defer gz.Close()
+
+	reader := bufio.NewReader(gz)
+	for lineNo := 1; ; lineNo++ {
+		raw, err := reader.ReadBytes('\n')
+		if len(raw) > 0 && raw[len(raw)-1] == '\n' {
+			var line archiveLine
+			if jsonErr := json.Unmarshal(raw, &line); jsonErr != nil {
+				return fmt.Errorf("归档第 %d 行格式错误: %w", lineNo, jsonErr)
+			}
+			if line.Version > archiveVersion {
+				return fmt.Errorf("不支持的归档版本: %d", line.Version)
+			}
+			if line.Type == "execution" && line.Execution != nil {
+				if err := fn(&line.Execution.JobExecution); err != nil {
+					return err
+				}
+			}
+		}
+
+		switch {
+		case err == io.EOF || err == io.ErrUnexpectedEOF:
+			return nil
+		case err != nil:
+			return fmt.Errorf("读取归档文件失败: %w", err)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package retention
+
+import (
+	"fmt"
+	"go-job/internal/logstore"
+	"go-job/internal/models"
+	"go-job/pkg/config"
+	"go-job/pkg/logger"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// finishedStatuses 已结束的执行状态, 按时间和数量清理时都只删除已结束的记录
+var finishedStatuses = []models.JobExecutionStatus{
+	models.ExecutionStatusSuccess,
+	models.ExecutionStatusFailed,
+	models.ExecutionStatusTimeout,
+	models.ExecutionStatusCancelled,
+}
+
+// Cleaner 按保留策略清理执行记录和调度记录
+//
+// 过期的执行记录先写入归档并落盘, 再从数据库中物理删除; 归档失败时本次清理中止, 不删除未归档的记录。
+// 从归档恢复的记录在 restoreHoldDays 天内不会被再次清理。
+type Cleaner struct {
+	db   *gorm.DB
+	cfg  config.RetentionConfig
+	logs *logstore.Store
+	mu   sync.Mutex
+}
+
+// Result 一次清理的结果
+type Result struct {
+	Executions int                      `json:"executions"` // 删除的执行记录数
+	Archived   int                      `json:"archived"`   // 其中已归档的记录数
+	Schedules  int64                    `json:"schedules"`  // 删除的调度记录数
+	Archive    *models.RetentionArchive `json:"archive,omitempty"`
+}
+
+// NewCleaner 创建清理器, 删除执行记录时同时删除 logs 中的日志
+func NewCleaner(db *gorm.DB, cfg config.RetentionConfig, logs *logstore.Store) *Cleaner {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+	if cfg.ArchiveDir == "" {
+		cfg.ArchiveDir = "data/archives"
+	}
+	return &Cleaner{db: db, cfg: cfg, logs: logs}
+}
+
+// Run 执行一次清理
+func (c *Cleaner) Run(now time.Time) (*Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resolver, err := LoadResolver(c.db, c.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	departments, err := c.jobDepartments()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	var archive *archiveWriter
+	err = func() error {
+		for jobID, departmentID := range departments {
+			policy := resolver.Resolve(jobID, departmentID)
+			if policy.Unlimited() {
+				continue
+			}
+
+			ids, err := c.expired(jobID, policy, now)
+			if err != nil {
+				return err
+			}
+
+			for start := 0; start < len(ids); start += c.cfg.BatchSize {
+				end := start + c.cfg.BatchSize
+				if end > len(ids) {
+					end = len(ids)
+				}
+				batch := ids[start:end]
+
+				if policy.Archive {
+					var executions []models.JobExecution
+					if err := c.db.Unscoped().Where("id IN ?", batch).Order("created_at").Find(&executions).Error; err != nil {
+						return fmt.Errorf("查询过期执行记录失败: %w", err)
+					}
+					if archive == nil {
+						if archive, err = createArchive(c.cfg.ArchiveDir, now); err != nil {
+							return err
+						}
+					}
+					if err := archive.write(executions); err != nil {
+						return err
+					}
+				}
+
+				deleted := c.db.Unscoped().Where("id IN ?", batch).Delete(&models.JobExecution{})
+				if deleted.Error != nil {
+					return fmt.Errorf("删除过期执行记录失败: %w", deleted.Error)
+				}
+				result.Executions += int(deleted.RowsAffected)
+
+				// 日志随执行记录一起删除, 归档中只保留执行记录里截断后的输出
+				if err := c.logs.Delete(batch); err != nil {
+					return err
+				}
+				if policy.Archive {
+					result.Archived += len(batch)
+				}
+			}
+		}
+		return nil
+	}()
+
+	if archive != nil {
+		record, closeErr := c.finishArchive(archive, now)
+		result.Archive = record
+		if err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return result, err
+	}
+
+	// 调度记录只是分发过程的流水, 按时间清理, 不归档
+	if c.cfg.ScheduleMaxAgeDays > 0 {
+		cutoff := now.AddDate(0, 0, -c.cfg.ScheduleMaxAgeDays)
+		deleted := c.db.Unscoped().Where("created_at < ?", cutoff).Delete(&models.JobSchedule{})
+		if deleted.Error != nil {
+			return result, fmt.Errorf("清理调度记录失败: %w", deleted.Error)
+		}
+		result.Schedules = deleted.RowsAffected
+	}
+
+	return result, nil
+}
+
+// jobDepartments 返回有执行记录的任务及其部门, 已删除的任务同样参与清理
+func (c *Cleaner) jobDepartments() (map[string]string, error) {
+	var jobIDs []string
+	if err := c.db.Unscoped().Model(&models.JobExecution{}).Distinct("job_id").Pluck("job_id", &jobIDs).Error; err != nil {
+		return nil, fmt.Errorf("查询执行记录失败: %w", err)
+	}
+
+	departments := make(map[string]string, len(jobIDs))
+	for _, id := range jobIDs {
+		departments[id] = ""
+	}
+
+	for start := 0; start < len(jobIDs); start += c.cfg.BatchSize {
+		end := start + c.cfg.BatchSize
+		if end > len(jobIDs) {
+			end = len(jobIDs)
+		}
+		var jobs []models.Job
+		if err := c.db.Unscoped().Select("id", "department_id").Where("id IN ?", jobIDs[start:end]).Find(&jobs).Error; err != nil {
+			return nil, fmt.Errorf("查询任务失败: %w", err)
+		}
+		for _, job := range jobs {
+			departments[job.ID] = job.DepartmentID
+		}
+	}
+	return departments, nil
+}
+
+// expired 返回任务按策略过期的执行记录 ID
+func (c *Cleaner) expired(jobID string, policy Policy, now time.Time) ([]string, error) {
+	holdCutoff := now.AddDate(0, 0, -c.cfg.RestoreHoldDays)
+	seen := make(map[string]bool)
+	var ids []string
+
+	if policy.MaxAgeDays > 0 {
+		var aged []string
+		err := c.db.Unscoped().Model(&models.JobExecution{}).
+			Where("job_id = ? AND created_at < ? AND status IN ?", jobID, now.AddDate(0, 0, -policy.MaxAgeDays), finishedStatuses).
+			Where("restored_at IS NULL OR restored_at < ?", holdCutoff).
+			Order("created_at").
+			Pluck("id", &aged).Error
+		if err != nil {
+			return nil, fmt.Errorf("查询过期执行记录失败: %w", err)
+		}
+		for _, id := range aged {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if policy.MaxCount > 0 {
+		var rows []models.JobExecution
+		err := c.db.Unscoped().Select("id", "status", "restored_at").
+			Where("job_id = ?", jobID).
+			Order("created_at DESC").Order("id DESC").
+			Find(&rows).Error
+		if err != nil {
+			return nil, fmt.Errorf("查询执行记录失败: %w", err)
+		}
+		if len(rows) > policy.MaxCount {
+			for _, row := range rows[policy.MaxCount:] {
+				if seen[row.ID] || !isFinished(row.Status) {
+					continue
+				}
+				if row.RestoredAt != nil && !row.RestoredAt.Before(holdCutoff) {
+					continue
+				}
+				seen[row.ID] = true
+				ids = append(ids, row.ID)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// finishArchive 关闭归档文件并记录, 即使清理中途失败, 已写入并删除的记录也需要能找到
+func (c *Cleaner) finishArchive(archive *archiveWriter, now time.Time) (*models.RetentionArchive, error) {
+	closeErr := archive.close()
+
+	record := &models.RetentionArchive{
+		ID:      uuid.New().String(),
+		Path:    archive.path,
+		Records: archive.records,
+	}
+	if archive.records > 0 {
+		oldest, newest := archive.oldest, archive.newest
+		record.OldestAt, record.NewestAt = &oldest, &newest
+	}
+	if err := c.db.Create(record).Error; err != nil {
+		return nil, fmt.Errorf("保存归档记录失败: %w", err)
+	}
+
+	logger.Infof("已归档 %d 条执行记录: %s", archive.records, archive.path)
+	return record, closeErr
+}
+
+// Restore 将归档中的执行记录导回数据库, 已存在的记录保持不变, 返回新导入的数量
+//
+// 导入的记录标记恢复时间, 在保护期内不会被再次清理。
+func Restore(db *gorm.DB, path string, now time.Time) (int, error) {
+	restored := 0
+	batch := make([]models.JobExecution, 0, 500)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		inserted := db.Omit(clause.Associations).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&batch)
+		if inserted.Error != nil {
+			return fmt.Errorf("导入执行记录失败: %w", inserted.Error)
+		}
+		restored += int(inserted.RowsAffected)
+		batch = batch[:0]
+		return nil
+	}
+
+	err := ReadArchive(path, func(execution *models.JobExecution) error {
+		restoredAt := now
+		execution.RestoredAt = &restoredAt
+		batch = append(batch, *execution)
+		if len(batch) == cap(batch) {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return restored, err
+	}
+	if err := flush(); err != nil {
+		return restored, err
+	}
+
+	db.Model(&models.RetentionArchive{}).Where("path = ?", path).Update("restored_at", now)
+	logger.Infof("已从归档恢复 %d 条执行记录: %s", restored, path)
+	return restored, nil
+}
+
+func isFinished(status models.JobExecutionStatus) bool {
+	for _, s := range finishedStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
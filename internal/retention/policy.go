@@ -0,0 +1,126 @@
+package retention
+
+import (
+	"fmt"
+	"go-job/internal/models"
+	"go-job/pkg/config"
+
+	"gorm.io/gorm"
+)
+
+// Policy 对某个任务生效的保留策略
+type Policy struct {
+	Source     models.RetentionScope `json:"source"`              // 策略来源, 未配置全局策略时为 config
+	PolicyID   string                `json:"policy_id,omitempty"` // 来源为配置文件时为空
+	ScopeID    string                `json:"scope_id,omitempty"`
+	MaxAgeDays int                   `json:"max_age_days"`
+	MaxCount   int                   `json:"max_count"`
+	Archive    bool                  `json:"archive"`
+}
+
+// SourceConfig 策略来自配置文件
+const SourceConfig models.RetentionScope = "config"
+
+// Unlimited 策略是否既不按时间也不按数量清理
+func (p Policy) Unlimited() bool {
+	return p.MaxAgeDays <= 0 && p.MaxCount <= 0
+}
+
+// Resolver 按任务、部门(含上级部门)、全局的顺序确定生效的策略
+type Resolver struct {
+	defaults    Policy
+	departments map[string]Policy
+	jobs        map[string]Policy
+	parents     map[string]string
+}
+
+// LoadResolver 加载所有保留策略
+func LoadResolver(db *gorm.DB, cfg config.RetentionConfig) (*Resolver, error) {
+	var policies []models.RetentionPolicy
+	if err := db.Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("查询保留策略失败: %w", err)
+	}
+
+	r := &Resolver{
+		defaults: Policy{
+			Source:     SourceConfig,
+			MaxAgeDays: cfg.MaxAgeDays,
+			MaxCount:   cfg.MaxCount,
+			Archive:    cfg.Archive,
+		},
+		departments: make(map[string]Policy),
+		jobs:        make(map[string]Policy),
+		parents:     make(map[string]string),
+	}
+
+	for _, p := range policies {
+		policy := Policy{
+			Source:     p.Scope,
+			PolicyID:   p.ID,
+			ScopeID:    p.ScopeID,
+			MaxAgeDays: p.MaxAgeDays,
+			MaxCount:   p.MaxCount,
+			Archive:    p.Archive,
+		}
+		switch p.Scope {
+		case models.RetentionScopeGlobal:
+			r.defaults = policy
+		case models.RetentionScopeDepartment:
+			r.departments[p.ScopeID] = policy
+		case models.RetentionScopeJob:
+			r.jobs[p.ScopeID] = policy
+		}
+	}
+
+	if len(r.departments) > 0 {
+		var departments []models.Department
+		if err := db.Unscoped().Select("id", "parent_id").Find(&departments).Error; err != nil {
+			return nil, fmt.Errorf("查询部门失败: %w", err)
+		}
+		for _, d := range departments {
+			if d.ParentID != nil {
+				r.parents[d.ID] = *d.ParentID
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// Resolve 返回对任务生效的策略
+func (r *Resolver) Resolve(jobID, departmentID string) Policy {
+	if policy, ok := r.jobs[jobID]; ok {
+		return policy
+	}
+
+	// 沿部门层级向上查找, 防止数据异常时出现环
+	seen := make(map[string]bool)
+	for id := departmentID; id != "" && !seen[id]; id = r.parents[id] {
+		seen[id] = true
+		if policy, ok := r.departments[id]; ok {
+			return policy
+		}
+	}
+
+	return r.defaults
+}
+
+// Validate 校验保留策略
+func Validate(p *models.RetentionPolicy) error {
+	switch p.Scope {
+	case models.RetentionScopeGlobal:
+		if p.ScopeID != "" {
+			return fmt.Errorf("全局策略不能指定 scope_id")
+		}
+	case models.RetentionScopeDepartment, models.RetentionScopeJob:
+		if p.ScopeID == "" {
+			return fmt.Errorf("%s 策略需要指定 scope_id", p.Scope)
+		}
+	default:
+		return fmt.Errorf("无效的策略范围: %s, 可选 global、department 或 job", p.Scope)
+	}
+	if p.MaxAgeDays < 0 || p.MaxCount < 0 {
+		return fmt.Errorf("保留天数和保留数量不能为负数")
+	}
+	return nil
+}
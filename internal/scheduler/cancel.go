@@ -0,0 +1,156 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrExecutionNotFound 执行记录不存在
+	ErrExecutionNotFound = errors.New("执行记录不存在")
+	// ErrExecutionFinished 执行已结束, 不能取消
+	ErrExecutionFinished = errors.New("只能取消等待中或运行中的任务")
+)
+
+// CancelExecution 取消执行, 对应 JobService.CancelExecution
+func (s *Service) CancelExecution(ctx context.Context, req *grpc.CancelExecutionRequest) (*grpc.CancelExecutionResponse, error) {
+	notified, err := s.RequestCancel(req.GetExecutionId(), req.GetReason())
+	switch {
+	case errors.Is(err, ErrExecutionNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrExecutionFinished):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	return &grpc.CancelExecutionResponse{Success: true, WorkerNotified: notified}, nil
+}
+
+// RequestCancel 取消等待中或运行中的执行, 返回是否需要通知工作节点终止任务
+//
+// 执行记录立即标记为已取消。尚未下发的调度不再下发; 已下发的任务通过推送连接通知工作节点,
+// 没有推送连接时在下次心跳响应中下发。工作节点终止进程后上报的结果只补充输出和终止原因, 不覆盖取消状态。
+func (s *Service) RequestCancel(executionID, reason string) (bool, error) {
+	var execution models.JobExecution
+	if err := s.db.Select("id", "worker_id", "status").First(&execution, "id = ?", executionID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, ErrExecutionNotFound
+		}
+		return false, fmt.Errorf("查询执行记录失败: %w", err)
+	}
+
+	message := "用户取消执行"
+	if reason != "" {
+		message += ": " + reason
+	}
+	now := time.Now()
+	cancelled := s.db.Model(&models.JobExecution{}).
+		Where("id = ? AND status IN ?", executionID,
+			[]models.JobExecutionStatus{models.ExecutionStatusPending, models.ExecutionStatusRunning}).
+		Updates(map[string]interface{}{
+			"status":      models.ExecutionStatusCancelled,
+			"finished_at": &now,
+			"error":       message,
+		})
+	if cancelled.Error != nil {
+		return false, fmt.Errorf("取消执行记录失败: %w", cancelled.Error)
+	}
+	if cancelled.RowsAffected == 0 {
+		return false, ErrExecutionFinished
+	}
+	logger.Infof("取消执行: %s", executionID)
+
+	s.publishStatus(executionID, &grpc.ReportTaskResultRequest{
+		TaskId:     executionID,
+		Status:     grpc.ExecutionStatus_CANCELLED,
+		Error:      message,
+		FinishedAt: timestamppb.New(now),
+	})
+
+	// 尚未下发的调度直接结束, 与下发竞争时以条件更新为准
+	undelivered := s.db.Model(&models.JobSchedule{}).
+		Where("execution_id = ? AND status IN ?", executionID,
+			[]models.ScheduleStatus{models.ScheduleStatusPending, models.ScheduleStatusAssigned}).
+		Update("status", models.ScheduleStatusFailed)
+	if undelivered.Error != nil {
+		logger.WithError(undelivered.Error).Errorf("更新调度记录失败: %s", executionID)
+	}
+	if undelivered.RowsAffected > 0 || execution.WorkerID == "" {
+		if execution.WorkerID != "" {
+			s.workersMu.Lock()
+			if worker, exists := s.workers[execution.WorkerID]; exists && worker.CurrentLoad > 0 {
+				worker.CurrentLoad--
+			}
+			s.workersMu.Unlock()
+		}
+		go s.triggerDownstream(executionID)
+		return false, nil
+	}
+
+	s.notifyCancel(execution.WorkerID, executionID, message)
+	return true, nil
+}
+
+// notifyCancel 通知工作节点取消任务, 推送失败时排队等待心跳响应下发
+func (s *Service) notifyCancel(workerID, executionID, reason string) {
+	if ws := s.stream(workerID); ws != nil && ws.push(cancelMessage(executionID, reason)) {
+		logger.Infof("已通知工作节点 %s 取消任务 %s", workerID, executionID)
+		return
+	}
+
+	s.cancelsMu.Lock()
+	defer s.cancelsMu.Unlock()
+	if s.cancels[workerID] == nil {
+		s.cancels[workerID] = make(map[string]string)
+	}
+	s.cancels[workerID][executionID] = reason
+	logger.Infof("工作节点 %s 没有推送连接, 取消任务 %s 的指令将随心跳下发", workerID, executionID)
+}
+
+// takeCancels 取出排队等待下发给工作节点的取消指令
+func (s *Service) takeCancels(workerID string) []*grpc.CancelTask {
+	s.cancelsMu.Lock()
+	defer s.cancelsMu.Unlock()
+
+	pending := s.cancels[workerID]
+	if len(pending) == 0 {
+		return nil
+	}
+	delete(s.cancels, workerID)
+
+	cancels := make([]*grpc.CancelTask, 0, len(pending))
+	for executionID, reason := range pending {
+		cancels = append(cancels, &grpc.CancelTask{TaskId: executionID, Reason: reason})
+	}
+	return cancels
+}
+
+// forgetCancel 工作节点已上报结果时丢弃尚未下发的取消指令
+func (s *Service) forgetCancel(workerID, executionID string) {
+	s.cancelsMu.Lock()
+	defer s.cancelsMu.Unlock()
+
+	if pending := s.cancels[workerID]; pending != nil {
+		delete(pending, executionID)
+		if len(pending) == 0 {
+			delete(s.cancels, workerID)
+		}
+	}
+}
+
+// cancelMessage 推送给工作节点的取消指令
+func cancelMessage(executionID, reason string) *grpc.SchedulerMessage {
+	return &grpc.SchedulerMessage{
+		Payload: &grpc.SchedulerMessage_Cancel{Cancel: &grpc.CancelTask{TaskId: executionID, Reason: reason}},
+	}
+}
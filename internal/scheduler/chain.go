@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"fmt"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+	"go-job/pkg/metrics"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// triggerDownstream 根据执行结果触发下游任务
+//
+// 成功触发 OnSuccess, 最终失败或超时触发 OnFailure, 取消只触发 OnFinish,
+// 三种结果都会触发 OnFinish。上游执行 ID、任务 ID、退出码和状态通过参数传递给下游。
+// 链路深度超过 scheduler.chainMaxDepth 或下游任务已出现在上游链路中时不再触发, 防止循环。
+func (s *Service) triggerDownstream(executionID string) {
+	var execution models.JobExecution
+	if err := s.db.Preload("Job").First(&execution, "id = ?", executionID).Error; err != nil {
+		logger.WithError(err).Errorf("查询执行记录失败: %s", executionID)
+		return
+	}
+
+	var targets []string
+	switch execution.Status {
+	case models.ExecutionStatusSuccess:
+		targets = append(targets, models.DecodeJobIDs(execution.Job.OnSuccess)...)
+	case models.ExecutionStatusFailed, models.ExecutionStatusTimeout:
+		targets = append(targets, models.DecodeJobIDs(execution.Job.OnFailure)...)
+	case models.ExecutionStatusCancelled:
+	default:
+		return
+	}
+	targets = models.NormalizeJobIDs(append(targets, models.DecodeJobIDs(execution.Job.OnFinish)...))
+	if len(targets) == 0 {
+		return
+	}
+
+	depth := execution.ChainDepth + 1
+	if maxDepth := s.config.Scheduler.ChainMaxDepth; maxDepth > 0 && depth > maxDepth {
+		logger.Warnf("链式触发深度超过上限 %d, 停止触发下游任务: %s", maxDepth, executionID)
+		return
+	}
+
+	ancestors, err := s.chainAncestors(&execution)
+	if err != nil {
+		logger.WithError(err).Errorf("查询上游链路失败: %s", executionID)
+		return
+	}
+
+	params := map[string]string{
+		"UPSTREAM_EXECUTION_ID": execution.ID,
+		"UPSTREAM_JOB_ID":       execution.JobID,
+		"UPSTREAM_EXIT_CODE":    fmt.Sprintf("%d", execution.ExitCode),
+		"UPSTREAM_STATUS":       string(execution.Status),
+	}
+
+	for _, jobID := range targets {
+		if ancestors[jobID] {
+			logger.Warnf("检测到循环链式触发, 跳过下游任务: %s -> %s", execution.JobID, jobID)
+			continue
+		}
+
+		var job models.Job
+		if err := s.db.Select("id", "paused", "paused_until").First(&job, "id = ? AND enabled = ?", jobID, true).Error; err != nil {
+			logger.Warnf("下游任务不存在或已禁用, 跳过: %s", jobID)
+			continue
+		}
+		if job.IsPaused(time.Now()) {
+			logger.Infof("下游任务已暂停, 跳过: %s", jobID)
+			metrics.SchedulerSkippedFires.WithLabelValues("paused").Inc()
+			continue
+		}
+		metrics.SchedulerFires.WithLabelValues("chain").Inc()
+
+		schedule := newSchedule(jobID, params)
+		schedule.UpstreamExecutionID = execution.ID
+		schedule.ChainDepth = depth
+		if err := s.enqueueSchedule(schedule); err != nil {
+			logger.WithError(err).Errorf("触发下游任务失败: %s", jobID)
+			continue
+		}
+
+		logger.Infof("链式触发下游任务: %s -> %s (上游执行: %s)", execution.JobID, jobID, execution.ID)
+	}
+}
+
+// chainAncestors 沿上游执行记录回溯, 返回链路上所有任务 ID
+func (s *Service) chainAncestors(execution *models.JobExecution) (map[string]bool, error) {
+	ancestors := map[string]bool{execution.JobID: true}
+
+	upstreamID := execution.UpstreamExecutionID
+	for i := 0; upstreamID != "" && i < execution.ChainDepth; i++ {
+		var upstream models.JobExecution
+		if err := s.db.Select("id", "job_id", "upstream_execution_id").
+			First(&upstream, "id = ?", upstreamID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				// 上游记录已被清理
+				break
+			}
+			return nil, err
+		}
+		ancestors[upstream.JobID] = true
+		upstreamID = upstream.UpstreamExecutionID
+	}
+
+	return ancestors, nil
+}
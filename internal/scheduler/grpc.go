@@ -5,11 +5,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"go-job/api/grpc"
+	"go-job/internal/jobtype"
 	"go-job/internal/models"
+	"go-job/internal/secret"
 	"go-job/pkg/logger"
+	"go-job/pkg/metrics"
+	"go-job/pkg/output"
+	"go-job/pkg/tracing"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // RegisterWorker 注册工作节点
@@ -20,6 +27,7 @@ func (s *Service) RegisterWorker(ctx context.Context, req *grpc.RegisterWorkerRe
 
 	// 创建工作节点记录
 	metadataJSON, _ := json.Marshal(req.GetMetadata())
+	handlersJSON, _ := json.Marshal(req.GetHandlers())
 	worker := &models.Worker{
 		ID:          workerID,
 		Name:        req.GetName(),
@@ -29,6 +37,7 @@ func (s *Service) RegisterWorker(ctx context.Context, req *grpc.RegisterWorkerRe
 		Capacity:    int(req.GetCapacity()),
 		CurrentLoad: 0,
 		Metadata:    string(metadataJSON),
+		Handlers:    string(handlersJSON),
 	}
 
 	if err := s.db.Create(worker).Error; err != nil {
@@ -48,6 +57,7 @@ func (s *Service) RegisterWorker(ctx context.Context, req *grpc.RegisterWorkerRe
 		CurrentLoad: 0,
 		LastSeen:    time.Now(),
 		Metadata:    req.GetMetadata(),
+		Handlers:    handlerSet(req.GetHandlers()),
 	}
 	s.workersMu.Unlock()
 
@@ -58,6 +68,15 @@ func (s *Service) RegisterWorker(ctx context.Context, req *grpc.RegisterWorkerRe
 	}, nil
 }
 
+// handlerSet 工作节点提供的处理函数集合
+func handlerSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
 // Heartbeat 心跳
 func (s *Service) Heartbeat(ctx context.Context, req *grpc.HeartbeatRequest) (*grpc.HeartbeatResponse, error) {
 	workerID := req.GetWorkerId()
@@ -70,23 +89,37 @@ func (s *Service) Heartbeat(ctx context.Context, req *grpc.HeartbeatRequest) (*g
 	}
 
 	// 更新心跳时间和状态
-	worker.LastSeen = time.Now()
+	now := time.Now()
+	worker.LastSeen = now
 	worker.CurrentLoad = req.GetCurrentLoad()
 	worker.Status = req.GetStatus()
+	var hostMetrics string
+	var history *models.WorkerMetric
+	if req.GetHost() != nil {
+		hostMetrics, history = s.updateHostMetrics(worker, req.GetHost(), now)
+	}
 	s.workersMu.Unlock()
 
 	// 更新数据库
 	updates := map[string]interface{}{
-		"last_heartbeat": time.Now(),
+		"last_heartbeat": now,
 		"current_load":   req.GetCurrentLoad(),
 		"status":         convertWorkerStatus(req.GetStatus()),
 	}
+	if hostMetrics != "" {
+		updates["host_metrics"] = hostMetrics
+	}
 
 	if err := s.db.Model(&models.Worker{}).Where("id = ?", workerID).Updates(updates).Error; err != nil {
 		logger.WithError(err).Errorf("更新工作节点心跳失败: %s", workerID)
 	}
+	if history != nil {
+		if err := s.db.Create(history).Error; err != nil {
+			logger.WithError(err).Errorf("保存工作节点指标历史失败: %s", workerID)
+		}
+	}
 
-	return &grpc.HeartbeatResponse{Success: true}, nil
+	return &grpc.HeartbeatResponse{Success: true, Cancels: s.takeCancels(workerID)}, nil
 }
 
 // GetTask 获取任务
@@ -107,40 +140,195 @@ func (s *Service) GetTask(ctx context.Context, req *grpc.GetTaskRequest) (*grpc.
 	}
 
 	var tasks []*grpc.Task
-	for _, schedule := range schedules {
-		if schedule.Job.ID == "" {
-			continue
+	for i := range schedules {
+		if task := s.claimTask(ctx, &schedules[i], workerID, deliveryPoll); task != nil {
+			tasks = append(tasks, task)
 		}
+	}
 
-		// 解析任务参数
-		var params map[string]string
-		if schedule.Job.Params != "" {
-			json.Unmarshal([]byte(schedule.Job.Params), &params)
-		}
+	logger.Infof("为工作节点 %s 分配了 %d 个任务", workerID, len(tasks))
+
+	return &grpc.GetTaskResponse{
+		Tasks: tasks,
+	}, nil
+}
+
+// 任务下发方式
+const (
+	deliveryPoll = "poll" // 工作节点通过 GetTask 拉取
+	deliveryPush = "push" // 调度器通过长连接推送
+)
+
+// claimTask 将已分配的调度标记为执行中并构造下发给工作节点的任务
+//
+// 只有状态仍为已分配的调度会被领取, 拉取和推送同时发生时同一任务不会下发两次。
+func (s *Service) claimTask(ctx context.Context, schedule *models.JobSchedule, workerID, delivery string) *grpc.Task {
+	if schedule.Job.ID == "" {
+		return nil
+	}
+
+	// 解析任务参数和本次运行的参数
+	params := make(map[string]string)
+	if schedule.Job.Params != "" {
+		json.Unmarshal([]byte(schedule.Job.Params), &params)
+	}
+	var overrides map[string]string
+	if schedule.Params != "" {
+		json.Unmarshal([]byte(schedule.Params), &overrides)
+	}
+
+	var args []string
+	if schedule.Job.Args != "" {
+		json.Unmarshal([]byte(schedule.Job.Args), &args)
+	}
+
+	attrs := map[string]interface{}{
+		"job.id":       schedule.JobID,
+		"execution.id": schedule.ExecutionID,
+		"worker.id":    workerID,
+		"delivery":     delivery,
+	}
+
+	// 更新调度状态为执行中, 其他连接或调度器实例已经下发时放弃, 不产生下发链路
+	now := time.Now()
+	claimed := s.db.Model(&models.JobSchedule{}).
+		Where("id = ? AND status = ?", schedule.ID, models.ScheduleStatusAssigned).
+		Updates(map[string]interface{}{"status": models.ScheduleStatusExecuting, "executed_at": &now})
+	if claimed.Error != nil {
+		logger.WithError(claimed.Error).Errorf("更新调度记录失败: %s", schedule.ID)
+		return nil
+	}
+	if claimed.RowsAffected == 0 {
+		return nil
+	}
+	schedule.Status = models.ScheduleStatusExecuting
+	schedule.ExecutedAt = &now
+
+	// 从分配给工作节点到下发的等待
+	_, waitSpan := tracing.Start(ctx, "scheduler.await_delivery",
+		tracing.WithRemoteParent(schedule.TraceParent),
+		tracing.WithStartTime(schedule.UpdatedAt),
+		tracing.WithAttributes(attrs),
+	)
+	waitSpan.EndAt(now)
+
+	_, span := tracing.Start(ctx, "scheduler.deliver_task",
+		tracing.WithKind(tracing.KindServer),
+		tracing.WithRemoteParent(schedule.TraceParent),
+		tracing.WithStartTime(now),
+		tracing.WithAttributes(attrs),
+	)
+	defer span.End()
+
+	// 密钥只在下发时解密, 引用的密钥不存在或无法解密时按执行失败处理
+	//
+	// 只解析任务定义中的参数。本次运行的参数来自事件负载或上游任务, 可能由任务所有者之外的人控制,
+	// 原样覆盖同名参数而不解析其中的 ${secret:...}, 避免借此读取任务可见范围内的密钥。
+	params, redact, err := s.secrets.Resolve(&schedule.Job, params)
+	if err != nil {
+		logger.WithError(err).Errorf("解析任务密钥失败: %s", schedule.ExecutionID)
+		span.RecordError(err)
+		s.ReportTaskResult(ctx, &grpc.ReportTaskResultRequest{
+			TaskId:     schedule.ExecutionID,
+			WorkerId:   workerID,
+			Status:     grpc.ExecutionStatus_FAILED,
+			Error:      err.Error(),
+			ExitCode:   1,
+			StartedAt:  timestamppb.New(now),
+			FinishedAt: timestamppb.Now(),
+		})
+		return nil
+	}
+	for key, value := range overrides {
+		params[key] = value
+	}
 
-		task := &grpc.Task{
-			Id:            schedule.ExecutionID,
-			JobId:         schedule.JobID,
-			Command:       schedule.Job.Command,
-			Params:        params,
-			Timeout:       int32(schedule.Job.Timeout),
-			RetryAttempts: int32(schedule.Job.RetryAttempts),
+	var connection *grpc.Connection
+	if schedule.Job.Type == models.JobTypeSQL {
+		if connection = s.taskConnection(&schedule.Job); connection != nil {
+			redact = append(redact, connection.Dsn)
 		}
+	}
+	s.rememberRedaction(schedule.ExecutionID, redact)
+
+	return &grpc.Task{
+		Id:            schedule.ExecutionID,
+		JobId:         schedule.JobID,
+		Command:       schedule.Job.Command,
+		Params:        params,
+		Type:          string(schedule.Job.Type),
+		Config:        schedule.Job.Config,
+		Connection:    connection,
+		ExecMode:      string(schedule.Job.ExecMode),
+		Args:          args,
+		Interpreter:   schedule.Job.Interpreter,
+		WorkDir:       schedule.Job.WorkDir,
+		Umask:         schedule.Job.Umask,
+		Limits:        schedule.Job.Limits,
+		Sandbox:       s.taskSandbox(&schedule.Job),
+		Redact:        redact,
+		Timeout:       int32(schedule.Job.Timeout),
+		RetryAttempts: int32(schedule.Job.RetryAttempts),
+		Traceparent:   span.Traceparent(),
+	}
+}
 
-		tasks = append(tasks, task)
+// taskConnection 查询并解密 sql 类型任务引用的数据库连接, 只能引用不限部门或任务所在部门及上级部门的连接,
+// 找不到或无法解密时由工作节点报告执行失败
+func (s *Service) taskConnection(job *models.Job) *grpc.Connection {
+	cfg, err := jobtype.ParseSQL(job.Config)
+	if err != nil {
+		return nil
+	}
 
-		// 更新调度状态为执行中
-		schedule.Status = models.ScheduleStatusExecuting
-		schedule.ExecutedAt = &time.Time{}
-		*schedule.ExecutedAt = time.Now()
-		s.db.Save(&schedule)
+	connection, err := secret.FindConnection(s.db, cfg.Connection, job.DepartmentID)
+	if err != nil {
+		logger.WithError(err).Warnf("查询数据库连接失败: %s", cfg.Connection)
+		return nil
+	}
+	dsn, err := s.secrets.OpenConnection(connection)
+	if err != nil {
+		logger.WithError(err).Errorf("解密数据库连接失败: %s", cfg.Connection)
+		return nil
 	}
+	return &grpc.Connection{
+		Name:   connection.Name,
+		Driver: connection.Driver,
+		Dsn:    dsn,
+	}
+}
 
-	logger.Infof("为工作节点 %s 分配了 %d 个任务", workerID, len(tasks))
+// taskSandbox 合并任务和所属部门的沙箱设置, 都未设置时为空
+func (s *Service) taskSandbox(job *models.Job) string {
+	if job.Type != models.JobTypeCommand && job.Type != "" {
+		return ""
+	}
 
-	return &grpc.GetTaskResponse{
-		Tasks: tasks,
-	}, nil
+	var defaults *jobtype.Sandbox
+	if job.DepartmentID != "" {
+		var department models.Department
+		if err := s.db.Select("id", "sandbox").First(&department, "id = ?", job.DepartmentID).Error; err != nil {
+			logger.WithError(err).Warnf("查询部门失败: %s", job.DepartmentID)
+		} else if defaults, err = jobtype.ParseSandbox(department.Sandbox); err != nil {
+			logger.WithError(err).Warnf("部门的沙箱设置无效: %s", job.DepartmentID)
+		}
+	}
+
+	sandbox, err := jobtype.ParseSandbox(job.Sandbox)
+	if err != nil {
+		// 任务保存时已经校验过, 原样下发由工作节点拒绝执行
+		logger.WithError(err).Warnf("任务的沙箱设置无效: %s", job.ID)
+		return job.Sandbox
+	}
+	merged := sandbox.Merge(defaults)
+	if job.WorkDir != "" {
+		merged.TempWorkDir = nil // 任务指定的工作目录优先于部门默认的临时目录
+	}
+	if merged.Empty() {
+		return ""
+	}
+	raw, _ := json.Marshal(merged)
+	return string(raw)
 }
 
 // ReportTaskResult 报告任务结果
@@ -149,37 +337,49 @@ func (s *Service) ReportTaskResult(ctx context.Context, req *grpc.ReportTaskResu
 	workerID := req.GetWorkerId()
 
 	logger.Infof("收到任务结果报告: %s", executionID)
-
-	// 更新执行记录
-	updates := map[string]interface{}{
-		"status":    convertExecutionStatus(req.GetStatus()),
-		"output":    req.GetOutput(),
-		"error":     req.GetError(),
-		"exit_code": req.GetExitCode(),
-	}
-
-	if req.GetStartedAt() != nil {
-		startedAt := req.GetStartedAt().AsTime()
-		updates["started_at"] = &startedAt
+	s.forgetCancel(workerID, executionID)
+	s.redactResult(req)
+
+	// 已取消的执行只补充工作节点终止任务后的输出和终止原因, 不覆盖取消状态, 也不再重试
+	var current models.JobExecution
+	err := s.db.Select("id", "status", "error").First(&current, "id = ?", executionID).Error
+	if err == nil && current.Status == models.ExecutionStatusCancelled {
+		keepCancelled(req, &current)
 	}
 
-	if req.GetFinishedAt() != nil {
-		finishedAt := req.GetFinishedAt().AsTime()
-		updates["finished_at"] = &finishedAt
+	// 取消可能发生在上面读取状态之后, 非取消结果只更新尚未取消的执行
+	query := s.db.Model(&models.JobExecution{}).Where("id = ?", executionID)
+	if req.GetStatus() != grpc.ExecutionStatus_CANCELLED {
+		query = query.Where("status <> ?", models.ExecutionStatusCancelled)
 	}
-
-	if err := s.db.Model(&models.JobExecution{}).Where("id = ?", executionID).Updates(updates).Error; err != nil {
-		logger.WithError(err).Errorf("更新执行记录失败: %s", executionID)
+	updated := query.Updates(s.resultUpdates(req))
+	if updated.Error != nil {
+		logger.WithError(updated.Error).Errorf("更新执行记录失败: %s", executionID)
 		return &grpc.ReportTaskResultResponse{Success: false}, nil
 	}
+	if updated.RowsAffected == 0 && req.GetStatus() != grpc.ExecutionStatus_CANCELLED {
+		// 没有更新到记录时确认是否刚被取消, 是则按取消补充输出
+		err := s.db.Select("id", "status", "error").First(&current, "id = ?", executionID).Error
+		if err == nil && current.Status == models.ExecutionStatusCancelled {
+			keepCancelled(req, &current)
+			if err := s.db.Model(&models.JobExecution{}).Where("id = ?", executionID).Updates(s.resultUpdates(req)).Error; err != nil {
+				logger.WithError(err).Errorf("更新执行记录失败: %s", executionID)
+				return &grpc.ReportTaskResultResponse{Success: false}, nil
+			}
+		}
+	}
+
+	s.publishStatus(executionID, req)
 
 	// 更新调度记录状态
 	var scheduleStatus models.ScheduleStatus
 	switch req.GetStatus() {
 	case grpc.ExecutionStatus_SUCCESS:
 		scheduleStatus = models.ScheduleStatusCompleted
+		s.forgetRedaction(executionID)
 	case grpc.ExecutionStatus_FAILED, grpc.ExecutionStatus_TIMEOUT, grpc.ExecutionStatus_CANCELLED:
 		scheduleStatus = models.ScheduleStatusFailed
+		s.forgetRedaction(executionID)
 	default:
 		scheduleStatus = models.ScheduleStatusExecuting
 	}
@@ -188,6 +388,8 @@ func (s *Service) ReportTaskResult(ctx context.Context, req *grpc.ReportTaskResu
 		Where("execution_id = ?", executionID).
 		Update("status", scheduleStatus)
 
+	s.observeExecution(executionID, req)
+
 	// 减少工作节点负载
 	s.workersMu.Lock()
 	if worker, exists := s.workers[workerID]; exists && worker.CurrentLoad > 0 {
@@ -196,8 +398,18 @@ func (s *Service) ReportTaskResult(ctx context.Context, req *grpc.ReportTaskResu
 	s.workersMu.Unlock()
 
 	// 如果任务失败且需要重试，创建重试任务
+	retried := false
 	if req.GetStatus() == grpc.ExecutionStatus_FAILED {
-		s.handleTaskRetry(executionID)
+		retried = s.handleTaskRetry(executionID)
+	}
+
+	// 最终结果确定后触发下游任务, 重试中的失败不触发
+	if !retried {
+		switch req.GetStatus() {
+		case grpc.ExecutionStatus_SUCCESS, grpc.ExecutionStatus_FAILED,
+			grpc.ExecutionStatus_TIMEOUT, grpc.ExecutionStatus_CANCELLED:
+			s.triggerDownstream(executionID)
+		}
 	}
 
 	logger.Infof("任务结果处理完成: %s", executionID)
@@ -205,12 +417,62 @@ func (s *Service) ReportTaskResult(ctx context.Context, req *grpc.ReportTaskResu
 	return &grpc.ReportTaskResultResponse{Success: true}, nil
 }
 
-// handleTaskRetry 处理任务重试
-func (s *Service) handleTaskRetry(executionID string) {
+// keepCancelled 把已取消执行的上报结果改为取消, 保留取消时记录的错误信息
+func keepCancelled(req *grpc.ReportTaskResultRequest, current *models.JobExecution) {
+	if req.GetStatus() != grpc.ExecutionStatus_CANCELLED {
+		logger.Infof("执行已取消, 忽略工作节点上报的状态 %s: %s", req.GetStatus(), req.GetTaskId())
+	}
+	req.Status = grpc.ExecutionStatus_CANCELLED
+	req.Error = current.Error
+}
+
+// resultUpdates 执行结果需要更新的字段, 旧版本工作节点上报的输出不受长度限制, 在这里截断, 完整输出以日志存储为准
+func (s *Service) resultUpdates(req *grpc.ReportTaskResultRequest) map[string]interface{} {
+	result := []byte(req.GetOutput())
+	outputCfg := s.config.Scheduler.Output
+	if limit := output.Limit(outputCfg.HeadBytes, outputCfg.TailBytes); limit > 0 && len(result) > limit {
+		result = output.Truncate(result, outputCfg.HeadBytes, outputCfg.TailBytes)
+	}
+	updates := map[string]interface{}{
+		"status":    convertExecutionStatus(req.GetStatus()),
+		"output":    strings.ToValidUTF8(string(result), "\uFFFD"),
+		"error":     req.GetError(),
+		"exit_code": req.GetExitCode(),
+	}
+	if req.GetResult() != "" {
+		updates["result"] = req.GetResult()
+	}
+	if req.GetTermination() != "" {
+		updates["termination"] = req.GetTermination()
+	}
+	if req.GetPeakRssBytes() > 0 {
+		updates["peak_rss"] = req.GetPeakRssBytes()
+	}
+	if req.GetCpuSeconds() > 0 {
+		updates["cpu_seconds"] = req.GetCpuSeconds()
+	}
+	if req.GetStatus() == grpc.ExecutionStatus_SUCCESS {
+		updates["progress"] = 100
+	}
+
+	if req.GetStartedAt() != nil {
+		startedAt := req.GetStartedAt().AsTime()
+		updates["started_at"] = &startedAt
+	}
+
+	if req.GetFinishedAt() != nil {
+		finishedAt := req.GetFinishedAt().AsTime()
+		updates["finished_at"] = &finishedAt
+	}
+	return updates
+}
+
+// handleTaskRetry 处理任务重试, 返回是否创建了重试任务
+func (s *Service) handleTaskRetry(executionID string) bool {
 	var execution models.JobExecution
 	if err := s.db.Preload("Job").First(&execution, "id = ?", executionID).Error; err != nil {
 		logger.WithError(err).Errorf("查询执行记录失败: %s", executionID)
-		return
+		return false
 	}
 
 	// 检查是否还有重试次数
@@ -222,19 +484,30 @@ func (s *Service) handleTaskRetry(executionID string) {
 	if retryCount < int64(execution.Job.RetryAttempts) {
 		logger.Infof("创建重试任务: %s (第%d次重试)", execution.JobID, retryCount+1)
 
-		// 创建新的调度记录
+		// 创建新的调度记录, 沿用原调度的运行参数和链路信息
 		schedule := &models.JobSchedule{
-			ID:          uuid.New().String(),
-			JobID:       execution.JobID,
-			ScheduledAt: time.Now().Add(30 * time.Second), // 30秒后重试
-			Status:      models.ScheduleStatusPending,
+			ID:                  uuid.New().String(),
+			JobID:               execution.JobID,
+			ScheduledAt:         time.Now().Add(30 * time.Second), // 30秒后重试
+			Status:              models.ScheduleStatusPending,
+			UpstreamExecutionID: execution.UpstreamExecutionID,
+			ChainDepth:          execution.ChainDepth,
+		}
+
+		var original models.JobSchedule
+		if err := s.db.First(&original, "execution_id = ?", executionID).Error; err == nil {
+			schedule.Params = original.Params
+			schedule.TriggerID = original.TriggerID
+			schedule.TraceParent = original.TraceParent
 		}
 
 		if err := s.db.Create(schedule).Error; err != nil {
 			logger.WithError(err).Errorf("创建重试调度记录失败: %s", execution.JobID)
-			return
+			return false
 		}
 
+		metrics.SchedulerFires.WithLabelValues("retry").Inc()
+
 		// 延迟加入队列
 		time.AfterFunc(30*time.Second, func() {
 			select {
@@ -242,9 +515,13 @@ func (s *Service) handleTaskRetry(executionID string) {
 				logger.Debugf("重试任务已加入队列: %s", execution.JobID)
 			default:
 				logger.Warnf("任务队列已满，重试任务被丢弃: %s", execution.JobID)
+				metrics.SchedulerSkippedFires.WithLabelValues("requeue_dropped").Inc()
 			}
 		})
+		return true
 	}
+
+	return false
 }
 
 // 状态转换函数
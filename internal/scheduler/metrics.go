@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"go-job/api/grpc"
+	"go-job/internal/models"
+	"go-job/pkg/metrics"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	queueDepthDesc = prometheus.NewDesc(
+		metrics.Namespace+"_scheduler_queue_depth", "分发队列中等待的调度数", nil, nil)
+	queueCapacityDesc = prometheus.NewDesc(
+		metrics.Namespace+"_scheduler_queue_capacity", "分发队列容量", nil, nil)
+	workerLoadDesc = prometheus.NewDesc(
+		metrics.Namespace+"_worker_load", "工作节点当前负载", []string{"worker_id", "worker"}, nil)
+	workerCapacityDesc = prometheus.NewDesc(
+		metrics.Namespace+"_worker_capacity", "工作节点容量", []string{"worker_id", "worker"}, nil)
+	workerHeartbeatAgeDesc = prometheus.NewDesc(
+		metrics.Namespace+"_worker_heartbeat_age_seconds", "距工作节点最近一次心跳的时间", []string{"worker_id", "worker"}, nil)
+	workerOnlineDesc = prometheus.NewDesc(
+		metrics.Namespace+"_worker_online", "工作节点是否在线", []string{"worker_id", "worker"}, nil)
+	workerStreamingDesc = prometheus.NewDesc(
+		metrics.Namespace+"_worker_streaming", "工作节点是否通过推送连接接收任务", []string{"worker_id", "worker"}, nil)
+	workerCPUDesc = prometheus.NewDesc(
+		metrics.Namespace+"_worker_host_cpu_percent", "工作节点主机的 CPU 使用率", []string{"worker_id", "worker"}, nil)
+	workerMemoryDesc = prometheus.NewDesc(
+		metrics.Namespace+"_worker_host_memory_percent", "工作节点主机的内存使用率", []string{"worker_id", "worker"}, nil)
+	workerDiskDesc = prometheus.NewDesc(
+		metrics.Namespace+"_worker_host_disk_percent", "工作节点临时目录所在文件系统的使用率", []string{"worker_id", "worker"}, nil)
+	workerLoad1Desc = prometheus.NewDesc(
+		metrics.Namespace+"_worker_host_load1", "工作节点主机的 1 分钟平均负载", []string{"worker_id", "worker"}, nil)
+)
+
+// stateCollector 在采集时读取调度器的队列和工作节点状态
+type stateCollector struct {
+	s *Service
+}
+
+// Describe 实现 prometheus.Collector
+func (c *stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueDepthDesc
+	ch <- queueCapacityDesc
+	ch <- workerLoadDesc
+	ch <- workerCapacityDesc
+	ch <- workerHeartbeatAgeDesc
+	ch <- workerOnlineDesc
+	ch <- workerStreamingDesc
+	ch <- workerCPUDesc
+	ch <- workerMemoryDesc
+	ch <- workerDiskDesc
+	ch <- workerLoad1Desc
+}
+
+// Collect 实现 prometheus.Collector
+func (c *stateCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(queueDepthDesc, prometheus.GaugeValue, float64(len(c.s.taskQueue)))
+	ch <- prometheus.MustNewConstMetric(queueCapacityDesc, prometheus.GaugeValue, float64(cap(c.s.taskQueue)))
+
+	c.s.streamsMu.RLock()
+	streaming := make(map[string]bool, len(c.s.streams))
+	for id := range c.s.streams {
+		streaming[id] = true
+	}
+	c.s.streamsMu.RUnlock()
+
+	c.s.workersMu.RLock()
+	defer c.s.workersMu.RUnlock()
+
+	now := time.Now()
+	for _, worker := range c.s.workers {
+		online := boolGauge(worker.Status == grpc.WorkerStatus_ONLINE || worker.Status == grpc.WorkerStatus_BUSY)
+		ch <- prometheus.MustNewConstMetric(workerLoadDesc, prometheus.GaugeValue, float64(worker.CurrentLoad), worker.ID, worker.Name)
+		ch <- prometheus.MustNewConstMetric(workerCapacityDesc, prometheus.GaugeValue, float64(worker.Capacity), worker.ID, worker.Name)
+		ch <- prometheus.MustNewConstMetric(workerHeartbeatAgeDesc, prometheus.GaugeValue, now.Sub(worker.LastSeen).Seconds(), worker.ID, worker.Name)
+		ch <- prometheus.MustNewConstMetric(workerOnlineDesc, prometheus.GaugeValue, online, worker.ID, worker.Name)
+		ch <- prometheus.MustNewConstMetric(workerStreamingDesc, prometheus.GaugeValue, boolGauge(streaming[worker.ID]), worker.ID, worker.Name)
+		if host := worker.Host; host != nil {
+			ch <- prometheus.MustNewConstMetric(workerCPUDesc, prometheus.GaugeValue, host.GetCpuPercent(), worker.ID, worker.Name)
+			ch <- prometheus.MustNewConstMetric(workerMemoryDesc, prometheus.GaugeValue, memoryPercent(host), worker.ID, worker.Name)
+			ch <- prometheus.MustNewConstMetric(workerDiskDesc, prometheus.GaugeValue, diskPercent(host), worker.ID, worker.Name)
+			ch <- prometheus.MustNewConstMetric(workerLoad1Desc, prometheus.GaugeValue, host.GetLoad1(), worker.ID, worker.Name)
+		}
+	}
+}
+
+func boolGauge(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// observeExecution 记录执行结果和耗时, 只统计最终状态
+func (s *Service) observeExecution(executionID string, req *grpc.ReportTaskResultRequest) {
+	status := convertExecutionStatus(req.GetStatus())
+	switch req.GetStatus() {
+	case grpc.ExecutionStatus_SUCCESS, grpc.ExecutionStatus_FAILED,
+		grpc.ExecutionStatus_TIMEOUT, grpc.ExecutionStatus_CANCELLED:
+	default:
+		return
+	}
+	metrics.Executions.WithLabelValues(string(status)).Inc()
+
+	if req.GetStartedAt() == nil || req.GetFinishedAt() == nil {
+		return
+	}
+	var job models.Job
+	if err := s.db.Select("jobs.name").
+		Joins("JOIN job_executions ON job_executions.job_id = jobs.id").
+		Where("job_executions.id = ?", executionID).
+		First(&job).Error; err != nil {
+		return
+	}
+	duration := req.GetFinishedAt().AsTime().Sub(req.GetStartedAt().AsTime())
+	metrics.ExecutionDuration.WithLabelValues(job.Name, string(status)).Observe(duration.Seconds())
+}
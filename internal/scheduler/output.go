@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+	"go-job/pkg/output"
+	"go-job/pkg/websocket"
+	"strings"
+	"time"
+)
+
+// ReportTaskOutput 保存运行中任务的输出片段, 供未建立推送连接的工作节点使用
+func (s *Service) ReportTaskOutput(ctx context.Context, req *grpc.TaskOutput) (*grpc.ReportTaskOutputResponse, error) {
+	if err := s.saveOutput(req); err != nil {
+		logger.WithError(err).Warnf("保存任务输出失败: %s", req.GetTaskId())
+		return &grpc.ReportTaskOutputResponse{Success: false}, nil
+	}
+	return &grpc.ReportTaskOutputResponse{Success: true}, nil
+}
+
+// saveOutput 替换密钥值后保存输出片段并推送给订阅该执行的客户端, 重复上报的片段忽略
+//
+// 服务端按片段替换, 被拆在两个片段之间的密钥值依赖工作节点在上报前替换。
+func (s *Service) saveOutput(out *grpc.TaskOutput) error {
+	var count int64
+	if err := s.db.Model(&models.JobExecution{}).
+		Where("id = ? AND worker_id = ?", out.GetTaskId(), out.GetWorkerId()).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("查询执行记录失败: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("执行记录不存在或不属于工作节点 %s", out.GetWorkerId())
+	}
+
+	stream := models.OutputStreamStdout
+	if out.GetStream() == grpc.OutputStream_STDERR {
+		stream = models.OutputStreamStderr
+	}
+	at := time.Now()
+	if out.GetTime() != nil {
+		at = out.GetTime().AsTime()
+	}
+	data := strings.ToValidUTF8(string(out.GetData()), "\uFFFD")
+	data = output.Redact(data, s.redactionPatterns(out.GetTaskId()))
+
+	chunk, created, err := s.logs.Append(out.GetTaskId(), stream, out.GetSeq(), []byte(data), at)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+
+	if s.hub != nil {
+		s.hub.Publish(websocket.ExecutionChannel(chunk.ExecutionID), "execution_output", map[string]interface{}{
+			"execution_id": chunk.ExecutionID,
+			"chunk_id":     chunk.ID,
+			"stream":       chunk.Stream,
+			"seq":          chunk.Seq,
+			"data":         data,
+			"time":         chunk.CreatedAt,
+		})
+	}
+	return nil
+}
+
+// publishStatus 通知订阅该执行的客户端执行状态变化, 客户端收到最终状态后停止跟踪输出
+func (s *Service) publishStatus(executionID string, req *grpc.ReportTaskResultRequest) {
+	if s.hub == nil {
+		return
+	}
+
+	payload := map[string]interface{}{
+		"execution_id": executionID,
+		"status":       convertExecutionStatus(req.GetStatus()),
+		"exit_code":    req.GetExitCode(),
+		"error":        req.GetError(),
+		"time":         time.Now(),
+	}
+	if req.GetTermination() != "" {
+		payload["termination"] = req.GetTermination()
+	}
+	if req.GetFinishedAt() != nil {
+		payload["finished_at"] = req.GetFinishedAt().AsTime()
+	}
+	s.hub.Publish(websocket.ExecutionChannel(executionID), "execution_status", payload)
+}
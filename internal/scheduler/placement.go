@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+	"time"
+)
+
+// overloadReason 返回工作节点超过的资源阈值, 没有超过或没有上报主机指标时返回空字符串
+func (s *Service) overloadReason(host *grpc.HostMetrics) string {
+	if host == nil {
+		return ""
+	}
+	cfg := s.config.Scheduler.Placement
+	if cfg.MaxCPUPercent > 0 && host.GetCpuPercent() > cfg.MaxCPUPercent {
+		return fmt.Sprintf("CPU 使用率 %.1f%%", host.GetCpuPercent())
+	}
+	if cfg.MaxMemoryPercent > 0 && host.GetMemoryTotalBytes() > 0 && memoryPercent(host) > cfg.MaxMemoryPercent {
+		return fmt.Sprintf("内存使用率 %.1f%%", memoryPercent(host))
+	}
+	if cfg.MaxDiskPercent > 0 && host.GetDiskTotalBytes() > 0 && diskPercent(host) > cfg.MaxDiskPercent {
+		return fmt.Sprintf("磁盘使用率 %.1f%%", diskPercent(host))
+	}
+	if cfg.MaxLoadPerCPU > 0 && host.GetCpuCount() > 0 {
+		if load := host.GetLoad1() / float64(host.GetCpuCount()); load > cfg.MaxLoadPerCPU {
+			return fmt.Sprintf("每个 CPU 的平均负载 %.2f", load)
+		}
+	}
+	return ""
+}
+
+// memoryPercent 内存使用率, 可用内存之外的部分计为已使用
+func memoryPercent(host *grpc.HostMetrics) float64 {
+	return usedPercent(host.GetMemoryTotalBytes(), host.GetMemoryAvailableBytes())
+}
+
+// diskPercent 磁盘使用率, 只有 root 可用的保留空间计为已使用
+func diskPercent(host *grpc.HostMetrics) float64 {
+	return usedPercent(host.GetDiskTotalBytes(), host.GetDiskFreeBytes())
+}
+
+func usedPercent(total, free int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(total-min(free, total)) / float64(total) * 100
+}
+
+// updateHostMetrics 保存心跳上报的主机指标, 在 Heartbeat 持有 workersMu 时调用
+//
+// 返回工作节点记录中保存的最近指标和需要写入历史的记录, 距上次写入历史不足
+// historyInterval 时历史记录为 nil。
+func (s *Service) updateHostMetrics(worker *WorkerInfo, host *grpc.HostMetrics, now time.Time) (string, *models.WorkerMetric) {
+	worker.Host = host
+	metric := workerMetricOf(worker.ID, host, now)
+	latest, _ := json.Marshal(metric)
+
+	// 心跳到达的时间有抖动, 留出一成的余量, 避免每隔一次心跳才保存
+	interval := time.Duration(s.config.Scheduler.Placement.HistoryInterval) * time.Second
+	if interval <= 0 || now.Sub(worker.HistoryAt) < interval*9/10 {
+		return string(latest), nil
+	}
+	worker.HistoryAt = now
+	return string(latest), metric
+}
+
+// workerMetricOf 主机指标的历史记录
+func workerMetricOf(workerID string, host *grpc.HostMetrics, at time.Time) *models.WorkerMetric {
+	return &models.WorkerMetric{
+		WorkerID:             workerID,
+		CPUPercent:           host.GetCpuPercent(),
+		MemoryPercent:        memoryPercent(host),
+		DiskPercent:          diskPercent(host),
+		Load1:                host.GetLoad1(),
+		Load5:                host.GetLoad5(),
+		Load15:               host.GetLoad15(),
+		CPUCount:             int(host.GetCpuCount()),
+		MemoryTotalBytes:     host.GetMemoryTotalBytes(),
+		MemoryAvailableBytes: host.GetMemoryAvailableBytes(),
+		DiskTotalBytes:       host.GetDiskTotalBytes(),
+		DiskFreeBytes:        host.GetDiskFreeBytes(),
+		HostUptimeSeconds:    host.GetHostUptimeSeconds(),
+		UptimeSeconds:        host.GetUptimeSeconds(),
+		CreatedAt:            at,
+	}
+}
+
+// metricsCleanupInterval 清理主机指标历史的间隔
+const metricsCleanupInterval = time.Hour
+
+// metricsCleaner 定期清理主机指标历史
+func (s *Service) metricsCleaner(ctx context.Context) {
+	ticker := time.NewTicker(metricsCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.cleanupWorkerMetrics(now)
+		}
+	}
+}
+
+// cleanupWorkerMetrics 删除超过保留时间的主机指标历史
+func (s *Service) cleanupWorkerMetrics(now time.Time) {
+	hours := s.config.Scheduler.Placement.HistoryHours
+	if hours <= 0 {
+		return
+	}
+	result := s.db.Where("created_at < ?", now.Add(-time.Duration(hours)*time.Hour)).Delete(&models.WorkerMetric{})
+	if result.Error != nil {
+		logger.WithError(result.Error).Error("清理工作节点指标历史失败")
+		return
+	}
+	if result.RowsAffected > 0 {
+		logger.Infof("已清理 %d 条工作节点指标历史", result.RowsAffected)
+	}
+}
@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+	"go-job/pkg/output"
+	"go-job/pkg/websocket"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxProgressMessage 进度说明的最大字符数, 与 progress_message 列长度一致
+const maxProgressMessage = 500
+
+// ReportTaskProgress 保存处理函数的执行进度, 供未建立推送连接的工作节点使用
+func (s *Service) ReportTaskProgress(ctx context.Context, req *grpc.TaskProgress) (*grpc.ReportTaskProgressResponse, error) {
+	if err := s.saveProgress(req); err != nil {
+		logger.WithError(err).Warnf("保存任务进度失败: %s", req.GetTaskId())
+		return &grpc.ReportTaskProgressResponse{Success: false}, nil
+	}
+	return &grpc.ReportTaskProgressResponse{Success: true}, nil
+}
+
+// saveProgress 替换密钥值后更新运行中执行的进度并推送给订阅该执行的客户端, 已结束的执行忽略迟到的进度
+func (s *Service) saveProgress(progress *grpc.TaskProgress) error {
+	percent := progress.GetPercent()
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+	message := strings.ToValidUTF8(progress.GetMessage(), "\uFFFD")
+	message = output.Redact(message, s.redactionPatterns(progress.GetTaskId()))
+	if utf8.RuneCountInString(message) > maxProgressMessage {
+		message = string([]rune(message)[:maxProgressMessage])
+	}
+
+	result := s.db.Model(&models.JobExecution{}).
+		Where("id = ? AND worker_id = ? AND status IN ?", progress.GetTaskId(), progress.GetWorkerId(),
+			[]models.JobExecutionStatus{models.ExecutionStatusPending, models.ExecutionStatusRunning}).
+		Updates(map[string]interface{}{"progress": percent, "progress_message": message})
+	if result.Error != nil {
+		return fmt.Errorf("更新执行进度失败: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	at := time.Now()
+	if progress.GetTime() != nil {
+		at = progress.GetTime().AsTime()
+	}
+	if s.hub != nil {
+		s.hub.Publish(websocket.ExecutionChannel(progress.GetTaskId()), "execution_progress", map[string]interface{}{
+			"execution_id": progress.GetTaskId(),
+			"percent":      percent,
+			"message":      message,
+			"time":         at,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"go-job/api/grpc"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+	"go-job/pkg/output"
+	"time"
+)
+
+// redactionTTL 替换规则在内存中的保留时间, 过期后按需重新解析
+const redactionTTL = time.Hour
+
+// redaction 一次执行需要从输出中去掉的密钥值
+type redaction struct {
+	patterns []string
+	at       time.Time
+}
+
+// rememberRedaction 记录下发任务时解析出的密钥值, 工作节点上报输出和结果时再替换一次
+//
+// 工作节点已经在上报前替换, 这里防止旧版本或异常的工作节点把密钥值写入执行记录和日志存储。
+func (s *Service) rememberRedaction(executionID string, values []string) {
+	now := time.Now()
+	s.redactsMu.Lock()
+	defer s.redactsMu.Unlock()
+
+	for id, r := range s.redacts {
+		if now.Sub(r.at) > redactionTTL {
+			delete(s.redacts, id)
+		}
+	}
+	s.redacts[executionID] = &redaction{patterns: output.RedactPatterns(values), at: now}
+}
+
+// forgetRedaction 执行结束后删除替换规则
+func (s *Service) forgetRedaction(executionID string) {
+	s.redactsMu.Lock()
+	delete(s.redacts, executionID)
+	s.redactsMu.Unlock()
+}
+
+// redactionPatterns 返回执行的替换规则
+//
+// 规则已过期或由其他调度器实例下发时, 按任务当前的定义重新解析密钥和数据库连接串。
+func (s *Service) redactionPatterns(executionID string) []string {
+	s.redactsMu.Lock()
+	r, ok := s.redacts[executionID]
+	s.redactsMu.Unlock()
+	if ok {
+		return r.patterns
+	}
+
+	var execution models.JobExecution
+	if err := s.db.Preload("Job").Select("id", "job_id").First(&execution, "id = ?", executionID).Error; err != nil {
+		return nil
+	}
+	values := s.secretValues(&execution.Job)
+	s.rememberRedaction(executionID, values)
+	return output.RedactPatterns(values)
+}
+
+// secretValues 任务定义中引用的密钥值和数据库连接串, 解析失败时返回已得到的部分
+func (s *Service) secretValues(job *models.Job) []string {
+	params := make(map[string]string)
+	if job.Params != "" {
+		json.Unmarshal([]byte(job.Params), &params)
+	}
+	_, values, err := s.secrets.Resolve(job, params)
+	if err != nil {
+		logger.WithError(err).Debugf("解析任务密钥失败: %s", job.ID)
+	}
+	if job.Type == models.JobTypeSQL {
+		if connection := s.taskConnection(job); connection != nil {
+			values = append(values, connection.Dsn)
+		}
+	}
+	return values
+}
+
+// redactResult 替换执行结果中的密钥值
+func (s *Service) redactResult(req *grpc.ReportTaskResultRequest) {
+	patterns := s.redactionPatterns(req.GetTaskId())
+	if len(patterns) == 0 {
+		return
+	}
+	req.Output = output.Redact(req.GetOutput(), patterns)
+	req.Error = output.Redact(req.GetError(), patterns)
+	req.Result = output.Redact(req.GetResult(), patterns)
+}
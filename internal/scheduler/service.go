@@ -2,13 +2,28 @@ package scheduler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"go-job/api/grpc"
+	"go-job/internal/job"
+	"go-job/internal/jobtype"
+	"go-job/internal/logstore"
 	"go-job/internal/models"
+	"go-job/internal/retention"
+	"go-job/internal/secret"
+	"go-job/internal/sla"
+	"go-job/internal/trigger"
 	"go-job/pkg/config"
+	"go-job/pkg/cronexpr"
 	"go-job/pkg/database"
 	"go-job/pkg/logger"
+	"go-job/pkg/metrics"
 	"go-job/pkg/redis"
+	"go-job/pkg/tracing"
+	"go-job/pkg/websocket"
+	"os"
+	"strconv"
 	"sync"
 	"time"
 
@@ -24,8 +39,20 @@ type Service struct {
 	cron      *cron.Cron
 	workers   map[string]*WorkerInfo
 	workersMu sync.RWMutex
+	streams   map[string]*workerStream // 已建立推送连接的工作节点
+	streamsMu sync.RWMutex
+	cancels   map[string]map[string]string // 等待随心跳下发的取消指令: 工作节点 ID -> 执行 ID -> 原因
+	cancelsMu sync.Mutex
+	redacts   map[string]*redaction // 执行中任务需要从输出中去掉的密钥值: 执行 ID -> 替换规则
+	redactsMu sync.Mutex
 	db        *gorm.DB
 	taskQueue chan *models.JobSchedule
+	triggers  *trigger.Manager
+	sla       *sla.Monitor
+	retention *retention.Cleaner
+	logs      *logstore.Store
+	secrets   *secret.Store
+	hub       *websocket.Hub
 	quit      chan struct{}
 }
 
@@ -40,20 +67,54 @@ type WorkerInfo struct {
 	CurrentLoad int32
 	LastSeen    time.Time
 	Metadata    map[string]string
+	Handlers    map[string]bool   // 提供的进程内处理函数
+	Host        *grpc.HostMetrics // 最近一次心跳上报的主机指标
+	HistoryAt   time.Time         // 最近一次保存主机指标历史的时间
 }
 
-// NewService 创建调度器服务
-func NewService(cfg *config.Config) *Service {
+// NewService 创建调度器服务, wsHub 用于推送 SLA 违约和任务实时输出等事件, secrets 用于下发时解析任务参数中的密钥
+func NewService(cfg *config.Config, wsHub *websocket.Hub, secrets *secret.Store) *Service {
 	location, _ := time.LoadLocation(cfg.Scheduler.Timezone)
 
-	return &Service{
+	s := &Service{
 		config:    cfg,
-		cron:      cron.New(cron.WithLocation(location)),
+		cron:      cron.New(cron.WithLocation(location), cron.WithParser(cronexpr.Parser)),
 		workers:   make(map[string]*WorkerInfo),
+		streams:   make(map[string]*workerStream),
+		cancels:   make(map[string]map[string]string),
+		redacts:   make(map[string]*redaction),
 		db:        database.GetDB(),
 		taskQueue: make(chan *models.JobSchedule, 1000),
+		hub:       wsHub,
+		secrets:   secrets,
 		quit:      make(chan struct{}),
 	}
+
+	consumerName := cfg.Scheduler.Events.ConsumerName
+	if consumerName == "" {
+		hostname, _ := os.Hostname()
+		consumerName = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	s.triggers = trigger.NewManager(
+		s.db,
+		trigger.Options{ConsumerName: consumerName},
+		time.Duration(cfg.Scheduler.Events.SyncInterval)*time.Second,
+		s.fireEvent,
+	)
+	s.sla = sla.NewMonitor(
+		s.db,
+		wsHub,
+		location,
+		time.Duration(cfg.Scheduler.SLA.CheckInterval)*time.Second,
+	)
+	s.logs = logstore.New(s.db, cfg.Scheduler.Output)
+	s.retention = retention.NewCleaner(s.db, cfg.Scheduler.Retention, s.logs)
+
+	if err := metrics.Register(&stateCollector{s: s}); err != nil {
+		logger.WithError(err).Warn("注册调度器指标失败")
+	}
+
+	return s
 }
 
 // Start 启动调度器
@@ -75,7 +136,24 @@ func (s *Service) Start(ctx context.Context) error {
 	go s.taskDispatcher(ctx)
 
 	// 启动任务清理器
-	go s.taskCleaner(ctx)
+	if s.config.Scheduler.Retention.Enabled {
+		go s.taskCleaner(ctx)
+	}
+
+	// 启动主机指标历史清理, 与执行记录的保留策略无关
+	if s.config.Scheduler.Placement.HistoryHours > 0 {
+		go s.metricsCleaner(ctx)
+	}
+
+	// 启动事件触发器
+	if s.config.Scheduler.Events.Enabled {
+		go s.triggers.Run(ctx)
+	}
+
+	// 启动 SLA 监控
+	if s.config.Scheduler.SLA.Enabled {
+		go s.sla.Run(ctx)
+	}
 
 	<-ctx.Done()
 	logger.Info("调度器服务已停止")
@@ -106,11 +184,21 @@ func (s *Service) loadJobs() error {
 	return nil
 }
 
+// cronJob cron 调度器中的任务条目, 记录注册时的 cron 表达式
+type cronJob struct {
+	s     *Service
+	jobID string
+	expr  string
+}
+
+// Run 到达调度时间时调度任务
+func (j *cronJob) Run() {
+	j.s.scheduleJob(j.jobID, j.expr)
+}
+
 // addJobToCron 添加任务到 cron 调度器
 func (s *Service) addJobToCron(job models.Job) error {
-	entryID, err := s.cron.AddFunc(job.Cron, func() {
-		s.scheduleJob(job.ID)
-	})
+	entryID, err := s.cron.AddJob(job.Cron, &cronJob{s: s, jobID: job.ID, expr: job.Cron})
 	if err != nil {
 		return err
 	}
@@ -120,10 +208,124 @@ func (s *Service) addJobToCron(job models.Job) error {
 	return redis.Set(context.Background(), key, fmt.Sprintf("%d", entryID), 0)
 }
 
-// scheduleJob 调度任务
-func (s *Service) scheduleJob(jobID string) {
+// removeJobFromCron 从 cron 调度器中删除任务的条目
+//
+// 优先按 job_cron 映射查找条目, 映射缺失或已被其他调度器实例覆盖时遍历全部条目。
+func (s *Service) removeJobFromCron(jobID string) {
+	key := fmt.Sprintf("job_cron:%s", jobID)
+	if value, err := redis.Get(context.Background(), key); err == nil {
+		if id, err := strconv.Atoi(value); err == nil {
+			if j, ok := s.cron.Entry(cron.EntryID(id)).Job.(*cronJob); ok && j.jobID == jobID {
+				s.cron.Remove(cron.EntryID(id))
+			}
+		}
+	}
+	for _, entry := range s.cron.Entries() {
+		if j, ok := entry.Job.(*cronJob); ok && j.jobID == jobID {
+			s.cron.Remove(entry.ID)
+		}
+	}
+	if err := redis.Del(context.Background(), key); err != nil {
+		logger.WithError(err).Warnf("删除任务的 cron 映射失败: %s", jobID)
+	}
+}
+
+// scheduleJob 调度任务, expr 为条目注册时的 cron 表达式
+//
+// 任务被禁用、删除或修改了 cron 表达式 (如批量操作、声明式同步清理和版本回滚) 后,
+// 在下一次触发时删除或重新注册条目, 本次不调度。
+func (s *Service) scheduleJob(jobID, expr string) {
 	logger.Infof("调度任务: %s", jobID)
 
+	job, reason := s.jobRunnable(jobID)
+	switch reason {
+	case skipDeleted, skipDisabled:
+		logger.Infof("任务已%s, 从 cron 调度器中删除: %s", skipReasonText[reason], jobID)
+		s.removeJobFromCron(jobID)
+		metrics.SchedulerSkippedFires.WithLabelValues(reason).Inc()
+		return
+	}
+	if job != nil && job.Cron != expr {
+		logger.Infof("任务的 cron 表达式已从 %s 修改为 %s, 重新注册: %s", expr, job.Cron, jobID)
+		s.removeJobFromCron(jobID)
+		if err := s.addJobToCron(*job); err != nil {
+			logger.WithError(err).Errorf("添加任务到 cron 失败: %s", jobID)
+		}
+		metrics.SchedulerSkippedFires.WithLabelValues("rescheduled").Inc()
+		return
+	}
+	if reason == skipPaused {
+		logger.Infof("任务已暂停, 跳过本次调度: %s", jobID)
+		metrics.SchedulerSkippedFires.WithLabelValues(reason).Inc()
+		return
+	}
+	metrics.SchedulerFires.WithLabelValues("cron").Inc()
+
+	if err := s.enqueueSchedule(newSchedule(jobID, nil)); err != nil {
+		logger.WithError(err).Errorf("调度任务失败: %s", jobID)
+	}
+}
+
+// fireEvent 事件命中触发器后调度任务, 事件信息通过参数传递给任务
+func (s *Service) fireEvent(ctx context.Context, t models.JobTrigger, event *trigger.Event) error {
+	logger.Infof("事件触发任务: %s (触发器: %s, 事件: %s)", t.JobID, t.ID, event.ID)
+
+	if _, reason := s.jobRunnable(t.JobID); reason != "" {
+		logger.Infof("任务已%s, 忽略事件: %s (事件: %s)", skipReasonText[reason], t.JobID, event.ID)
+		metrics.SchedulerSkippedFires.WithLabelValues(reason).Inc()
+		return nil
+	}
+	metrics.SchedulerFires.WithLabelValues("event").Inc()
+
+	params := map[string]string{
+		"EVENT_ID":         event.ID,
+		"EVENT_SOURCE":     event.Source,
+		"EVENT_PAYLOAD":    event.Payload,
+		"EVENT_TRIGGER_ID": t.ID,
+	}
+	schedule := newSchedule(t.JobID, params)
+	schedule.TriggerID = t.ID
+	return s.enqueueSchedule(schedule)
+}
+
+// 任务不能运行的原因, 同时作为 skipped_fires_total 指标的 reason
+const (
+	skipDeleted  = "deleted"
+	skipDisabled = "disabled"
+	skipPaused   = "paused"
+)
+
+// skipReasonText 不能运行的原因在日志中的说明
+var skipReasonText = map[string]string{
+	skipDeleted:  "删除",
+	skipDisabled: "禁用",
+	skipPaused:   "暂停",
+}
+
+// jobRunnable 判断任务当前能否运行, 不能运行时返回原因
+//
+// 查询失败时不阻止调度, 此时返回的任务为 nil。
+func (s *Service) jobRunnable(jobID string) (*models.Job, string) {
+	var job models.Job
+	err := s.db.Select("id", "cron", "enabled", "paused", "paused_until").First(&job, "id = ?", jobID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, skipDeleted
+	}
+	if err != nil {
+		logger.WithError(err).Warnf("查询任务状态失败: %s", jobID)
+		return nil, ""
+	}
+	if !job.Enabled {
+		return &job, skipDisabled
+	}
+	if job.IsPaused(time.Now()) {
+		return &job, skipPaused
+	}
+	return &job, ""
+}
+
+// newSchedule 构造待调度记录, params 为本次运行的参数覆盖
+func newSchedule(jobID string, params map[string]string) *models.JobSchedule {
 	schedule := &models.JobSchedule{
 		ID:          uuid.New().String(),
 		JobID:       jobID,
@@ -131,17 +333,48 @@ func (s *Service) scheduleJob(jobID string) {
 		Status:      models.ScheduleStatusPending,
 	}
 
+	if len(params) > 0 {
+		paramsJSON, _ := json.Marshal(params)
+		schedule.Params = string(paramsJSON)
+	}
+	return schedule
+}
+
+// enqueueSchedule 保存调度记录并加入分发队列, 本次运行的追踪从这里开始
+func (s *Service) enqueueSchedule(schedule *models.JobSchedule) error {
+	_, span := tracing.Start(context.Background(), "scheduler.enqueue",
+		tracing.WithKind(tracing.KindProducer),
+		tracing.WithRemoteParent(schedule.TraceParent),
+		tracing.WithAttributes(map[string]interface{}{
+			"job.id":      schedule.JobID,
+			"schedule.id": schedule.ID,
+		}),
+	)
+	defer span.End()
+	if schedule.TriggerID != "" {
+		span.SetAttribute("trigger.id", schedule.TriggerID)
+	}
+	if schedule.UpstreamExecutionID != "" {
+		span.SetAttribute("upstream_execution.id", schedule.UpstreamExecutionID)
+	}
+	schedule.TraceParent = span.Traceparent()
+
 	if err := s.db.Create(schedule).Error; err != nil {
-		logger.WithError(err).Errorf("创建任务调度记录失败: %s", jobID)
-		return
+		span.RecordError(err)
+		return fmt.Errorf("创建任务调度记录失败: %w", err)
 	}
 
 	// 将任务加入队列
 	select {
 	case s.taskQueue <- schedule:
-		logger.Debugf("任务已加入队列: %s", jobID)
+		logger.Debugf("任务已加入队列: %s", schedule.JobID)
+		return nil
 	default:
-		logger.Warnf("任务队列已满，跳过任务: %s", jobID)
+		s.db.Model(schedule).Update("status", models.ScheduleStatusSkipped)
+		metrics.SchedulerSkippedFires.WithLabelValues("queue_full").Inc()
+		err := fmt.Errorf("任务队列已满，跳过任务: %s", schedule.JobID)
+		span.RecordError(err)
+		return err
 	}
 }
 
@@ -161,25 +394,68 @@ func (s *Service) taskDispatcher(ctx context.Context) {
 
 // dispatchTask 分发任务
 func (s *Service) dispatchTask(schedule *models.JobSchedule) {
-	// 查找可用的工作节点
-	worker := s.findAvailableWorker()
+	attrs := map[string]interface{}{
+		"job.id":      schedule.JobID,
+		"schedule.id": schedule.ID,
+	}
+
+	// 排队等待: 从计划时间(重试为延迟结束时)到被分发器取出
+	queuedAt := schedule.ScheduledAt
+	if now := time.Now(); queuedAt.After(now) {
+		queuedAt = now
+	}
+	_, queueSpan := tracing.Start(context.Background(), "scheduler.queue",
+		tracing.WithRemoteParent(schedule.TraceParent),
+		tracing.WithStartTime(queuedAt),
+		tracing.WithAttributes(attrs),
+	)
+	queueSpan.End()
+
+	_, span := tracing.Start(context.Background(), "scheduler.dispatch",
+		tracing.WithRemoteParent(schedule.TraceParent),
+		tracing.WithAttributes(attrs),
+	)
+	defer span.End()
+
+	// 查找可用的工作节点, handler 类型的任务只分配给提供对应处理函数的节点
+	handler := s.requiredHandler(schedule.JobID)
+	if handler != "" {
+		span.SetAttribute("job.handler", handler)
+	}
+	worker, overloaded := s.findAvailableWorker(handler)
 	if worker == nil {
-		logger.Warnf("没有可用的工作节点，任务将被重新调度: %s", schedule.JobID)
+		switch {
+		case overloaded > 0:
+			logger.Warnf("%d 个工作节点的资源使用超过阈值，任务将被重新调度: %s", overloaded, schedule.JobID)
+		case handler != "":
+			logger.Warnf("没有提供处理函数 %s 的可用工作节点，任务将被重新调度: %s", handler, schedule.JobID)
+		default:
+			logger.Warnf("没有可用的工作节点，任务将被重新调度: %s", schedule.JobID)
+		}
+		metrics.Dispatches.WithLabelValues("no_worker").Inc()
+		span.RecordError(fmt.Errorf("没有可用的工作节点"))
 		// 重新调度
 		time.AfterFunc(30*time.Second, func() {
 			select {
 			case s.taskQueue <- schedule:
 			default:
+				metrics.SchedulerSkippedFires.WithLabelValues("requeue_dropped").Inc()
 			}
 		})
 		return
 	}
 
-	// 更新调度记录
+	span.SetAttribute("worker.id", worker.ID)
+	span.SetAttribute("worker.name", worker.Name)
+
+	// 更新调度记录, 后续下发任务时以分发跨度为父跨度
 	schedule.WorkerID = worker.ID
 	schedule.Status = models.ScheduleStatusAssigned
+	schedule.TraceParent = span.Traceparent()
 	if err := s.db.Save(schedule).Error; err != nil {
 		logger.WithError(err).Errorf("更新调度记录失败: %s", schedule.ID)
+		metrics.Dispatches.WithLabelValues("error").Inc()
+		span.RecordError(err)
 		return
 	}
 
@@ -189,12 +465,20 @@ func (s *Service) dispatchTask(schedule *models.JobSchedule) {
 		JobID:    schedule.JobID,
 		WorkerID: worker.ID,
 		Status:   models.ExecutionStatusPending,
+
+		UpstreamExecutionID: schedule.UpstreamExecutionID,
+		ChainDepth:          schedule.ChainDepth,
+		RevisionID:          job.CurrentRevisionID(s.db, schedule.JobID),
+		TraceID:             span.TraceID(),
 	}
 
 	if err := s.db.Create(execution).Error; err != nil {
 		logger.WithError(err).Errorf("创建执行记录失败: %s", schedule.JobID)
+		metrics.Dispatches.WithLabelValues("error").Inc()
+		span.RecordError(err)
 		return
 	}
+	span.SetAttribute("execution.id", execution.ID)
 
 	// 更新调度记录的执行 ID
 	schedule.ExecutionID = execution.ID
@@ -207,27 +491,63 @@ func (s *Service) dispatchTask(schedule *models.JobSchedule) {
 	worker.CurrentLoad++
 	s.workersMu.Unlock()
 
+	// 已建立推送连接的工作节点立即下发, 其余的等待工作节点拉取
+	if ws := s.stream(worker.ID); ws != nil {
+		go s.deliverAssigned(ws)
+	}
+
+	metrics.Dispatches.WithLabelValues("assigned").Inc()
+	metrics.DispatchLatency.Observe(time.Since(schedule.ScheduledAt).Seconds())
+
 	logger.Infof("任务 %s 已分配给工作节点 %s", schedule.JobID, worker.ID)
 }
 
-// findAvailableWorker 查找可用的工作节点
-func (s *Service) findAvailableWorker() *WorkerInfo {
+// requiredHandler 返回 handler 类型任务需要的处理函数, 其他类型返回空字符串
+func (s *Service) requiredHandler(jobID string) string {
+	var job models.Job
+	if err := s.db.Select("id", "type", "config").First(&job, "id = ?", jobID).Error; err != nil {
+		return ""
+	}
+	if job.Type != models.JobTypeHandler {
+		return ""
+	}
+	cfg, err := jobtype.ParseHandler(job.Config)
+	if err != nil {
+		return ""
+	}
+	return cfg.Handler
+}
+
+// findAvailableWorker 查找负载最低的可用工作节点, handler 不为空时只考虑提供该处理函数的节点
+//
+// 主机资源使用超过 scheduler.placement 阈值的节点不参与分配, 返回因此跳过的节点数;
+// 负载相同时选择 CPU 使用率较低的节点。
+func (s *Service) findAvailableWorker(handler string) (*WorkerInfo, int) {
 	s.workersMu.RLock()
 	defer s.workersMu.RUnlock()
 
 	var bestWorker *WorkerInfo
-	minLoad := int32(1000)
+	overloaded := 0
 
 	for _, worker := range s.workers {
-		if worker.Status == grpc.WorkerStatus_ONLINE &&
-			worker.CurrentLoad < worker.Capacity &&
-			worker.CurrentLoad < minLoad {
+		if handler != "" && !worker.Handlers[handler] {
+			continue
+		}
+		if worker.Status != grpc.WorkerStatus_ONLINE || worker.CurrentLoad >= worker.Capacity {
+			continue
+		}
+		if s.overloadReason(worker.Host) != "" {
+			overloaded++
+			continue
+		}
+		if bestWorker == nil ||
+			worker.CurrentLoad < bestWorker.CurrentLoad ||
+			worker.CurrentLoad == bestWorker.CurrentLoad && worker.Host.GetCpuPercent() < bestWorker.Host.GetCpuPercent() {
 			bestWorker = worker
-			minLoad = worker.CurrentLoad
 		}
 	}
 
-	return bestWorker
+	return bestWorker, overloaded
 }
 
 // monitorWorkers 监控工作节点
@@ -264,9 +584,13 @@ func (s *Service) checkWorkersHealth() {
 	}
 }
 
-// taskCleaner 任务清理器
+// taskCleaner 按保留策略定期清理执行记录和调度记录
 func (s *Service) taskCleaner(ctx context.Context) {
-	ticker := time.NewTicker(1 * time.Hour)
+	interval := time.Duration(s.config.Scheduler.Retention.Interval) * time.Second
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
@@ -279,24 +603,19 @@ func (s *Service) taskCleaner(ctx context.Context) {
 	}
 }
 
-// cleanupOldTasks 清理旧的任务记录
+// cleanupOldTasks 清理过期的任务记录
 func (s *Service) cleanupOldTasks() {
-	// 清理 7 天前的执行记录
-	cutoff := time.Now().AddDate(0, 0, -7)
-
-	result := s.db.Where("created_at < ?", cutoff).Delete(&models.JobExecution{})
-	if result.Error != nil {
-		logger.WithError(result.Error).Error("清理旧执行记录失败")
-	} else if result.RowsAffected > 0 {
-		logger.Infof("已清理 %d 条旧执行记录", result.RowsAffected)
-	}
-
-	// 清理 30 天前的调度记录
-	cutoff = time.Now().AddDate(0, 0, -30)
-	result = s.db.Where("created_at < ?", cutoff).Delete(&models.JobSchedule{})
-	if result.Error != nil {
-		logger.WithError(result.Error).Error("清理旧调度记录失败")
-	} else if result.RowsAffected > 0 {
-		logger.Infof("已清理 %d 条旧调度记录", result.RowsAffected)
+	result, err := s.retention.Run(time.Now())
+	if err != nil {
+		logger.WithError(err).Error("清理过期执行记录失败")
+	}
+	if result == nil {
+		return
+	}
+	if result.Executions > 0 {
+		logger.Infof("已清理 %d 条过期执行记录, 其中归档 %d 条", result.Executions, result.Archived)
+	}
+	if result.Schedules > 0 {
+		logger.Infof("已清理 %d 条旧调度记录", result.Schedules)
 	}
 }
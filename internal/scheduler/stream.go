@@ -0,0 +1,272 @@
+package scheduler
+
+import (
+	"context"
+	"go-job/api/grpc"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+	"go-job/pkg/tracing"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// workerStream 工作节点的推送连接
+type workerStream struct {
+	workerID string
+	send     chan *grpc.SchedulerMessage
+	done     chan struct{}
+
+	mu      sync.Mutex
+	unacked map[string]string // 已推送但未确认的任务: 执行 ID -> 调度 ID
+}
+
+func newWorkerStream(workerID string) *workerStream {
+	return &workerStream{
+		workerID: workerID,
+		send:     make(chan *grpc.SchedulerMessage, 100),
+		done:     make(chan struct{}),
+		unacked:  make(map[string]string),
+	}
+}
+
+// push 将消息放入发送队列, 连接已关闭或队列已满时返回 false
+func (ws *workerStream) push(msg *grpc.SchedulerMessage) bool {
+	select {
+	case <-ws.done:
+		return false
+	default:
+	}
+
+	select {
+	case ws.send <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// Connect 处理工作节点的推送连接
+//
+// 连接建立后调度器通过它推送任务和取消指令, 工作节点通过它发送确认、心跳、实时输出和执行结果。
+// 连接断开时已推送但未确认的任务恢复为已分配状态, 由工作节点重连后重新推送或通过 GetTask 拉取。
+func (s *Service) Connect(stream grpc.SchedulerService_ConnectServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	hello := first.GetHello()
+	if hello == nil {
+		return status.Error(codes.InvalidArgument, "连接后的第一条消息必须是 hello")
+	}
+
+	workerID := hello.GetWorkerId()
+	s.workersMu.Lock()
+	worker, exists := s.workers[workerID]
+	if exists {
+		worker.LastSeen = time.Now()
+		if hello.GetCapacity() > 0 {
+			worker.Capacity = hello.GetCapacity()
+		}
+	}
+	s.workersMu.Unlock()
+	if !exists {
+		return status.Errorf(codes.FailedPrecondition, "工作节点未注册: %s", workerID)
+	}
+
+	ws := newWorkerStream(workerID)
+	s.attachStream(ws)
+	defer s.detachStream(ws)
+
+	logger.Infof("工作节点已建立推送连接: %s", workerID)
+
+	// gRPC 流不支持并发发送, 由单独的协程发送
+	go func() {
+		for {
+			select {
+			case <-ws.done:
+				return
+			case msg := <-ws.send:
+				if err := stream.Send(msg); err != nil {
+					logger.WithError(err).Warnf("向工作节点推送消息失败: %s", workerID)
+					return
+				}
+			}
+		}
+	}()
+
+	// 下发连接建立前已分配的任务
+	go s.deliverAssigned(ws)
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			logger.Infof("工作节点推送连接已断开: %s (%v)", workerID, err)
+			return nil
+		}
+		s.handleWorkerMessage(stream.Context(), ws, msg)
+	}
+}
+
+// handleWorkerMessage 处理工作节点通过推送连接发送的消息
+func (s *Service) handleWorkerMessage(ctx context.Context, ws *workerStream, msg *grpc.WorkerMessage) {
+	switch payload := msg.GetPayload().(type) {
+	case *grpc.WorkerMessage_Heartbeat:
+		heartbeat := payload.Heartbeat
+		heartbeat.WorkerId = ws.workerID
+		resp, _ := s.Heartbeat(ctx, heartbeat)
+
+		// 补发排队中的取消指令
+		for _, cancel := range resp.GetCancels() {
+			s.notifyCancel(ws.workerID, cancel.GetTaskId(), cancel.GetReason())
+		}
+
+		// 补发推送失败或被拒绝的任务
+		go s.deliverAssigned(ws)
+
+	case *grpc.WorkerMessage_Ack:
+		ack := payload.Ack
+		ws.mu.Lock()
+		scheduleID, ok := ws.unacked[ack.GetTaskId()]
+		delete(ws.unacked, ack.GetTaskId())
+		ws.mu.Unlock()
+
+		if ok && !ack.GetAccepted() {
+			logger.Warnf("工作节点 %s 拒绝了任务 %s: %s", ws.workerID, ack.GetTaskId(), ack.GetReason())
+			s.releaseSchedule(scheduleID)
+		}
+
+	case *grpc.WorkerMessage_Result:
+		result := payload.Result
+		result.WorkerId = ws.workerID
+		ws.mu.Lock()
+		delete(ws.unacked, result.GetTaskId())
+		ws.mu.Unlock()
+
+		ctx, span := tracing.Start(ctx, "scheduler.report_result",
+			tracing.WithKind(tracing.KindServer),
+			tracing.WithRemoteParent(result.GetTraceparent()),
+			tracing.WithAttributes(map[string]interface{}{
+				"execution.id": result.GetTaskId(),
+				"worker.id":    ws.workerID,
+			}),
+		)
+		s.ReportTaskResult(ctx, result)
+		span.End()
+
+	case *grpc.WorkerMessage_Output:
+		output := payload.Output
+		output.WorkerId = ws.workerID
+		if err := s.saveOutput(output); err != nil {
+			logger.WithError(err).Warnf("保存任务输出失败: %s", output.GetTaskId())
+		}
+
+	case *grpc.WorkerMessage_Progress:
+		progress := payload.Progress
+		progress.WorkerId = ws.workerID
+		if err := s.saveProgress(progress); err != nil {
+			logger.WithError(err).Warnf("保存任务进度失败: %s", progress.GetTaskId())
+		}
+	}
+}
+
+// deliverAssigned 推送已分配给该工作节点但尚未下发的任务
+func (s *Service) deliverAssigned(ws *workerStream) {
+	var schedules []models.JobSchedule
+	err := s.db.Preload("Job").
+		Where("worker_id = ? AND status = ?", ws.workerID, models.ScheduleStatusAssigned).
+		Order("scheduled_at").
+		Find(&schedules).Error
+	if err != nil {
+		logger.WithError(err).Errorf("查询工作节点任务失败: %s", ws.workerID)
+		return
+	}
+
+	for i := range schedules {
+		schedule := &schedules[i]
+		task := s.claimTask(context.Background(), schedule, ws.workerID, deliveryPush)
+		if task == nil {
+			continue
+		}
+
+		// 先登记再发送, 连接在两者之间断开时由 detachStream 或这里恢复调度状态
+		ws.mu.Lock()
+		ws.unacked[task.GetId()] = schedule.ID
+		ws.mu.Unlock()
+
+		if !ws.push(&grpc.SchedulerMessage{Payload: &grpc.SchedulerMessage_Task{Task: task}}) {
+			ws.mu.Lock()
+			delete(ws.unacked, task.GetId())
+			ws.mu.Unlock()
+			s.releaseSchedule(schedule.ID)
+			return
+		}
+		logger.Infof("已向工作节点 %s 推送任务 %s", ws.workerID, task.GetId())
+	}
+}
+
+// releaseSchedule 将已领取但未被工作节点接收的调度恢复为已分配
+func (s *Service) releaseSchedule(scheduleID string) {
+	err := s.db.Model(&models.JobSchedule{}).
+		Where("id = ? AND status = ?", scheduleID, models.ScheduleStatusExecuting).
+		Updates(map[string]interface{}{"status": models.ScheduleStatusAssigned, "executed_at": nil}).Error
+	if err != nil {
+		logger.WithError(err).Errorf("恢复调度记录失败: %s", scheduleID)
+	}
+}
+
+// stream 返回工作节点的推送连接, 没有时返回 nil
+func (s *Service) stream(workerID string) *workerStream {
+	s.streamsMu.RLock()
+	defer s.streamsMu.RUnlock()
+	return s.streams[workerID]
+}
+
+// attachStream 登记推送连接, 同一工作节点重连时替换旧连接
+func (s *Service) attachStream(ws *workerStream) {
+	s.streamsMu.Lock()
+	old := s.streams[ws.workerID]
+	s.streams[ws.workerID] = ws
+	s.streamsMu.Unlock()
+
+	if old != nil {
+		s.closeStream(old)
+	}
+}
+
+// detachStream 注销推送连接
+func (s *Service) detachStream(ws *workerStream) {
+	s.streamsMu.Lock()
+	if s.streams[ws.workerID] == ws {
+		delete(s.streams, ws.workerID)
+	}
+	s.streamsMu.Unlock()
+
+	s.closeStream(ws)
+	logger.Infof("工作节点推送连接已关闭: %s", ws.workerID)
+}
+
+// closeStream 关闭连接并恢复未确认的任务, 可重复调用
+func (s *Service) closeStream(ws *workerStream) {
+	ws.mu.Lock()
+	select {
+	case <-ws.done:
+		ws.mu.Unlock()
+		return
+	default:
+		close(ws.done)
+	}
+	unacked := ws.unacked
+	ws.unacked = make(map[string]string)
+	ws.mu.Unlock()
+
+	for _, scheduleID := range unacked {
+		s.releaseSchedule(scheduleID)
+	}
+}
@@ -0,0 +1,86 @@
+package secret
+
+import (
+	"fmt"
+	"go-job/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SealConnection 加密连接串并写入连接记录, 记录的 ID 必须已经确定
+func (s *Store) SealConnection(connection *models.Connection, dsn string) error {
+	if len(dsn) > MaxValueSize {
+		return fmt.Errorf("连接串不能超过 %d 字节", MaxValueSize)
+	}
+	sealed, err := s.keyring.Seal([]byte(dsn), []byte(connection.ID))
+	if err != nil {
+		return err
+	}
+	connection.Ciphertext = sealed.Ciphertext
+	connection.DataKey = sealed.DataKey
+	connection.KeyID = sealed.KeyID
+	return nil
+}
+
+// OpenConnection 解密连接串
+func (s *Store) OpenConnection(connection *models.Connection) (string, error) {
+	dsn, err := s.keyring.Open(connectionSealed(connection), []byte(connection.ID))
+	if err != nil {
+		return "", fmt.Errorf("数据库连接 %s: %w", connection.Name, err)
+	}
+	return string(dsn), nil
+}
+
+// rotateConnections 用当前主密钥重新加密连接串的数据密钥
+func (s *Store) rotateConnections(active string) (int, error) {
+	var connections []models.Connection
+	if err := s.db.Where("key_id <> ?", active).Find(&connections).Error; err != nil {
+		return 0, fmt.Errorf("查询数据库连接失败: %w", err)
+	}
+
+	rotated := 0
+	for i := range connections {
+		connection := &connections[i]
+		sealed, err := s.keyring.Rewrap(connectionSealed(connection), []byte(connection.ID))
+		if err != nil {
+			return rotated, fmt.Errorf("数据库连接 %s: %w", connection.Name, err)
+		}
+		result := s.db.Model(&models.Connection{}).
+			Where("id = ? AND key_id = ?", connection.ID, connection.KeyID).
+			Updates(map[string]interface{}{"data_key": sealed.DataKey, "key_id": sealed.KeyID})
+		if result.Error != nil {
+			return rotated, fmt.Errorf("更新数据库连接 %s 失败: %w", connection.Name, result.Error)
+		}
+		rotated += int(result.RowsAffected)
+	}
+	return rotated, nil
+}
+
+// FindConnection 按名称查询任务可以引用的数据库连接: 不限部门的连接, 或属于任务所在部门及其上级部门的连接
+func FindConnection(db *gorm.DB, name, departmentID string) (*models.Connection, error) {
+	departments, err := DepartmentChain(db, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := db.Where("name = ?", name)
+	if len(departments) > 0 {
+		query = query.Where("department_id = '' OR department_id IS NULL OR department_id IN ?", departments)
+	} else {
+		query = query.Where("department_id = '' OR department_id IS NULL")
+	}
+
+	var connection models.Connection
+	if err := query.First(&connection).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("数据库连接不存在或不属于任务所在的部门: %s", name)
+		}
+		return nil, fmt.Errorf("查询数据库连接失败: %w", err)
+	}
+	return &connection, nil
+}
+
+// connectionSealed 连接记录中的密文
+func connectionSealed(connection *models.Connection) *Sealed {
+	return &Sealed{Ciphertext: connection.Ciphertext, DataKey: connection.DataKey, KeyID: connection.KeyID}
+}
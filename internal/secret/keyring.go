@@ -0,0 +1,177 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"go-job/pkg/config"
+)
+
+// dataKeySize 数据密钥和主密钥的长度, 使用 AES-256-GCM
+const dataKeySize = 32
+
+// ErrNoMasterKey 没有配置主密钥
+var ErrNoMasterKey = errors.New("未配置主密钥, 请在 secrets.keys 中配置或设置环境变量 GO_JOB_MASTER_KEY")
+
+// Keyring 主密钥, 只用于加密和解密每个密钥的数据密钥
+type Keyring struct {
+	active string
+	keys   map[string][]byte
+}
+
+// Sealed 加密后的密钥值
+type Sealed struct {
+	Ciphertext []byte // 数据密钥加密的值
+	DataKey    []byte // 主密钥加密的数据密钥
+	KeyID      string // 加密数据密钥的主密钥
+}
+
+// NewKeyring 解析配置中的主密钥, 没有配置主密钥时返回的 Keyring 在使用时报错
+func NewKeyring(cfg config.SecretsConfig) (*Keyring, error) {
+	k := &Keyring{active: cfg.ActiveKey, keys: make(map[string][]byte)}
+	for id, encoded := range cfg.Keys {
+		if encoded == "" {
+			continue
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("主密钥 %s 不是有效的 base64: %w", id, err)
+		}
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("主密钥 %s 应为 %d 字节, 实际为 %d 字节", id, dataKeySize, len(key))
+		}
+		k.keys[id] = key
+	}
+
+	if len(k.keys) > 0 && k.keys[k.active] == nil {
+		return nil, fmt.Errorf("secrets.activeKey 指定的主密钥 %s 不存在", k.active)
+	}
+	return k, nil
+}
+
+// ActiveKey 加密新数据密钥使用的主密钥 ID
+func (k *Keyring) ActiveKey() string {
+	return k.active
+}
+
+// HasKey 是否配置了主密钥
+func (k *Keyring) HasKey() bool {
+	return len(k.keys) > 0
+}
+
+// Seal 用新的数据密钥加密值, 数据密钥由当前主密钥加密; aad 绑定密文所属的记录, 解密时必须一致
+func (k *Keyring) Seal(plaintext, aad []byte) (*Sealed, error) {
+	master, err := k.key(k.active)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("生成数据密钥失败: %w", err)
+	}
+	ciphertext, err := encrypt(dataKey, plaintext, aad)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := encrypt(master, dataKey, aad)
+	if err != nil {
+		return nil, err
+	}
+	return &Sealed{Ciphertext: ciphertext, DataKey: wrapped, KeyID: k.active}, nil
+}
+
+// Open 解密值
+func (k *Keyring) Open(sealed *Sealed, aad []byte) ([]byte, error) {
+	dataKey, err := k.unwrap(sealed, aad)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := decrypt(dataKey, sealed.Ciphertext, aad)
+	if err != nil {
+		return nil, fmt.Errorf("解密密钥值失败: %w", err)
+	}
+	return plaintext, nil
+}
+
+// Rewrap 用当前主密钥重新加密数据密钥, 值的密文不变; 已使用当前主密钥时原样返回
+func (k *Keyring) Rewrap(sealed *Sealed, aad []byte) (*Sealed, error) {
+	if sealed.KeyID == k.active {
+		return sealed, nil
+	}
+	dataKey, err := k.unwrap(sealed, aad)
+	if err != nil {
+		return nil, err
+	}
+	master, err := k.key(k.active)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := encrypt(master, dataKey, aad)
+	if err != nil {
+		return nil, err
+	}
+	return &Sealed{Ciphertext: sealed.Ciphertext, DataKey: wrapped, KeyID: k.active}, nil
+}
+
+// unwrap 解密数据密钥
+func (k *Keyring) unwrap(sealed *Sealed, aad []byte) ([]byte, error) {
+	master, err := k.key(sealed.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := decrypt(master, sealed.DataKey, aad)
+	if err != nil {
+		return nil, fmt.Errorf("解密数据密钥失败: %w", err)
+	}
+	return dataKey, nil
+}
+
+// key 按 ID 返回主密钥
+func (k *Keyring) key(id string) ([]byte, error) {
+	if len(k.keys) == 0 {
+		return nil, ErrNoMasterKey
+	}
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("主密钥 %s 不存在, 轮换完成前不能删除旧主密钥", id)
+	}
+	return key, nil
+}
+
+// encrypt 使用 AES-GCM 加密, 随机 nonce 放在密文开头
+func encrypt(key, plaintext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("生成 nonce 失败: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// decrypt 解密 encrypt 生成的密文
+func decrypt(key, ciphertext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("密文长度不足")
+	}
+	nonce, data := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, data, aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,136 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"go-job/pkg/config"
+	"strings"
+	"testing"
+)
+
+// testKey 生成固定内容的主密钥
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, dataKeySize))
+}
+
+func newTestKeyring(t *testing.T, active string, keys map[string]string) *Keyring {
+	t.Helper()
+	k, err := NewKeyring(config.SecretsConfig{ActiveKey: active, Keys: keys})
+	if err != nil {
+		t.Fatalf("NewKeyring 失败: %v", err)
+	}
+	return k
+}
+
+func TestNewKeyringErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.SecretsConfig
+		want string
+	}{
+		{"无效 base64", config.SecretsConfig{ActiveKey: "a", Keys: map[string]string{"a": "!!"}}, "不是有效的 base64"},
+		{"长度错误", config.SecretsConfig{ActiveKey: "a", Keys: map[string]string{"a": base64.StdEncoding.EncodeToString([]byte("short"))}}, "应为 32 字节"},
+		{"activeKey 不存在", config.SecretsConfig{ActiveKey: "b", Keys: map[string]string{"a": testKey(1)}}, "主密钥 b 不存在"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyring(tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("NewKeyring 错误 = %v, 应包含 %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSealOpenRewrap(t *testing.T) {
+	plaintext := []byte("s3cr3t\nvalue")
+	aad := []byte("secret-id")
+
+	old := newTestKeyring(t, "old", map[string]string{"old": testKey(1)})
+	sealed, err := old.Seal(plaintext, aad)
+	if err != nil {
+		t.Fatalf("Seal 失败: %v", err)
+	}
+	if sealed.KeyID != "old" {
+		t.Errorf("KeyID = %q, 期望 %q", sealed.KeyID, "old")
+	}
+	if bytes.Contains(sealed.Ciphertext, plaintext) {
+		t.Error("密文中包含明文")
+	}
+	got, err := old.Open(sealed, aad)
+	if err != nil || !bytes.Equal(got, plaintext) {
+		t.Fatalf("Open = %q, %v, 期望 %q", got, err, plaintext)
+	}
+
+	// 轮换: 新主密钥加密数据密钥, 值的密文不变
+	rotated := newTestKeyring(t, "new", map[string]string{"old": testKey(1), "new": testKey(2)})
+	rewrapped, err := rotated.Rewrap(sealed, aad)
+	if err != nil {
+		t.Fatalf("Rewrap 失败: %v", err)
+	}
+	if rewrapped.KeyID != "new" {
+		t.Errorf("Rewrap 后 KeyID = %q, 期望 %q", rewrapped.KeyID, "new")
+	}
+	if !bytes.Equal(rewrapped.Ciphertext, sealed.Ciphertext) {
+		t.Error("Rewrap 不应修改值的密文")
+	}
+	if again, err := rotated.Rewrap(rewrapped, aad); err != nil || again != rewrapped {
+		t.Errorf("已使用当前主密钥时 Rewrap 应原样返回, err = %v", err)
+	}
+
+	// 删除旧主密钥后仍能解密轮换过的值
+	current := newTestKeyring(t, "new", map[string]string{"new": testKey(2)})
+	if got, err := current.Open(rewrapped, aad); err != nil || !bytes.Equal(got, plaintext) {
+		t.Errorf("轮换后 Open = %q, %v, 期望 %q", got, err, plaintext)
+	}
+	if _, err := current.Open(sealed, aad); err == nil || !strings.Contains(err.Error(), "主密钥 old 不存在") {
+		t.Errorf("旧主密钥删除后 Open 错误 = %v", err)
+	}
+}
+
+func TestOpenFailures(t *testing.T) {
+	aad := []byte("secret-id")
+	k := newTestKeyring(t, "a", map[string]string{"a": testKey(1)})
+	if !k.HasKey() {
+		t.Error("配置了主密钥时 HasKey 应为 true")
+	}
+	sealed, err := k.Seal([]byte("value"), aad)
+	if err != nil {
+		t.Fatalf("Seal 失败: %v", err)
+	}
+
+	// 同一个 ID 对应了不同的主密钥
+	wrong := newTestKeyring(t, "a", map[string]string{"a": testKey(9)})
+	if _, err := wrong.Open(sealed, aad); err == nil || !strings.Contains(err.Error(), "解密数据密钥失败") {
+		t.Errorf("错误的主密钥 Open 错误 = %v", err)
+	}
+
+	// 密文绑定所属的记录
+	if _, err := k.Open(sealed, []byte("other-id")); err == nil {
+		t.Error("aad 不一致时 Open 应返回错误")
+	}
+
+	tampered := *sealed
+	tampered.Ciphertext = append([]byte(nil), sealed.Ciphertext...)
+	tampered.Ciphertext[len(tampered.Ciphertext)-1] ^= 1
+	if _, err := k.Open(&tampered, aad); err == nil || !strings.Contains(err.Error(), "解密密钥值失败") {
+		t.Errorf("篡改密文后 Open 错误 = %v", err)
+	}
+
+	truncated := *sealed
+	truncated.DataKey = sealed.DataKey[:4]
+	if _, err := k.Open(&truncated, aad); err == nil {
+		t.Error("数据密钥长度不足时 Open 应返回错误")
+	}
+}
+
+func TestNoMasterKey(t *testing.T) {
+	k := newTestKeyring(t, "", nil)
+	if k.HasKey() {
+		t.Error("未配置主密钥时 HasKey 应为 false")
+	}
+	if _, err := k.Seal([]byte("value"), nil); !errors.Is(err, ErrNoMasterKey) {
+		t.Errorf("未配置主密钥时 Seal 错误 = %v, 期望 ErrNoMasterKey", err)
+	}
+}
@@ -0,0 +1,242 @@
+package secret
+
+import (
+	"fmt"
+	"go-job/internal/models"
+	"go-job/pkg/config"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// MaxValueSize 密钥值的最大长度
+const MaxValueSize = 32 * 1024
+
+// maxDepartmentDepth 查找上级部门的最大层数, 避免循环引用
+const maxDepartmentDepth = 20
+
+var (
+	// namePattern 密钥名称
+	namePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,100}$`)
+	// refPattern 参数中的密钥引用 ${secret:name}
+	refPattern = regexp.MustCompile(`\$\{secret:([A-Za-z0-9_.-]{1,100})\}`)
+)
+
+// Store 加密保存密钥, 下发任务时解析参数中的引用
+type Store struct {
+	db      *gorm.DB
+	keyring *Keyring
+}
+
+// NewStore 创建密钥存储, 主密钥配置无效时返回错误
+func NewStore(db *gorm.DB, cfg config.SecretsConfig) (*Store, error) {
+	keyring, err := NewKeyring(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db, keyring: keyring}, nil
+}
+
+// ValidateName 校验密钥名称
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("无效的密钥名称: %q, 只能包含字母、数字、下划线、点和短横线", name)
+	}
+	return nil
+}
+
+// Seal 加密值并写入密钥记录, 记录的 ID 必须已经确定
+func (s *Store) Seal(secret *models.Secret, value string) error {
+	if len(value) > MaxValueSize {
+		return fmt.Errorf("密钥值不能超过 %d 字节", MaxValueSize)
+	}
+	sealed, err := s.keyring.Seal([]byte(value), []byte(secret.ID))
+	if err != nil {
+		return err
+	}
+	secret.Ciphertext = sealed.Ciphertext
+	secret.DataKey = sealed.DataKey
+	secret.KeyID = sealed.KeyID
+	return nil
+}
+
+// open 解密密钥值
+func (s *Store) open(secret *models.Secret) (string, error) {
+	value, err := s.keyring.Open(sealedOf(secret), []byte(secret.ID))
+	if err != nil {
+		return "", fmt.Errorf("密钥 %s: %w", secret.Name, err)
+	}
+	return string(value), nil
+}
+
+// ActiveKey 加密新数据密钥使用的主密钥 ID
+func (s *Store) ActiveKey() string {
+	return s.keyring.ActiveKey()
+}
+
+// HasKey 是否配置了主密钥, 没有主密钥时不能保存密钥和数据库连接
+func (s *Store) HasKey() bool {
+	return s.keyring.HasKey()
+}
+
+// Rotate 用当前主密钥重新加密密钥和数据库连接串中使用旧主密钥的数据密钥, 返回重新加密的数量
+func (s *Store) Rotate() (int, error) {
+	active := s.keyring.ActiveKey()
+	if _, err := s.keyring.key(active); err != nil {
+		return 0, err
+	}
+
+	var secrets []models.Secret
+	if err := s.db.Where("key_id <> ?", active).Find(&secrets).Error; err != nil {
+		return 0, fmt.Errorf("查询密钥失败: %w", err)
+	}
+
+	rotated := 0
+	for i := range secrets {
+		secret := &secrets[i]
+		sealed, err := s.keyring.Rewrap(sealedOf(secret), []byte(secret.ID))
+		if err != nil {
+			return rotated, fmt.Errorf("密钥 %s: %w", secret.Name, err)
+		}
+		// 按原主密钥条件更新, 与并发的值更新竞争时以先写入的为准
+		result := s.db.Model(&models.Secret{}).
+			Where("id = ? AND key_id = ?", secret.ID, secret.KeyID).
+			Updates(map[string]interface{}{"data_key": sealed.DataKey, "key_id": sealed.KeyID})
+		if result.Error != nil {
+			return rotated, fmt.Errorf("更新密钥 %s 失败: %w", secret.Name, result.Error)
+		}
+		rotated += int(result.RowsAffected)
+	}
+
+	connections, err := s.rotateConnections(active)
+	return rotated + connections, err
+}
+
+// References 参数中引用的密钥名称, 已排序去重
+func References(params map[string]string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, value := range params {
+		for _, match := range refPattern.FindAllStringSubmatch(value, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				names = append(names, match[1])
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve 把参数中的 ${secret:name} 替换为密钥值, 返回替换后的参数和用到的密钥值
+//
+// 按任务、所属部门、上级部门的顺序查找同名密钥, 任一引用找不到时返回错误。
+func (s *Store) Resolve(job *models.Job, params map[string]string) (map[string]string, []string, error) {
+	names := References(params)
+	if len(names) == 0 {
+		return params, nil, nil
+	}
+
+	// 越靠前的范围优先
+	scopes := []scopeRef{{models.SecretScopeJob, job.ID}}
+	departments, err := DepartmentChain(s.db, job.DepartmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, id := range departments {
+		scopes = append(scopes, scopeRef{models.SecretScopeDepartment, id})
+	}
+
+	query := s.db.Where("name IN ?", names)
+	condition := s.db.Where("scope = ? AND scope_id = ?", models.SecretScopeJob, job.ID)
+	if len(departments) > 0 {
+		condition = condition.Or("scope = ? AND scope_id IN ?", models.SecretScopeDepartment, departments)
+	}
+	var secrets []models.Secret
+	if err := query.Where(condition).Find(&secrets).Error; err != nil {
+		return nil, nil, fmt.Errorf("查询密钥失败: %w", err)
+	}
+
+	chosen := make(map[string]*models.Secret)
+	rank := func(secret *models.Secret) int {
+		for i, scope := range scopes {
+			if scope.scope == secret.Scope && scope.id == secret.ScopeID {
+				return i
+			}
+		}
+		return len(scopes)
+	}
+	for i := range secrets {
+		secret := &secrets[i]
+		if current, ok := chosen[secret.Name]; !ok || rank(secret) < rank(current) {
+			chosen[secret.Name] = secret
+		}
+	}
+
+	var missing []string
+	values := make(map[string]string, len(chosen))
+	for _, name := range names {
+		secret, ok := chosen[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		value, err := s.open(secret)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[name] = value
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("任务引用的密钥不存在: %s", strings.Join(missing, ", "))
+	}
+
+	resolved := make(map[string]string, len(params))
+	for key, value := range params {
+		resolved[key] = refPattern.ReplaceAllStringFunc(value, func(ref string) string {
+			return values[refPattern.FindStringSubmatch(ref)[1]]
+		})
+	}
+	used := make([]string, 0, len(values))
+	for _, name := range names {
+		used = append(used, values[name])
+	}
+	return resolved, used, nil
+}
+
+// scopeRef 密钥的一个查找范围
+type scopeRef struct {
+	scope models.SecretScope
+	id    string
+}
+
+// DepartmentChain 部门及其上级部门的 ID, 由近到远
+func DepartmentChain(db *gorm.DB, departmentID string) ([]string, error) {
+	var chain []string
+	seen := make(map[string]bool)
+	for id := departmentID; id != "" && !seen[id] && len(chain) < maxDepartmentDepth; {
+		seen[id] = true
+		chain = append(chain, id)
+
+		var department models.Department
+		err := db.Unscoped().Select("id", "parent_id").First(&department, "id = ?", id).Error
+		if err == gorm.ErrRecordNotFound {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("查询部门失败: %w", err)
+		}
+		id = ""
+		if department.ParentID != nil {
+			id = *department.ParentID
+		}
+	}
+	return chain, nil
+}
+
+// sealedOf 密钥记录中的密文
+func sealedOf(secret *models.Secret) *Sealed {
+	return &Sealed{Ciphertext: secret.Ciphertext, DataKey: secret.DataKey, KeyID: secret.KeyID}
+}
@@ -0,0 +1,241 @@
+package sla
+
+import (
+	"context"
+	"fmt"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+	"go-job/pkg/websocket"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Monitor SLA 监控器
+//
+// 定期检查配置了 SLA 的任务, 对超过最晚开始时间未开始、超过最晚完成时间未成功完成
+// 以及运行时长超过上限的情况生成违约记录, 并通过 WebSocket 推送 sla_miss 事件。
+// 违约记录按 (任务, 类型, 日期, 执行) 唯一, 多个调度器实例同时运行时不会重复记录。
+type Monitor struct {
+	db       *gorm.DB
+	hub      *websocket.Hub
+	location *time.Location
+	interval time.Duration
+}
+
+// NewMonitor 创建 SLA 监控器, hub 为空时只记录不推送
+func NewMonitor(db *gorm.DB, hub *websocket.Hub, location *time.Location, interval time.Duration) *Monitor {
+	if location == nil {
+		location = time.Local
+	}
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return &Monitor{
+		db:       db,
+		hub:      hub,
+		location: location,
+		interval: interval,
+	}
+}
+
+// Run 启动监控器, 阻塞直到 ctx 结束
+func (m *Monitor) Run(ctx context.Context) {
+	logger.Info("启动 SLA 监控器")
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("SLA 监控器已停止")
+			return
+		case <-ticker.C:
+			m.check(time.Now().In(m.location))
+		}
+	}
+}
+
+// check 检查所有配置了 SLA 的任务
+func (m *Monitor) check(now time.Time) {
+	var jobs []models.Job
+	err := m.db.Where("enabled = ?", true).
+		Where("sla_start_by <> '' OR sla_finish_by <> '' OR sla_max_duration > 0").
+		Find(&jobs).Error
+	if err != nil {
+		logger.WithError(err).Error("查询 SLA 任务失败")
+		return
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+
+		// 同时检查前一天, 避免调度器停机期间错过截止时间
+		for _, day := range []time.Time{now.AddDate(0, 0, -1), now} {
+			if job.SLAStartBy != "" {
+				m.checkStartBy(job, day, now)
+			}
+			if job.SLAFinishBy != "" {
+				m.checkFinishBy(job, day, now)
+			}
+		}
+
+		if job.SLAMaxDuration > 0 {
+			m.checkMaxDuration(job, now)
+		}
+	}
+}
+
+// checkStartBy 检查最晚开始时间
+func (m *Monitor) checkStartBy(job *models.Job, day, now time.Time) {
+	deadline, err := Deadline(job.SLAStartBy, day, m.location)
+	if err != nil || now.Before(deadline) || !Expected(job, deadline) {
+		return
+	}
+	if m.recorded(job.ID, models.SLAMissStartBy, deadline) {
+		return
+	}
+
+	windowStart := deadline.Add(-24 * time.Hour)
+
+	var started int64
+	m.db.Model(&models.JobSchedule{}).
+		Where("job_id = ? AND executed_at > ? AND executed_at <= ?", job.ID, windowStart, deadline).
+		Count(&started)
+	if started == 0 {
+		m.db.Model(&models.JobExecution{}).
+			Where("job_id = ? AND started_at > ? AND started_at <= ?", job.ID, windowStart, deadline).
+			Count(&started)
+	}
+	if started > 0 {
+		return
+	}
+
+	m.record(job, &models.SLAMiss{
+		Type:     models.SLAMissStartBy,
+		SLADate:  deadline.Format("2006-01-02"),
+		Deadline: &deadline,
+		Expected: fmt.Sprintf("%s 前开始运行", job.SLAStartBy),
+		Actual:   "截止时间前未开始运行",
+	})
+}
+
+// checkFinishBy 检查最晚完成时间
+func (m *Monitor) checkFinishBy(job *models.Job, day, now time.Time) {
+	deadline, err := Deadline(job.SLAFinishBy, day, m.location)
+	if err != nil || now.Before(deadline) || !Expected(job, deadline) {
+		return
+	}
+	if m.recorded(job.ID, models.SLAMissFinishBy, deadline) {
+		return
+	}
+
+	windowStart := deadline.Add(-24 * time.Hour)
+
+	var finished int64
+	m.db.Model(&models.JobExecution{}).
+		Where("job_id = ? AND status = ? AND finished_at > ? AND finished_at <= ?",
+			job.ID, models.ExecutionStatusSuccess, windowStart, deadline).
+		Count(&finished)
+	if finished > 0 {
+		return
+	}
+
+	m.record(job, &models.SLAMiss{
+		Type:     models.SLAMissFinishBy,
+		SLADate:  deadline.Format("2006-01-02"),
+		Deadline: &deadline,
+		Expected: fmt.Sprintf("%s 前成功完成", job.SLAFinishBy),
+		Actual:   "截止时间前未成功完成",
+	})
+}
+
+// checkMaxDuration 检查运行时长, 包括仍在运行和最近完成的执行
+func (m *Monitor) checkMaxDuration(job *models.Job, now time.Time) {
+	limit := time.Duration(job.SLAMaxDuration) * time.Second
+	expected := fmt.Sprintf("运行时长不超过 %d 秒", job.SLAMaxDuration)
+	recorded := m.db.Model(&models.SLAMiss{}).Select("execution_id").
+		Where("job_id = ? AND type = ?", job.ID, models.SLAMissMaxDuration)
+
+	// 仍在运行的执行以调度记录的开始执行时间计算
+	var running []models.JobSchedule
+	m.db.Where("job_id = ? AND status = ? AND execution_id <> '' AND executed_at <= ?",
+		job.ID, models.ScheduleStatusExecuting, now.Add(-limit)).
+		Where("execution_id NOT IN (?)", recorded).
+		Find(&running)
+	for _, schedule := range running {
+		m.record(job, &models.SLAMiss{
+			Type:        models.SLAMissMaxDuration,
+			SLADate:     schedule.ExecutedAt.In(m.location).Format("2006-01-02"),
+			ExecutionID: schedule.ExecutionID,
+			Expected:    expected,
+			Actual:      fmt.Sprintf("已运行 %d 秒, 仍未结束", int(now.Sub(*schedule.ExecutedAt).Seconds())),
+		})
+	}
+
+	// 最近 24 小时内完成的执行
+	var executions []models.JobExecution
+	m.db.Select("id", "started_at", "finished_at").
+		Where("job_id = ? AND started_at IS NOT NULL AND finished_at > ?", job.ID, now.Add(-24*time.Hour)).
+		Where("id NOT IN (?)", recorded).
+		Find(&executions)
+	for _, execution := range executions {
+		duration := execution.FinishedAt.Sub(*execution.StartedAt)
+		if duration <= limit {
+			continue
+		}
+		m.record(job, &models.SLAMiss{
+			Type:        models.SLAMissMaxDuration,
+			SLADate:     execution.StartedAt.In(m.location).Format("2006-01-02"),
+			ExecutionID: execution.ID,
+			Expected:    expected,
+			Actual:      fmt.Sprintf("运行了 %d 秒", int(duration.Seconds())),
+		})
+	}
+}
+
+// recorded 判断某一天的截止时间违约是否已记录
+func (m *Monitor) recorded(jobID string, missType models.SLAMissType, deadline time.Time) bool {
+	var count int64
+	m.db.Model(&models.SLAMiss{}).
+		Where("job_id = ? AND type = ? AND sla_date = ?", jobID, missType, deadline.Format("2006-01-02")).
+		Count(&count)
+	return count > 0
+}
+
+// record 保存违约记录, 新记录会推送到 WebSocket
+func (m *Monitor) record(job *models.Job, miss *models.SLAMiss) {
+	miss.ID = uuid.New().String()
+	miss.JobID = job.ID
+	miss.DepartmentID = job.DepartmentID
+	miss.DetectedAt = time.Now()
+
+	result := m.db.Clauses(clause.OnConflict{DoNothing: true}).Create(miss)
+	if result.Error != nil {
+		logger.WithError(result.Error).Errorf("保存 SLA 违约记录失败: %s", job.ID)
+		return
+	}
+	if result.RowsAffected == 0 {
+		return
+	}
+
+	logger.Warnf("任务 SLA 违约: %s (%s) %s, %s", job.Name, miss.Type, miss.Expected, miss.Actual)
+
+	if m.hub != nil {
+		m.hub.BroadcastMessage("sla_miss", map[string]interface{}{
+			"id":           miss.ID,
+			"job_id":       job.ID,
+			"job_name":     job.Name,
+			"type":         miss.Type,
+			"sla_date":     miss.SLADate,
+			"execution_id": miss.ExecutionID,
+			"deadline":     miss.Deadline,
+			"expected":     miss.Expected,
+			"actual":       miss.Actual,
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package sla
+
+import (
+	"go-job/internal/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// JobCompliance 任务 SLA 达成情况
+type JobCompliance struct {
+	JobID          string                       `json:"job_id"`
+	JobName        string                       `json:"job_name"`
+	DepartmentID   string                       `json:"department_id"`
+	SLAStartBy     string                       `json:"sla_start_by"`
+	SLAFinishBy    string                       `json:"sla_finish_by"`
+	SLAMaxDuration int                          `json:"sla_max_duration"`
+	Checks         int64                        `json:"checks"` // 考核次数: 每日截止时间按天计, 最长时长按执行次数计
+	Misses         int64                        `json:"misses"`
+	MissesByType   map[models.SLAMissType]int64 `json:"misses_by_type"`
+	ComplianceRate float64                      `json:"compliance_rate"`
+}
+
+// DepartmentCompliance 部门 SLA 达成情况
+type DepartmentCompliance struct {
+	DepartmentID   string  `json:"department_id"`
+	DepartmentName string  `json:"department_name"`
+	Jobs           int     `json:"jobs"`
+	Checks         int64   `json:"checks"`
+	Misses         int64   `json:"misses"`
+	ComplianceRate float64 `json:"compliance_rate"`
+}
+
+// ComplianceReport SLA 达成报告
+type ComplianceReport struct {
+	From        time.Time              `json:"from"`
+	To          time.Time              `json:"to"`
+	Jobs        []JobCompliance        `json:"jobs"`
+	Departments []DepartmentCompliance `json:"departments"`
+}
+
+// Report 统计 [from, to) 时间段内配置了 SLA 的任务的达成情况, departmentID 为空时统计全部部门
+func Report(db *gorm.DB, location *time.Location, from, to time.Time, departmentID string) (*ComplianceReport, error) {
+	query := db.Where("sla_start_by <> '' OR sla_finish_by <> '' OR sla_max_duration > 0")
+	if departmentID != "" {
+		query = query.Where("department_id = ?", departmentID)
+	}
+
+	var jobs []models.Job
+	if err := query.Order("name").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	report := &ComplianceReport{
+		From:        from,
+		To:          to,
+		Jobs:        make([]JobCompliance, 0, len(jobs)),
+		Departments: make([]DepartmentCompliance, 0),
+	}
+	departments := make(map[string]*DepartmentCompliance)
+
+	for i := range jobs {
+		job := &jobs[i]
+		item := JobCompliance{
+			JobID:          job.ID,
+			JobName:        job.Name,
+			DepartmentID:   job.DepartmentID,
+			SLAStartBy:     job.SLAStartBy,
+			SLAFinishBy:    job.SLAFinishBy,
+			SLAMaxDuration: job.SLAMaxDuration,
+			MissesByType:   make(map[models.SLAMissType]int64),
+		}
+
+		// 每日截止时间: 统计区间内已过期且按计划应运行的天数
+		start := from.In(location)
+		start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, location)
+		for day := start; day.Before(to); day = day.AddDate(0, 0, 1) {
+			for _, clock := range []string{job.SLAStartBy, job.SLAFinishBy} {
+				if clock == "" {
+					continue
+				}
+				deadline, err := Deadline(clock, day, location)
+				if err != nil || deadline.Before(from) || !deadline.Before(to) {
+					continue
+				}
+				if Expected(job, deadline) {
+					item.Checks++
+				}
+			}
+		}
+
+		// 最长时长: 统计区间内开始的执行次数
+		if job.SLAMaxDuration > 0 {
+			var runs int64
+			db.Model(&models.JobExecution{}).
+				Where("job_id = ? AND started_at >= ? AND started_at < ?", job.ID, from, to).
+				Count(&runs)
+			item.Checks += runs
+		}
+
+		var misses []struct {
+			Type  models.SLAMissType
+			Count int64
+		}
+		db.Model(&models.SLAMiss{}).
+			Select("type, count(*) as count").
+			Where("job_id = ? AND sla_date >= ? AND sla_date <= ?",
+				job.ID, from.In(location).Format("2006-01-02"), to.In(location).Format("2006-01-02")).
+			Group("type").
+			Scan(&misses)
+		for _, miss := range misses {
+			item.MissesByType[miss.Type] = miss.Count
+			item.Misses += miss.Count
+		}
+
+		item.ComplianceRate = complianceRate(item.Checks, item.Misses)
+		report.Jobs = append(report.Jobs, item)
+
+		dept, ok := departments[job.DepartmentID]
+		if !ok {
+			dept = &DepartmentCompliance{DepartmentID: job.DepartmentID}
+			departments[job.DepartmentID] = dept
+		}
+		dept.Jobs++
+		dept.Checks += item.Checks
+		dept.Misses += item.Misses
+	}
+
+	for id, dept := range departments {
+		if id != "" {
+			var department models.Department
+			if err := db.Select("id", "name").First(&department, "id = ?", id).Error; err == nil {
+				dept.DepartmentName = department.Name
+			}
+		}
+		dept.ComplianceRate = complianceRate(dept.Checks, dept.Misses)
+		report.Departments = append(report.Departments, *dept)
+	}
+	sort.Slice(report.Departments, func(i, j int) bool {
+		return report.Departments[i].ComplianceRate < report.Departments[j].ComplianceRate
+	})
+
+	return report, nil
+}
+
+// complianceRate 计算达成率(百分比), 没有考核时视为 100%
+func complianceRate(checks, misses int64) float64 {
+	if checks <= 0 {
+		return 100
+	}
+	if misses > checks {
+		misses = checks
+	}
+	return float64(checks-misses) / float64(checks) * 100
+}
@@ -0,0 +1,71 @@
+package sla
+
+import (
+	"fmt"
+	"go-job/internal/models"
+	"go-job/pkg/cronexpr"
+	"time"
+)
+
+// Validate 校验任务的 SLA 配置
+func Validate(startBy, finishBy string, maxDuration int) error {
+	if startBy != "" {
+		if _, _, err := parseClock(startBy); err != nil {
+			return fmt.Errorf("无效的最晚开始时间: %w", err)
+		}
+	}
+	if finishBy != "" {
+		if _, _, err := parseClock(finishBy); err != nil {
+			return fmt.Errorf("无效的最晚完成时间: %w", err)
+		}
+	}
+	if maxDuration < 0 {
+		return fmt.Errorf("最长运行时长不能为负数")
+	}
+	return nil
+}
+
+// Enabled 任务是否配置了 SLA
+func Enabled(job *models.Job) bool {
+	return job.SLAStartBy != "" || job.SLAFinishBy != "" || job.SLAMaxDuration > 0
+}
+
+// Deadline 返回某一天的截止时间
+func Deadline(clock string, day time.Time, loc *time.Location) (time.Time, error) {
+	hour, minute, err := parseClock(clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+	day = day.In(loc)
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc), nil
+}
+
+// Expected 判断任务是否应在截止时间前的 24 小时内运行
+//
+// 每日截止时间只对这段时间内按 cron 计划会运行的任务生效,
+// 例如每周一运行的任务, 其余日期不会产生违约记录。
+func Expected(job *models.Job, deadline time.Time) bool {
+	windowStart := deadline.Add(-24 * time.Hour)
+	if job.CreatedAt.After(deadline) {
+		return false
+	}
+	if job.CreatedAt.After(windowStart) {
+		windowStart = job.CreatedAt
+	}
+
+	schedule, err := cronexpr.Parser.Parse(job.Cron)
+	if err != nil {
+		return false
+	}
+	next := schedule.Next(windowStart.In(deadline.Location()))
+	return !next.IsZero() && !next.After(deadline)
+}
+
+// parseClock 解析 HH:MM 格式的时间
+func parseClock(clock string) (int, int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, 0, fmt.Errorf("时间格式应为 HH:MM: %s", clock)
+	}
+	return t.Hour(), t.Minute(), nil
+}
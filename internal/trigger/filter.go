@@ -0,0 +1,226 @@
+package trigger
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Filter 事件过滤表达式
+//
+// 表达式由若干条件以 && 连接, 所有条件均满足时事件才会触发任务:
+//
+//	type == "order.paid"         字段等于
+//	env != dev                   字段不等于
+//	region =~ "^cn-"             字段匹配正则
+//	user.id !~ "^test"           字段不匹配正则
+//	amount                       字段存在
+//	!debug                       字段不存在
+//
+// 字段名支持 a.b.c 形式访问 JSON 负载中的嵌套字段, 值可以使用单引号或双引号包裹。
+// 空表达式匹配所有事件。
+type Filter struct {
+	conditions []condition
+}
+
+type condition struct {
+	field  string
+	op     string
+	value  string
+	regexp *regexp.Regexp
+}
+
+// filterOps 按长度排列, 保证 != 先于 = 匹配
+var filterOps = []string{"==", "!=", "=~", "!~"}
+
+// ParseFilter 解析过滤表达式
+func ParseFilter(expr string) (*Filter, error) {
+	filter := &Filter{}
+	if strings.TrimSpace(expr) == "" {
+		return filter, nil
+	}
+
+	parts, err := splitConditions(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, part := range parts {
+		cond, err := parseCondition(part)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 个条件 %q: %w", i+1, part, err)
+		}
+		filter.conditions = append(filter.conditions, cond)
+	}
+
+	return filter, nil
+}
+
+// Match 判断事件字段是否满足过滤条件
+func (f *Filter) Match(fields map[string]string) bool {
+	for _, cond := range f.conditions {
+		value, exists := fields[cond.field]
+
+		switch cond.op {
+		case "exists":
+			if !exists {
+				return false
+			}
+		case "not_exists":
+			if exists {
+				return false
+			}
+		case "==":
+			if !exists || value != cond.value {
+				return false
+			}
+		case "!=":
+			if exists && value == cond.value {
+				return false
+			}
+		case "=~":
+			if !exists || !cond.regexp.MatchString(value) {
+				return false
+			}
+		case "!~":
+			if exists && cond.regexp.MatchString(value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// splitConditions 在引号之外按 && 切分表达式
+func splitConditions(expr string) ([]string, error) {
+	var parts []string
+	var quote byte
+	start := 0
+
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '&' && i+1 < len(expr) && expr[i+1] == '&':
+			parts = append(parts, strings.TrimSpace(expr[start:i]))
+			start = i + 2
+			i++
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("引号未闭合")
+	}
+	parts = append(parts, strings.TrimSpace(expr[start:]))
+
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("存在空条件")
+		}
+	}
+	return parts, nil
+}
+
+// parseCondition 解析单个条件
+func parseCondition(part string) (condition, error) {
+	for _, op := range filterOps {
+		idx := indexOutsideQuotes(part, op)
+		if idx < 0 {
+			continue
+		}
+
+		field := strings.TrimSpace(part[:idx])
+		if !isValidFieldName(field) {
+			return condition{}, fmt.Errorf("无效的字段名: %q", field)
+		}
+
+		value, err := unquoteValue(strings.TrimSpace(part[idx+len(op):]))
+		if err != nil {
+			return condition{}, err
+		}
+
+		cond := condition{field: field, op: op, value: value}
+		if op == "=~" || op == "!~" {
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return condition{}, fmt.Errorf("无效的正则表达式: %w", err)
+			}
+			cond.regexp = re
+		}
+		return cond, nil
+	}
+
+	if strings.HasPrefix(part, "!") {
+		field := strings.TrimSpace(part[1:])
+		if !isValidFieldName(field) {
+			return condition{}, fmt.Errorf("无效的字段名: %q", field)
+		}
+		return condition{field: field, op: "not_exists"}, nil
+	}
+
+	if !isValidFieldName(part) {
+		return condition{}, fmt.Errorf("无法识别的条件")
+	}
+	return condition{field: part, op: "exists"}, nil
+}
+
+// indexOutsideQuotes 查找引号之外的运算符位置
+func indexOutsideQuotes(s, op string) int {
+	var quote byte
+	for i := 0; i+len(op) <= len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case s[i:i+len(op)] == op:
+			return i
+		}
+	}
+	return -1
+}
+
+// unquoteValue 去除值两端的引号
+func unquoteValue(value string) (string, error) {
+	if len(value) >= 2 {
+		switch {
+		case value[0] == '"' && value[len(value)-1] == '"':
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return "", fmt.Errorf("无效的字符串: %s", value)
+			}
+			return unquoted, nil
+		case value[0] == '\'' && value[len(value)-1] == '\'':
+			return value[1 : len(value)-1], nil
+		}
+	}
+	if strings.ContainsAny(value, "\"' ") {
+		return "", fmt.Errorf("包含空格或引号的值需要用引号包裹: %s", value)
+	}
+	return value, nil
+}
+
+// isValidFieldName 字段名只允许字母、数字、下划线、中划线和点
+func isValidFieldName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if !(c == '_' || c == '-' || c == '.' ||
+			(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package trigger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want string // 错误信息中应包含的内容
+	}{
+		{"未闭合的引号", `type == "order`, "引号未闭合"},
+		{"空条件", `type == a && && env == b`, "存在空条件"},
+		{"结尾的连接符", `type == a &&`, "存在空条件"},
+		{"无效字段名", `ty pe == a`, "无效的字段名"},
+		{"无效正则", `region =~ "("`, "无效的正则表达式"},
+		{"未加引号的空格", `type == order paid`, "需要用引号包裹"},
+		{"无效的否定字段", `!a b`, "无效的字段名"},
+		{"条件序号", `a && ty pe`, "第 2 个条件"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFilter(tt.expr)
+			if err == nil {
+				t.Fatalf("ParseFilter(%q) 应返回错误", tt.expr)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseFilter(%q) 错误 = %q, 应包含 %q", tt.expr, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	fields := map[string]string{
+		"type":      "order.paid",
+		"env":       "prod",
+		"region":    "cn-hangzhou",
+		"user.id":   "u-100",
+		"amount":    "42",
+		"note":      "a && b",
+		"empty":     "",
+		"user.name": "O'Brien",
+	}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{``, true},
+		{`   `, true},
+		{`type == "order.paid"`, true},
+		{`type == order.paid`, true},
+		{`type == 'order.paid'`, true},
+		{`type == order.refund`, false},
+		{`env != dev`, true},
+		{`env != prod`, false},
+		{`missing != x`, true},
+		{`missing == x`, false},
+		{`region =~ "^cn-"`, true},
+		{`region =~ "^us-"`, false},
+		{`missing =~ ".*"`, false},
+		{`user.id !~ "^test"`, true},
+		{`user.id !~ "^u-"`, false},
+		{`missing !~ "x"`, true},
+		{`amount`, true},
+		{`missing`, false},
+		{`!debug`, true},
+		{`!amount`, false},
+		{`empty`, true},
+		{`empty == ""`, true},
+		{`note == "a && b"`, true},
+		{`user.name == "O'Brien"`, true},
+		{`type == order.paid && env == prod && amount`, true},
+		{`type == order.paid && env == dev`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			filter, err := ParseFilter(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) 失败: %v", tt.expr, err)
+			}
+			if got := filter.Match(fields); got != tt.want {
+				t.Errorf("Match(%q) = %v, 期望 %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
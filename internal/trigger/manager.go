@@ -0,0 +1,158 @@
+package trigger
+
+import (
+	"context"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FireFunc 事件命中触发器后的回调, 由调度器负责创建运行记录
+type FireFunc func(ctx context.Context, trigger models.JobTrigger, event *Event) error
+
+// Manager 事件触发器管理器
+//
+// 定期从数据库同步启用的触发器, 为每个触发器启动一个事件源,
+// 触发器配置变更或删除后自动重启或停止对应的事件源。
+type Manager struct {
+	db           *gorm.DB
+	opts         Options
+	syncInterval time.Duration
+	fire         FireFunc
+
+	running map[string]*runningTrigger
+	mu      sync.Mutex
+}
+
+type runningTrigger struct {
+	trigger models.JobTrigger
+	cancel  context.CancelFunc
+}
+
+// NewManager 创建事件触发器管理器
+func NewManager(db *gorm.DB, opts Options, syncInterval time.Duration, fire FireFunc) *Manager {
+	if syncInterval <= 0 {
+		syncInterval = 30 * time.Second
+	}
+
+	return &Manager{
+		db:           db,
+		opts:         opts,
+		syncInterval: syncInterval,
+		fire:         fire,
+		running:      make(map[string]*runningTrigger),
+	}
+}
+
+// Run 启动管理器, 阻塞直到 ctx 结束
+func (m *Manager) Run(ctx context.Context) {
+	logger.Info("启动事件触发器管理器")
+
+	ticker := time.NewTicker(m.syncInterval)
+	defer ticker.Stop()
+
+	m.sync(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			m.stopAll()
+			return
+		case <-ticker.C:
+			m.sync(ctx)
+		}
+	}
+}
+
+// sync 同步数据库中的触发器配置
+func (m *Manager) sync(ctx context.Context) {
+	var triggers []models.JobTrigger
+	err := m.db.Joins("JOIN jobs ON jobs.id = job_triggers.job_id AND jobs.deleted_at IS NULL").
+		Where("job_triggers.enabled = ? AND jobs.enabled = ?", true, true).
+		Find(&triggers).Error
+	if err != nil {
+		logger.WithError(err).Error("加载事件触发器失败")
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	active := make(map[string]bool, len(triggers))
+	for _, t := range triggers {
+		active[t.ID] = true
+
+		if current, ok := m.running[t.ID]; ok {
+			if current.trigger.UpdatedAt.Equal(t.UpdatedAt) {
+				continue
+			}
+			current.cancel()
+			delete(m.running, t.ID)
+		}
+
+		if err := m.start(ctx, t); err != nil {
+			logger.WithError(err).Errorf("启动事件触发器失败: %s", t.ID)
+		}
+	}
+
+	for id, current := range m.running {
+		if !active[id] {
+			logger.Infof("停止事件触发器: %s", id)
+			current.cancel()
+			delete(m.running, id)
+		}
+	}
+}
+
+// start 启动单个触发器的事件源
+func (m *Manager) start(ctx context.Context, t models.JobTrigger) error {
+	filter, err := ParseFilter(t.Filter)
+	if err != nil {
+		return err
+	}
+
+	source, err := newSource(t, m.opts)
+	if err != nil {
+		return err
+	}
+
+	sourceCtx, cancel := context.WithCancel(ctx)
+	m.running[t.ID] = &runningTrigger{trigger: t, cancel: cancel}
+
+	handler := func(ctx context.Context, event *Event) error {
+		if !filter.Match(event.Fields) {
+			logger.Debugf("事件未命中过滤条件: %s/%s", t.ID, event.ID)
+			return nil
+		}
+		return m.fire(ctx, t, event)
+	}
+
+	go func() {
+		logger.Infof("事件触发器已启动: %s (%s %s)", t.ID, t.Type, t.Source)
+		for sourceCtx.Err() == nil {
+			if err := source.Run(sourceCtx, handler); err != nil {
+				logger.WithError(err).Errorf("事件源异常退出, 5 秒后重试: %s", t.ID)
+				select {
+				case <-sourceCtx.Done():
+				case <-time.After(5 * time.Second):
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// stopAll 停止所有事件源
+func (m *Manager) stopAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, current := range m.running {
+		current.cancel()
+		delete(m.running, id)
+	}
+	logger.Info("事件触发器管理器已停止")
+}
@@ -0,0 +1,306 @@
+package trigger
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go-job/internal/models"
+	"go-job/pkg/logger"
+	"go-job/pkg/redis"
+	"strings"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+)
+
+const (
+	// defaultConsumerGroup 未配置消费者组时使用的默认组名
+	defaultConsumerGroup = "go-job"
+	// streamBlockTimeout XREADGROUP 的阻塞时长
+	streamBlockTimeout = 5 * time.Second
+	// pubsubDedupTTL pub/sub 消息去重锁的有效期, 没有 event_id 时内容相同的消息在此期间只触发一次
+	pubsubDedupTTL = time.Minute
+	// streamReclaimInterval 检查消费者组中长时间未确认消息的间隔
+	streamReclaimInterval = 30 * time.Second
+	// streamClaimIdle 未确认消息空闲超过该时长后重新处理, 包括其他消费者领取后未确认的消息
+	streamClaimIdle = time.Minute
+	// streamMaxDeliveries 消息最多投递的次数, 超过后转入死信流
+	streamMaxDeliveries = 5
+	// deadLetterSuffix 死信流的 key 后缀
+	deadLetterSuffix = ":dead"
+)
+
+func init() {
+	Register(models.TriggerTypeRedisStream, newRedisStreamSource)
+	Register(models.TriggerTypeRedisPubSub, newRedisPubSubSource)
+}
+
+// redisStreamSource 基于 Redis Stream 消费者组的事件源
+//
+// 同一消费者组内每条消息只会投递给一个消费者, 处理成功后 XACK。处理失败的消息保留在
+// pending 列表中, 空闲超过 streamClaimIdle 后由组内任一消费者领取重试, 投递次数超过
+// streamMaxDeliveries 的消息写入 <stream>:dead 死信流后确认。
+type redisStreamSource struct {
+	triggerID string
+	stream    string
+	group     string
+	consumer  string
+}
+
+func newRedisStreamSource(trigger models.JobTrigger, opts Options) (Source, error) {
+	group := trigger.ConsumerGroup
+	if group == "" {
+		group = defaultConsumerGroup
+	}
+
+	return &redisStreamSource{
+		triggerID: trigger.ID,
+		stream:    trigger.Source,
+		group:     group,
+		consumer:  opts.ConsumerName,
+	}, nil
+}
+
+// Run 消费流消息
+func (s *redisStreamSource) Run(ctx context.Context, handler Handler) error {
+	err := redis.XGroupCreateMkStream(ctx, s.stream, s.group, "$")
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("创建消费者组失败: %w", err)
+	}
+
+	// 先处理本消费者未确认的消息, 再读取新消息
+	lastID := "0"
+	lastReclaim := time.Now()
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if lastID == ">" && time.Since(lastReclaim) >= streamReclaimInterval {
+			s.reclaim(ctx, handler)
+			lastReclaim = time.Now()
+		}
+
+		streams, err := redis.XReadGroup(ctx, &goredis.XReadGroupArgs{
+			Group:    s.group,
+			Consumer: s.consumer,
+			Streams:  []string{s.stream, lastID},
+			Count:    10,
+			Block:    streamBlockTimeout,
+		})
+		if err != nil {
+			if err == goredis.Nil || ctx.Err() != nil {
+				continue
+			}
+			logger.WithError(err).Errorf("读取事件流失败: %s", s.stream)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		received := 0
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				received++
+				if lastID != ">" {
+					lastID = message.ID
+				}
+				s.handle(ctx, handler, message)
+			}
+		}
+
+		// pending 消息处理完毕后切换到新消息
+		if lastID != ">" && received == 0 {
+			lastID = ">"
+		}
+	}
+}
+
+// handle 处理一条消息, 成功后确认
+func (s *redisStreamSource) handle(ctx context.Context, handler Handler, message goredis.XMessage) {
+	event := streamEvent(s.stream, message)
+	if err := handler(ctx, event); err != nil {
+		logger.WithError(err).Errorf("处理事件失败: %s/%s", s.stream, message.ID)
+		return
+	}
+	if err := redis.XAck(ctx, s.stream, s.group, message.ID); err != nil {
+		logger.WithError(err).Errorf("确认事件失败: %s/%s", s.stream, message.ID)
+	}
+}
+
+// reclaim 领取空闲过久的未确认消息重新处理, 投递次数用完的消息转入死信流
+func (s *redisStreamSource) reclaim(ctx context.Context, handler Handler) {
+	pending, err := redis.XPendingExt(ctx, &goredis.XPendingExtArgs{
+		Stream: s.stream,
+		Group:  s.group,
+		Start:  "-",
+		End:    "+",
+		Count:  100,
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.WithError(err).Errorf("查询未确认事件失败: %s", s.stream)
+		}
+		return
+	}
+
+	deliveries := make(map[string]int64)
+	var ids []string
+	for _, entry := range pending {
+		if entry.Idle >= streamClaimIdle {
+			deliveries[entry.ID] = entry.RetryCount
+			ids = append(ids, entry.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	// 按空闲时长领取, 其他调度器实例同时领取时只有一个成功
+	messages, err := redis.XClaim(ctx, &goredis.XClaimArgs{
+		Stream:   s.stream,
+		Group:    s.group,
+		Consumer: s.consumer,
+		MinIdle:  streamClaimIdle,
+		Messages: ids,
+	})
+	if err != nil {
+		logger.WithError(err).Errorf("领取未确认事件失败: %s", s.stream)
+		return
+	}
+
+	for _, message := range messages {
+		if message.Values == nil {
+			// 消息已被 XTRIM/XDEL 删除, 只剩 pending 记录
+			redis.XAck(ctx, s.stream, s.group, message.ID)
+			continue
+		}
+		if deliveries[message.ID] >= streamMaxDeliveries {
+			s.deadLetter(ctx, message, deliveries[message.ID])
+			continue
+		}
+		s.handle(ctx, handler, message)
+	}
+}
+
+// deadLetter 将多次处理失败的消息写入死信流并确认
+func (s *redisStreamSource) deadLetter(ctx context.Context, message goredis.XMessage, deliveries int64) {
+	values := make(map[string]interface{}, len(message.Values)+3)
+	for key, value := range message.Values {
+		values[key] = value
+	}
+	values["dead_letter_id"] = message.ID
+	values["dead_letter_trigger"] = s.triggerID
+	values["dead_letter_deliveries"] = deliveries
+
+	deadStream := s.stream + deadLetterSuffix
+	if _, err := redis.XAdd(ctx, &goredis.XAddArgs{Stream: deadStream, Values: values}); err != nil {
+		logger.WithError(err).Errorf("写入死信流失败: %s/%s", s.stream, message.ID)
+		return
+	}
+	if err := redis.XAck(ctx, s.stream, s.group, message.ID); err != nil {
+		logger.WithError(err).Errorf("确认事件失败: %s/%s", s.stream, message.ID)
+		return
+	}
+	logger.Warnf("事件 %s/%s 处理失败 %d 次, 已转入死信流 %s", s.stream, message.ID, deliveries, deadStream)
+}
+
+// streamEvent 将流消息转换为事件
+func streamEvent(stream string, message goredis.XMessage) *Event {
+	fields := make(map[string]string, len(message.Values))
+	for key, value := range message.Values {
+		fields[key] = fmt.Sprint(value)
+	}
+
+	// 约定 payload 字段为消息负载, 否则将所有字段序列化为负载
+	payload, ok := fields["payload"]
+	if ok {
+		flattenPayload(payload, fields)
+	} else {
+		raw, _ := json.Marshal(fields)
+		payload = string(raw)
+	}
+
+	return &Event{
+		ID:      message.ID,
+		Source:  stream,
+		Payload: payload,
+		Fields:  fields,
+	}
+}
+
+// redisPubSubSource 基于 Redis Pub/Sub 的事件源
+//
+// Pub/Sub 会把消息广播给所有订阅者, 多个调度器实例通过 SETNX 抢占消息标识,
+// 只有抢占成功的实例触发任务。Pub/Sub 本身不持久化消息, 调度器离线期间的消息会丢失,
+// 因此每条消息最多触发一次, 不保证一定触发, 需要可靠投递时使用 Redis Stream 触发器。
+type redisPubSubSource struct {
+	triggerID string
+	channel   string
+}
+
+func newRedisPubSubSource(trigger models.JobTrigger, opts Options) (Source, error) {
+	return &redisPubSubSource{
+		triggerID: trigger.ID,
+		channel:   trigger.Source,
+	}, nil
+}
+
+// Run 订阅频道消息
+func (s *redisPubSubSource) Run(ctx context.Context, handler Handler) error {
+	pubsub := redis.Subscribe(ctx, s.channel)
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("订阅频道失败: %w", err)
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return fmt.Errorf("频道订阅已关闭: %s", s.channel)
+			}
+
+			fields := make(map[string]string)
+			flattenPayload(message.Payload, fields)
+			id := pubsubIdentity(message.Channel, message.Payload, fields)
+
+			key := fmt.Sprintf("trigger_dedup:%s:%s", s.triggerID, id)
+			acquired, err := redis.SetNX(ctx, key, "1", pubsubDedupTTL)
+			if err != nil {
+				logger.WithError(err).Errorf("事件去重失败: %s", s.channel)
+				continue
+			}
+			if !acquired {
+				continue
+			}
+
+			event := &Event{
+				ID:      id,
+				Source:  message.Channel,
+				Payload: message.Payload,
+				Fields:  fields,
+			}
+			if err := handler(ctx, event); err != nil {
+				logger.WithError(err).Errorf("处理事件失败: %s", s.channel)
+			}
+		}
+	}
+}
+
+// pubsubIdentity 计算 pub/sub 消息在各调度器实例间一致的去重标识
+//
+// 负载中有 event_id 字段时使用发布者提供的 ID, 否则使用频道和内容的摘要。没有 event_id 时
+// 内容相同的消息在 pubsubDedupTTL 内最多触发一次, 之后的重复消息被当作重复投递丢弃;
+// 需要每条消息都触发一次时, 发布者应提供 event_id 或改用 Redis Stream 触发器。
+func pubsubIdentity(channel, payload string, fields map[string]string) string {
+	if id := fields["event_id"]; id != "" {
+		return "id:" + id
+	}
+	sum := sha1.Sum([]byte(channel + "\n" + payload))
+	return "sha1:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,31 @@
+package trigger
+
+import "testing"
+
+func TestPubsubIdentity(t *testing.T) {
+	payload := `{"action":"refresh"}`
+
+	// 各调度器实例只依赖消息本身计算标识, 与收到消息的次序和时间无关
+	id := pubsubIdentity("jobs", payload, nil)
+	if got := pubsubIdentity("jobs", payload, nil); got != id {
+		t.Errorf("同一消息的标识不一致: %s, %s", id, got)
+	}
+	if pubsubIdentity("other", payload, nil) == id {
+		t.Error("不同频道的消息标识不应相同")
+	}
+	if pubsubIdentity("jobs", `{"action":"rebuild"}`, nil) == id {
+		t.Error("内容不同的消息标识不应相同")
+	}
+
+	// 发布者提供的 event_id 优先
+	fields := map[string]string{"event_id": "evt-1"}
+	if got := pubsubIdentity("jobs", payload, fields); got != "id:evt-1" {
+		t.Errorf("带 event_id 的标识 = %s", got)
+	}
+	if pubsubIdentity("jobs", `{"other":1}`, fields) != pubsubIdentity("jobs", payload, fields) {
+		t.Error("event_id 相同的消息标识应相同")
+	}
+	if pubsubIdentity("jobs", payload, map[string]string{"event_id": "evt-2"}) == pubsubIdentity("jobs", payload, fields) {
+		t.Error("event_id 不同的消息即使内容相同, 标识也应不同")
+	}
+}
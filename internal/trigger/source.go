@@ -0,0 +1,126 @@
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-job/internal/models"
+	"sort"
+	"sync"
+)
+
+// Event 事件源产生的一条事件
+type Event struct {
+	ID      string            // 事件 ID (stream 消息 ID, pub/sub 消息的 event_id 或内容摘要加次序)
+	Source  string            // stream key 或 channel 名称
+	Payload string            // 原始负载
+	Fields  map[string]string // 用于过滤的字段, JSON 负载会被展开为 a.b.c 形式
+}
+
+// Handler 事件处理函数, 返回错误时事件不会被确认
+type Handler func(ctx context.Context, event *Event) error
+
+// Source 事件源
+//
+// 每个触发器对应一个事件源实例, Run 阻塞直到 ctx 结束或发生不可恢复的错误。
+// 事件源负责保证同一事件只交给一个调度器实例处理。
+type Source interface {
+	Run(ctx context.Context, handler Handler) error
+}
+
+// Factory 根据触发器配置创建事件源
+type Factory func(trigger models.JobTrigger, opts Options) (Source, error)
+
+// Options 事件源公共选项
+type Options struct {
+	ConsumerName string // 当前调度器实例在消费者组中的名称
+}
+
+var (
+	factories   = make(map[models.TriggerType]Factory)
+	factoriesMu sync.RWMutex
+)
+
+// Register 注册事件源类型, 其他消息中间件可通过此方法接入
+func Register(triggerType models.TriggerType, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	factories[triggerType] = factory
+}
+
+// IsRegistered 检查事件源类型是否已注册
+func IsRegistered(triggerType models.TriggerType) bool {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	_, ok := factories[triggerType]
+	return ok
+}
+
+// Types 返回已注册的事件源类型
+func Types() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	types := make([]string, 0, len(factories))
+	for t := range factories {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+	return types
+}
+
+// newSource 创建事件源
+func newSource(trigger models.JobTrigger, opts Options) (Source, error) {
+	factoriesMu.RLock()
+	factory, ok := factories[trigger.Type]
+	factoriesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("不支持的触发器类型: %s", trigger.Type)
+	}
+	return factory(trigger, opts)
+}
+
+// Validate 校验触发器配置
+func Validate(trigger models.JobTrigger) error {
+	if !IsRegistered(trigger.Type) {
+		return fmt.Errorf("不支持的触发器类型: %s", trigger.Type)
+	}
+	if trigger.Source == "" {
+		return fmt.Errorf("事件源不能为空")
+	}
+	if _, err := ParseFilter(trigger.Filter); err != nil {
+		return fmt.Errorf("过滤表达式无效: %w", err)
+	}
+	return nil
+}
+
+// flattenPayload 将 JSON 对象负载展开为字段, 非 JSON 负载返回空
+func flattenPayload(payload string, fields map[string]string) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return
+	}
+	flatten("", data, fields)
+}
+
+func flatten(prefix string, data map[string]interface{}, fields map[string]string) {
+	for key, value := range data {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flatten(name, v, fields)
+		case string:
+			fields[name] = v
+		case nil:
+			fields[name] = ""
+		default:
+			raw, _ := json.Marshal(v)
+			fields[name] = string(raw)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package trigger
+
+import "testing"
+
+func TestFlattenPayload(t *testing.T) {
+	fields := make(map[string]string)
+	flattenPayload(`{"type":"order.paid","user":{"id":"u-1","vip":true},"amount":42,"tags":["a"],"note":null}`, fields)
+
+	want := map[string]string{
+		"type":     "order.paid",
+		"user.id":  "u-1",
+		"user.vip": "true",
+		"amount":   "42",
+		"tags":     `["a"]`,
+		"note":     "",
+	}
+	if len(fields) != len(want) {
+		t.Errorf("字段数 = %d, 期望 %d: %v", len(fields), len(want), fields)
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("fields[%q] = %q, 期望 %q", key, fields[key], value)
+		}
+	}
+
+	fields = make(map[string]string)
+	flattenPayload("not json", fields)
+	if len(fields) != 0 {
+		t.Errorf("非 JSON 负载不应产生字段: %v", fields)
+	}
+}
@@ -0,0 +1,227 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/pkg/tracing"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// 任务的执行模式, 与 models.ExecMode 取值一致
+const (
+	execModeExec   = "exec"
+	execModeShell  = "shell"
+	execModeScript = "script"
+)
+
+// defaultShell 未指定解释器时 shell 模式使用的解释器
+const defaultShell = "/bin/sh -c"
+
+// defaultScriptShell 未指定解释器且脚本没有 #! 时使用的解释器
+const defaultScriptShell = "/bin/sh"
+
+// umaskWrapper 先设置 umask 再用 exec 替换为实际命令, 不再经过一次 shell 解析
+const umaskWrapper = `umask "$0" && exec "$@"`
+
+// limitsWrapper 等待工作节点通过 fd 3 通知资源限制已设置, 再用 exec 替换为实际命令
+const limitsWrapper = `read -r go_job_sync <&3 || exit 125; exec 3<&-; exec "$@"`
+
+// commandExecutor 执行 command 类型的任务
+type commandExecutor struct {
+	worker *Worker
+}
+
+// Execute 按执行模式创建命令并等待结束, 任务参数和工作节点信息通过环境变量传给命令
+//
+// 命令在独立的进程组中运行, 超时或取消时先发送 SIGTERM, 宽限期后发送 SIGKILL。
+// 任务设置了资源限制时在命令开始执行前生效, 结束后统计内存峰值和 CPU 时间。
+// 沙箱设置决定命令的运行用户、工作目录和继承的环境变量。
+func (e *commandExecutor) Execute(ctx context.Context, task *grpc.Task, stdout, stderr io.Writer) error {
+	limiter, err := newResourceLimiter(task, e.worker.config.Worker.CgroupParent, stderr)
+	if err != nil {
+		return err
+	}
+	if limiter != nil {
+		defer limiter.close()
+	}
+
+	sb, err := e.worker.newSandbox(task)
+	if err != nil {
+		return err
+	}
+	defer sb.cleanup()
+
+	cmd, cleanup, err := buildCommand(ctx, task, sb)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if sb.workDir != "" {
+		cmd.Dir = sb.workDir
+	}
+
+	// 设置环境变量, 工作节点的环境变量按沙箱的策略继承
+	cmd.Env = sb.environ(os.Environ(), e.worker.config.Worker.EnvDeny)
+	for key, value := range task.GetParams() {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	// 添加工作节点信息到环境变量
+	cmd.Env = append(cmd.Env, fmt.Sprintf("WORKER_ID=%s", e.worker.id))
+	cmd.Env = append(cmd.Env, fmt.Sprintf("WORKER_NAME=%s", e.worker.name))
+	cmd.Env = append(cmd.Env, fmt.Sprintf("TASK_ID=%s", task.GetId()))
+	if traceparent := tracing.TraceparentFromContext(ctx); traceparent != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("TRACEPARENT=%s", traceparent))
+	}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	terminate := setupProcessGroup(ctx, cmd, e.worker.killGracePeriod(), stderr)
+	defer terminate()
+	if sb.identity != nil {
+		sb.identity.apply(cmd)
+	}
+
+	if limiter != nil {
+		limiter.attach(cmd)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if limiter != nil {
+		if err := limiter.release(cmd.Process.Pid); err != nil {
+			cmd.Wait()
+			return err
+		}
+	}
+	err = cmd.Wait()
+
+	if cmd.ProcessState != nil {
+		usage := peakRSS(cmd.ProcessState)
+		if limiter != nil {
+			usage = max(usage, limiter.peakMemory())
+		}
+		e.worker.setUsage(task.GetId(), usage, cmd.ProcessState.UserTime()+cmd.ProcessState.SystemTime())
+	}
+	return err
+}
+
+// setUsage 保存命令的资源消耗
+func (w *Worker) setUsage(taskID string, peakRSS int64, cpuTime time.Duration) {
+	w.tasksMu.Lock()
+	defer w.tasksMu.Unlock()
+	if execution, ok := w.tasks[taskID]; ok {
+		execution.PeakRSS = peakRSS
+		execution.CPUTime = cpuTime
+	}
+}
+
+// buildCommand 按任务的执行模式创建命令, 返回的清理函数在命令结束后调用
+//
+// exec 模式直接执行 command 和 args, 不经过 shell; shell 模式把 command 交给解释器,
+// args 作为位置参数 $1... 传入; script 模式把 command 作为脚本内容写入临时文件再执行,
+// 脚本文件属于沙箱的运行用户。
+func buildCommand(ctx context.Context, task *grpc.Task, sb *sandbox) (*exec.Cmd, func(), error) {
+	cleanup := func() {}
+	command := task.GetCommand()
+	if strings.TrimSpace(command) == "" {
+		return nil, cleanup, fmt.Errorf("命令为空")
+	}
+
+	var argv []string
+	switch mode := task.GetExecMode(); mode {
+	case execModeExec:
+		argv = append([]string{command}, task.GetArgs()...)
+	case "":
+		// 不支持执行模式的旧版本调度器: 按空白拆分命令直接执行
+		argv = strings.Fields(command)
+	case execModeShell:
+		interpreter, err := interpreterArgv(task.GetInterpreter(), defaultShell)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		argv = append(interpreter, command)
+		if len(task.GetArgs()) > 0 {
+			// sh -c 的第一个额外参数是 $0, 其后才是 $1...
+			argv = append(argv, "go-job")
+			argv = append(argv, task.GetArgs()...)
+		}
+	case execModeScript:
+		path, err := writeScript(command)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		cleanup = func() { os.Remove(path) }
+		if err := sb.chown(path); err != nil {
+			cleanup()
+			return nil, func() {}, err
+		}
+
+		switch {
+		case task.GetInterpreter() != "":
+			interpreter, err := interpreterArgv(task.GetInterpreter(), "")
+			if err != nil {
+				cleanup()
+				return nil, func() {}, err
+			}
+			argv = append(interpreter, path)
+		case strings.HasPrefix(command, "#!"):
+			argv = []string{path}
+		default:
+			argv = []string{defaultScriptShell, path}
+		}
+		argv = append(argv, task.GetArgs()...)
+	default:
+		return nil, cleanup, fmt.Errorf("不支持的执行模式: %s", mode)
+	}
+
+	if umask := task.GetUmask(); umask != "" {
+		argv = append([]string{"/bin/sh", "-c", umaskWrapper, umask}, argv...)
+	}
+	if strings.TrimSpace(task.GetLimits()) != "" {
+		argv = append([]string{"/bin/sh", "-c", limitsWrapper, "go-job"}, argv...)
+	}
+
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = task.GetWorkDir()
+	return cmd, cleanup, nil
+}
+
+// interpreterArgv 拆分解释器配置, 如 "bash -lc"
+func interpreterArgv(interpreter, fallback string) ([]string, error) {
+	if interpreter == "" {
+		interpreter = fallback
+	}
+	argv := strings.Fields(interpreter)
+	if len(argv) == 0 {
+		return nil, fmt.Errorf("解释器为空")
+	}
+	return argv, nil
+}
+
+// writeScript 将脚本内容写入可执行的临时文件
+func writeScript(content string) (string, error) {
+	file, err := os.CreateTemp("", "go-job-script-*")
+	if err != nil {
+		return "", fmt.Errorf("创建脚本文件失败: %w", err)
+	}
+	path := file.Name()
+
+	_, err = file.WriteString(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(path, 0o700)
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("写入脚本文件失败: %w", err)
+	}
+	return path, nil
+}
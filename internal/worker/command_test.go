@@ -0,0 +1,187 @@
+package worker
+
+import (
+	"context"
+	"go-job/api/grpc"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestInterpreterArgv(t *testing.T) {
+	tests := []struct {
+		name        string
+		interpreter string
+		fallback    string
+		want        []string
+		wantErr     bool
+	}{
+		{"默认 shell", "", defaultShell, []string{"/bin/sh", "-c"}, false},
+		{"带参数的解释器", "bash -lc", defaultShell, []string{"bash", "-lc"}, false},
+		{"多余空白", "  /usr/bin/env   python3 ", "", []string{"/usr/bin/env", "python3"}, false},
+		{"没有默认值", "", "", nil, true},
+		{"只有空白", "   ", defaultShell, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := interpreterArgv(tt.interpreter, tt.fallback)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("interpreterArgv 错误 = %v, 期望出错 %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("interpreterArgv = %q, 期望 %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		task *grpc.Task
+		want []string
+	}{
+		{
+			"exec 模式",
+			&grpc.Task{ExecMode: execModeExec, Command: "/usr/bin/rsync", Args: []string{"-a", "/data/my files/"}},
+			[]string{"/usr/bin/rsync", "-a", "/data/my files/"},
+		},
+		{
+			"旧版本调度器按空白拆分",
+			&grpc.Task{Command: "echo  hello world"},
+			[]string{"echo", "hello", "world"},
+		},
+		{
+			"shell 模式没有参数",
+			&grpc.Task{ExecMode: execModeShell, Command: "echo $HOME | tr a-z A-Z"},
+			[]string{"/bin/sh", "-c", "echo $HOME | tr a-z A-Z"},
+		},
+		{
+			"shell 模式参数前补 $0",
+			&grpc.Task{ExecMode: execModeShell, Command: `echo "$1"`, Args: []string{"a b", "c"}},
+			[]string{"/bin/sh", "-c", `echo "$1"`, "go-job", "a b", "c"},
+		},
+		{
+			"shell 模式指定解释器",
+			&grpc.Task{ExecMode: execModeShell, Interpreter: "bash -lc", Command: "source ~/.profile && run"},
+			[]string{"bash", "-lc", "source ~/.profile && run"},
+		},
+		{
+			"umask 包装",
+			&grpc.Task{ExecMode: execModeExec, Command: "/bin/true", Umask: "027"},
+			[]string{"/bin/sh", "-c", umaskWrapper, "027", "/bin/true"},
+		},
+		{
+			"资源限制包装在 umask 之外",
+			&grpc.Task{ExecMode: execModeShell, Command: "make", Umask: "022", Limits: `{"memory_mb":128}`},
+			[]string{"/bin/sh", "-c", limitsWrapper, "go-job", "/bin/sh", "-c", umaskWrapper, "022", "/bin/sh", "-c", "make"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, cleanup, err := buildCommand(context.Background(), tt.task, &sandbox{})
+			if err != nil {
+				t.Fatalf("buildCommand 失败: %v", err)
+			}
+			defer cleanup()
+			if !reflect.DeepEqual(cmd.Args, tt.want) {
+				t.Errorf("argv = %q, 期望 %q", cmd.Args, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCommandWorkDir(t *testing.T) {
+	task := &grpc.Task{ExecMode: execModeExec, Command: "/bin/pwd", WorkDir: "/srv/app"}
+	cmd, cleanup, err := buildCommand(context.Background(), task, &sandbox{})
+	if err != nil {
+		t.Fatalf("buildCommand 失败: %v", err)
+	}
+	defer cleanup()
+	if cmd.Dir != "/srv/app" {
+		t.Errorf("Dir = %q, 期望 %q", cmd.Dir, "/srv/app")
+	}
+}
+
+func TestBuildCommandScript(t *testing.T) {
+	tests := []struct {
+		name string
+		task *grpc.Task
+		// want 中的空字符串替换为脚本文件路径
+		want []string
+	}{
+		{
+			"没有 #! 时使用默认 shell",
+			&grpc.Task{ExecMode: execModeScript, Command: "echo one\necho two\n", Args: []string{"x"}},
+			[]string{defaultScriptShell, "", "x"},
+		},
+		{
+			"#! 脚本直接执行",
+			&grpc.Task{ExecMode: execModeScript, Command: "#!/usr/bin/env python3\nprint(1)\n"},
+			[]string{""},
+		},
+		{
+			"指定解释器优先于 #!",
+			&grpc.Task{ExecMode: execModeScript, Interpreter: "bash -e", Command: "#!/bin/sh\nfalse\n"},
+			[]string{"bash", "-e", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, cleanup, err := buildCommand(context.Background(), tt.task, &sandbox{})
+			if err != nil {
+				t.Fatalf("buildCommand 失败: %v", err)
+			}
+
+			var path string
+			want := make([]string, len(tt.want))
+			for i, arg := range tt.want {
+				if arg == "" {
+					path = cmd.Args[i]
+					arg = path
+				}
+				want[i] = arg
+			}
+			if !reflect.DeepEqual(cmd.Args, want) {
+				t.Errorf("argv = %q, 期望 %q", cmd.Args, want)
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("读取脚本文件失败: %v", err)
+			}
+			if string(content) != tt.task.GetCommand() {
+				t.Errorf("脚本内容 = %q, 期望 %q", content, tt.task.GetCommand())
+			}
+			if info, err := os.Stat(path); err != nil {
+				t.Errorf("查看脚本文件失败: %v", err)
+			} else if info.Mode().Perm() != 0o700 {
+				t.Errorf("脚本文件权限 = %v, 期望 0700", info.Mode().Perm())
+			}
+
+			cleanup()
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("cleanup 后脚本文件仍然存在: %v", err)
+			}
+		})
+	}
+}
+
+func TestBuildCommandErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		task *grpc.Task
+	}{
+		{"空命令", &grpc.Task{ExecMode: execModeShell, Command: "  "}},
+		{"未知的执行模式", &grpc.Task{ExecMode: "batch", Command: "run"}},
+		{"空解释器", &grpc.Task{ExecMode: execModeShell, Interpreter: " ", Command: "run"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, cleanup, err := buildCommand(context.Background(), tt.task, &sandbox{}); err == nil {
+				cleanup()
+				t.Error("buildCommand 应返回错误")
+			}
+		})
+	}
+}
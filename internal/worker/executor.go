@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/jobtype"
+	"io"
+)
+
+// Executor 任务执行器, 按任务类型注册
+//
+// Execute 在 ctx 结束(超时或任务被取消)时应尽快返回, 输出写入 stdout 和 stderr。
+// 返回 *exec.ExitError 时以其退出码上报, 其他错误上报退出码 1。
+type Executor interface {
+	Execute(ctx context.Context, task *grpc.Task, stdout, stderr io.Writer) error
+}
+
+// RegisterExecutor 注册任务类型的执行器, 同一类型重复注册时覆盖, 应在 Start 之前调用
+func (w *Worker) RegisterExecutor(jobType string, executor Executor) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.executors[jobType] = executor
+}
+
+// executor 返回任务类型对应的执行器
+func (w *Worker) executor(jobType string) (Executor, error) {
+	jobType = jobtype.Normalize(jobType)
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	executor, ok := w.executors[jobType]
+	if !ok {
+		return nil, fmt.Errorf("工作节点不支持任务类型: %s", jobType)
+	}
+	return executor, nil
+}
+
+// registerBuiltinExecutors 注册内置的执行器
+func (w *Worker) registerBuiltinExecutors() {
+	w.executors[jobtype.Command] = &commandExecutor{worker: w}
+	w.executors[jobtype.HTTP] = newHTTPExecutor()
+	w.executors[jobtype.GRPC] = &grpcExecutor{}
+	w.executors[jobtype.SQL] = &sqlExecutor{}
+	w.executors[jobtype.Handler] = &handlerExecutor{worker: w}
+}
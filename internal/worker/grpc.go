@@ -0,0 +1,267 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/jobtype"
+	"go-job/pkg/tracing"
+	"io"
+	"time"
+
+	grpcpkg "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// grpcExecutor 执行 grpc 类型的任务
+type grpcExecutor struct{}
+
+// Execute 通过服务端反射解析方法, 把 JSON 请求转换为消息后发起一元调用, 响应以 JSON 写入标准输出
+func (e *grpcExecutor) Execute(ctx context.Context, task *grpc.Task, stdout, stderr io.Writer) error {
+	cfg, err := jobtype.ParseGRPC(task.GetConfig())
+	if err != nil {
+		return err
+	}
+	rendered, err := cfg.Render(jobtype.TemplateData{
+		TaskID: task.GetId(),
+		JobID:  task.GetJobId(),
+		Params: task.GetParams(),
+	})
+	if err != nil {
+		return err
+	}
+
+	creds := insecure.NewCredentials()
+	if !cfg.Plaintext {
+		tlsConfig := &jobtype.TLSConfig{}
+		if cfg.TLS != nil {
+			tlsConfig = cfg.TLS
+		}
+		config, err := tlsConfig.Config()
+		if err != nil {
+			return err
+		}
+		creds = credentials.NewTLS(config)
+	}
+	conn, err := grpcpkg.NewClient(cfg.Target, grpcpkg.WithTransportCredentials(creds))
+	if err != nil {
+		return fmt.Errorf("创建 gRPC 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.Timeout)*time.Second)
+		defer cancel()
+	}
+	md := metadata.New(rendered.Metadata)
+	if traceparent := tracing.TraceparentFromContext(ctx); traceparent != "" {
+		md.Set("traceparent", traceparent)
+	}
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	method, err := resolveMethod(ctx, conn, cfg.Service(), cfg.MethodName())
+	if err != nil {
+		return err
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return fmt.Errorf("只支持一元方法, %s 为流式方法", method.FullName())
+	}
+
+	req := dynamicpb.NewMessage(method.Input())
+	if err := protojson.Unmarshal([]byte(rendered.Request), req); err != nil {
+		return fmt.Errorf("请求无法转换为 %s: %w", method.Input().FullName(), err)
+	}
+	resp := dynamicpb.NewMessage(method.Output())
+
+	fmt.Fprintf(stderr, "> %s %s\n", cfg.Target, cfg.FullMethod())
+	startTime := time.Now()
+	err = conn.Invoke(ctx, cfg.FullMethod(), req, resp)
+	code := status.Code(err)
+	fmt.Fprintf(stderr, "< %s, 耗时 %s\n", code, time.Since(startTime).Round(time.Millisecond))
+
+	if err == nil {
+		body, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("响应无法转换为 JSON: %w", err)
+		}
+		fmt.Fprintf(stdout, "%s\n", body)
+	} else {
+		fmt.Fprintf(stdout, "%s: %s\n", code, status.Convert(err).Message())
+	}
+
+	if code != cfg.ExpectedCode() {
+		if err != nil {
+			return fmt.Errorf("调用失败: %w", err)
+		}
+		return fmt.Errorf("响应状态码不符合期望: %s", code)
+	}
+	return nil
+}
+
+// resolveMethod 通过服务端反射获取方法描述, 递归加载方法所在文件的依赖
+func resolveMethod(ctx context.Context, conn *grpcpkg.ClientConn, service, method string) (protoreflect.MethodDescriptor, error) {
+	client := &reflectionClient{conn: conn}
+	protos := make(map[string]*descriptorpb.FileDescriptorProto)
+	add := func(raw [][]byte) error {
+		for _, data := range raw {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(data, fd); err != nil {
+				return fmt.Errorf("解析文件描述失败: %w", err)
+			}
+			protos[fd.GetName()] = fd
+		}
+		return nil
+	}
+
+	raw, err := client.fetch(ctx, service, "")
+	if err != nil {
+		return nil, fmt.Errorf("通过反射查询服务 %s 失败: %w", service, err)
+	}
+	if err := add(raw); err != nil {
+		return nil, err
+	}
+	roots := make([]string, 0, len(protos))
+	for name := range protos {
+		roots = append(roots, name)
+	}
+
+	files := new(protoregistry.Files)
+	var build func(name string) error
+	build = func(name string) error {
+		if _, err := files.FindFileByPath(name); err == nil {
+			return nil
+		}
+		fd, ok := protos[name]
+		if !ok {
+			raw, err := client.fetch(ctx, "", name)
+			if err == nil {
+				err = add(raw)
+			}
+			if fd, ok = protos[name]; !ok {
+				// 服务端没有提供的公共依赖(如 google/protobuf/*.proto)使用本地编译进来的描述
+				if local, localErr := protoregistry.GlobalFiles.FindFileByPath(name); localErr == nil {
+					return files.RegisterFile(local)
+				}
+				if err == nil {
+					err = fmt.Errorf("服务端未返回该文件")
+				}
+				return fmt.Errorf("获取依赖 %s 失败: %w", name, err)
+			}
+		}
+		for _, dep := range fd.GetDependency() {
+			if err := build(dep); err != nil {
+				return err
+			}
+		}
+		file, err := protodesc.NewFile(fd, files)
+		if err != nil {
+			return fmt.Errorf("构建文件描述 %s 失败: %w", name, err)
+		}
+		return files.RegisterFile(file)
+	}
+	for _, name := range roots {
+		if err := build(name); err != nil {
+			return nil, err
+		}
+	}
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("服务不存在: %s", service)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s 不是服务", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("服务 %s 没有方法 %s", service, method)
+	}
+	return md, nil
+}
+
+// reflectionClient 服务端反射客户端, 优先使用 v1, 服务端不支持时回退到 v1alpha
+type reflectionClient struct {
+	conn  *grpcpkg.ClientConn
+	alpha bool
+}
+
+// fetch 按符号或文件名获取文件描述, 服务端通常一并返回其依赖
+func (r *reflectionClient) fetch(ctx context.Context, symbol, filename string) ([][]byte, error) {
+	if !r.alpha {
+		raw, err := r.fetchV1(ctx, symbol, filename)
+		if status.Code(err) != codes.Unimplemented {
+			return raw, err
+		}
+		r.alpha = true
+	}
+	return r.fetchV1Alpha(ctx, symbol, filename)
+}
+
+// fetchV1 通过 grpc.reflection.v1 获取文件描述
+func (r *reflectionClient) fetchV1(ctx context.Context, symbol, filename string) ([][]byte, error) {
+	stream, err := reflectionv1.NewServerReflectionClient(r.conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+
+	req := &reflectionv1.ServerReflectionRequest{}
+	if symbol != "" {
+		req.MessageRequest = &reflectionv1.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol}
+	} else {
+		req.MessageRequest = &reflectionv1.ServerReflectionRequest_FileByFilename{FileByFilename: filename}
+	}
+	if err := stream.Send(req); err != nil {
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if e := resp.GetErrorResponse(); e != nil {
+		return nil, status.Error(codes.Code(e.GetErrorCode()), e.GetErrorMessage())
+	}
+	return resp.GetFileDescriptorResponse().GetFileDescriptorProto(), nil
+}
+
+// fetchV1Alpha 通过 grpc.reflection.v1alpha 获取文件描述
+func (r *reflectionClient) fetchV1Alpha(ctx context.Context, symbol, filename string) ([][]byte, error) {
+	stream, err := reflectionv1alpha.NewServerReflectionClient(r.conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+
+	req := &reflectionv1alpha.ServerReflectionRequest{}
+	if symbol != "" {
+		req.MessageRequest = &reflectionv1alpha.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol}
+	} else {
+		req.MessageRequest = &reflectionv1alpha.ServerReflectionRequest_FileByFilename{FileByFilename: filename}
+	}
+	if err := stream.Send(req); err != nil {
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if e := resp.GetErrorResponse(); e != nil {
+		return nil, status.Error(codes.Code(e.GetErrorCode()), e.GetErrorMessage())
+	}
+	return resp.GetFileDescriptorResponse().GetFileDescriptorProto(), nil
+}
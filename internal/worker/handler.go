@@ -0,0 +1,253 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/jobtype"
+	"go-job/pkg/logger"
+	"go-job/pkg/output"
+	"io"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// progressInterval 进度上报的最小间隔, 间隔内的多次上报只发送最后一次
+const progressInterval = time.Second
+
+// HandlerFunc 进程内任务处理函数
+//
+// ctx 在任务超时或被取消时结束, 处理函数应及时返回。任务信息通过 TaskFromContext 获取,
+// 进度通过 ReportProgress 上报, 写入 Output 的内容作为执行输出实时上报。
+type HandlerFunc func(ctx context.Context, params map[string]string) (Result, error)
+
+// Result 处理函数的结果
+type Result struct {
+	Output string      // 追加到执行输出
+	Data   interface{} // 结构化结果, 序列化为 JSON 保存在执行记录中
+}
+
+// TaskInfo 处理函数正在执行的任务
+type TaskInfo struct {
+	ID     string // 执行 ID
+	JobID  string
+	Params map[string]string
+}
+
+// handlerContextKey 处理函数上下文中保存的任务信息
+type handlerContextKey struct{}
+
+// handlerContext 处理函数的运行信息
+type handlerContext struct {
+	task     TaskInfo
+	output   io.Writer
+	progress *progressReporter
+}
+
+// TaskFromContext 返回处理函数正在执行的任务, 不在处理函数中调用时返回 false
+func TaskFromContext(ctx context.Context) (TaskInfo, bool) {
+	hc, ok := ctx.Value(handlerContextKey{}).(*handlerContext)
+	if !ok {
+		return TaskInfo{}, false
+	}
+	return hc.task, true
+}
+
+// Output 返回执行输出, 不在处理函数中调用时返回 io.Discard
+func Output(ctx context.Context) io.Writer {
+	hc, ok := ctx.Value(handlerContextKey{}).(*handlerContext)
+	if !ok {
+		return io.Discard
+	}
+	return hc.output
+}
+
+// ReportProgress 上报处理函数的执行进度, percent 取 0-100
+func ReportProgress(ctx context.Context, percent int, message string) {
+	hc, ok := ctx.Value(handlerContextKey{}).(*handlerContext)
+	if !ok {
+		return
+	}
+	hc.progress.report(percent, message)
+}
+
+// RegisterHandler 注册进程内处理函数, 应在 Start 之前调用, 注册时会把处理函数名称告知调度器
+func (w *Worker) RegisterHandler(name string, fn HandlerFunc) error {
+	if err := jobtype.ValidateHandlerName(name); err != nil {
+		return err
+	}
+	if fn == nil {
+		return fmt.Errorf("处理函数不能为空: %s", name)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers[name] = fn
+	return nil
+}
+
+// handlerNames 已注册的处理函数名称
+func (w *Worker) handlerNames() []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	names := make([]string, 0, len(w.handlers))
+	for name := range w.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// handler 返回处理函数
+func (w *Worker) handler(name string) (HandlerFunc, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	fn, ok := w.handlers[name]
+	return fn, ok
+}
+
+// handlerExecutor 执行 handler 类型的任务
+type handlerExecutor struct {
+	worker *Worker
+}
+
+// Execute 调用注册的处理函数, 结构化结果保存到任务执行信息中随结果上报
+func (e *handlerExecutor) Execute(ctx context.Context, task *grpc.Task, stdout, stderr io.Writer) error {
+	cfg, err := jobtype.ParseHandler(task.GetConfig())
+	if err != nil {
+		return err
+	}
+	fn, ok := e.worker.handler(cfg.Handler)
+	if !ok {
+		return fmt.Errorf("工作节点没有注册处理函数: %s", cfg.Handler)
+	}
+
+	progress := &progressReporter{worker: e.worker, taskID: task.GetId(), redact: output.RedactPatterns(task.GetRedact())}
+	defer progress.flush()
+
+	hc := &handlerContext{
+		task: TaskInfo{
+			ID:     task.GetId(),
+			JobID:  task.GetJobId(),
+			Params: task.GetParams(),
+		},
+		output:   stdout,
+		progress: progress,
+	}
+	result, err := callHandler(context.WithValue(ctx, handlerContextKey{}, hc), fn, task.GetParams(), stderr)
+
+	if result.Output != "" {
+		io.WriteString(stdout, result.Output)
+	}
+	if result.Data != nil {
+		data, marshalErr := json.Marshal(result.Data)
+		if marshalErr != nil {
+			fmt.Fprintf(stderr, "结构化结果无法序列化为 JSON: %v\n", marshalErr)
+		} else {
+			e.worker.setResult(task.GetId(), string(data))
+		}
+	}
+	if err != nil {
+		return err
+	}
+	// 处理函数忽略了取消继续执行完毕时, 仍按超时或取消上报
+	return ctx.Err()
+}
+
+// callHandler 调用处理函数, panic 时记录调用栈并作为失败返回
+func callHandler(ctx context.Context, fn HandlerFunc, params map[string]string, stderr io.Writer) (result Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(stderr, "处理函数 panic: %v\n%s", r, debug.Stack())
+			err = fmt.Errorf("处理函数 panic: %v", r)
+		}
+	}()
+	return fn(ctx, params)
+}
+
+// setResult 保存任务的结构化结果
+func (w *Worker) setResult(taskID, result string) {
+	w.tasksMu.Lock()
+	defer w.tasksMu.Unlock()
+	if execution, ok := w.tasks[taskID]; ok {
+		execution.Result = result
+	}
+}
+
+// progressReporter 合并频繁的进度上报, 每个间隔最多发送一次
+type progressReporter struct {
+	worker  *Worker
+	taskID  string
+	redact  []string // 进度说明中需要替换的密钥值
+	mu      sync.Mutex
+	last    time.Time
+	pending *grpc.TaskProgress
+}
+
+// report 记录进度, 距上次发送超过间隔或进度完成时立即发送
+func (p *progressReporter) report(percent int, message string) {
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+
+	p.mu.Lock()
+	p.pending = &grpc.TaskProgress{
+		TaskId:   p.taskID,
+		WorkerId: p.worker.id,
+		Percent:  int32(percent),
+		Message:  output.Redact(message, p.redact),
+		Time:     timestamppb.Now(),
+	}
+	if percent < 100 && time.Since(p.last) < progressInterval {
+		p.mu.Unlock()
+		return
+	}
+	progress := p.take()
+	p.mu.Unlock()
+
+	p.worker.sendProgress(progress)
+}
+
+// flush 发送尚未发送的进度
+func (p *progressReporter) flush() {
+	p.mu.Lock()
+	progress := p.take()
+	p.mu.Unlock()
+
+	if progress != nil {
+		p.worker.sendProgress(progress)
+	}
+}
+
+// take 取出待发送的进度, 调用方持有锁
+func (p *progressReporter) take() *grpc.TaskProgress {
+	progress := p.pending
+	p.pending = nil
+	if progress != nil {
+		p.last = time.Now()
+	}
+	return progress
+}
+
+// sendProgress 上报进度, 推送连接可用时通过它发送
+func (w *Worker) sendProgress(progress *grpc.TaskProgress) {
+	if w.streaming.Load() {
+		if err := w.send(&grpc.WorkerMessage{Payload: &grpc.WorkerMessage_Progress{Progress: progress}}); err == nil {
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := w.client.ReportTaskProgress(ctx, progress); err != nil {
+		logger.WithError(err).Warnf("上报任务进度失败: %s", progress.GetTaskId())
+	}
+}
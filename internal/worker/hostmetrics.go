@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"go-job/api/grpc"
+	"go-job/pkg/logger"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// hostSampler 采集工作节点所在主机的资源使用情况, 随心跳上报
+type hostSampler struct {
+	mu        sync.Mutex
+	diskPath  string    // 统计磁盘使用的目录
+	startedAt time.Time // 工作节点进程的启动时间
+	cpu       cpuTimes  // 上次采集时的 CPU 时间, 用于计算区间内的使用率
+	last      *grpc.HostMetrics
+	warned    bool
+}
+
+// cpuTimes 系统启动以来 CPU 的累计时间(时钟节拍)
+type cpuTimes struct {
+	idle  uint64
+	total uint64
+}
+
+// newHostSampler 创建采集器, diskPath 为空时统计系统临时目录所在的文件系统
+func newHostSampler(diskPath string) *hostSampler {
+	if diskPath == "" {
+		diskPath = os.TempDir()
+	}
+	s := &hostSampler{diskPath: diskPath, startedAt: time.Now()}
+	s.cpu, _ = readCPUTimes()
+	return s
+}
+
+// uptime 工作节点进程的运行时间
+func (s *hostSampler) uptime() time.Duration {
+	return time.Since(s.startedAt)
+}
+
+// sample 采集一次主机指标, 读取失败的项保持为 0, 只在第一次失败时记录日志
+func (s *hostSampler) sample() *grpc.HostMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := &grpc.HostMetrics{
+		CpuCount:      int32(runtime.NumCPU()),
+		UptimeSeconds: s.uptime().Seconds(),
+	}
+	if err := s.readHost(m); err != nil && !s.warned {
+		s.warned = true
+		logger.WithError(err).Warn("采集主机指标失败")
+	}
+	s.last = m
+	return m
+}
+
+// latest 最近一次采集的主机指标, 还没有采集过时立即采集
+func (s *hostSampler) latest() *grpc.HostMetrics {
+	s.mu.Lock()
+	last := s.last
+	s.mu.Unlock()
+	if last == nil {
+		return s.sample()
+	}
+	return last
+}
+
+// hostMetricsMap 以 GetMetrics 的格式返回主机指标
+func hostMetricsMap(m *grpc.HostMetrics) map[string]interface{} {
+	return map[string]interface{}{
+		"cpu_percent":            m.GetCpuPercent(),
+		"cpu_count":              m.GetCpuCount(),
+		"load1":                  m.GetLoad1(),
+		"load5":                  m.GetLoad5(),
+		"load15":                 m.GetLoad15(),
+		"memory_total_bytes":     m.GetMemoryTotalBytes(),
+		"memory_available_bytes": m.GetMemoryAvailableBytes(),
+		"disk_total_bytes":       m.GetDiskTotalBytes(),
+		"disk_free_bytes":        m.GetDiskFreeBytes(),
+		"host_uptime_seconds":    m.GetHostUptimeSeconds(),
+	}
+}
@@ -0,0 +1,146 @@
+package worker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go-job/api/grpc"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// readHost 从 /proc 读取 CPU、内存、负载和运行时间, 从 statfs 读取磁盘空间
+func (s *hostSampler) readHost(m *grpc.HostMetrics) error {
+	var errs []error
+
+	if cpu, err := readCPUTimes(); err != nil {
+		errs = append(errs, err)
+	} else {
+		if cpu.total > s.cpu.total {
+			// iowait 在部分内核上会回退, 空闲时间按不减少处理
+			total := cpu.total - s.cpu.total
+			var idle uint64
+			if cpu.idle > s.cpu.idle {
+				idle = min(cpu.idle-s.cpu.idle, total)
+			}
+			m.CpuPercent = float64(total-idle) / float64(total) * 100
+		}
+		s.cpu = cpu
+	}
+
+	if total, available, err := readMeminfo(); err != nil {
+		errs = append(errs, err)
+	} else {
+		m.MemoryTotalBytes, m.MemoryAvailableBytes = total, available
+	}
+
+	if fields, err := readProcFields("/proc/loadavg", 3); err != nil {
+		errs = append(errs, err)
+	} else {
+		m.Load1, m.Load5, m.Load15 = fields[0], fields[1], fields[2]
+	}
+
+	if fields, err := readProcFields("/proc/uptime", 1); err != nil {
+		errs = append(errs, err)
+	} else {
+		m.HostUptimeSeconds = fields[0]
+	}
+
+	var fs unix.Statfs_t
+	if err := unix.Statfs(s.diskPath, &fs); err != nil {
+		errs = append(errs, fmt.Errorf("读取 %s 的磁盘空间失败: %w", s.diskPath, err))
+	} else {
+		m.DiskTotalBytes = int64(fs.Blocks) * int64(fs.Bsize)
+		m.DiskFreeBytes = int64(fs.Bavail) * int64(fs.Bsize)
+	}
+
+	return errors.Join(errs...)
+}
+
+// readCPUTimes 读取 /proc/stat 中所有 CPU 的累计时间, iowait 计为空闲
+func readCPUTimes() (cpuTimes, error) {
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return cpuTimes{}, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+		// user nice system idle iowait irq softirq steal, guest 已计入 user
+		var times cpuTimes
+		for i, field := range fields[1:min(len(fields), 9)] {
+			value, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return cpuTimes{}, fmt.Errorf("解析 /proc/stat 失败: %w", err)
+			}
+			times.total += value
+			if i == 3 || i == 4 {
+				times.idle += value
+			}
+		}
+		return times, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return cpuTimes{}, err
+	}
+	return cpuTimes{}, errors.New("/proc/stat 中没有 cpu 行")
+}
+
+// readMeminfo 读取 /proc/meminfo 中的总内存和可用内存(字节)
+func readMeminfo() (total, available int64, err error) {
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		var target *int64
+		switch fields[0] {
+		case "MemTotal:":
+			target = &total
+		case "MemAvailable:":
+			target = &available
+		default:
+			continue
+		}
+		kb, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("解析 /proc/meminfo 失败: %w", err)
+		}
+		*target = kb << 10
+	}
+	return total, available, scanner.Err()
+}
+
+// readProcFields 读取 /proc 文件开头的 n 个数值
+func readProcFields(path string, n int) ([]float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < n {
+		return nil, fmt.Errorf("%s 格式无效", path)
+	}
+	values := make([]float64, n)
+	for i := range values {
+		if values[i], err = strconv.ParseFloat(fields[i], 64); err != nil {
+			return nil, fmt.Errorf("解析 %s 失败: %w", path, err)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package worker
+
+import "go-job/api/grpc"
+
+// readHost 非 Linux 平台只上报 CPU 数和运行时间, 调度器不按资源阈值过滤这样的节点
+func (s *hostSampler) readHost(m *grpc.HostMetrics) error {
+	return nil
+}
+
+// readCPUTimes 非 Linux 平台不统计 CPU 时间
+func readCPUTimes() (cpuTimes, error) {
+	return cpuTimes{}, nil
+}
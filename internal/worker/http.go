@@ -0,0 +1,133 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/jobtype"
+	"go-job/pkg/tracing"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxAssertBytes 响应体断言只检查开头的这部分内容, 完整响应体仍写入输出
+const maxAssertBytes = 1 << 20
+
+// httpExecutor 执行 http 类型的任务
+type httpExecutor struct {
+	transport *http.Transport
+}
+
+// newHTTPExecutor 创建 HTTP 执行器
+func newHTTPExecutor() *httpExecutor {
+	return &httpExecutor{transport: http.DefaultTransport.(*http.Transport).Clone()}
+}
+
+// Execute 发送请求, 状态码和响应体写入标准输出, 状态码或响应体不符合期望时返回错误
+func (e *httpExecutor) Execute(ctx context.Context, task *grpc.Task, stdout, stderr io.Writer) error {
+	cfg, err := jobtype.ParseHTTP(task.GetConfig())
+	if err != nil {
+		return err
+	}
+	rendered, err := cfg.Render(jobtype.TemplateData{
+		TaskID: task.GetId(),
+		JobID:  task.GetJobId(),
+		Params: task.GetParams(),
+	})
+	if err != nil {
+		return err
+	}
+
+	client, err := e.client(cfg)
+	if err != nil {
+		return err
+	}
+	if transport := client.Transport.(*http.Transport); transport != e.transport {
+		defer transport.CloseIdleConnections()
+	}
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.Timeout)*time.Second)
+		defer cancel()
+	}
+
+	var body io.Reader
+	if rendered.Body != "" {
+		body = strings.NewReader(rendered.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, rendered.Method, rendered.URL, body)
+	if err != nil {
+		return fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("User-Agent", "go-job")
+	if traceparent := tracing.TraceparentFromContext(ctx); traceparent != "" {
+		req.Header.Set("traceparent", traceparent)
+	}
+	for name, value := range rendered.Headers {
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+
+	fmt.Fprintf(stderr, "> %s %s\n", req.Method, req.URL.Redacted())
+	startTime := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	fmt.Fprintf(stdout, "%s %s\n", resp.Proto, resp.Status)
+	captured := &limitedBuffer{limit: maxAssertBytes}
+	_, err = io.Copy(io.MultiWriter(stdout, captured), resp.Body)
+	fmt.Fprintf(stderr, "< %d, 耗时 %s\n", resp.StatusCode, time.Since(startTime).Round(time.Millisecond))
+	if err != nil {
+		return fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	if !cfg.StatusExpected(resp.StatusCode) {
+		return fmt.Errorf("响应状态码不符合期望: %d", resp.StatusCode)
+	}
+	return cfg.CheckBody(captured.data)
+}
+
+// client 按任务的 TLS 和重定向配置创建客户端, 没有 TLS 配置时复用共享连接池
+func (e *httpExecutor) client(cfg *jobtype.HTTPConfig) (*http.Client, error) {
+	client := &http.Client{Transport: e.transport}
+	if cfg.TLS != nil {
+		tlsConfig, err := cfg.TLS.Config()
+		if err != nil {
+			return nil, err
+		}
+		transport := e.transport.Clone()
+		transport.TLSClientConfig = tlsConfig
+		client.Transport = transport
+	}
+	if cfg.FollowRedirects != nil && !*cfg.FollowRedirects {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	return client, nil
+}
+
+// limitedBuffer 只保留开头 limit 字节的缓冲区
+type limitedBuffer struct {
+	data  []byte
+	limit int
+}
+
+// Write 实现 io.Writer, 超出部分直接丢弃
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - len(b.data); room > 0 {
+		if len(p) < room {
+			room = len(p)
+		}
+		b.data = append(b.data, p[:room]...)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,224 @@
+//go:build linux
+
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/jobtype"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// cpuPeriod cgroup cpu.max 的统计周期(微秒)
+const cpuPeriod = 100000
+
+// cpuTimeKillMargin 超出 cpu_time 后先收到 SIGXCPU, 再过该秒数被 SIGKILL 结束
+const cpuTimeKillMargin = 5
+
+// resourceLimiter 在命令启动后、执行实际命令前设置资源限制
+//
+// 命令先由 limitsWrapper 启动并阻塞在同步管道上, 工作节点对该进程设置 rlimit 并把它加入 cgroup 后
+// 再写入同步管道, 命令 exec 为实际命令时限制已经生效, 之后派生的进程继承同样的限制。
+type resourceLimiter struct {
+	limits *jobtype.Limits
+	stderr io.Writer
+	syncR  *os.File // 作为 fd 3 传给命令
+	syncW  *os.File
+	cgroup string // 任务的 cgroup 目录, 为空时不使用 cgroup
+}
+
+// newResourceLimiter 按任务的资源限制创建限制器, 任务没有资源限制时返回 nil
+//
+// memory_mb 和 cpus 需要工作节点配置可写的 cgroup v2 目录, 无法使用 cgroup 时在输出中提示并忽略这两项。
+func newResourceLimiter(task *grpc.Task, cgroupParent string, stderr io.Writer) (*resourceLimiter, error) {
+	limits, err := jobtype.ParseLimits(task.GetLimits())
+	if err != nil || limits == nil {
+		return nil, err
+	}
+
+	syncR, syncW, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("创建同步管道失败: %w", err)
+	}
+	l := &resourceLimiter{limits: limits, stderr: stderr, syncR: syncR, syncW: syncW}
+
+	if limits.Cgroup() {
+		l.cgroup, err = createCgroup(cgroupParent, task.GetId(), limits)
+		if err != nil {
+			fmt.Fprintf(stderr, "[go-job] %v, 不限制 memory_mb 和 cpus\n", err)
+		}
+	}
+	return l, nil
+}
+
+// attach 把同步管道的读端作为 fd 3 传给命令
+func (l *resourceLimiter) attach(cmd *exec.Cmd) {
+	cmd.ExtraFiles = []*os.File{l.syncR}
+}
+
+// release 对已启动的命令设置资源限制后让它继续执行, 设置失败时结束命令的进程组
+func (l *resourceLimiter) release(pid int) error {
+	l.syncR.Close()
+	defer l.syncW.Close()
+
+	if err := l.apply(pid); err != nil {
+		syscall.Kill(-pid, syscall.SIGKILL)
+		return err
+	}
+	// 写入失败说明命令已经退出, 由 Wait 返回结果
+	l.syncW.Write([]byte("\n"))
+	return nil
+}
+
+// apply 设置进程的 rlimit 并加入任务的 cgroup
+func (l *resourceLimiter) apply(pid int) error {
+	for _, limit := range l.rlimits() {
+		if err := unix.Prlimit(pid, limit.resource, &limit.value, nil); err != nil {
+			return fmt.Errorf("设置资源限制 %s 失败: %w", limit.name, err)
+		}
+	}
+
+	if l.cgroup != "" {
+		if err := os.WriteFile(filepath.Join(l.cgroup, "cgroup.procs"), []byte(strconv.Itoa(pid)), 0); err != nil {
+			fmt.Fprintf(l.stderr, "[go-job] 加入 cgroup 失败: %v, 不限制 memory_mb 和 cpus\n", err)
+			removeCgroup(l.cgroup, l.stderr)
+			l.cgroup = ""
+		}
+	}
+	return nil
+}
+
+// rlimit 要设置的一项 rlimit
+type rlimit struct {
+	name     string
+	resource int
+	value    unix.Rlimit
+}
+
+// rlimits 资源限制对应的 rlimit, 未设置的项不修改
+func (l *resourceLimiter) rlimits() []rlimit {
+	var limits []rlimit
+	add := func(name string, resource int, soft, hard uint64) {
+		limits = append(limits, rlimit{name: name, resource: resource, value: unix.Rlimit{Cur: soft, Max: hard}})
+	}
+	if n := uint64(l.limits.CPUTime); n > 0 {
+		add("cpu_time", unix.RLIMIT_CPU, n, n+cpuTimeKillMargin)
+	}
+	if n := uint64(l.limits.AddressSpaceMB); n > 0 {
+		add("address_space_mb", unix.RLIMIT_AS, n<<20, n<<20)
+	}
+	if n := uint64(l.limits.OpenFiles); n > 0 {
+		add("open_files", unix.RLIMIT_NOFILE, n, n)
+	}
+	if n := uint64(l.limits.Processes); n > 0 {
+		add("processes", unix.RLIMIT_NPROC, n, n)
+	}
+	return limits
+}
+
+// peakMemory 命令结束后读取 cgroup 统计的内存峰值, 被 OOM 结束时在输出中提示
+func (l *resourceLimiter) peakMemory() int64 {
+	if l.cgroup == "" {
+		return 0
+	}
+
+	if events, err := os.ReadFile(filepath.Join(l.cgroup, "memory.events")); err == nil {
+		scanner := bufio.NewScanner(bytes.NewReader(events))
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) == 2 && fields[0] == "oom_kill" && fields[1] != "0" {
+				fmt.Fprintf(l.stderr, "[go-job] 任务超出内存限制 %dMB, %s 个进程被 OOM 结束\n", l.limits.MemoryMB, fields[1])
+			}
+		}
+	}
+
+	// memory.peak 需要 5.19 以上的内核
+	raw, err := os.ReadFile(filepath.Join(l.cgroup, "memory.peak"))
+	if err != nil {
+		return 0
+	}
+	peak, _ := strconv.ParseInt(strings.TrimSpace(string(raw)), 10, 64)
+	return peak
+}
+
+// close 关闭同步管道并删除任务的 cgroup
+func (l *resourceLimiter) close() {
+	l.syncR.Close()
+	l.syncW.Close()
+	if l.cgroup != "" {
+		removeCgroup(l.cgroup, l.stderr)
+	}
+}
+
+// createCgroup 在 parent 下为任务创建 cgroup 并写入内存和 CPU 限制
+func createCgroup(parent, taskID string, limits *jobtype.Limits) (string, error) {
+	if parent == "" {
+		return "", fmt.Errorf("工作节点未配置 worker.cgroupParent")
+	}
+
+	// 启用子 cgroup 需要的控制器, 已启用时写入没有影响
+	var controllers []string
+	if limits.MemoryMB > 0 {
+		controllers = append(controllers, "+memory")
+	}
+	if limits.CPUs > 0 {
+		controllers = append(controllers, "+cpu")
+	}
+	if err := os.WriteFile(filepath.Join(parent, "cgroup.subtree_control"), []byte(strings.Join(controllers, " ")), 0); err != nil {
+		return "", fmt.Errorf("启用 cgroup 控制器失败: %w", err)
+	}
+
+	dir := filepath.Join(parent, "go-job-"+filepath.Base(taskID))
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return "", fmt.Errorf("创建 cgroup 失败: %w", err)
+	}
+
+	settings := map[string]string{}
+	if limits.MemoryMB > 0 {
+		settings["memory.max"] = strconv.FormatInt(int64(limits.MemoryMB)<<20, 10)
+	}
+	if limits.CPUs > 0 {
+		settings["cpu.max"] = fmt.Sprintf("%d %d", int64(limits.CPUs*cpuPeriod), cpuPeriod)
+	}
+	for file, value := range settings {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(value), 0); err != nil {
+			os.Remove(dir)
+			return "", fmt.Errorf("设置 cgroup %s 失败: %w", file, err)
+		}
+	}
+	return dir, nil
+}
+
+// removeCgroup 结束 cgroup 中剩余的进程并删除 cgroup
+func removeCgroup(dir string, stderr io.Writer) {
+	// cgroup.kill 需要 5.14 以上的内核, 不支持时剩余进程由进程组终止处理
+	os.WriteFile(filepath.Join(dir, "cgroup.kill"), []byte("1"), 0)
+
+	var err error
+	for i := 0; i < 20; i++ {
+		if err = os.Remove(dir); err == nil || os.IsNotExist(err) {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	fmt.Fprintf(stderr, "[go-job] 删除 cgroup %s 失败: %v\n", dir, err)
+}
+
+// peakRSS 命令主进程及其等待过的子进程的内存峰值(字节)
+func peakRSS(state *os.ProcessState) int64 {
+	if usage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		return int64(usage.Maxrss) * 1024 // Linux 上以 KB 为单位
+	}
+	return 0
+}
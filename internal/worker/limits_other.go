@@ -0,0 +1,33 @@
+//go:build !linux
+
+package worker
+
+import (
+	"fmt"
+	"go-job/api/grpc"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// resourceLimiter 非 Linux 平台不支持资源限制
+type resourceLimiter struct{}
+
+// newResourceLimiter 任务设置了资源限制时返回错误, 不在没有限制的情况下执行
+func newResourceLimiter(task *grpc.Task, cgroupParent string, stderr io.Writer) (*resourceLimiter, error) {
+	if strings.TrimSpace(task.GetLimits()) == "" {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("资源限制只支持 Linux 工作节点")
+}
+
+func (l *resourceLimiter) attach(cmd *exec.Cmd)  {}
+func (l *resourceLimiter) release(pid int) error { return nil }
+func (l *resourceLimiter) peakMemory() int64     { return 0 }
+func (l *resourceLimiter) close()                {}
+
+// peakRSS 非 Linux 平台不统计内存峰值
+func peakRSS(state *os.ProcessState) int64 {
+	return 0
+}
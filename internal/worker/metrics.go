@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"go-job/api/grpc"
+	"go-job/pkg/metrics"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// workerMetrics 工作节点指标, 使用独立的注册表, 同一进程中的多个工作节点互不影响
+type workerMetrics struct {
+	registry          *prometheus.Registry
+	tasks             *prometheus.CounterVec
+	taskDuration      *prometheus.HistogramVec
+	heartbeatFailures prometheus.Counter
+	lastHeartbeat     prometheus.Gauge
+	fetchFailures     prometheus.Counter
+}
+
+// newWorkerMetrics 创建工作节点指标
+func newWorkerMetrics(w *Worker) *workerMetrics {
+	m := &workerMetrics{
+		registry: prometheus.NewRegistry(),
+		tasks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "worker",
+			Name:      "tasks_total",
+			Help:      "按状态统计的已完成任务数",
+		}, []string{"status"}),
+		taskDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "worker",
+			Name:      "task_duration_seconds",
+			Help:      "任务执行耗时",
+			Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 1800, 3600},
+		}, []string{"job_id", "status"}),
+		heartbeatFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "worker",
+			Name:      "heartbeat_failures_total",
+			Help:      "心跳发送失败次数",
+		}),
+		lastHeartbeat: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "worker",
+			Name:      "last_heartbeat_timestamp_seconds",
+			Help:      "最近一次心跳成功的时间",
+		}),
+		fetchFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "worker",
+			Name:      "fetch_failures_total",
+			Help:      "获取任务失败次数",
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.tasks,
+		m.taskDuration,
+		m.heartbeatFailures,
+		m.lastHeartbeat,
+		m.fetchFailures,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "worker",
+			Name:      "running_tasks",
+			Help:      "正在执行的任务数",
+		}, func() float64 {
+			w.mu.RLock()
+			defer w.mu.RUnlock()
+			return float64(w.currentLoad)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "worker",
+			Name:      "capacity",
+			Help:      "工作节点容量",
+		}, func() float64 {
+			w.mu.RLock()
+			defer w.mu.RUnlock()
+			return float64(w.capacity)
+		}),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// observeTask 记录任务结果和耗时
+func (m *workerMetrics) observeTask(task *grpc.Task, status grpc.ExecutionStatus, seconds float64) {
+	label := strings.ToLower(status.String())
+	m.tasks.WithLabelValues(label).Inc()
+	m.taskDuration.WithLabelValues(task.GetJobId(), label).Observe(seconds)
+}
+
+// MetricsHandler 返回工作节点的 /metrics 处理器
+func (w *Worker) MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(w.metrics.registry, promhttp.HandlerOpts{})
+}
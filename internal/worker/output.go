@@ -0,0 +1,172 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/pkg/logger"
+	"go-job/pkg/output"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// 实时输出的上报粒度
+const (
+	outputChunkSize     = 32 * 1024   // 单个片段的最大字节数, 缓冲达到该大小时立即上报
+	outputFlushInterval = time.Second // 未达到片段大小时的上报间隔
+)
+
+// logBudget 一次执行可上报到日志存储的剩余字节数, 标准输出和标准错误共用
+type logBudget struct {
+	remaining atomic.Int64
+	limited   bool
+	exceeded  atomic.Bool
+}
+
+func newLogBudget(max int64) *logBudget {
+	b := &logBudget{limited: max > 0}
+	b.remaining.Store(max)
+	return b
+}
+
+// take 占用 n 字节, 返回允许上报的字节数和是否刚刚超出上限
+func (b *logBudget) take(n int) (int, bool) {
+	if !b.limited {
+		return n, false
+	}
+	left := b.remaining.Add(-int64(n))
+	if left >= 0 {
+		return n, false
+	}
+	allowed := n + int(left)
+	if allowed < 0 {
+		allowed = 0
+	}
+	return allowed, b.exceeded.CompareAndSwap(false, true)
+}
+
+// outputStreamer 将命令的一个输出流按片段实时上报给调度器
+type outputStreamer struct {
+	w      *Worker
+	taskID string
+	stream grpc.OutputStream
+	budget *logBudget
+
+	mu  sync.Mutex
+	buf []byte
+	seq int64
+}
+
+func newOutputStreamer(w *Worker, taskID string, stream grpc.OutputStream, budget *logBudget) *outputStreamer {
+	return &outputStreamer{w: w, taskID: taskID, stream: stream, budget: budget}
+}
+
+// Write 实现 io.Writer, 上报失败不影响命令执行, 完整输出仍随执行结果上报
+func (o *outputStreamer) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.buf = append(o.buf, p...)
+	if len(o.buf) >= outputChunkSize {
+		o.flushLocked(false)
+	}
+	return len(p), nil
+}
+
+// Flush 上报缓冲的输出, final 为 true 时连同不完整的 UTF-8 字符一起上报
+func (o *outputStreamer) Flush(final bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.flushLocked(final)
+}
+
+func (o *outputStreamer) flushLocked(final bool) {
+	n := len(o.buf)
+	if !final {
+		n = output.CompletePrefix(o.buf)
+	}
+
+	for sent := 0; sent < n; {
+		end := sent + outputChunkSize
+		if end > n {
+			end = n
+		} else if !final {
+			end = sent + output.CompletePrefix(o.buf[sent:end])
+			if end == sent {
+				end = n
+			}
+		}
+
+		// 超出日志上限后只上报一次提示, 之后的输出只保留在执行记录的开头和结尾中
+		data := o.buf[sent:end]
+		allowed, exceeded := o.budget.take(len(data))
+		data = data[:output.CompletePrefix(data[:allowed])]
+		if exceeded {
+			data = append(append([]byte(nil), data...),
+				fmt.Sprintf("\n... [日志超过 %d 字节, 后续输出不再保存] ...\n", o.w.config.Scheduler.Output.MaxLogBytes)...)
+		}
+		if len(data) > 0 {
+			o.seq++
+			o.w.sendOutput(&grpc.TaskOutput{
+				TaskId:   o.taskID,
+				WorkerId: o.w.id,
+				Stream:   o.stream,
+				Seq:      o.seq,
+				Data:     append([]byte(nil), data...),
+				Time:     timestamppb.Now(),
+			})
+		}
+		sent = end
+	}
+
+	o.buf = o.buf[:copy(o.buf, o.buf[n:])]
+}
+
+// sendOutput 上报输出片段, 推送连接可用时通过它发送
+func (w *Worker) sendOutput(output *grpc.TaskOutput) {
+	if w.streaming.Load() {
+		if err := w.send(&grpc.WorkerMessage{Payload: &grpc.WorkerMessage_Output{Output: output}}); err == nil {
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := w.client.ReportTaskOutput(ctx, output); err != nil {
+		logger.WithError(err).Warnf("上报任务输出失败: %s", output.GetTaskId())
+	}
+}
+
+// streamOutput 定时上报输出直到返回的函数被调用, 调用时上报剩余的输出
+func streamOutput(streamers ...*outputStreamer) (stop func()) {
+	done := make(chan struct{})
+	finished := make(chan struct{})
+
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(outputFlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				for _, o := range streamers {
+					o.Flush(false)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+		for _, o := range streamers {
+			o.Flush(true)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package worker
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"time"
+)
+
+// setupProcessGroup 不支持进程组的平台上 ctx 结束时直接结束命令, 宽限期后不再等待输出管道
+func setupProcessGroup(ctx context.Context, cmd *exec.Cmd, grace time.Duration, stderr io.Writer) func() {
+	cmd.WaitDelay = grace
+	return func() {}
+}
@@ -0,0 +1,56 @@
+//go:build unix
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// setupProcessGroup 让命令在独立的进程组中运行, ctx 结束时终止整个进程组
+//
+// ctx 结束时先向进程组发送 SIGTERM, 进程在宽限期内未退出时向进程组发送 SIGKILL,
+// 避免 shell 派生的子进程在超时或取消后继续运行。返回的函数在命令结束后调用,
+// 已开始终止时等待进程组中剩余的进程退出, 宽限期结束后强制结束。
+func setupProcessGroup(ctx context.Context, cmd *exec.Cmd, grace time.Duration, stderr io.Writer) func() {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	var mu sync.Mutex
+	var deadline time.Time // 发送 SIGTERM 后强制结束的时间, 为零表示未开始终止
+
+	cmd.Cancel = func() error {
+		pgid := cmd.Process.Pid
+		mu.Lock()
+		deadline = time.Now().Add(grace)
+		mu.Unlock()
+		fmt.Fprintf(stderr, "\n[go-job] %v, 向进程组 %d 发送 SIGTERM\n", context.Cause(ctx), pgid)
+		return syscall.Kill(-pgid, syscall.SIGTERM)
+	}
+	// 宽限期后进程仍未退出时 Wait 会结束主进程并不再等待输出管道, 进程组由返回的函数结束
+	cmd.WaitDelay = grace
+
+	return func() {
+		mu.Lock()
+		killAt := deadline
+		mu.Unlock()
+		if killAt.IsZero() || cmd.Process == nil {
+			return
+		}
+
+		pgid := cmd.Process.Pid
+		for time.Now().Before(killAt) {
+			if syscall.Kill(-pgid, 0) != nil {
+				return // 进程组中的进程都已退出
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		if syscall.Kill(-pgid, syscall.SIGKILL) == nil {
+			fmt.Fprintf(stderr, "[go-job] 进程组 %d 在 %s 内未退出, 已发送 SIGKILL\n", pgid, grace)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package worker
+
+import (
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/jobtype"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// defaultPath 不继承工作节点的 PATH 时命令使用的 PATH
+const defaultPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+// identity 任务的运行身份
+type identity struct {
+	uid      uint32
+	gid      uint32
+	groups   []uint32 // 附加组
+	username string
+	home     string
+	group    string // 沙箱设置指定的组名, 未指定时为空
+	member   bool   // 运行组是否为用户的主组或附加组
+}
+
+// sandbox 任务的运行环境, 由调度器合并任务和部门的沙箱设置后下发
+type sandbox struct {
+	settings *jobtype.Sandbox
+	identity *identity // 为空时以工作节点的用户运行
+	workDir  string    // 本次执行新建的临时工作目录
+}
+
+// newSandbox 解析任务的沙箱设置, 解析运行身份并创建临时工作目录
+//
+// 运行用户必须在工作节点的 worker.allowedUsers 中, 运行组必须是该用户所属的组或在 worker.allowedGroups 中,
+// 避免任务通过沙箱设置获得工作节点的 root 权限或其他组的文件权限。
+func (w *Worker) newSandbox(task *grpc.Task) (*sandbox, error) {
+	settings, err := jobtype.ParseSandbox(task.GetSandbox())
+	if err != nil {
+		return nil, err
+	}
+	sb := &sandbox{settings: settings}
+	if settings == nil {
+		return sb, nil
+	}
+
+	if settings.User != "" {
+		sb.identity, err = lookupIdentity(settings.User, settings.Group)
+		if err != nil {
+			return nil, err
+		}
+		if !w.userAllowed(sb.identity) {
+			return nil, fmt.Errorf("工作节点不允许以用户 %s 运行任务, 请在 worker.allowedUsers 中配置", settings.User)
+		}
+		if !w.groupAllowed(sb.identity) {
+			return nil, fmt.Errorf("用户 %s 不属于组 %s, 工作节点不允许以该组运行任务, 请在 worker.allowedGroups 中配置", settings.User, settings.Group)
+		}
+	}
+
+	if settings.UseTempWorkDir() && task.GetWorkDir() == "" {
+		dir, err := os.MkdirTemp("", "go-job-task-*")
+		if err != nil {
+			return nil, fmt.Errorf("创建临时工作目录失败: %w", err)
+		}
+		sb.workDir = dir
+		if err := sb.chown(dir); err != nil {
+			sb.cleanup()
+			return nil, err
+		}
+	}
+	return sb, nil
+}
+
+// userAllowed 运行身份是否在工作节点允许的用户中
+func (w *Worker) userAllowed(id *identity) bool {
+	uid := strconv.FormatUint(uint64(id.uid), 10)
+	return slices.ContainsFunc(w.config.Worker.AllowedUsers, func(allowed string) bool {
+		return allowed == "*" || allowed == id.username || allowed == uid
+	})
+}
+
+// groupAllowed 运行组是否为用户所属的组, 或在工作节点允许的组中
+func (w *Worker) groupAllowed(id *identity) bool {
+	if id.member {
+		return true
+	}
+	gid := strconv.FormatUint(uint64(id.gid), 10)
+	return slices.ContainsFunc(w.config.Worker.AllowedGroups, func(allowed string) bool {
+		return allowed == "*" || allowed == id.group || allowed == gid
+	})
+}
+
+// chown 把工作节点创建的文件交给运行用户, 未切换用户时不修改
+func (sb *sandbox) chown(path string) error {
+	if sb.identity == nil {
+		return nil
+	}
+	if err := os.Chown(path, int(sb.identity.uid), int(sb.identity.gid)); err != nil {
+		return fmt.Errorf("修改 %s 的所有者失败: %w", path, err)
+	}
+	return nil
+}
+
+// environ 按环境变量策略过滤工作节点的环境变量, deny 为工作节点配置的禁止继承的变量
+//
+// 切换用户时 HOME、USER 和 LOGNAME 改为运行用户的值; 没有继承 PATH 时使用 defaultPath。
+func (sb *sandbox) environ(environ []string, deny []string) []string {
+	var policy *jobtype.EnvPolicy
+	if sb.settings != nil {
+		policy = sb.settings.Env
+	}
+	env := policy.Filter(environ, deny)
+
+	if sb.identity != nil {
+		env = append(env,
+			"HOME="+sb.identity.home,
+			"USER="+sb.identity.username,
+			"LOGNAME="+sb.identity.username,
+		)
+	}
+	if !slices.ContainsFunc(env, func(entry string) bool { return strings.HasPrefix(entry, "PATH=") }) {
+		env = append(env, "PATH="+defaultPath)
+	}
+	return env
+}
+
+// cleanup 删除临时工作目录
+func (sb *sandbox) cleanup() {
+	if sb.workDir != "" {
+		os.RemoveAll(sb.workDir)
+	}
+}
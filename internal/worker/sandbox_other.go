@@ -0,0 +1,15 @@
+//go:build !unix
+
+package worker
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// lookupIdentity 不支持切换用户的平台上指定运行用户时返回错误
+func lookupIdentity(name, group string) (*identity, error) {
+	return nil, fmt.Errorf("工作节点所在平台不支持指定运行用户")
+}
+
+func (id *identity) apply(cmd *exec.Cmd) {}
@@ -0,0 +1,73 @@
+//go:build unix
+
+package worker
+
+import (
+	"fmt"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// lookupIdentity 按用户名或 uid 查找运行身份, group 为空时使用用户的主组
+func lookupIdentity(name, group string) (*identity, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		if _, numErr := strconv.ParseUint(name, 10, 32); numErr != nil {
+			return nil, fmt.Errorf("运行用户不存在: %s", name)
+		}
+		if u, err = user.LookupId(name); err != nil {
+			return nil, fmt.Errorf("运行用户不存在: %s", name)
+		}
+	}
+
+	id := &identity{username: u.Username, home: u.HomeDir}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("无效的 uid: %s", u.Uid)
+	}
+	id.uid = uint32(uid)
+
+	gid := u.Gid
+	if group != "" {
+		g, err := user.LookupGroup(group)
+		if err != nil {
+			if g, err = user.LookupGroupId(group); err != nil {
+				return nil, fmt.Errorf("运行用户组不存在: %s", group)
+			}
+		}
+		gid = g.Gid
+		id.group = g.Name
+	}
+	primary, err := strconv.ParseUint(gid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("无效的 gid: %s", gid)
+	}
+	id.gid = uint32(primary)
+	id.member = gid == u.Gid
+
+	// 附加组查询失败时只保留主组, 此时指定的组只有在用户的主组时才算作所属的组
+	groupIDs, _ := u.GroupIds()
+	for _, groupID := range groupIDs {
+		if groupID == gid {
+			id.member = true
+		}
+		if n, err := strconv.ParseUint(groupID, 10, 32); err == nil && uint32(n) != id.gid {
+			id.groups = append(id.groups, uint32(n))
+		}
+	}
+	return id, nil
+}
+
+// apply 让命令以运行身份启动, 需要在 setupProcessGroup 之后调用
+func (id *identity) apply(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Credential = &syscall.Credential{
+		Uid:    id.uid,
+		Gid:    id.gid,
+		Groups: id.groups,
+	}
+}
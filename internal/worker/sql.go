@@ -0,0 +1,192 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-job/api/grpc"
+	"go-job/internal/jobtype"
+	"io"
+	"strings"
+	"time"
+
+	_ "github.com/glebarez/go-sqlite"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+)
+
+// sqlDrivers 连接驱动到 database/sql 驱动名称的映射
+var sqlDrivers = map[string]string{
+	jobtype.DriverMySQL:    "mysql",
+	jobtype.DriverPostgres: "postgres",
+	jobtype.DriverSQLite:   "sqlite",
+}
+
+// sqlSession 事务和单个连接共有的执行方法
+type sqlSession interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// sqlExecutor 执行 sql 类型的任务
+type sqlExecutor struct{}
+
+// Execute 在任务引用的数据库连接上依次执行语句, 查询结果和影响行数写入标准输出
+//
+// 不使用事务时所有语句也在同一个连接上执行, SET 等会话级语句对后续语句生效。
+func (e *sqlExecutor) Execute(ctx context.Context, task *grpc.Task, stdout, stderr io.Writer) error {
+	cfg, err := jobtype.ParseSQL(task.GetConfig())
+	if err != nil {
+		return err
+	}
+	args, err := cfg.RenderArgs(jobtype.TemplateData{
+		TaskID: task.GetId(),
+		JobID:  task.GetJobId(),
+		Params: task.GetParams(),
+	})
+	if err != nil {
+		return err
+	}
+
+	connection := task.GetConnection()
+	if connection == nil {
+		return fmt.Errorf("数据库连接不存在: %s", cfg.Connection)
+	}
+	driver, ok := sqlDrivers[connection.GetDriver()]
+	if !ok {
+		return jobtype.ValidateDriver(connection.GetDriver())
+	}
+
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.Timeout)*time.Second)
+		defer cancel()
+	}
+
+	db, err := sql.Open(driver, connection.GetDsn())
+	if err != nil {
+		return fmt.Errorf("打开数据库连接失败: %w", err)
+	}
+	defer db.Close()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("连接数据库失败: %w", err)
+	}
+	defer conn.Close()
+	fmt.Fprintf(stderr, "> 已连接 %s (%s)\n", connection.GetName(), connection.GetDriver())
+
+	var session sqlSession = conn
+	var tx *sql.Tx
+	if cfg.Transaction {
+		if tx, err = conn.BeginTx(ctx, nil); err != nil {
+			return fmt.Errorf("开启事务失败: %w", err)
+		}
+		defer tx.Rollback()
+		session = tx
+	}
+
+	for i, statement := range cfg.Statements() {
+		if len(cfg.Statements()) > 1 {
+			fmt.Fprintf(stdout, "-- [%d] %s\n", i+1, firstLine(statement))
+		}
+		if err := runStatement(ctx, session, statement, args, cfg.MaxRows, stdout); err != nil {
+			if tx != nil {
+				return fmt.Errorf("第 %d 条语句执行失败, 事务已回滚: %w", i+1, err)
+			}
+			return fmt.Errorf("第 %d 条语句执行失败: %w", i+1, err)
+		}
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("提交事务失败: %w", err)
+		}
+		fmt.Fprintln(stderr, "< 事务已提交")
+	}
+	return nil
+}
+
+// runStatement 执行一条语句, 查询输出列名和结果行, 其他语句输出影响行数
+func runStatement(ctx context.Context, session sqlSession, statement string, args []interface{}, maxRows int, out io.Writer) error {
+	if !jobtype.IsQuery(statement) {
+		result, err := session.ExecContext(ctx, statement, args...)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err == nil {
+			fmt.Fprintf(out, "影响行数: %d\n", affected)
+		} else {
+			fmt.Fprintln(out, "执行成功")
+		}
+		return nil
+	}
+
+	rows, err := session.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, strings.Join(columns, "\t"))
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	fields := make([]string, len(columns))
+
+	count := 0
+	for rows.Next() {
+		count++
+		if count > maxRows {
+			continue // 继续读取以统计总行数
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+		for i, value := range values {
+			fields[i] = formatValue(value)
+		}
+		fmt.Fprintln(out, strings.Join(fields, "\t"))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if count > maxRows {
+		fmt.Fprintf(out, "(共 %d 行, 只输出前 %d 行)\n", count, maxRows)
+	} else {
+		fmt.Fprintf(out, "(共 %d 行)\n", count)
+	}
+	return nil
+}
+
+// formatValue 格式化查询结果中的值, 制表符和换行转义后输出, 保证一行对应一条记录
+func formatValue(value interface{}) string {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		text = string(v)
+	case time.Time:
+		text = v.Format(time.RFC3339Nano)
+	default:
+		text = fmt.Sprint(v)
+	}
+	return strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r").Replace(text)
+}
+
+// firstLine 语句的第一行, 用于在输出中标识脚本中的语句
+func firstLine(statement string) string {
+	if index := strings.IndexByte(statement, '\n'); index >= 0 {
+		return strings.TrimSpace(statement[:index]) + " ..."
+	}
+	return statement
+}
@@ -0,0 +1,167 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"go-job/api/grpc"
+	"go-job/pkg/logger"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errNotStreaming 推送连接未建立
+var errNotStreaming = errors.New("推送连接未建立")
+
+// 推送连接断开后的重连间隔
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// streamLoop 维持与调度器的推送连接, 断开后按指数退避重连
+//
+// 调度器不支持推送连接时退回轮询, 不再重连。
+func (w *Worker) streamLoop(ctx context.Context) {
+	delay := minReconnectDelay
+	for {
+		connected, err := w.runStream(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		switch status.Code(err) {
+		case codes.Unimplemented:
+			logger.Info("调度器不支持推送连接, 通过轮询获取任务")
+			return
+		case codes.FailedPrecondition:
+			// 调度器重启后丢失了注册信息, 重新注册
+			logger.Warn("调度器未识别工作节点, 重新注册")
+			if err := w.register(); err != nil {
+				logger.WithError(err).Error("重新注册工作节点失败")
+			}
+		default:
+			logger.WithError(err).Warn("推送连接已断开")
+		}
+
+		if connected {
+			delay = minReconnectDelay
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// runStream 建立推送连接并处理调度器下发的消息, 直到连接断开
+//
+// connected 表示连接曾被调度器接受, 用于重置重连间隔。
+func (w *Worker) runStream(ctx context.Context) (connected bool, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := w.client.Connect(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	w.mu.RLock()
+	hello := &grpc.WorkerHello{WorkerId: w.id, Capacity: w.capacity}
+	w.mu.RUnlock()
+	if err := stream.Send(&grpc.WorkerMessage{Payload: &grpc.WorkerMessage_Hello{Hello: hello}}); err != nil {
+		return false, err
+	}
+
+	w.streamMu.Lock()
+	w.stream = stream
+	w.streamMu.Unlock()
+	w.streaming.Store(true)
+
+	defer func() {
+		w.streaming.Store(false)
+		w.streamMu.Lock()
+		w.stream = nil
+		w.streamMu.Unlock()
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return connected, err
+		}
+		if !connected {
+			connected = true
+			logger.Info("已建立推送连接")
+		}
+
+		switch payload := msg.GetPayload().(type) {
+		case *grpc.SchedulerMessage_Task:
+			w.acceptTask(payload.Task)
+		case *grpc.SchedulerMessage_Cancel:
+			w.cancelTask(payload.Cancel.GetTaskId(), payload.Cancel.GetReason())
+		}
+	}
+}
+
+// send 通过推送连接发送消息
+func (w *Worker) send(msg *grpc.WorkerMessage) error {
+	w.streamMu.Lock()
+	defer w.streamMu.Unlock()
+
+	if w.stream == nil {
+		return errNotStreaming
+	}
+	return w.stream.Send(msg)
+}
+
+// acceptTask 确认并执行推送的任务, 容量已满时拒绝, 由调度器稍后重新下发
+func (w *Worker) acceptTask(task *grpc.Task) {
+	ack := &grpc.TaskAck{TaskId: task.GetId(), Accepted: true}
+
+	w.tasksMu.RLock()
+	_, running := w.tasks[task.GetId()]
+	w.tasksMu.RUnlock()
+
+	switch {
+	case running:
+		// 确认丢失后调度器重新下发的任务, 只确认不重复执行
+	case w.reserve():
+		go w.runTask(task)
+	default:
+		ack.Accepted = false
+		ack.Reason = "容量已满"
+	}
+
+	if err := w.send(&grpc.WorkerMessage{Payload: &grpc.WorkerMessage_Ack{Ack: ack}}); err != nil {
+		logger.WithError(err).Warnf("确认任务失败: %s", task.GetId())
+	}
+}
+
+// cancelTask 取消正在执行的任务, 任务尚未开始时记录下来, 开始执行前直接取消
+func (w *Worker) cancelTask(taskID, reason string) {
+	w.tasksMu.Lock()
+	execution, ok := w.tasks[taskID]
+	if ok {
+		execution.Reason = reason
+	} else {
+		w.earlyCancels[taskID] = earlyCancel{reason: reason, at: time.Now()}
+	}
+	w.tasksMu.Unlock()
+
+	if !ok {
+		logger.Warnf("要取消的任务不在执行中, 开始执行前将直接取消: %s", taskID)
+		return
+	}
+
+	logger.Infof("取消任务: %s", taskID)
+	if execution.Cancel != nil {
+		execution.Cancel(errTaskCancelled)
+	}
+}
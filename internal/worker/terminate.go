@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// 任务被终止的原因, 与 models.TerminationCause 取值一致
+const (
+	terminationTimeout  = "timeout"
+	terminationCancel   = "cancel"
+	terminationShutdown = "shutdown"
+)
+
+// defaultKillGracePeriod 未配置时发送 SIGTERM 后等待进程组退出的时间
+const defaultKillGracePeriod = 10 * time.Second
+
+// 任务上下文结束的原因, 通过 context.Cause 区分超时、取消和工作节点停止
+var (
+	errTaskTimeout    = errors.New("任务执行超时")
+	errTaskCancelled  = errors.New("任务已取消")
+	errWorkerShutdown = errors.New("工作节点停止, 任务被终止")
+)
+
+// terminationOf 返回任务上下文结束的原因, 上下文未结束时返回空字符串
+func terminationOf(ctx context.Context) string {
+	switch cause := context.Cause(ctx); {
+	case cause == nil:
+		return ""
+	case errors.Is(cause, errTaskCancelled):
+		return terminationCancel
+	case errors.Is(cause, errWorkerShutdown):
+		return terminationShutdown
+	default:
+		return terminationTimeout
+	}
+}
+
+// killGracePeriod 超时或取消时发送 SIGTERM 后等待进程组退出的时间
+func (w *Worker) killGracePeriod() time.Duration {
+	if seconds := w.config.Worker.KillGracePeriod; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultKillGracePeriod
+}
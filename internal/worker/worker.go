@@ -6,11 +6,15 @@ import (
 	"go-job/api/grpc"
 	"go-job/pkg/config"
 	"go-job/pkg/logger"
+	"go-job/pkg/output"
+	"go-job/pkg/tracing"
+	"io"
 	"net"
 	"os"
 	"os/exec"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	grpcpkg "google.golang.org/grpc"
@@ -20,27 +24,44 @@ import (
 
 // Worker 工作节点
 type Worker struct {
-	config      *config.Config
-	id          string
-	name        string
-	ip          string
-	port        int32
-	capacity    int32
-	currentLoad int32
-	mu          sync.RWMutex
-	client      grpc.SchedulerServiceClient
-	conn        *grpcpkg.ClientConn
-	tasks       map[string]*TaskExecution
-	tasksMu     sync.RWMutex
-	quit        chan struct{}
+	config       *config.Config
+	id           string
+	name         string
+	ip           string
+	port         int32
+	capacity     int32
+	currentLoad  int32
+	mu           sync.RWMutex
+	client       grpc.SchedulerServiceClient
+	conn         *grpcpkg.ClientConn
+	tasks        map[string]*TaskExecution
+	tasksMu      sync.RWMutex
+	earlyCancels map[string]earlyCancel // 收到取消指令时尚未开始执行的任务, 由 tasksMu 保护
+	metrics      *workerMetrics
+	host         *hostSampler                        // 主机指标, 随心跳上报
+	executors    map[string]Executor                 // 任务类型到执行器
+	handlers     map[string]HandlerFunc              // 进程内处理函数
+	stream       grpc.SchedulerService_ConnectClient // 推送连接, 未连接时为 nil
+	streamMu     sync.Mutex                          // 保护 stream 及其发送
+	streaming    atomic.Bool                         // 是否通过推送连接接收任务
+	quit         chan struct{}
 }
 
 // TaskExecution 任务执行信息
 type TaskExecution struct {
 	Task      *grpc.Task
-	Cmd       *exec.Cmd
 	StartTime time.Time
-	Cancel    context.CancelFunc
+	Cancel    context.CancelCauseFunc // 以 errTaskCancelled 等原因结束任务上下文
+	Reason    string                  // 取消原因
+	Result    string                  // 处理函数返回的结构化结果(JSON)
+	PeakRSS   int64                   // 命令的内存峰值(字节)
+	CPUTime   time.Duration           // 命令消耗的 CPU 时间
+}
+
+// earlyCancel 先于任务到达的取消指令
+type earlyCancel struct {
+	reason string
+	at     time.Time
 }
 
 // NewWorker 创建工作节点
@@ -48,16 +69,28 @@ func NewWorker(cfg *config.Config) *Worker {
 	hostname, _ := os.Hostname()
 	ip := getLocalIP()
 
-	return &Worker{
-		config:      cfg,
-		name:        fmt.Sprintf("worker-%s", hostname),
-		ip:          ip,
-		port:        9091, // 工作节点端口
-		capacity:    10,   // 默认容量
-		currentLoad: 0,
-		tasks:       make(map[string]*TaskExecution),
-		quit:        make(chan struct{}),
+	port := int32(cfg.Metrics.WorkerPort)
+	if port == 0 {
+		port = 9091
 	}
+
+	w := &Worker{
+		config:       cfg,
+		name:         fmt.Sprintf("worker-%s", hostname),
+		ip:           ip,
+		port:         port, // 工作节点端口, 用于暴露指标
+		capacity:     10,   // 默认容量
+		currentLoad:  0,
+		tasks:        make(map[string]*TaskExecution),
+		earlyCancels: make(map[string]earlyCancel),
+		executors:    make(map[string]Executor),
+		handlers:     make(map[string]HandlerFunc),
+		host:         newHostSampler(cfg.Worker.DiskPath),
+		quit:         make(chan struct{}),
+	}
+	w.metrics = newWorkerMetrics(w)
+	w.registerBuiltinExecutors()
+	return w
 }
 
 // SetName 设置工作节点名称
@@ -67,6 +100,20 @@ func (w *Worker) SetName(name string) {
 	w.name = name
 }
 
+// SetPort 设置工作节点端口
+func (w *Worker) SetPort(port int32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.port = port
+}
+
+// Port 返回工作节点端口
+func (w *Worker) Port() int32 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.port
+}
+
 // Start 启动工作节点
 func (w *Worker) Start(ctx context.Context) error {
 	logger.Info("启动工作节点")
@@ -84,6 +131,9 @@ func (w *Worker) Start(ctx context.Context) error {
 	// 启动心跳
 	go w.heartbeat(ctx)
 
+	// 建立推送连接, 调度器不支持时由任务获取循环轮询
+	go w.streamLoop(ctx)
+
 	// 启动任务获取循环
 	go w.taskLoop(ctx)
 
@@ -99,15 +149,20 @@ func (w *Worker) Start(ctx context.Context) error {
 func (w *Worker) Stop() {
 	logger.Info("正在停止工作节点")
 
-	// 取消所有正在执行的任务
+	// 终止所有正在执行的任务, 等待它们退出并上报结果后再断开连接
 	w.tasksMu.Lock()
 	for _, task := range w.tasks {
 		if task.Cancel != nil {
-			task.Cancel()
+			task.Cancel(errWorkerShutdown)
 		}
 	}
 	w.tasksMu.Unlock()
 
+	deadline := time.Now().Add(w.killGracePeriod() + 5*time.Second)
+	for w.activeTasks() > 0 && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+	}
+
 	close(w.quit)
 
 	if w.conn != nil {
@@ -115,11 +170,19 @@ func (w *Worker) Stop() {
 	}
 }
 
+// activeTasks 正在执行的任务数
+func (w *Worker) activeTasks() int {
+	w.tasksMu.RLock()
+	defer w.tasksMu.RUnlock()
+	return len(w.tasks)
+}
+
 // connectToScheduler 连接调度器
 func (w *Worker) connectToScheduler() error {
 	conn, err := grpcpkg.Dial(
 		w.config.GetGRPCAddr(),
 		grpcpkg.WithTransportCredentials(insecure.NewCredentials()),
+		grpcpkg.WithUnaryInterceptor(tracing.UnaryClientInterceptor()),
 	)
 	if err != nil {
 		return err
@@ -139,6 +202,7 @@ func (w *Worker) register() error {
 		Ip:       w.ip,
 		Port:     w.port,
 		Capacity: w.capacity,
+		Handlers: w.handlerNames(),
 		Metadata: map[string]string{
 			"hostname": w.name,
 			"version":  "1.0.0",
@@ -187,15 +251,32 @@ func (w *Worker) sendHeartbeat() {
 		WorkerId:    w.id,
 		CurrentLoad: currentLoad,
 		Status:      status,
+		Host:        w.host.sample(),
 	}
 
-	_, err := w.client.Heartbeat(context.Background(), req)
+	// 推送连接可用时通过它发送, 否则单独调用
+	if w.streaming.Load() {
+		if err := w.send(&grpc.WorkerMessage{Payload: &grpc.WorkerMessage_Heartbeat{Heartbeat: req}}); err == nil {
+			w.metrics.lastHeartbeat.SetToCurrentTime()
+			return
+		}
+	}
+
+	resp, err := w.client.Heartbeat(context.Background(), req)
 	if err != nil {
 		logger.WithError(err).Error("发送心跳失败")
+		w.metrics.heartbeatFailures.Inc()
+		return
+	}
+	w.metrics.lastHeartbeat.SetToCurrentTime()
+
+	// 没有推送连接时取消指令随心跳响应下发
+	for _, cancel := range resp.GetCancels() {
+		w.cancelTask(cancel.GetTaskId(), cancel.GetReason())
 	}
 }
 
-// taskLoop 任务获取循环
+// taskLoop 任务获取循环, 推送连接建立后暂停轮询
 func (w *Worker) taskLoop(ctx context.Context) {
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
@@ -205,6 +286,9 @@ func (w *Worker) taskLoop(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			if w.streaming.Load() {
+				continue
+			}
 			w.getTasks()
 		}
 	}
@@ -228,6 +312,7 @@ func (w *Worker) getTasks() {
 	resp, err := w.client.GetTask(context.Background(), req)
 	if err != nil {
 		logger.WithError(err).Error("获取任务失败")
+		w.metrics.fetchFailures.Inc()
 		return
 	}
 
@@ -238,13 +323,30 @@ func (w *Worker) getTasks() {
 
 // executeTask 执行任务
 func (w *Worker) executeTask(task *grpc.Task) {
-	logger.Infof("开始执行任务: %s", task.GetId())
-
 	// 增加当前负载
 	w.mu.Lock()
 	w.currentLoad++
 	w.mu.Unlock()
 
+	w.runTask(task)
+}
+
+// reserve 在容量允许时占用一个执行位置, 推送的任务先占位再确认
+func (w *Worker) reserve() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.currentLoad >= w.capacity {
+		return false
+	}
+	w.currentLoad++
+	return true
+}
+
+// runTask 执行已占用执行位置的任务
+func (w *Worker) runTask(task *grpc.Task) {
+	logger.Infof("开始执行任务: %s", task.GetId())
+
 	defer func() {
 		// 减少当前负载
 		w.mu.Lock()
@@ -258,8 +360,24 @@ func (w *Worker) executeTask(task *grpc.Task) {
 	}()
 
 	startTime := time.Now()
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(task.GetTimeout())*time.Second)
-	defer cancel()
+
+	// 在调度器下发任务的追踪中继续记录执行过程
+	traceCtx, span := tracing.Start(context.Background(), "worker.execute_task",
+		tracing.WithKind(tracing.KindConsumer),
+		tracing.WithRemoteParent(task.GetTraceparent()),
+		tracing.WithAttributes(map[string]interface{}{
+			"job.id":       task.GetJobId(),
+			"execution.id": task.GetId(),
+			"worker.id":    w.id,
+		}),
+	)
+	defer span.End()
+
+	// 上下文结束的原因区分超时、取消和工作节点停止
+	ctx, cancel := context.WithCancelCause(traceCtx)
+	defer cancel(nil)
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, time.Duration(task.GetTimeout())*time.Second, errTaskTimeout)
+	defer cancelTimeout()
 
 	// 记录任务执行信息
 	execution := &TaskExecution{
@@ -270,43 +388,86 @@ func (w *Worker) executeTask(task *grpc.Task) {
 
 	w.tasksMu.Lock()
 	w.tasks[task.GetId()] = execution
+	early, cancelled := w.earlyCancels[task.GetId()]
+	if cancelled {
+		delete(w.earlyCancels, task.GetId())
+		execution.Reason = early.reason
+	}
 	w.tasksMu.Unlock()
+	if cancelled {
+		cancel(errTaskCancelled)
+	}
 
-	// 解析命令
-	parts := strings.Fields(task.GetCommand())
-	if len(parts) == 0 {
-		w.reportResult(task, grpc.ExecutionStatus_FAILED, "", "命令为空", 1, startTime, time.Now())
+	executor, err := w.executor(task.GetType())
+	if err != nil {
+		span.RecordError(err)
+		w.reportResult(traceCtx, task, &grpc.ReportTaskResultRequest{
+			Status:     grpc.ExecutionStatus_FAILED,
+			Error:      err.Error(),
+			ExitCode:   1,
+			StartedAt:  timestamppb.New(startTime),
+			FinishedAt: timestamppb.Now(),
+		})
 		return
 	}
 
-	// 创建命令
-	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
-	execution.Cmd = cmd
-
-	// 设置环境变量
-	cmd.Env = os.Environ()
-	for key, value := range task.GetParams() {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
+	// 执行任务, 标准输出和标准错误分别实时上报到日志存储, 合并后的输出只保留开头和结尾随结果上报
+	outputCfg := w.config.Scheduler.Output
+	combined := output.NewCapture(outputCfg.HeadBytes, outputCfg.TailBytes)
+	budget := newLogBudget(outputCfg.MaxLogBytes)
+	stdout := newOutputStreamer(w, task.GetId(), grpc.OutputStream_STDOUT, budget)
+	stderr := newOutputStreamer(w, task.GetId(), grpc.OutputStream_STDERR, budget)
+	stopOutput := streamOutput(stdout, stderr)
+	stdoutWriter := io.MultiWriter(combined, stdout)
+	stderrWriter := io.MultiWriter(combined, stderr)
+
+	// 任务参数引用了密钥时, 输出中的密钥值在上报前替换掉
+	redact := output.RedactPatterns(task.GetRedact())
+	var redactors []*output.Redactor
+	if len(redact) > 0 {
+		stdoutRedactor := output.NewRedactor(stdoutWriter, redact)
+		stderrRedactor := output.NewRedactor(stderrWriter, redact)
+		stdoutWriter, stderrWriter = stdoutRedactor, stderrRedactor
+		redactors = append(redactors, stdoutRedactor, stderrRedactor)
 	}
-
-	// 添加工作节点信息到环境变量
-	cmd.Env = append(cmd.Env, fmt.Sprintf("WORKER_ID=%s", w.id))
-	cmd.Env = append(cmd.Env, fmt.Sprintf("WORKER_NAME=%s", w.name))
-	cmd.Env = append(cmd.Env, fmt.Sprintf("TASK_ID=%s", task.GetId()))
-
-	// 执行命令
-	output, err := cmd.CombinedOutput()
+	if ctx.Err() == nil {
+		err = executor.Execute(ctx, task, stdoutWriter, stderrWriter)
+	} else {
+		err = context.Cause(ctx) // 开始执行前已被取消
+	}
+	for _, r := range redactors {
+		r.Flush()
+	}
+	stopOutput()
+	captured := combined.Bytes()
 	finishTime := time.Now()
 
 	var status grpc.ExecutionStatus
-	var errorMsg string
+	var errorMsg, termination string
 	var exitCode int32
 
+	w.tasksMu.RLock()
+	reason, result := execution.Reason, execution.Result
+	peakRSS, cpuTime := execution.PeakRSS, execution.CPUTime
+	w.tasksMu.RUnlock()
+
 	if err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
+		termination = terminationOf(ctx)
+		switch termination {
+		case terminationCancel:
+			status = grpc.ExecutionStatus_CANCELLED
+			errorMsg = errTaskCancelled.Error()
+			if reason != "" {
+				errorMsg += ": " + reason
+			}
+		case terminationTimeout:
 			status = grpc.ExecutionStatus_TIMEOUT
-			errorMsg = "任务执行超时"
-		} else {
+			errorMsg = errTaskTimeout.Error()
+		case terminationShutdown:
+			// 按失败上报, 任务配置了重试时由调度器重新分配
+			status = grpc.ExecutionStatus_FAILED
+			errorMsg = errWorkerShutdown.Error()
+		default:
 			status = grpc.ExecutionStatus_FAILED
 			errorMsg = err.Error()
 		}
@@ -320,25 +481,49 @@ func (w *Worker) executeTask(task *grpc.Task) {
 		exitCode = 0
 	}
 
-	w.reportResult(task, status, string(output), errorMsg, exitCode, startTime, finishTime)
+	errorMsg, result = output.Redact(errorMsg, redact), output.Redact(result, redact)
+
+	span.SetAttribute("process.exit_code", exitCode)
+	span.SetAttribute("execution.status", strings.ToLower(status.String()))
+	if termination != "" {
+		span.SetAttribute("execution.termination", termination)
+	}
+	if errorMsg != "" {
+		span.RecordError(fmt.Errorf("%s", errorMsg))
+	}
+
+	w.reportResult(traceCtx, task, &grpc.ReportTaskResultRequest{
+		Status:       status,
+		Output:       string(captured),
+		Result:       result,
+		Termination:  termination,
+		Error:        errorMsg,
+		ExitCode:     exitCode,
+		StartedAt:    timestamppb.New(startTime),
+		FinishedAt:   timestamppb.New(finishTime),
+		PeakRssBytes: peakRSS,
+		CpuSeconds:   cpuTime.Seconds(),
+	})
 }
 
-// reportResult 报告任务结果
-func (w *Worker) reportResult(task *grpc.Task, status grpc.ExecutionStatus, output, errorMsg string, exitCode int32, startTime, finishTime time.Time) {
-	logger.Infof("报告任务结果: %s, 状态: %v", task.GetId(), status)
+// reportResult 报告任务结果, 任务和工作节点 ID 由这里填写
+func (w *Worker) reportResult(ctx context.Context, task *grpc.Task, req *grpc.ReportTaskResultRequest) {
+	logger.Infof("报告任务结果: %s, 状态: %v", task.GetId(), req.GetStatus())
+	duration := req.GetFinishedAt().AsTime().Sub(req.GetStartedAt().AsTime())
+	w.metrics.observeTask(task, req.GetStatus(), duration.Seconds())
 
-	req := &grpc.ReportTaskResultRequest{
-		TaskId:     task.GetId(),
-		WorkerId:   w.id,
-		Status:     status,
-		Output:     output,
-		Error:      errorMsg,
-		ExitCode:   exitCode,
-		StartedAt:  timestamppb.New(startTime),
-		FinishedAt: timestamppb.New(finishTime),
+	req.TaskId = task.GetId()
+	req.WorkerId = w.id
+
+	// 推送连接可用时通过它上报, 调度器据此在任务的追踪中记录上报跨度
+	if w.streaming.Load() {
+		req.Traceparent = tracing.TraceparentFromContext(ctx)
+		if err := w.send(&grpc.WorkerMessage{Payload: &grpc.WorkerMessage_Result{Result: req}}); err == nil {
+			return
+		}
 	}
 
-	_, err := w.client.ReportTaskResult(context.Background(), req)
+	_, err := w.client.ReportTaskResult(ctx, req)
 	if err != nil {
 		logger.WithError(err).Errorf("报告任务结果失败: %s", task.GetId())
 	}
@@ -370,11 +555,18 @@ func (w *Worker) cleanupTimeoutTasks() {
 		if now.Sub(execution.StartTime) > timeout+time.Minute {
 			logger.Warnf("清理超时任务: %s", id)
 			if execution.Cancel != nil {
-				execution.Cancel()
+				execution.Cancel(errTaskTimeout)
 			}
 			delete(w.tasks, id)
 		}
 	}
+
+	// 对应的任务一直没有到达的取消指令
+	for id, early := range w.earlyCancels {
+		if now.Sub(early.at) > 10*time.Minute {
+			delete(w.earlyCancels, id)
+		}
+	}
 }
 
 // GetMetrics 获取工作节点性能指标
@@ -392,7 +584,8 @@ func (w *Worker) GetMetrics() map[string]interface{} {
 		"current_load": w.currentLoad,
 		"utilization":  float64(w.currentLoad) / float64(w.capacity) * 100,
 		"active_tasks": len(w.tasks),
-		"uptime":       time.Since(time.Now()).Seconds(), // 简化实现
+		"uptime":       w.host.uptime().Seconds(),
+		"host":         hostMetricsMap(w.host.latest()),
 	}
 }
 
@@ -12,13 +12,16 @@ import (
 	"go-job/internal/permission"
 	"go-job/internal/role"
 	"go-job/internal/scheduler"
+	"go-job/internal/secret"
 	"go-job/internal/user"
 	"go-job/pkg/auth"
 	"go-job/pkg/broadcaster"
 	"go-job/pkg/config"
 	"go-job/pkg/database"
 	"go-job/pkg/logger"
+	"go-job/pkg/metrics"
 	"go-job/pkg/redis"
+	"go-job/pkg/tracing"
 	"go-job/pkg/websocket"
 	"log"
 	"net"
@@ -48,6 +51,11 @@ func main() {
 
 	logrus.Info("启动 go-job 服务...")
 
+	// 初始化链路追踪
+	if err := tracing.Init(cfg, "go-job-scheduler"); err != nil {
+		logrus.Fatalf("初始化链路追踪失败: %v", err)
+	}
+
 	// 初始化数据库
 	if err := database.Init(cfg); err != nil {
 		logrus.Fatalf("初始化数据库失败: %v", err)
@@ -115,6 +123,12 @@ func main() {
 
 	// 等待所有协程结束
 	wg.Wait()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer shutdownCancel()
+	if err := tracing.Shutdown(shutdownCtx); err != nil {
+		logrus.Errorf("导出剩余追踪数据失败: %v", err)
+	}
 	logrus.Info("服务已停止")
 }
 
@@ -133,8 +147,14 @@ func initServices(cfg *config.Config, wsHub *websocket.Hub) (*httpapi.Services,
 	permissionService := permission.NewPermissionService(db)
 	jobService := job.NewService()
 
+	// 密钥存储, 主密钥配置无效时无法解密已保存的密钥
+	secretStore, err := secret.NewStore(db, cfg.Secrets)
+	if err != nil {
+		logrus.Fatalf("初始化密钥存储失败: %v", err)
+	}
+
 	// 初始化调度器服务
-	schedulerService := scheduler.NewService(cfg)
+	schedulerService := scheduler.NewService(cfg, wsHub, secretStore)
 
 	// 初始化AI相关服务
 	aiScheduler := mcp.NewAISchedulerService(db, cfg)
@@ -147,6 +167,8 @@ func initServices(cfg *config.Config, wsHub *websocket.Hub) (*httpapi.Services,
 		RoleService:       roleService,
 		PermissionService: permissionService,
 		JobService:        jobService,
+		Scheduler:         schedulerService,
+		Secrets:           secretStore,
 		AIScheduler:       aiScheduler,
 		MCPService:        mcpService,
 		WSHub:             wsHub,
@@ -202,10 +224,21 @@ func startHTTPServer(ctx context.Context, cfg *config.Config, services *httpapi.
 // startGRPCServer 启动gRPC服务器
 func startGRPCServer(ctx context.Context, cfg *config.Config, services *httpapi.Services, schedulerService *scheduler.Service) {
 	// 创建gRPC服务器
-	s := grpc.NewServer()
+	// 心跳、任务拉取和输出上报是高频调用, 推送连接是长连接, 都不单独创建追踪; 下发和上报结果在任务所属的追踪中记录跨度
+	untraced := []string{
+		grpcapi.SchedulerService_Heartbeat_FullMethodName,
+		grpcapi.SchedulerService_GetTask_FullMethodName,
+		grpcapi.SchedulerService_Connect_FullMethodName,
+		grpcapi.SchedulerService_ReportTaskOutput_FullMethodName,
+		grpcapi.SchedulerService_ReportTaskProgress_FullMethodName,
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(metrics.UnaryServerInterceptor(), tracing.UnaryServerInterceptor(untraced...)),
+		grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor(), tracing.StreamServerInterceptor(untraced...)),
+	)
 
 	// 注册服务
-	grpcapi.RegisterJobServiceServer(s, &grpcJobServer{jobService: services.JobService})
+	grpcapi.RegisterJobServiceServer(s, &grpcJobServer{jobService: services.JobService, schedulerService: schedulerService})
 	grpcapi.RegisterSchedulerServiceServer(s, &grpcSchedulerServer{schedulerService: schedulerService})
 	grpcapi.RegisterAuthServiceServer(s, &grpcAuthServer{authService: services.AuthService})
 	grpcapi.RegisterUserServiceServer(s, &grpcUserServer{userService: services.UserService})
@@ -273,7 +306,8 @@ type grpcSchedulerServer struct {
 // gRPC服务器实现结构体
 type grpcJobServer struct {
 	grpcapi.UnimplementedJobServiceServer
-	jobService *job.Service
+	jobService       *job.Service
+	schedulerService *scheduler.Service
 }
 
 // JobService gRPC 方法实现
@@ -301,6 +335,54 @@ func (s *grpcJobServer) TriggerJob(ctx context.Context, req *grpcapi.TriggerJobR
 	return s.jobService.TriggerJob(ctx, req)
 }
 
+func (s *grpcJobServer) ValidateCron(ctx context.Context, req *grpcapi.ValidateCronRequest) (*grpcapi.ValidateCronResponse, error) {
+	return s.jobService.ValidateCron(ctx, req)
+}
+
+func (s *grpcJobServer) ListJobRevisions(ctx context.Context, req *grpcapi.ListJobRevisionsRequest) (*grpcapi.ListJobRevisionsResponse, error) {
+	return s.jobService.ListJobRevisions(ctx, req)
+}
+
+func (s *grpcJobServer) DiffJobRevisions(ctx context.Context, req *grpcapi.DiffJobRevisionsRequest) (*grpcapi.DiffJobRevisionsResponse, error) {
+	return s.jobService.DiffJobRevisions(ctx, req)
+}
+
+func (s *grpcJobServer) CancelExecution(ctx context.Context, req *grpcapi.CancelExecutionRequest) (*grpcapi.CancelExecutionResponse, error) {
+	return s.schedulerService.CancelExecution(ctx, req)
+}
+
+func (s *grpcJobServer) RollbackJob(ctx context.Context, req *grpcapi.RollbackJobRequest) (*grpcapi.RollbackJobResponse, error) {
+	return s.jobService.RollbackJob(ctx, req)
+}
+
+func (s *grpcJobServer) CreateJobTemplate(ctx context.Context, req *grpcapi.CreateJobTemplateRequest) (*grpcapi.CreateJobTemplateResponse, error) {
+	return s.jobService.CreateJobTemplate(ctx, req)
+}
+
+func (s *grpcJobServer) GetJobTemplate(ctx context.Context, req *grpcapi.GetJobTemplateRequest) (*grpcapi.GetJobTemplateResponse, error) {
+	return s.jobService.GetJobTemplate(ctx, req)
+}
+
+func (s *grpcJobServer) ListJobTemplates(ctx context.Context, req *grpcapi.ListJobTemplatesRequest) (*grpcapi.ListJobTemplatesResponse, error) {
+	return s.jobService.ListJobTemplates(ctx, req)
+}
+
+func (s *grpcJobServer) UpdateJobTemplate(ctx context.Context, req *grpcapi.UpdateJobTemplateRequest) (*grpcapi.UpdateJobTemplateResponse, error) {
+	return s.jobService.UpdateJobTemplate(ctx, req)
+}
+
+func (s *grpcJobServer) DeleteJobTemplate(ctx context.Context, req *grpcapi.DeleteJobTemplateRequest) (*grpcapi.DeleteJobTemplateResponse, error) {
+	return s.jobService.DeleteJobTemplate(ctx, req)
+}
+
+func (s *grpcJobServer) InstantiateJobTemplate(ctx context.Context, req *grpcapi.InstantiateJobTemplateRequest) (*grpcapi.InstantiateJobTemplateResponse, error) {
+	return s.jobService.InstantiateJobTemplate(ctx, req)
+}
+
+func (s *grpcJobServer) BulkJobOperation(ctx context.Context, req *grpcapi.BulkJobOperationRequest) (*grpcapi.BulkJobOperationResponse, error) {
+	return s.jobService.BulkJobOperation(ctx, req)
+}
+
 // SchedulerService gRPC 方法实现
 func (s *grpcSchedulerServer) RegisterWorker(ctx context.Context, req *grpcapi.RegisterWorkerRequest) (*grpcapi.RegisterWorkerResponse, error) {
 	return s.schedulerService.RegisterWorker(ctx, req)
@@ -318,6 +400,18 @@ func (s *grpcSchedulerServer) ReportTaskResult(ctx context.Context, req *grpcapi
 	return s.schedulerService.ReportTaskResult(ctx, req)
 }
 
+func (s *grpcSchedulerServer) Connect(stream grpcapi.SchedulerService_ConnectServer) error {
+	return s.schedulerService.Connect(stream)
+}
+
+func (s *grpcSchedulerServer) ReportTaskOutput(ctx context.Context, req *grpcapi.TaskOutput) (*grpcapi.ReportTaskOutputResponse, error) {
+	return s.schedulerService.ReportTaskOutput(ctx, req)
+}
+
+func (s *grpcSchedulerServer) ReportTaskProgress(ctx context.Context, req *grpcapi.TaskProgress) (*grpcapi.ReportTaskProgressResponse, error) {
+	return s.schedulerService.ReportTaskProgress(ctx, req)
+}
+
 type grpcAuthServer struct {
 	grpcapi.UnimplementedAuthServiceServer
 	authService *authservice.AuthService
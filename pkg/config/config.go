@@ -16,6 +16,10 @@ type Config struct {
 	Logger    LoggerConfig    `mapstructure:"logger"`
 	JWT       JWTConfig       `mapstructure:"jwt"`
 	AI        AIConfig        `mapstructure:"ai"`
+	Metrics   MetricsConfig   `mapstructure:"metrics"`
+	Tracing   TracingConfig   `mapstructure:"tracing"`
+	Worker    WorkerConfig    `mapstructure:"worker"`
+	Secrets   SecretsConfig   `mapstructure:"secrets"`
 }
 
 // ServerConfig 服务器配置
@@ -68,11 +72,102 @@ type RedisConfig struct {
 
 // SchedulerConfig 调度器配置
 type SchedulerConfig struct {
-	Timezone          string   `mapstructure:"timezone"`
-	MaxWorkers        int      `mapstructure:"maxWorkers"`
-	RetryAttempts     int      `mapstructure:"retryAttempts"`
-	HeartbeatInterval int      `mapstructure:"heartbeatInterval"`
-	AI                AIConfig `mapstructure:"ai"`
+	Timezone          string          `mapstructure:"timezone"`
+	MaxWorkers        int             `mapstructure:"maxWorkers"`
+	RetryAttempts     int             `mapstructure:"retryAttempts"`
+	HeartbeatInterval int             `mapstructure:"heartbeatInterval"`
+	ChainMaxDepth     int             `mapstructure:"chainMaxDepth"` // 链式触发的最大深度, 防止任务循环触发
+	AI                AIConfig        `mapstructure:"ai"`
+	Events            EventsConfig    `mapstructure:"events"`
+	SLA               SLAConfig       `mapstructure:"sla"`
+	Retention         RetentionConfig `mapstructure:"retention"`
+	Output            OutputConfig    `mapstructure:"output"`
+	Placement         PlacementConfig `mapstructure:"placement"`
+}
+
+// EventsConfig 事件触发配置
+type EventsConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	ConsumerName string `mapstructure:"consumerName"` // 消费者组中的名称, 为空时使用 主机名-进程号
+	SyncInterval int    `mapstructure:"syncInterval"` // 触发器配置同步间隔(秒)
+}
+
+// SLAConfig SLA 监控配置
+type SLAConfig struct {
+	Enabled       bool `mapstructure:"enabled"`
+	CheckInterval int  `mapstructure:"checkInterval"` // 检查间隔(秒)
+}
+
+// RetentionConfig 执行记录保留配置, 作为没有配置全局保留策略时的默认值
+type RetentionConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	Interval           int    `mapstructure:"interval"`           // 清理间隔(秒)
+	MaxAgeDays         int    `mapstructure:"maxAgeDays"`         // 执行记录保留天数, 0 表示不按时间清理
+	MaxCount           int    `mapstructure:"maxCount"`           // 每个任务保留的最近执行数, 0 表示不限制
+	Archive            bool   `mapstructure:"archive"`            // 删除前是否归档
+	ArchiveDir         string `mapstructure:"archiveDir"`         // 归档文件目录
+	ScheduleMaxAgeDays int    `mapstructure:"scheduleMaxAgeDays"` // 调度记录保留天数
+	BatchSize          int    `mapstructure:"batchSize"`          // 每批归档和删除的记录数
+	RestoreHoldDays    int    `mapstructure:"restoreHoldDays"`    // 恢复的记录在多少天内不会被再次清理
+}
+
+// OutputConfig 任务输出配置
+//
+// 执行记录只保留输出的开头和结尾, 完整输出以片段形式保存在日志存储中。
+type OutputConfig struct {
+	HeadBytes   int    `mapstructure:"headBytes"`   // 执行记录中保留的输出开头字节数
+	TailBytes   int    `mapstructure:"tailBytes"`   // 执行记录中保留的输出结尾字节数
+	MaxLogBytes int64  `mapstructure:"maxLogBytes"` // 每次执行上报到日志存储的最大字节数, 0 表示不限制
+	Store       string `mapstructure:"store"`       // 日志存储: database 或 file
+	Dir         string `mapstructure:"dir"`         // file 存储的目录
+}
+
+// PlacementConfig 按工作节点随心跳上报的主机指标分配任务
+//
+// 超过任一阈值的工作节点不再分配新任务, 阈值为 0 表示不检查该项; 没有上报主机指标的工作节点不受限制。
+type PlacementConfig struct {
+	MaxCPUPercent    float64 `mapstructure:"maxCpuPercent"`    // CPU 使用率(%)
+	MaxMemoryPercent float64 `mapstructure:"maxMemoryPercent"` // 内存使用率(%), 按可用内存计算
+	MaxDiskPercent   float64 `mapstructure:"maxDiskPercent"`   // 工作节点临时目录所在文件系统的使用率(%)
+	MaxLoadPerCPU    float64 `mapstructure:"maxLoadPerCpu"`    // 1 分钟平均负载除以 CPU 数
+	HistoryInterval  int     `mapstructure:"historyInterval"`  // 保存主机指标历史的最小间隔(秒), 0 表示不保存
+	HistoryHours     int     `mapstructure:"historyHours"`     // 主机指标历史的保留时间(小时)
+}
+
+// MetricsConfig Prometheus 指标配置
+type MetricsConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	Path       string `mapstructure:"path"`
+	WorkerPort int    `mapstructure:"workerPort"` // 工作节点暴露指标的端口
+}
+
+// TracingConfig 链路追踪配置
+type TracingConfig struct {
+	Enabled       bool    `mapstructure:"enabled"`
+	Exporter      string  `mapstructure:"exporter"`      // file 或 otlp
+	File          string  `mapstructure:"file"`          // file 导出器写入的文件
+	Endpoint      string  `mapstructure:"endpoint"`      // otlp 导出器的收集器地址
+	SampleRatio   float64 `mapstructure:"sampleRatio"`   // 新建追踪的采样比例
+	FlushInterval int     `mapstructure:"flushInterval"` // 批量导出间隔(秒)
+}
+
+// WorkerConfig 工作节点配置
+type WorkerConfig struct {
+	KillGracePeriod int      `mapstructure:"killGracePeriod"` // 超时或取消时发送 SIGTERM 后等待进程组退出的时间(秒), 之后发送 SIGKILL
+	CgroupParent    string   `mapstructure:"cgroupParent"`    // 工作节点可写的 cgroup v2 目录, 为空时不执行 memory_mb 和 cpus 限制
+	AllowedUsers    []string `mapstructure:"allowedUsers"`    // 任务可以指定的运行用户(用户名或 uid), * 表示任意用户, 为空时不允许切换用户
+	AllowedGroups   []string `mapstructure:"allowedGroups"`   // 运行用户所属组之外, 任务可以指定的运行组(组名或 gid), * 表示任意组
+	EnvDeny         []string `mapstructure:"envDeny"`         // 任何任务都不继承的工作节点环境变量, 支持 * 结尾的前缀匹配
+	DiskPath        string   `mapstructure:"diskPath"`        // 随心跳上报磁盘空间的目录, 为空时使用系统临时目录
+}
+
+// SecretsConfig 密钥加密配置
+//
+// 每个密钥的值由独立的数据密钥加密, 数据密钥再由主密钥加密。轮换主密钥时添加新密钥并设为 activeKey,
+// 调用轮换接口用新主密钥重新加密所有数据密钥后才能删除旧密钥。
+type SecretsConfig struct {
+	ActiveKey string            `mapstructure:"activeKey"` // 加密数据密钥使用的主密钥 ID
+	Keys      map[string]string `mapstructure:"keys"`      // 主密钥 ID 到 base64 编码的 32 字节密钥
 }
 
 // LoggerConfig 日志配置
@@ -112,6 +207,7 @@ func Load(configPath string) (*Config, error) {
 
 	// 显式绑定环境变量
 	viper.BindEnv("scheduler.ai.dashscopeApiKey", "DASHSCOPE_API_KEY")
+	viper.BindEnv("secrets.keys.default", "GO_JOB_MASTER_KEY")
 
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("读取配置文件失败: %w", err)
@@ -163,6 +259,42 @@ func setDefaults() {
 	viper.SetDefault("scheduler.maxWorkers", 100)
 	viper.SetDefault("scheduler.retryAttempts", 3)
 	viper.SetDefault("scheduler.heartbeatInterval", 30)
+	viper.SetDefault("scheduler.chainMaxDepth", 10)
+
+	// 事件触发默认值
+	viper.SetDefault("scheduler.events.enabled", true)
+	viper.SetDefault("scheduler.events.consumerName", "")
+	viper.SetDefault("scheduler.events.syncInterval", 30)
+
+	// SLA 监控默认值
+	viper.SetDefault("scheduler.sla.enabled", true)
+	viper.SetDefault("scheduler.sla.checkInterval", 60)
+
+	// 保留策略默认值
+	viper.SetDefault("scheduler.retention.enabled", true)
+	viper.SetDefault("scheduler.retention.interval", 3600)
+	viper.SetDefault("scheduler.retention.maxAgeDays", 7)
+	viper.SetDefault("scheduler.retention.maxCount", 0)
+	viper.SetDefault("scheduler.retention.archive", true)
+	viper.SetDefault("scheduler.retention.archiveDir", "data/archives")
+	viper.SetDefault("scheduler.retention.scheduleMaxAgeDays", 30)
+	viper.SetDefault("scheduler.retention.batchSize", 500)
+	viper.SetDefault("scheduler.retention.restoreHoldDays", 7)
+
+	// 任务输出默认值
+	viper.SetDefault("scheduler.output.headBytes", 64*1024)
+	viper.SetDefault("scheduler.output.tailBytes", 256*1024)
+	viper.SetDefault("scheduler.output.maxLogBytes", 1<<30)
+	viper.SetDefault("scheduler.output.store", "database")
+	viper.SetDefault("scheduler.output.dir", "data/logs")
+
+	// 资源感知分配默认值
+	viper.SetDefault("scheduler.placement.maxCpuPercent", 95)
+	viper.SetDefault("scheduler.placement.maxMemoryPercent", 95)
+	viper.SetDefault("scheduler.placement.maxDiskPercent", 95)
+	viper.SetDefault("scheduler.placement.maxLoadPerCpu", 0)
+	viper.SetDefault("scheduler.placement.historyInterval", 60)
+	viper.SetDefault("scheduler.placement.historyHours", 24)
 
 	// AI 调度器默认值
 	viper.SetDefault("scheduler.ai.enabled", true)
@@ -179,6 +311,25 @@ func setDefaults() {
 	// JWT 默认值
 	viper.SetDefault("jwt.secret", "your-secret-key")
 	viper.SetDefault("jwt.expire", time.Hour*24)
+
+	// 指标默认值
+	viper.SetDefault("metrics.enabled", true)
+	viper.SetDefault("metrics.path", "/metrics")
+	viper.SetDefault("metrics.workerPort", 9091)
+
+	// 链路追踪默认值
+	viper.SetDefault("tracing.enabled", false)
+	viper.SetDefault("tracing.exporter", "file")
+	viper.SetDefault("tracing.file", "logs/traces.jsonl")
+	viper.SetDefault("tracing.endpoint", "http://localhost:4318")
+	viper.SetDefault("tracing.sampleRatio", 1.0)
+	viper.SetDefault("tracing.flushInterval", 5)
+
+	// 工作节点默认值
+	viper.SetDefault("worker.killGracePeriod", 10)
+
+	// 密钥加密默认值
+	viper.SetDefault("secrets.activeKey", "default")
 }
 
 // GetHTTPAddr 获取 HTTP 地址
@@ -0,0 +1,244 @@
+package cronexpr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Parser 调度器与任务校验共用的 cron 解析器
+//
+// 支持 5 字段(分 时 日 月 周)和 6 字段(秒 分 时 日 月 周)两种格式,
+// 支持 @daily、@every 5m 等描述符, 以及 CRON_TZ=Asia/Shanghai 前缀指定时区。
+var Parser = cron.NewParser(
+	cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// FieldError 字段级的表达式错误
+type FieldError struct {
+	Position int    // 字段位置, 从 1 开始
+	Field    string // 字段名称
+	Value    string // 字段内容
+	Reason   string // 错误原因
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("第 %d 个字段(%s) %q: %s", e.Position, e.Field, e.Value, e.Reason)
+}
+
+// field 字段定义
+type field struct {
+	name     string
+	nameEN   string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	secondField = field{name: "秒", nameEN: "second", min: 0, max: 59}
+	minuteField = field{name: "分钟", nameEN: "minute", min: 0, max: 59}
+	hourField   = field{name: "小时", nameEN: "hour", min: 0, max: 23}
+	domField    = field{name: "日", nameEN: "day of month", min: 1, max: 31}
+	monthField  = field{name: "月", nameEN: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = field{name: "周", nameEN: "day of week", min: 0, max: 6, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// descriptors 支持的描述符
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// Expression 解析后的表达式
+type Expression struct {
+	Raw      string
+	Location *time.Location // CRON_TZ 指定的时区, 未指定时为空
+	Every    time.Duration  // @every 描述符的间隔
+	Fields   []string       // 统一为 6 字段: 秒 分 时 日 月 周
+	Schedule cron.Schedule
+}
+
+// Validate 校验 cron 表达式
+func Validate(expr string) error {
+	_, err := Parse(expr)
+	return err
+}
+
+// Parse 解析 cron 表达式
+//
+// 先逐字段检查并给出具体的字段级错误, 再交给 Parser 解析, 保证与调度器的行为一致。
+func Parse(expr string) (*Expression, error) {
+	spec := strings.TrimSpace(expr)
+	if spec == "" {
+		return nil, fmt.Errorf("cron 表达式不能为空")
+	}
+
+	result := &Expression{Raw: expr}
+
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		i := strings.Index(spec, " ")
+		if i < 0 {
+			return nil, fmt.Errorf("时区前缀后缺少调度表达式")
+		}
+		eq := strings.Index(spec, "=")
+		name := spec[eq+1 : i]
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("无效的时区 %q: %w", name, err)
+		}
+		result.Location = location
+		spec = strings.TrimSpace(spec[i:])
+	}
+
+	if strings.HasPrefix(spec, "@") {
+		if err := result.parseDescriptor(spec); err != nil {
+			return nil, err
+		}
+	} else {
+		fields := strings.Fields(spec)
+		if len(fields) != 5 && len(fields) != 6 {
+			return nil, fmt.Errorf("cron 表达式必须包含 5 或 6 个字段，当前有 %d 个字段", len(fields))
+		}
+
+		defs := []field{minuteField, hourField, domField, monthField, dowField}
+		offset := 1
+		if len(fields) == 6 {
+			defs = append([]field{secondField}, defs...)
+			offset = 0
+		}
+		for i, value := range fields {
+			if err := defs[i].validate(value); err != nil {
+				return nil, &FieldError{Position: i + 1, Field: defs[i].name, Value: value, Reason: err.Error()}
+			}
+		}
+		if offset == 1 {
+			fields = append([]string{"0"}, fields...)
+		}
+		result.Fields = fields
+	}
+
+	schedule, err := Parser.Parse(strings.TrimSpace(expr))
+	if err != nil {
+		return nil, fmt.Errorf("解析失败: %w", err)
+	}
+	result.Schedule = schedule
+
+	return result, nil
+}
+
+// parseDescriptor 解析 @ 开头的描述符
+func (e *Expression) parseDescriptor(spec string) error {
+	if strings.HasPrefix(spec, "@every ") {
+		every, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return fmt.Errorf("@every 间隔无效: %w", err)
+		}
+		if every < time.Second {
+			return fmt.Errorf("@every 间隔不能小于 1 秒")
+		}
+		e.Every = every
+		return nil
+	}
+
+	fields, ok := descriptors[spec]
+	if !ok {
+		return fmt.Errorf("不支持的描述符: %s, 可用: @yearly @annually @monthly @weekly @daily @midnight @hourly @every <间隔>", spec)
+	}
+	e.Fields = strings.Fields(fields)
+	return nil
+}
+
+// Next 返回 from 之后的 n 次运行时间
+func (e *Expression) Next(from time.Time, n int) []time.Time {
+	var times []time.Time
+	for i := 0; i < n; i++ {
+		from = e.Schedule.Next(from)
+		if from.IsZero() {
+			break
+		}
+		times = append(times, from)
+	}
+	return times
+}
+
+// validate 校验单个字段, 规则与 robfig/cron 一致:
+// 逗号分隔的列表, 每项为 *、?、单值、a-b 范围, 可追加 /步长
+func (f field) validate(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if item == "" {
+			return fmt.Errorf("列表中存在空项")
+		}
+
+		rangeAndStep := strings.Split(item, "/")
+		if len(rangeAndStep) > 2 {
+			return fmt.Errorf("步长格式错误: %s", item)
+		}
+
+		start, end := f.min, f.max
+		if rangeAndStep[0] != "*" && rangeAndStep[0] != "?" {
+			bounds := strings.Split(rangeAndStep[0], "-")
+			if len(bounds) > 2 {
+				return fmt.Errorf("范围格式错误: %s", item)
+			}
+
+			var err error
+			if start, err = f.value(bounds[0]); err != nil {
+				return err
+			}
+			if len(bounds) == 2 {
+				if end, err = f.value(bounds[1]); err != nil {
+					return err
+				}
+			} else if len(rangeAndStep) == 1 {
+				end = start
+			}
+		}
+
+		if len(rangeAndStep) == 2 {
+			step, err := strconv.Atoi(rangeAndStep[1])
+			if err != nil || step <= 0 {
+				return fmt.Errorf("步长必须是正整数: %s", rangeAndStep[1])
+			}
+		}
+
+		if start > end {
+			return fmt.Errorf("范围起始值 %d 大于结束值 %d", start, end)
+		}
+	}
+	return nil
+}
+
+// value 解析单个值, 支持月份和星期的英文缩写
+func (f field) value(s string) (int, error) {
+	if s == "" {
+		return 0, fmt.Errorf("缺少数值")
+	}
+	if n, ok := f.names[strings.ToLower(s)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		if strings.ContainsAny(s, "LW#") {
+			return 0, fmt.Errorf("不支持 L、W、# 等扩展语法")
+		}
+		return 0, fmt.Errorf("无效的数值: %s", s)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("数值 %d 超出有效范围 [%d-%d]", n, f.min, f.max)
+	}
+	return n, nil
+}
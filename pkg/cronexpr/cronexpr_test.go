@@ -0,0 +1,119 @@
+package cronexpr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFieldErrors(t *testing.T) {
+	tests := []struct {
+		expr     string
+		position int
+		field    string
+		reason   string
+	}{
+		{"60 * * * *", 1, "分钟", "超出有效范围 [0-59]"},
+		{"* 24 * * *", 2, "小时", "超出有效范围 [0-23]"},
+		{"* * 0 * *", 3, "日", "超出有效范围 [1-31]"},
+		{"* * * 13 *", 4, "月", "超出有效范围 [1-12]"},
+		{"* * * * 7", 5, "周", "超出有效范围 [0-6]"},
+		{"60 * * * * *", 1, "秒", "超出有效范围 [0-59]"},
+		{"0 60 * * * *", 2, "分钟", "超出有效范围 [0-59]"},
+		{"5-1 * * * *", 1, "分钟", "范围起始值 5 大于结束值 1"},
+		{"*/0 * * * *", 1, "分钟", "步长必须是正整数"},
+		{"1/2/3 * * * *", 1, "分钟", "步长格式错误"},
+		{"1-2-3 * * * *", 1, "分钟", "范围格式错误"},
+		{"* * L * *", 3, "日", "不支持 L、W、# 等扩展语法"},
+		{"a * * * *", 1, "分钟", "无效的数值"},
+		{"1,,2 * * * *", 1, "分钟", "列表中存在空项"},
+		{"* * * foo *", 4, "月", "无效的数值"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("Parse(%q) 错误 = %v, 期望 *FieldError", tt.expr, err)
+			}
+			if fieldErr.Position != tt.position || fieldErr.Field != tt.field {
+				t.Errorf("字段 = 第 %d 个(%s), 期望第 %d 个(%s)", fieldErr.Position, fieldErr.Field, tt.position, tt.field)
+			}
+			if !strings.Contains(fieldErr.Reason, tt.reason) {
+				t.Errorf("原因 = %q, 应包含 %q", fieldErr.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", "不能为空"},
+		{"   ", "不能为空"},
+		{"* * * *", "当前有 4 个字段"},
+		{"* * * * * * *", "当前有 7 个字段"},
+		{"@foo", "不支持的描述符"},
+		{"@every 10ms", "不能小于 1 秒"},
+		{"@every x", "@every 间隔无效"},
+		{"TZ=Nope/Zone 0 * * * *", "无效的时区"},
+		{"CRON_TZ=UTC", "缺少调度表达式"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			err := Validate(tt.expr)
+			if err == nil {
+				t.Fatalf("Validate(%q) 应返回错误", tt.expr)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate(%q) 错误 = %q, 应包含 %q", tt.expr, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseValid(t *testing.T) {
+	tests := []string{
+		"* * * * *",
+		"0 9 * * 1-5",
+		"*/15 * * * * *",
+		"0 0 1,15 * *",
+		"0 0 * jan-mar sun",
+		"0 0 ? * MON",
+		"@hourly",
+		"@every 1h30m",
+		"CRON_TZ=UTC 0 9 * * *",
+		"TZ=UTC @daily",
+	}
+	for _, expr := range tests {
+		if err := Validate(expr); err != nil {
+			t.Errorf("Validate(%q) = %v", expr, err)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	e, err := Parse("CRON_TZ=UTC 30 8 * * 1-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2026-01-02 是周五
+	from := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	want := []time.Time{
+		time.Date(2026, 1, 5, 8, 30, 0, 0, time.UTC),
+		time.Date(2026, 1, 6, 8, 30, 0, 0, time.UTC),
+		time.Date(2026, 1, 7, 8, 30, 0, 0, time.UTC),
+	}
+	got := e.Next(from, 3)
+	if len(got) != len(want) {
+		t.Fatalf("Next 返回 %d 个时间, 期望 %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("第 %d 次运行 = %s, 期望 %s", i+1, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package cronexpr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 描述语言
+const (
+	LangZH = "zh"
+	LangEN = "en"
+)
+
+var (
+	monthNamesEN   = []string{"", "January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+	weekdayNamesEN = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+	weekdayNamesZH = []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"}
+)
+
+// Describe 返回表达式的可读描述, lang 为 zh 或 en, 其他值按 zh 处理
+func Describe(expr, lang string) (string, error) {
+	e, err := Parse(expr)
+	if err != nil {
+		return "", err
+	}
+	return e.Describe(lang), nil
+}
+
+// Describe 返回表达式的可读描述
+func (e *Expression) Describe(lang string) string {
+	var text string
+	if lang == LangEN {
+		text = e.describeEN()
+	} else {
+		text = e.describeZH()
+	}
+
+	if e.Location != nil {
+		if lang == LangEN {
+			text += fmt.Sprintf(" (time zone %s)", e.Location)
+		} else {
+			text += fmt.Sprintf("（时区 %s）", e.Location)
+		}
+	}
+	return text
+}
+
+// describeEN 英文描述
+func (e *Expression) describeEN() string {
+	if e.Every > 0 {
+		return "Every " + e.Every.String()
+	}
+
+	second, minute, hour := e.Fields[0], e.Fields[1], e.Fields[2]
+	dom, month, dow := e.Fields[3], e.Fields[4], e.Fields[5]
+
+	var parts []string
+	if isSingle(second) && isSingle(minute) && isSingle(hour) {
+		parts = append(parts, "At "+clock(hour, minute, second))
+	} else {
+		// 较小单位已经是"每隔"时, 省略通配的较大单位
+		if second != "0" {
+			parts = append(parts, phraseEN(second, secondField, nil))
+		}
+		if !isWildcard(minute) || second == "0" || !isEvery(second) {
+			parts = append(parts, phraseEN(minute, minuteField, nil))
+		}
+		if !isWildcard(hour) || !isEvery(minute) {
+			parts = append(parts, phraseEN(hour, hourField, nil))
+		}
+	}
+
+	var days []string
+	if !isWildcard(dom) {
+		days = append(days, "on day "+itemsEN(dom, domField, nil)+" of the month")
+	}
+	if !isWildcard(dow) {
+		days = append(days, "on "+itemsEN(dow, dowField, weekdayNamesEN))
+	}
+	if len(days) > 0 {
+		parts = append(parts, strings.Join(days, " or "))
+	} else if isSingle(hour) {
+		parts = append(parts, "every day")
+	}
+	if !isWildcard(month) {
+		parts = append(parts, "in "+itemsEN(month, monthField, monthNamesEN))
+	}
+
+	text := strings.Join(parts, ", ")
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+// describeZH 中文描述
+func (e *Expression) describeZH() string {
+	if e.Every > 0 {
+		return "每隔 " + e.Every.String()
+	}
+
+	second, minute, hour := e.Fields[0], e.Fields[1], e.Fields[2]
+	dom, month, dow := e.Fields[3], e.Fields[4], e.Fields[5]
+
+	var parts []string
+
+	var date string
+	if !isWildcard(month) {
+		date = itemsZH(month, monthField, nil) + "月"
+	} else if !isWildcard(dom) {
+		date = "每月"
+	}
+	var days []string
+	if !isWildcard(dom) {
+		days = append(days, itemsZH(dom, domField, nil)+"日")
+	}
+	if !isWildcard(dow) {
+		days = append(days, "每"+itemsZH(dow, dowField, weekdayNamesZH))
+	}
+	if len(days) > 0 {
+		date += strings.Join(days, "或")
+	} else if isSingle(hour) {
+		date += "每天"
+	}
+	if date != "" {
+		parts = append(parts, date)
+	}
+
+	if isSingle(second) && isSingle(minute) && isSingle(hour) {
+		parts = append(parts, clock(hour, minute, second))
+	} else {
+		if !isWildcard(hour) || !isEvery(minute) {
+			parts = append(parts, phraseZH(hour, hourField))
+		}
+		if !isWildcard(minute) || second == "0" || !isEvery(second) {
+			parts = append(parts, phraseZH(minute, minuteField))
+		}
+		if second != "0" {
+			parts = append(parts, phraseZH(second, secondField))
+		}
+	}
+
+	return strings.Join(parts, "，")
+}
+
+// phraseEN 单个时间字段的英文描述
+func phraseEN(value string, f field, names []string) string {
+	switch {
+	case isWildcard(value):
+		return "every " + f.nameEN
+	case strings.HasPrefix(value, "*/") || strings.HasPrefix(value, "?/"):
+		return fmt.Sprintf("every %s %ss", value[2:], f.nameEN)
+	default:
+		return "at " + f.nameEN + " " + itemsEN(value, f, names)
+	}
+}
+
+// phraseZH 单个时间字段的中文描述
+func phraseZH(value string, f field) string {
+	unit := f.name
+	switch {
+	case isWildcard(value):
+		return "每" + unit
+	case strings.HasPrefix(value, "*/") || strings.HasPrefix(value, "?/"):
+		return fmt.Sprintf("每 %s %s", value[2:], unit)
+	case f.name == "小时":
+		return itemsZH(value, f, nil) + " 点"
+	default:
+		return "第 " + itemsZH(value, f, nil) + " " + unit
+	}
+}
+
+// itemsEN 列表项的英文描述
+func itemsEN(value string, f field, names []string) string {
+	var items []string
+	for _, it := range splitItems(value, f) {
+		name := func(n int) string {
+			if names != nil {
+				return names[n]
+			}
+			return strconv.Itoa(n)
+		}
+		text := name(it.start)
+		if it.end != it.start {
+			text += " through " + name(it.end)
+		}
+		if it.step > 1 {
+			text = fmt.Sprintf("every %d from %s", it.step, text)
+		}
+		items = append(items, text)
+	}
+	return joinEN(items)
+}
+
+// itemsZH 列表项的中文描述
+func itemsZH(value string, f field, names []string) string {
+	var items []string
+	for _, it := range splitItems(value, f) {
+		name := func(n int) string {
+			if names != nil {
+				return names[n]
+			}
+			return strconv.Itoa(n)
+		}
+		text := name(it.start)
+		if it.end != it.start {
+			text += "至" + name(it.end)
+		}
+		if it.step > 1 {
+			text = fmt.Sprintf("%s每隔 %d", text, it.step)
+		}
+		items = append(items, text)
+	}
+	return strings.Join(items, "、")
+}
+
+// item 列表中的一项
+type item struct {
+	start, end, step int
+}
+
+// splitItems 拆分已校验的字段
+func splitItems(value string, f field) []item {
+	var items []item
+	for _, part := range strings.Split(value, ",") {
+		rangeAndStep := strings.Split(part, "/")
+		it := item{start: f.min, end: f.max, step: 1}
+		if rangeAndStep[0] != "*" && rangeAndStep[0] != "?" {
+			bounds := strings.Split(rangeAndStep[0], "-")
+			it.start, _ = f.value(bounds[0])
+			if len(bounds) == 2 {
+				it.end, _ = f.value(bounds[1])
+			} else if len(rangeAndStep) == 1 {
+				it.end = it.start
+			}
+		}
+		if len(rangeAndStep) == 2 {
+			it.step, _ = strconv.Atoi(rangeAndStep[1])
+		}
+		items = append(items, it)
+	}
+	return items
+}
+
+// joinEN 英文列表连接: a, b and c
+func joinEN(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// clock 格式化单值的时分秒
+func clock(hour, minute, second string) string {
+	h, _ := strconv.Atoi(hour)
+	m, _ := strconv.Atoi(minute)
+	s, _ := strconv.Atoi(second)
+	if s != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", h, m)
+}
+
+func isWildcard(value string) bool {
+	return value == "*" || value == "?"
+}
+
+func isEvery(value string) bool {
+	return isWildcard(value) || strings.HasPrefix(value, "*/") || strings.HasPrefix(value, "?/")
+}
+
+func isSingle(value string) bool {
+	_, err := strconv.Atoi(value)
+	return err == nil
+}
@@ -0,0 +1,40 @@
+package cronexpr
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		expr string
+		zh   string
+		en   string
+	}{
+		{"0 9 * * *", "每天，09:00", "At 09:00, every day"},
+		{"*/5 * * * *", "每 5 分钟", "Every 5 minutes"},
+		{"*/10 * * * * *", "每 10 秒", "Every 10 seconds"},
+		{"30 8 * * 1-5", "每周一至周五，08:30", "At 08:30, on Monday through Friday"},
+		{"0 0 1 * *", "每月1日，00:00", "At 00:00, on day 1 of the month"},
+		{"0 0 0 1 1 *", "1月1日，00:00", "At 00:00, on day 1 of the month, in January"},
+		{"0 9,18 * * mon,fri", "每周一、周五，9、18 点，第 0 分钟", "At minute 0, at hour 9 and 18, on Monday and Friday"},
+		{"0 */2 * * *", "每 2 小时，第 0 分钟", "At minute 0, every 2 hours"},
+		{"15 10 1-7 * *", "每月1至7日，10:15", "At 10:15, on day 1 through 7 of the month"},
+		{"0 0 * jan-mar sun", "1至3月每周日，00:00", "At 00:00, on Sunday, in January through March"},
+		{"@daily", "每天，00:00", "At 00:00, every day"},
+		{"@every 90s", "每隔 1m30s", "Every 1m30s"},
+		{"CRON_TZ=UTC 0 9 * * *", "每天，09:00（时区 UTC）", "At 09:00, every day (time zone UTC)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			zh, err := Describe(tt.expr, LangZH)
+			if err != nil {
+				t.Fatalf("Describe(%q) 失败: %v", tt.expr, err)
+			}
+			if zh != tt.zh {
+				t.Errorf("中文描述 = %q, 期望 %q", zh, tt.zh)
+			}
+			en, _ := Describe(tt.expr, LangEN)
+			if en != tt.en {
+				t.Errorf("英文描述 = %q, 期望 %q", en, tt.en)
+			}
+		})
+	}
+}
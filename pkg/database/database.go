@@ -1,6 +1,7 @@
 package database
 
 import (
+	"encoding/json"
 	"fmt"
 	"go-job/internal/models"
 	"go-job/pkg/config"
@@ -57,7 +58,7 @@ func Init(cfg *config.Config) error {
 
 // migrate 自动迁移数据库表
 func migrate() error {
-	return db.AutoMigrate(
+	if err := db.AutoMigrate(
 		&models.User{},
 		&models.Department{},
 		&models.Role{},
@@ -69,7 +70,52 @@ func migrate() error {
 		&models.Worker{},
 		&models.JobSchedule{},
 		&models.AISchedule{},
-	)
+		&models.JobTrigger{},
+		&models.JobTemplate{},
+		&models.JobLabel{},
+		&models.AuditLog{},
+		&models.JobRevision{},
+		&models.SLAMiss{},
+		&models.RetentionPolicy{},
+		&models.RetentionArchive{},
+		&models.ExecutionOutputChunk{},
+		&models.WorkerMetric{},
+		&models.Connection{},
+		&models.Secret{},
+	); err != nil {
+		return err
+	}
+	return migrateExecMode()
+}
+
+// migrateExecMode 将添加执行模式前创建的命令任务转换为 exec 模式
+//
+// 旧版本按空白拆分 command 后直接执行, 不经过 shell, 转换后的 command 为程序, 其余部分写入 args,
+// 避免升级后这些任务改由 /bin/sh -c 执行。
+func migrateExecMode() error {
+	var jobs []models.Job
+	if err := db.Unscoped().Select("id", "command").
+		Where("(exec_mode = '' OR exec_mode IS NULL) AND (type = ? OR type = '' OR type IS NULL)", models.JobTypeCommand).
+		Find(&jobs).Error; err != nil {
+		return fmt.Errorf("查询待转换执行模式的任务失败: %w", err)
+	}
+
+	for _, job := range jobs {
+		command, args := models.SplitLegacyCommand(job.Command)
+		argsJSON := ""
+		if len(args) > 0 {
+			raw, _ := json.Marshal(args)
+			argsJSON = string(raw)
+		}
+		if err := db.Unscoped().Model(&models.Job{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+			"exec_mode": models.ExecModeExec,
+			"command":   command,
+			"args":      argsJSON,
+		}).Error; err != nil {
+			return fmt.Errorf("转换任务 %s 的执行模式失败: %w", job.ID, err)
+		}
+	}
+	return nil
 }
 
 // InitDefaultData 初始化默认数据
@@ -0,0 +1,235 @@
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// 标签键最长 63 个字符, 可带 example.com/ 形式的前缀
+var (
+	keyPattern   = regexp.MustCompile(`^([a-z0-9]([a-z0-9.-]*[a-z0-9])?/)?[A-Za-z0-9]([A-Za-z0-9_.-]{0,61}[A-Za-z0-9])?$`)
+	valuePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9_.-]{0,61}[A-Za-z0-9])?)?$`)
+)
+
+// Operator 选择器运算符
+type Operator string
+
+const (
+	Equals       Operator = "="
+	NotEquals    Operator = "!="
+	In           Operator = "in"
+	NotIn        Operator = "notin"
+	Exists       Operator = "exists"
+	DoesNotExist Operator = "!"
+)
+
+// Requirement 选择器中的单个条件
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Selector 标签选择器, 所有条件同时满足才匹配
+type Selector []Requirement
+
+// ValidateKey 校验标签键
+func ValidateKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("无效的标签键 %q: 只能包含字母、数字、-、_、., 以字母或数字开头和结尾, 最长 63 个字符", key)
+	}
+	return nil
+}
+
+// ValidateValue 校验标签值, 允许为空(只打标签不赋值)
+func ValidateValue(value string) error {
+	if !valuePattern.MatchString(value) {
+		return fmt.Errorf("无效的标签值 %q: 只能包含字母、数字、-、_、., 以字母或数字开头和结尾, 最长 63 个字符", value)
+	}
+	return nil
+}
+
+// Validate 校验一组标签
+func Validate(set map[string]string) error {
+	for _, key := range Keys(set) {
+		if err := ValidateKey(key); err != nil {
+			return err
+		}
+		if err := ValidateValue(set[key]); err != nil {
+			return fmt.Errorf("标签 %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Keys 返回排序后的标签键
+func Keys(set map[string]string) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Parse 解析标签选择器, 条件之间用逗号分隔:
+//
+//	team=data         等于(也可写作 ==)
+//	env!=dev          不等于, 没有该标签的任务也匹配
+//	tier in (a,b)     取值之一
+//	tier notin (a,b)  不是取值之一, 没有该标签的任务也匹配
+//	critical          存在该标签
+//	!deprecated       不存在该标签
+func Parse(selector string) (Selector, error) {
+	var result Selector
+	for _, part := range splitRequirements(selector) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		req, err := parseRequirement(part)
+		if err != nil {
+			return nil, fmt.Errorf("无效的标签选择器 %q: %w", part, err)
+		}
+		result = append(result, req)
+	}
+	return result, nil
+}
+
+// Matches 判断标签是否满足选择器
+func (s Selector) Matches(set map[string]string) bool {
+	for _, req := range s {
+		value, ok := set[req.Key]
+		switch req.Operator {
+		case Exists:
+			if !ok {
+				return false
+			}
+		case DoesNotExist:
+			if ok {
+				return false
+			}
+		case Equals, In:
+			if !ok || !contains(req.Values, value) {
+				return false
+			}
+		case NotEquals, NotIn:
+			if ok && contains(req.Values, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// String 返回选择器的规范写法
+func (s Selector) String() string {
+	parts := make([]string, 0, len(s))
+	for _, req := range s {
+		switch req.Operator {
+		case Exists:
+			parts = append(parts, req.Key)
+		case DoesNotExist:
+			parts = append(parts, "!"+req.Key)
+		case In, NotIn:
+			parts = append(parts, fmt.Sprintf("%s %s (%s)", req.Key, req.Operator, strings.Join(req.Values, ",")))
+		default:
+			parts = append(parts, req.Key+string(req.Operator)+req.Values[0])
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseRequirement 解析单个条件
+func parseRequirement(part string) (Requirement, error) {
+	if strings.HasPrefix(part, "!") && !strings.ContainsAny(part, "=") {
+		key := strings.TrimSpace(part[1:])
+		return Requirement{Key: key, Operator: DoesNotExist}, ValidateKey(key)
+	}
+
+	if i := strings.Index(part, "!="); i >= 0 {
+		return equality(part[:i], NotEquals, part[i+2:])
+	}
+	if i := strings.Index(part, "=="); i >= 0 {
+		return equality(part[:i], Equals, part[i+2:])
+	}
+	if i := strings.Index(part, "="); i >= 0 {
+		return equality(part[:i], Equals, part[i+1:])
+	}
+
+	fields := strings.Fields(part)
+	if len(fields) == 1 {
+		return Requirement{Key: fields[0], Operator: Exists}, ValidateKey(fields[0])
+	}
+	if len(fields) < 3 {
+		return Requirement{}, fmt.Errorf("缺少运算符或取值")
+	}
+
+	key := fields[0]
+	if err := ValidateKey(key); err != nil {
+		return Requirement{}, err
+	}
+	op := Operator(strings.ToLower(fields[1]))
+	if op != In && op != NotIn {
+		return Requirement{}, fmt.Errorf("不支持的运算符 %s", fields[1])
+	}
+
+	list := strings.TrimSpace(strings.Join(fields[2:], " "))
+	if !strings.HasPrefix(list, "(") || !strings.HasSuffix(list, ")") {
+		return Requirement{}, fmt.Errorf("%s 的取值需要用括号包围", op)
+	}
+
+	var values []string
+	for _, value := range strings.Split(list[1:len(list)-1], ",") {
+		value = strings.TrimSpace(value)
+		if err := ValidateValue(value); err != nil {
+			return Requirement{}, err
+		}
+		values = append(values, value)
+	}
+	return Requirement{Key: key, Operator: op, Values: values}, nil
+}
+
+// equality 构造等于或不等于条件
+func equality(key string, op Operator, value string) (Requirement, error) {
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if err := ValidateKey(key); err != nil {
+		return Requirement{}, err
+	}
+	if err := ValidateValue(value); err != nil {
+		return Requirement{}, err
+	}
+	return Requirement{Key: key, Operator: op, Values: []string{value}}, nil
+}
+
+// splitRequirements 按逗号拆分条件, 忽略括号内的逗号
+func splitRequirements(selector string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, selector[start:])
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package labels
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     string // 规范写法
+	}{
+		{"", ""},
+		{" , ", ""},
+		{"team=data", "team=data"},
+		{"team == data", "team=data"},
+		{"env!=dev", "env!=dev"},
+		{"tier in (a, b)", "tier in (a,b)"},
+		{"tier IN (a)", "tier in (a)"},
+		{"tier notin (a,b)", "tier notin (a,b)"},
+		{"critical", "critical"},
+		{"!deprecated", "!deprecated"},
+		{"empty=", "empty="},
+		{"example.com/owner=ops", "example.com/owner=ops"},
+		{"team=data, tier in (a,b), !deprecated", "team=data,tier in (a,b),!deprecated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := Parse(tt.selector)
+			if err != nil {
+				t.Fatalf("Parse(%q) 失败: %v", tt.selector, err)
+			}
+			if got := selector.String(); got != tt.want {
+				t.Errorf("Parse(%q) = %q, 期望 %q", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     string
+	}{
+		{"-team=data", "无效的标签键"},
+		{"team=da ta", "无效的标签值"},
+		{"team=data-", "无效的标签值"},
+		{"tier in", "缺少运算符或取值"},
+		{"tier like (a)", "不支持的运算符 like"},
+		{"tier in a,b", "需要用括号包围"},
+		{"tier in (a,-b)", "无效的标签值"},
+		{"!bad key", "无效的标签键"},
+		{"team=data,!", "无效的标签键"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			_, err := Parse(tt.selector)
+			if err == nil {
+				t.Fatalf("Parse(%q) 应返回错误", tt.selector)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) 错误 = %q, 应包含 %q", tt.selector, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	set := map[string]string{"team": "data", "tier": "b", "critical": ""}
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"", true},
+		{"team=data", true},
+		{"team=ops", false},
+		{"env!=dev", true},
+		{"team!=data", false},
+		{"tier in (a,b)", true},
+		{"tier in (a,c)", false},
+		{"owner in (a)", false},
+		{"tier notin (a,c)", true},
+		{"tier notin (b)", false},
+		{"owner notin (a)", true},
+		{"critical", true},
+		{"owner", false},
+		{"!owner", true},
+		{"!critical", false},
+		{"critical=", true},
+		{"team=data,tier in (a,b),!owner", true},
+		{"team=data,owner", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := Parse(tt.selector)
+			if err != nil {
+				t.Fatalf("Parse(%q) 失败: %v", tt.selector, err)
+			}
+			if got := selector.Matches(set); got != tt.want {
+				t.Errorf("Matches(%q) = %v, 期望 %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(map[string]string{"team": "data", "example.com/owner": ""}); err != nil {
+		t.Errorf("Validate 返回错误: %v", err)
+	}
+	err := Validate(map[string]string{"team": "data", "tier": "a b"})
+	if err == nil || !strings.Contains(err.Error(), "标签 tier") {
+		t.Errorf("Validate 错误 = %v, 应包含 %q", err, "标签 tier")
+	}
+	if err := ValidateKey(strings.Repeat("a", 64)); err == nil {
+		t.Error("超过 63 个字符的标签键应返回错误")
+	}
+}
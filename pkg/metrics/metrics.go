@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Namespace 所有指标的前缀
+const Namespace = "gojob"
+
+// 调度器指标
+var (
+	// SchedulerFires 调度触发次数, source 为 cron、event、chain、retry
+	SchedulerFires = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "scheduler",
+		Name:      "fires_total",
+		Help:      "调度触发次数",
+	}, []string{"source"})
+
+	// SchedulerSkippedFires 被跳过或丢弃的调度, reason 为 paused、disabled、deleted、rescheduled、queue_full、requeue_dropped
+	SchedulerSkippedFires = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "scheduler",
+		Name:      "skipped_fires_total",
+		Help:      "被跳过或丢弃的调度次数",
+	}, []string{"reason"})
+
+	// DispatchLatency 从计划调度到分配给工作节点的耗时
+	DispatchLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "dispatcher",
+		Name:      "latency_seconds",
+		Help:      "从计划调度到分配给工作节点的耗时",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 15, 30, 60, 120, 300},
+	})
+
+	// Dispatches 分发结果, result 为 assigned、no_worker、error
+	Dispatches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "dispatcher",
+		Name:      "dispatches_total",
+		Help:      "任务分发次数",
+	}, []string{"result"})
+)
+
+// 执行指标
+var (
+	// Executions 工作节点上报的执行结果
+	Executions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "execution",
+		Name:      "results_total",
+		Help:      "按状态统计的执行结果",
+	}, []string{"status"})
+
+	// ExecutionDuration 每个任务的执行耗时
+	ExecutionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "execution",
+		Name:      "duration_seconds",
+		Help:      "任务执行耗时",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 1800, 3600},
+	}, []string{"job", "status"})
+)
+
+// 接口指标
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP 请求数",
+	}, []string{"method", "route", "code"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP 请求耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	grpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "gRPC 请求数",
+	}, []string{"method", "code"})
+
+	grpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "gRPC 请求耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// Register 注册自定义采集器, 重复注册时忽略
+func Register(collector prometheus.Collector) error {
+	err := prometheus.Register(collector)
+	var already prometheus.AlreadyRegisteredError
+	if errors.As(err, &already) {
+		return nil
+	}
+	return err
+}
+
+// Handler 返回默认注册表的 /metrics 处理器
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// GinMiddleware 统计 HTTP 请求数和耗时, 按路由模板聚合以控制标签基数
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// UnaryServerInterceptor 统计 gRPC 一元调用
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeGRPC(info.FullMethod, err, start)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor 统计 gRPC 流式调用
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeGRPC(info.FullMethod, err, start)
+		return err
+	}
+}
+
+func observeGRPC(method string, err error, start time.Time) {
+	grpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	grpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,139 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"unicode/utf8"
+)
+
+// Capture 只保留开头和结尾的输出缓冲区, 中间的输出丢弃并记录字节数, 可并发写入
+type Capture struct {
+	mu    sync.Mutex
+	head  int
+	tail  int
+	first []byte
+	last  []byte
+	total int64
+}
+
+// NewCapture 创建输出缓冲区, head 和 tail 都不大于 0 时保留全部输出
+func NewCapture(head, tail int) *Capture {
+	if head < 0 {
+		head = 0
+	}
+	if tail < 0 {
+		tail = 0
+	}
+	return &Capture{head: head, tail: tail}
+}
+
+// Write 实现 io.Writer
+func (c *Capture) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.total += int64(len(p))
+	if c.unlimited() {
+		c.first = append(c.first, p...)
+		return len(p), nil
+	}
+
+	rest := p
+	if n := c.head - len(c.first); n > 0 {
+		if n > len(rest) {
+			n = len(rest)
+		}
+		c.first = append(c.first, rest[:n]...)
+		rest = rest[n:]
+	}
+	if c.tail > 0 && len(rest) > 0 {
+		c.last = append(c.last, rest...)
+		// 超过两倍容量时再裁剪, 避免每次写入都移动数据
+		if len(c.last) > 2*c.tail {
+			c.last = c.last[:copy(c.last, c.last[len(c.last)-c.tail:])]
+		}
+	}
+	return len(p), nil
+}
+
+// Bytes 返回保留的输出, 有输出被丢弃时在开头和结尾之间插入截断标记
+func (c *Capture) Bytes() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := c.last
+	if len(last) > c.tail {
+		last = last[len(last)-c.tail:]
+	}
+	return join(c.first, last, c.total-int64(len(c.first))-int64(len(last)))
+}
+
+// Truncated 返回被丢弃的字节数
+func (c *Capture) Truncated() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	kept := len(c.last)
+	if kept > c.tail {
+		kept = c.tail
+	}
+	return c.total - int64(len(c.first)) - int64(kept)
+}
+
+func (c *Capture) unlimited() bool {
+	return c.head == 0 && c.tail == 0
+}
+
+// Truncate 只保留 data 开头 head 字节和结尾 tail 字节, head 和 tail 都不大于 0 时原样返回
+func Truncate(data []byte, head, tail int) []byte {
+	c := NewCapture(head, tail)
+	c.Write(data)
+	return c.Bytes()
+}
+
+// Limit 返回按 head 和 tail 截断后输出的最大长度, 0 表示不限制
+func Limit(head, tail int) int {
+	if head <= 0 && tail <= 0 {
+		return 0
+	}
+	return head + tail + len(Marker(math.MaxInt64))
+}
+
+// Marker 截断标记
+func Marker(omitted int64) string {
+	return fmt.Sprintf("\n... [输出过长, 已省略 %d 字节, 完整输出见执行日志] ...\n", omitted)
+}
+
+// CompletePrefix 返回 p 中以完整 UTF-8 字符结尾的最长前缀的长度
+func CompletePrefix(p []byte) int {
+	for i := len(p) - 1; i >= 0 && i >= len(p)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(p[i]) {
+			if utf8.FullRune(p[i:]) {
+				return len(p)
+			}
+			return i
+		}
+	}
+	return len(p)
+}
+
+// join 拼接开头和结尾, 截断处不拆分 UTF-8 字符
+func join(first, last []byte, omitted int64) []byte {
+	if omitted <= 0 {
+		return append(append([]byte(nil), first...), last...)
+	}
+
+	keepFirst := CompletePrefix(first)
+	skipLast := 0
+	for skipLast < len(last) && skipLast < utf8.UTFMax && !utf8.RuneStart(last[skipLast]) {
+		skipLast++
+	}
+	omitted += int64(len(first)-keepFirst) + int64(skipLast)
+
+	marker := Marker(omitted)
+	result := make([]byte, 0, keepFirst+len(marker)+len(last)-skipLast)
+	result = append(result, first[:keepFirst]...)
+	result = append(result, marker...)
+	return append(result, last[skipLast:]...)
+}
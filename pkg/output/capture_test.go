@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCapture(t *testing.T) {
+	tests := []struct {
+		name      string
+		head      int
+		tail      int
+		writes    []string
+		want      string
+		truncated int64
+	}{
+		{"不限制", 0, 0, []string{"abc", "def"}, "abcdef", 0},
+		{"未超过限制", 4, 4, []string{"abc", "de"}, "abcde", 0},
+		{"恰好等于限制", 3, 3, []string{"abcdef"}, "abcdef", 0},
+		{"保留开头和结尾", 3, 3, []string{"abcdefghij"}, "abc" + Marker(4) + "hij", 4},
+		{"拆分写入", 3, 3, []string{"ab", "cdef", "gh", "ij"}, "abc" + Marker(4) + "hij", 4},
+		{"逐字节写入", 2, 3, strings.Split("abcdefghijklmnopqrst", ""), "ab" + Marker(15) + "rst", 15},
+		{"只保留开头", 3, 0, []string{"abc", "def"}, "abc" + Marker(3), 3},
+		{"只保留结尾", 0, 3, []string{"abc", "def"}, Marker(3) + "def", 3},
+		{"空写入", 3, 3, []string{"", "abcdefgh", ""}, "abc" + Marker(2) + "fgh", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCapture(tt.head, tt.tail)
+			for _, w := range tt.writes {
+				if n, err := c.Write([]byte(w)); err != nil || n != len(w) {
+					t.Fatalf("Write(%q) = %d, %v", w, n, err)
+				}
+			}
+			if got := string(c.Bytes()); got != tt.want {
+				t.Errorf("Bytes = %q, 期望 %q", got, tt.want)
+			}
+			if got := c.Truncated(); got != tt.truncated {
+				t.Errorf("Truncated = %d, 期望 %d", got, tt.truncated)
+			}
+		})
+	}
+}
+
+func TestCaptureUTF8(t *testing.T) {
+	// 开头的第 4 字节和结尾的第 1 字节分别是 "好" 和 "世" 的一部分, 截断时一并省略
+	c := NewCapture(4, 4)
+	c.Write([]byte("你好"))
+	c.Write([]byte("世界"))
+	if got, want := string(c.Bytes()), "你"+Marker(6)+"界"; got != want {
+		t.Errorf("Bytes = %q, 期望 %q", got, want)
+	}
+	if got := c.Truncated(); got != 4 {
+		t.Errorf("Truncated = %d, 期望 4", got)
+	}
+}
+
+func TestTruncateLimit(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 100)
+	if got := Truncate(data, 0, 0); !bytes.Equal(got, data) {
+		t.Error("不限制时 Truncate 应原样返回")
+	}
+	if got := Limit(0, 0); got != 0 {
+		t.Errorf("Limit(0, 0) = %d, 期望 0", got)
+	}
+
+	got := Truncate(data, 10, 20)
+	if want := "0123456789" + Marker(970) + "01234567890123456789"; string(got) != want {
+		t.Errorf("Truncate = %q, 期望 %q", got, want)
+	}
+	if limit := Limit(10, 20); len(got) > limit {
+		t.Errorf("截断后长度 %d 超过 Limit %d", len(got), limit)
+	}
+}
+
+func TestCompletePrefix(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"a你", 4},
+		{"a\xe4\xbd", 1},
+		{"a\xe4", 1},
+		{"\xf0\x9f\x98", 0},
+		{"\xf0\x9f\x98\x80", 4},
+	}
+	for _, tt := range tests {
+		if got := CompletePrefix([]byte(tt.in)); got != tt.want {
+			t.Errorf("CompletePrefix(%q) = %d, 期望 %d", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RedactMask 替换输出中密钥值的文本
+const RedactMask = "***"
+
+// minRedactLine 多行密钥值按行替换时行的最短长度, 更短的行在普通输出中太常见, 替换后输出无法阅读
+const minRedactLine = 8
+
+// RedactPatterns 需要从输出中去掉的文本, 长的在前
+//
+// 多行的密钥值按行再去掉一次, 但跳过短行和 PEM 分隔行、括号等通用的行, 它们不含密钥内容。
+func RedactPatterns(values []string) []string {
+	seen := make(map[string]bool)
+	var patterns []string
+	add := func(value string) {
+		if value != "" && !seen[value] {
+			seen[value] = true
+			patterns = append(patterns, value)
+		}
+	}
+	for _, value := range values {
+		add(value)
+		if strings.Contains(value, "\n") {
+			for _, line := range strings.Split(value, "\n") {
+				if line = strings.TrimSpace(line); len(line) >= minRedactLine && !genericLine(line) {
+					add(line)
+				}
+			}
+		}
+	}
+	sort.SliceStable(patterns, func(i, j int) bool { return len(patterns[i]) > len(patterns[j]) })
+	return patterns
+}
+
+// genericLine 是否为 PEM 的 -----BEGIN/END ...----- 分隔行, 或只由括号、引号等符号组成的行
+func genericLine(line string) bool {
+	if strings.HasPrefix(line, "-----BEGIN ") || strings.HasPrefix(line, "-----END ") {
+		return true
+	}
+	return strings.Trim(line, "{}[]()<>,;:'\"`=-_.*#/\\ \t") == ""
+}
+
+// Redact 替换文本中的密钥值, patterns 由 RedactPatterns 生成
+func Redact(s string, patterns []string) string {
+	if len(patterns) == 0 {
+		return s
+	}
+	pairs := make([]string, 0, len(patterns)*2)
+	for _, pattern := range patterns {
+		pairs = append(pairs, pattern, RedactMask)
+	}
+	return strings.NewReplacer(pairs...).Replace(s)
+}
+
+// Redactor 替换写入内容中的密钥值后再写入下游
+//
+// 密钥值可能被拆在两次写入之间, 末尾可能是密钥值开头的部分先保留, 等后续写入或 Flush 时再处理。
+type Redactor struct {
+	mu       sync.Mutex
+	out      io.Writer
+	patterns [][]byte
+	pending  []byte
+}
+
+// NewRedactor 创建 Redactor, patterns 由 RedactPatterns 生成
+func NewRedactor(out io.Writer, patterns []string) *Redactor {
+	r := &Redactor{out: out}
+	for _, pattern := range patterns {
+		r.patterns = append(r.patterns, []byte(pattern))
+	}
+	return r
+}
+
+// Write 写入内容, 返回值总是 len(p), 下游的错误原样返回
+func (r *Redactor) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data := append(r.pending, p...)
+	var out []byte
+	start := 0 // 尚未写入 out 的原文开始位置
+	i := 0
+scan:
+	for i < len(data) {
+		for _, pattern := range r.patterns {
+			rest := data[i:]
+			if bytes.HasPrefix(rest, pattern) {
+				out = append(out, data[start:i]...)
+				out = append(out, RedactMask...)
+				i += len(pattern)
+				start = i
+				continue scan
+			}
+			if len(rest) < len(pattern) && bytes.HasPrefix(pattern, rest) {
+				// 剩余内容可能是密钥值的开头
+				break scan
+			}
+		}
+		i++
+	}
+	out = append(out, data[start:i]...)
+	r.pending = append([]byte(nil), data[i:]...)
+
+	if len(out) > 0 {
+		if _, err := r.out.Write(out); err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush 写出保留的内容, 命令结束后调用
+func (r *Redactor) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.pending) == 0 {
+		return nil
+	}
+	patterns := make([]string, len(r.patterns))
+	for i, pattern := range r.patterns {
+		patterns[i] = string(pattern)
+	}
+	data := Redact(string(r.pending), patterns)
+	r.pending = nil
+	_, err := io.WriteString(r.out, data)
+	return err
+}
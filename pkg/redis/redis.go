@@ -141,6 +141,46 @@ func ZRem(ctx context.Context, key string, members ...interface{}) error {
 	return client.ZRem(ctx, key, members...).Err()
 }
 
+// SetNX 仅在键不存在时设置
+func SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return client.SetNX(ctx, key, value, expiration).Result()
+}
+
+// XGroupCreateMkStream 创建消费者组, 流不存在时自动创建
+func XGroupCreateMkStream(ctx context.Context, stream, group, start string) error {
+	return client.XGroupCreateMkStream(ctx, stream, group, start).Err()
+}
+
+// XReadGroup 以消费者组方式读取流消息
+func XReadGroup(ctx context.Context, args *redis.XReadGroupArgs) ([]redis.XStream, error) {
+	return client.XReadGroup(ctx, args).Result()
+}
+
+// XAck 确认流消息
+func XAck(ctx context.Context, stream, group string, ids ...string) error {
+	return client.XAck(ctx, stream, group, ids...).Err()
+}
+
+// XPendingExt 查询消费者组中未确认的消息
+func XPendingExt(ctx context.Context, args *redis.XPendingExtArgs) ([]redis.XPendingExt, error) {
+	return client.XPendingExt(ctx, args).Result()
+}
+
+// XClaim 将空闲超过 MinIdle 的未确认消息转给指定消费者
+func XClaim(ctx context.Context, args *redis.XClaimArgs) ([]redis.XMessage, error) {
+	return client.XClaim(ctx, args).Result()
+}
+
+// XAdd 追加流消息
+func XAdd(ctx context.Context, args *redis.XAddArgs) (string, error) {
+	return client.XAdd(ctx, args).Result()
+}
+
+// Subscribe 订阅频道
+func Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	return client.Subscribe(ctx, channels...)
+}
+
 // IsConnected 检查 Redis 连接状态
 func IsConnected() bool {
 	if client == nil {
@@ -0,0 +1,97 @@
+// Package sdk 嵌入工作节点的公共接口
+//
+// 业务服务在进程内注册处理函数并启动工作节点, handler 类型的任务只会分派给注册了对应处理函数的工作节点:
+//
+//	sdk.Register("billing.close-month", func(ctx context.Context, params map[string]string) (sdk.Result, error) {
+//		sdk.ReportProgress(ctx, 50, "已汇总账单")
+//		return sdk.Result{Data: map[string]int{"invoices": 42}}, nil
+//	})
+//	w, _ := sdk.NewWorker(cfg)
+//	go w.Start(ctx)
+package sdk
+
+import (
+	"context"
+	"go-job/internal/jobtype"
+	"go-job/internal/worker"
+	"go-job/pkg/config"
+	"io"
+	"sort"
+	"sync"
+)
+
+// HandlerFunc 进程内任务处理函数, ctx 在任务超时或被取消时结束
+type HandlerFunc = worker.HandlerFunc
+
+// Result 处理函数的结果, Output 追加到执行输出, Data 序列化为 JSON 保存在执行记录中
+type Result = worker.Result
+
+// TaskInfo 处理函数正在执行的任务
+type TaskInfo = worker.TaskInfo
+
+// Worker 嵌入的工作节点
+type Worker = worker.Worker
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]HandlerFunc)
+)
+
+// Register 注册全局处理函数, 名称不合法或重复注册时 panic, 通常在 init 中调用
+func Register(name string, fn HandlerFunc) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic("sdk: 处理函数重复注册: " + name)
+	}
+	if err := jobtype.ValidateHandlerName(name); err != nil {
+		panic("sdk: " + err.Error())
+	}
+	if fn == nil {
+		panic("sdk: 处理函数不能为空: " + name)
+	}
+	registry[name] = fn
+}
+
+// Handlers 返回已注册的全局处理函数名称
+func Handlers() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewWorker 创建工作节点并注册所有全局处理函数, 返回的工作节点还可以通过 RegisterHandler 单独注册
+func NewWorker(cfg *config.Config) (*Worker, error) {
+	w := worker.NewWorker(cfg)
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for name, fn := range registry {
+		if err := w.RegisterHandler(name, fn); err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// TaskFromContext 返回处理函数正在执行的任务, 不在处理函数中调用时返回 false
+func TaskFromContext(ctx context.Context) (TaskInfo, bool) {
+	return worker.TaskFromContext(ctx)
+}
+
+// ReportProgress 上报处理函数的执行进度, percent 取 0-100, 频繁上报时合并发送
+func ReportProgress(ctx context.Context, percent int, message string) {
+	worker.ReportProgress(ctx, percent, message)
+}
+
+// Output 返回执行输出, 写入的内容实时上报, 不在处理函数中调用时返回 io.Discard
+func Output(ctx context.Context) io.Writer {
+	return worker.Output(ctx)
+}
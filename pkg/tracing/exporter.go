@@ -0,0 +1,211 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-job/pkg/config"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SpanData 已结束的跨度
+type SpanData struct {
+	TraceID      string                 `json:"trace_id"`
+	SpanID       string                 `json:"span_id"`
+	ParentSpanID string                 `json:"parent_span_id,omitempty"`
+	Name         string                 `json:"name"`
+	Kind         SpanKind               `json:"kind"`
+	Service      string                 `json:"service"`
+	StartTime    time.Time              `json:"start_time"`
+	EndTime      time.Time              `json:"end_time"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+}
+
+// Exporter 跨度导出器
+type Exporter interface {
+	Export(ctx context.Context, spans []*SpanData) error
+	Shutdown(ctx context.Context) error
+}
+
+// NewExporter 按配置创建导出器
+func NewExporter(cfg config.TracingConfig) (Exporter, error) {
+	switch cfg.Exporter {
+	case "", "file":
+		return NewFileExporter(cfg.File)
+	case "otlp":
+		return NewOTLPExporter(cfg.Endpoint)
+	default:
+		return nil, fmt.Errorf("不支持的追踪导出器: %s, 可选 file 或 otlp", cfg.Exporter)
+	}
+}
+
+// FileExporter 以 JSON Lines 格式追加写入文件
+type FileExporter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileExporter 创建文件导出器
+func NewFileExporter(path string) (*FileExporter, error) {
+	if path == "" {
+		return nil, fmt.Errorf("追踪文件路径不能为空")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("创建追踪文件目录失败: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("打开追踪文件失败: %w", err)
+	}
+	return &FileExporter{file: file}, nil
+}
+
+// Export 实现 Exporter
+func (e *FileExporter) Export(ctx context.Context, spans []*SpanData) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, span := range spans {
+		if err := encoder.Encode(span); err != nil {
+			return err
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err := e.file.Write(buf.Bytes())
+	return err
+}
+
+// Shutdown 实现 Exporter
+func (e *FileExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.file.Close()
+}
+
+// OTLPExporter 通过 OTLP/HTTP(JSON 编码)发送到 OpenTelemetry Collector
+type OTLPExporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewOTLPExporter 创建 OTLP 导出器, endpoint 形如 http://otel-collector:4318
+func NewOTLPExporter(endpoint string) (*OTLPExporter, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("追踪收集器地址不能为空")
+	}
+	url := strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	return &OTLPExporter{url: url, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+// Export 实现 Exporter
+func (e *OTLPExporter) Export(ctx context.Context, spans []*SpanData) error {
+	body, err := json.Marshal(otlpRequest(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("收集器返回 %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// Shutdown 实现 Exporter
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// otlpRequest 按服务分组构造 ExportTraceServiceRequest
+func otlpRequest(spans []*SpanData) map[string]interface{} {
+	byService := make(map[string][]map[string]interface{})
+	for _, span := range spans {
+		item := map[string]interface{}{
+			"traceId":           span.TraceID,
+			"spanId":            span.SpanID,
+			"name":              span.Name,
+			"kind":              int(span.Kind),
+			"startTimeUnixNano": strconv.FormatInt(span.StartTime.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+			"attributes":        otlpAttributes(span.Attributes),
+			"status":            map[string]interface{}{"code": 1},
+		}
+		if span.ParentSpanID != "" {
+			item["parentSpanId"] = span.ParentSpanID
+		}
+		if span.Error != "" {
+			item["status"] = map[string]interface{}{"code": 2, "message": span.Error}
+		}
+		byService[span.Service] = append(byService[span.Service], item)
+	}
+
+	resourceSpans := make([]map[string]interface{}, 0, len(byService))
+	for service, items := range byService {
+		resourceSpans = append(resourceSpans, map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": otlpAttributes(map[string]interface{}{"service.name": service}),
+			},
+			"scopeSpans": []map[string]interface{}{{
+				"scope": map[string]interface{}{"name": "go-job"},
+				"spans": items,
+			}},
+		})
+	}
+	return map[string]interface{}{"resourceSpans": resourceSpans}
+}
+
+// otlpAttributes 转换为 OTLP 的 KeyValue 列表
+func otlpAttributes(attrs map[string]interface{}) []map[string]interface{} {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]map[string]interface{}, 0, len(attrs))
+	for _, key := range keys {
+		var value map[string]interface{}
+		switch v := attrs[key].(type) {
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		case int:
+			value = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		case int32:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(int64(v), 10)}
+		case int64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]interface{}{"doubleValue": v}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		result = append(result, map[string]interface{}{"key": key, "value": value})
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TraceparentHeader W3C Trace Context 请求头, gRPC 元数据使用同名小写键
+const TraceparentHeader = "traceparent"
+
+// GinMiddleware 为每个 HTTP 请求创建服务端跨度, 并在响应头中返回追踪 ID
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx, span := Start(c.Request.Context(), c.Request.Method+" "+route,
+			WithKind(KindServer),
+			WithRemoteParent(c.GetHeader(TraceparentHeader)),
+			WithAttributes(map[string]interface{}{
+				"http.method": c.Request.Method,
+				"http.route":  route,
+				"http.target": c.Request.URL.Path,
+			}),
+		)
+		c.Request = c.Request.WithContext(ctx)
+		if traceID := span.TraceID(); traceID != "" {
+			c.Header("X-Trace-Id", traceID)
+		}
+
+		c.Next()
+
+		span.SetAttribute("http.status_code", c.Writer.Status())
+		if len(c.Errors) > 0 {
+			span.RecordError(c.Errors.Last())
+		}
+		span.End()
+	}
+}
+
+// UnaryServerInterceptor 为 gRPC 一元调用创建服务端跨度, skip 中的方法不创建
+func UnaryServerInterceptor(skip ...string) grpc.UnaryServerInterceptor {
+	skipped := toSet(skip)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if skipped[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+		resp, err := handler(ctx, req)
+		endServerSpan(span, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor 为 gRPC 流式调用创建服务端跨度, skip 中的方法不创建
+func StreamServerInterceptor(skip ...string) grpc.StreamServerInterceptor {
+	skipped := toSet(skip)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if skipped[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+		err := handler(srv, &tracedStream{ServerStream: ss, ctx: ctx})
+		endServerSpan(span, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor 将当前跨度写入 gRPC 元数据
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if traceparent := TraceparentFromContext(ctx); traceparent != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, TraceparentHeader, traceparent)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func startServerSpan(ctx context.Context, method string) (context.Context, *Span) {
+	var traceparent string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(TraceparentHeader); len(values) > 0 {
+			traceparent = values[0]
+		}
+	}
+	return Start(ctx, method,
+		WithKind(KindServer),
+		WithRemoteParent(traceparent),
+		WithAttributes(map[string]interface{}{"rpc.method": method}),
+	)
+}
+
+func endServerSpan(span *Span, err error) {
+	span.SetAttribute("rpc.grpc.status_code", status.Code(err).String())
+	span.RecordError(err)
+	span.End()
+}
+
+// tracedStream 替换流的 context 以携带跨度
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,419 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"go-job/pkg/config"
+	"go-job/pkg/logger"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TraceID 追踪 ID
+type TraceID [16]byte
+
+// SpanID 跨度 ID
+type SpanID [8]byte
+
+// String 十六进制表示
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+
+// String 十六进制表示
+func (s SpanID) String() string { return hex.EncodeToString(s[:]) }
+
+// SpanContext 在进程间传递的追踪上下文
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid 追踪 ID 和跨度 ID 都不为零时有效
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// Traceparent 按 W3C Trace Context 格式编码, 无效时返回空字符串
+func (sc SpanContext) Traceparent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceparent 解析 W3C traceparent, 格式不合法时返回 false
+func ParseTraceparent(value string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return SpanContext{}, false
+	}
+
+	var sc SpanContext
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return SpanContext{}, false
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return SpanContext{}, false
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return SpanContext{}, false
+	}
+	sc.Sampled = flags[0]&0x01 == 1
+	return sc, sc.IsValid()
+}
+
+// SpanKind 跨度类型, 取值与 OTLP 一致
+type SpanKind int
+
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+	KindClient   SpanKind = 3
+	KindProducer SpanKind = 4
+	KindConsumer SpanKind = 5
+)
+
+// Span 一段被追踪的操作, 未启用追踪时为不记录的空跨度, 方法均可安全调用
+type Span struct {
+	tracer    *Tracer
+	name      string
+	sc        SpanContext
+	parent    SpanID
+	kind      SpanKind
+	start     time.Time
+	recording bool
+
+	mu         sync.Mutex
+	attributes map[string]interface{}
+	errMsg     string
+	ended      bool
+}
+
+// SpanContext 返回跨度的追踪上下文
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// Traceparent 返回用于向下游传递的 traceparent
+func (s *Span) Traceparent() string {
+	return s.SpanContext().Traceparent()
+}
+
+// TraceID 返回追踪 ID, 无效时返回空字符串
+func (s *Span) TraceID() string {
+	sc := s.SpanContext()
+	if !sc.IsValid() {
+		return ""
+	}
+	return sc.TraceID.String()
+}
+
+// SetAttribute 设置属性, 值支持字符串、整数、浮点数和布尔值
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil || !s.recording {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.attributes == nil {
+		s.attributes = make(map[string]interface{})
+	}
+	s.attributes[key] = value
+}
+
+// RecordError 将跨度标记为失败
+func (s *Span) RecordError(err error) {
+	if s == nil || !s.recording || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errMsg = err.Error()
+}
+
+// End 结束跨度并交给导出器
+func (s *Span) End() {
+	s.EndAt(time.Now())
+}
+
+// EndAt 以指定时间结束跨度
+func (s *Span) EndAt(end time.Time) {
+	if s == nil || !s.recording {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	data := &SpanData{
+		TraceID:    s.sc.TraceID.String(),
+		SpanID:     s.sc.SpanID.String(),
+		Name:       s.name,
+		Kind:       s.kind,
+		Service:    s.tracer.service,
+		StartTime:  s.start,
+		EndTime:    end,
+		Attributes: s.attributes,
+		Error:      s.errMsg,
+	}
+	if s.parent != (SpanID{}) {
+		data.ParentSpanID = s.parent.String()
+	}
+	s.mu.Unlock()
+
+	s.tracer.enqueue(data)
+}
+
+// StartOption 创建跨度的选项
+type StartOption func(*startConfig)
+
+type startConfig struct {
+	kind   SpanKind
+	start  time.Time
+	remote *SpanContext
+	attrs  map[string]interface{}
+}
+
+// WithKind 指定跨度类型
+func WithKind(kind SpanKind) StartOption {
+	return func(c *startConfig) { c.kind = kind }
+}
+
+// WithStartTime 指定开始时间, 用于补记已经发生的等待
+func WithStartTime(t time.Time) StartOption {
+	return func(c *startConfig) { c.start = t }
+}
+
+// WithRemoteParent 以传入的 traceparent 作为父跨度, 优先于 context 中的跨度, 无效时忽略
+func WithRemoteParent(traceparent string) StartOption {
+	return func(c *startConfig) {
+		if sc, ok := ParseTraceparent(traceparent); ok {
+			c.remote = &sc
+		}
+	}
+}
+
+// WithAttributes 指定初始属性
+func WithAttributes(attrs map[string]interface{}) StartOption {
+	return func(c *startConfig) { c.attrs = attrs }
+}
+
+type spanKey struct{}
+
+// SpanFromContext 返回 context 中的当前跨度, 没有时返回 nil
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// ContextWithSpan 将跨度放入 context
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// TraceparentFromContext 返回 context 中当前跨度的 traceparent
+func TraceparentFromContext(ctx context.Context) string {
+	return SpanFromContext(ctx).Traceparent()
+}
+
+// Start 创建跨度, 父跨度取自 WithRemoteParent 或 context
+func Start(ctx context.Context, name string, opts ...StartOption) (context.Context, *Span) {
+	cfg := startConfig{kind: KindInternal}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var parent SpanContext
+	if cfg.remote != nil {
+		parent = *cfg.remote
+	} else {
+		parent = SpanFromContext(ctx).SpanContext()
+	}
+
+	t := global.Load()
+	if t == nil {
+		// 未启用追踪时原样传递上游的追踪上下文
+		span := &Span{sc: parent}
+		return ContextWithSpan(ctx, span), span
+	}
+
+	span := &Span{
+		tracer: t,
+		name:   name,
+		kind:   cfg.kind,
+		start:  cfg.start,
+		parent: parent.SpanID,
+	}
+	if span.start.IsZero() {
+		span.start = time.Now()
+	}
+	if parent.IsValid() {
+		span.sc.TraceID = parent.TraceID
+		span.sc.Sampled = parent.Sampled
+	} else {
+		rand.Read(span.sc.TraceID[:])
+		span.sc.Sampled = t.sample(span.sc.TraceID)
+	}
+	rand.Read(span.sc.SpanID[:])
+	span.recording = span.sc.Sampled
+
+	for key, value := range cfg.attrs {
+		span.SetAttribute(key, value)
+	}
+	return ContextWithSpan(ctx, span), span
+}
+
+// Tracer 收集结束的跨度并分批导出
+type Tracer struct {
+	service  string
+	ratio    float64
+	exporter Exporter
+	queue    chan *SpanData
+	stop     chan struct{}
+	done     chan struct{}
+	dropped  atomic.Int64
+}
+
+var global atomic.Pointer[Tracer]
+
+// Init 按配置启用追踪, 未启用时所有跨度均为空跨度
+func Init(cfg *config.Config, service string) error {
+	tc := cfg.Tracing
+	if !tc.Enabled {
+		return nil
+	}
+
+	exporter, err := NewExporter(tc)
+	if err != nil {
+		return err
+	}
+
+	ratio := tc.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+
+	t := &Tracer{
+		service:  service,
+		ratio:    ratio,
+		exporter: exporter,
+		queue:    make(chan *SpanData, 2048),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go t.run(time.Duration(tc.FlushInterval) * time.Second)
+
+	if old := global.Swap(t); old != nil {
+		old.shutdown(context.Background())
+	}
+	return nil
+}
+
+// Shutdown 导出剩余的跨度并关闭导出器
+func Shutdown(ctx context.Context) error {
+	t := global.Swap(nil)
+	if t == nil {
+		return nil
+	}
+	return t.shutdown(ctx)
+}
+
+// sample 按追踪 ID 采样, 同一追踪在各个服务中的结果一致
+func (t *Tracer) sample(id TraceID) bool {
+	if t.ratio >= 1 {
+		return true
+	}
+	var v uint64
+	for _, b := range id[8:] {
+		v = v<<8 | uint64(b)
+	}
+	return float64(v>>1) < t.ratio*float64(math.MaxInt64)
+}
+
+// enqueue 将结束的跨度加入导出队列, 队列满时丢弃
+func (t *Tracer) enqueue(data *SpanData) {
+	select {
+	case t.queue <- data:
+	default:
+		t.dropped.Add(1)
+	}
+}
+
+// run 批量导出跨度
+func (t *Tracer) run(interval time.Duration) {
+	defer close(t.done)
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	const batchSize = 256
+	batch := make([]*SpanData, 0, batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := t.exporter.Export(ctx, batch); err != nil {
+			logger.WithError(err).Warnf("导出 %d 个跨度失败", len(batch))
+		}
+		cancel()
+		if n := t.dropped.Swap(0); n > 0 {
+			logger.Warnf("追踪队列已满, 丢弃了 %d 个跨度", n)
+		}
+		batch = make([]*SpanData, 0, batchSize)
+	}
+
+	for {
+		select {
+		case data := <-t.queue:
+			batch = append(batch, data)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-t.stop:
+			for {
+				select {
+				case data := <-t.queue:
+					batch = append(batch, data)
+					if len(batch) >= batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// shutdown 等待队列中剩余的跨度导出
+func (t *Tracer) shutdown(ctx context.Context) error {
+	close(t.stop)
+	select {
+	case <-t.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return t.exporter.Shutdown(ctx)
+}
@@ -50,6 +50,7 @@ type Client struct {
 // Message represents a WebSocket message
 type Message struct {
 	Type      string      `json:"type"`
+	Channel   string      `json:"channel,omitempty"`
 	Payload   interface{} `json:"payload"`
 	Timestamp time.Time   `json:"timestamp"`
 }
@@ -142,6 +143,40 @@ func (h *Hub) BroadcastMessage(msgType string, payload interface{}) {
 	}
 }
 
+// Publish sends a message only to the clients subscribed to the channel
+func (h *Hub) Publish(channel, msgType string, payload interface{}) {
+	message := Message{
+		Type:      msgType,
+		Channel:   channel,
+		Payload:   payload,
+		Timestamp: time.Now(),
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal WebSocket message")
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for client := range h.clients {
+		if !client.subscriptions[channel] {
+			continue
+		}
+		select {
+		case client.send <- data:
+		default:
+			logger.Warnf("WebSocket client %s is too slow, dropping message on channel %s", client.id, channel)
+		}
+	}
+}
+
+// ExecutionChannel returns the channel carrying live output of an execution
+func ExecutionChannel(executionID string) string {
+	return "execution:" + executionID
+}
+
 // BroadcastLog sends a log message to all connected clients
 func (h *Hub) BroadcastLog(level, service, message string, data map[string]interface{}) {
 	logMsg := LogMessage{
@@ -270,12 +305,16 @@ func (c *Client) handleClientMessage(msgType string, msg map[string]interface{})
 	switch msgType {
 	case "subscribe":
 		if channel, ok := msg["channel"].(string); ok {
+			c.hub.mu.Lock()
 			c.subscriptions[channel] = true
+			c.hub.mu.Unlock()
 			logger.Infof("Client %s subscribed to channel: %s", c.id, channel)
 		}
 	case "unsubscribe":
 		if channel, ok := msg["channel"].(string); ok {
+			c.hub.mu.Lock()
 			delete(c.subscriptions, channel)
+			c.hub.mu.Unlock()
 			logger.Infof("Client %s unsubscribed from channel: %s", c.id, channel)
 		}
 	}